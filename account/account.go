@@ -23,6 +23,13 @@ func GetAccount(ctx context.Context, cfg *config.Configuration, fetcher stored_r
 		}}
 	}
 
+	// the accounts beyond the metrics account label limit share the metrics of the reserved account ID
+	if cfg.Metrics.AccountLabelLimit.MaxValues > 0 && accountID == metrics.AccountLabelOther {
+		return nil, []error{&errortypes.AcctRequired{
+			Message: fmt.Sprintf("Prebid-server has reserved the account ID \"%s\" for its metrics. Please reach out to the prebid server host.", accountID),
+		}}
+	}
+
 	if accountJSON, accErrs := fetcher.FetchAccount(ctx, cfg.AccountDefaultsJSON(), accountID); len(accErrs) > 0 || accountJSON == nil {
 		// accountID does not reference a valid account
 		for _, e := range accErrs {
@@ -60,6 +67,24 @@ func GetAccount(ctx context.Context, cfg *config.Configuration, fetcher stored_r
 			account.ID = accountID
 		}
 
+		if precisionErrs := account.Privacy.ValidatePrecision(nil); len(precisionErrs) > 0 {
+			return nil, []error{&errortypes.MalformedAcct{
+				Message: fmt.Sprintf("The prebid-server account config privacy for account id \"%s\" is malformed: %v. Please reach out to the prebid server host.", accountID, precisionErrs[0]),
+			}}
+		}
+
+		if usnatErrs := account.Privacy.USNat.Validate("privacy.usnat", nil); len(usnatErrs) > 0 {
+			return nil, []error{&errortypes.MalformedAcct{
+				Message: fmt.Sprintf("The prebid-server account config privacy for account id \"%s\" is malformed: %v. Please reach out to the prebid server host.", accountID, usnatErrs[0]),
+			}}
+		}
+
+		if currencyErrs := account.ValidateAdditionalCurrencies(nil); len(currencyErrs) > 0 {
+			return nil, []error{&errortypes.MalformedAcct{
+				Message: fmt.Sprintf("The prebid-server account config for account id \"%s\" is malformed: %v. Please reach out to the prebid server host.", accountID, currencyErrs[0]),
+			}}
+		}
+
 		// Set derived fields
 		setDerivedConfig(account)
 	}
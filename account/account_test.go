@@ -27,6 +27,9 @@ var mockAccountData = map[string]json.RawMessage{
 	"valid_acct_dsa":            json.RawMessage(`{"disabled":false, "privacy": {"dsa": {"default": "` + validDSA + `"}}}`),
 	"invalid_acct_dsa":          json.RawMessage(`{"disabled":false, "privacy": {"dsa": {"default": "` + invalidDSA + `"}}}`),
 	"invalid_acct_ipv6_ipv4":    json.RawMessage(`{"disabled":false, "privacy": {"ipv6": {"anon_keep_bits": -32}, "ipv4": {"anon_keep_bits": -16}}}`),
+	"invalid_acct_precision":    json.RawMessage(`{"disabled":false, "privacy": {"precision_profiles": {"coarse": {"ipv4_keep_bits": 33}}}}`),
+	"invalid_acct_currencies":   json.RawMessage(`{"disabled":false, "additional_currencies": ["EUR", "EURO"]}`),
+	"invalid_acct_usnat":        json.RawMessage(`{"disabled":false, "privacy": {"usnat": {"enabled": true, "activities": {"syncUser": ["geo"]}}}}`),
 	"disabled_acct":             json.RawMessage(`{"disabled":true}`),
 	"malformed_acct":            json.RawMessage(`{"disabled":"invalid type"}`),
 	"gdpr_channel_enabled_acct": json.RawMessage(`{"disabled":false,"gdpr":{"channel_enabled":{"amp":true}}}`),
@@ -93,6 +96,15 @@ func TestGetAccount(t *testing.T) {
 		{accountID: "invalid_acct_ipv6_ipv4", required: true, disabled: false, err: nil, wantDefaultIP: true},
 		{accountID: "invalid_acct_dsa", required: false, disabled: false, err: &errortypes.MalformedAcct{}},
 
+		// pubID given and matches a host account with an out of range precision profile
+		{accountID: "invalid_acct_precision", required: false, disabled: false, err: &errortypes.MalformedAcct{}},
+
+		// pubID given and matches a host account with an unknown US National signal
+		{accountID: "invalid_acct_usnat", required: false, disabled: false, err: &errortypes.MalformedAcct{}},
+
+		// pubID given and matches a host account with a malformed additional currency
+		{accountID: "invalid_acct_currencies", required: false, disabled: false, err: &errortypes.MalformedAcct{}},
+
 		// pubID given and matches a host account explicitly disabled (Disabled: true on account json)
 		{accountID: "disabled_acct", required: false, disabled: false, err: &errortypes.AccountDisabled{}},
 		{accountID: "disabled_acct", required: true, disabled: false, err: &errortypes.AccountDisabled{}},
@@ -147,6 +159,48 @@ func TestGetAccount(t *testing.T) {
 	}
 }
 
+func TestGetAccountReservedMetricsLabel(t *testing.T) {
+	testCases := []struct {
+		description   string
+		maxValues     int
+		expectedError error
+	}{
+		{
+			description:   "account label limit disabled",
+			maxValues:     0,
+			expectedError: nil,
+		},
+		{
+			description:   "account label limit enabled",
+			maxValues:     10,
+			expectedError: &errortypes.AcctRequired{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			cfg := &config.Configuration{
+				Metrics: config.Metrics{AccountLabelLimit: config.AccountLabelLimit{MaxValues: test.maxValues}},
+			}
+			assert.NoError(t, cfg.MarshalAccountDefaults())
+
+			metricsMock := &metrics.MetricsEngineMock{}
+			metricsMock.Mock.On("RecordAccountUpgradeStatus", mock.Anything, mock.Anything).Return()
+
+			account, errs := GetAccount(context.Background(), cfg, &mockAccountFetcher{}, metrics.AccountLabelOther, metricsMock)
+
+			if test.expectedError == nil {
+				assert.Empty(t, errs)
+				assert.Equal(t, metrics.AccountLabelOther, account.ID)
+			} else {
+				assert.Nil(t, account)
+				assert.Len(t, errs, 1)
+				assert.IsType(t, test.expectedError, errs[0])
+			}
+		})
+	}
+}
+
 func TestSetDerivedConfig(t *testing.T) {
 	tests := []struct {
 		description              string
@@ -59,10 +59,13 @@ type TimeoutBidder interface {
 // Currency declaration is not mandatory but helps to detect an eventual currency mismatch issue.
 // From the bid response, the bidder accepts a list of valid currencies for the bid.
 // The currency is the same across all bids.
+//
+// BidID is optionally provided by adapters with the bidresponse.bidid of the bidder, for the ${AUCTION_BID_ID} macro.
 type BidderResponse struct {
 	Currency             string
 	Bids                 []*TypedBid
 	FledgeAuctionConfigs []*openrtb_ext.FledgeAuctionConfig
+	BidID                string
 }
 
 // NewBidderResponseWithBidsCapacity create a new BidderResponse initialising the bids array capacity and the default currency value
@@ -95,6 +98,7 @@ func NewBidderResponse() *BidderResponse {
 // TypedBid.BidVideo will become "response.seatbid[i].bid.ext.prebid.video" in the final OpenRTB response.
 // TypedBid.DealPriority is optionally provided by adapters and used internally by the exchange to support deal targeted campaigns.
 // TypedBid.Seat new seat under which the bid should pe placed. Default is adapter name
+// TypedBid.ResponseSeat is optionally provided by adapters with the seatbid.seat of the bidder response, for the ${AUCTION_SEAT_ID} macro.
 type TypedBid struct {
 	Bid          *openrtb2.Bid
 	BidMeta      *openrtb_ext.ExtBidPrebidMeta
@@ -102,6 +106,7 @@ type TypedBid struct {
 	BidVideo     *openrtb_ext.ExtBidPrebidVideo
 	DealPriority int
 	Seat         openrtb_ext.BidderName
+	ResponseSeat string
 }
 
 // RequestData and ResponseData exist so that prebid-server core code can implement its "debug" functionality
@@ -144,7 +144,8 @@ func evaluateActivities(rw *openrtb_ext.RequestWrapper, ac privacy.ActivityContr
 		return false, nil
 	}
 	blockUserFPD := !ac.Allow(privacy.ActivityTransmitUserFPD, component, privacy.ActivityRequest{})
-	blockPreciseGeo := !ac.Allow(privacy.ActivityTransmitPreciseGeo, component, privacy.ActivityRequest{})
+	allowPreciseGeo, precisionProfile := ac.AllowPreciseGeo(component, privacy.ActivityRequest{})
+	blockPreciseGeo := !allowPreciseGeo
 
 	if !blockUserFPD && !blockPreciseGeo {
 		return true, nil
@@ -159,7 +160,7 @@ func evaluateActivities(rw *openrtb_ext.RequestWrapper, ac privacy.ActivityContr
 	}
 	if blockPreciseGeo {
 		ipConf := privacy.IPConf{IPV6: ac.IPv6Config, IPV4: ac.IPv4Config}
-		privacy.ScrubGeoAndDeviceIP(cloneReq, ipConf)
+		privacy.ScrubGeoAndDeviceIPWithProfile(cloneReq, ipConf, precisionProfile)
 	}
 
 	cloneReq.RebuildRequest()
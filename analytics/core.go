@@ -32,6 +32,9 @@ type AuctionObject struct {
 	HookExecutionOutcome []hookexecution.StageOutcome
 	SeatNonBid           []openrtb_ext.SeatNonBid
 	RequestWrapper       *openrtb_ext.RequestWrapper
+	PrivacyAudit         openrtb_ext.ExtPrivacyAudit
+	FloorsOutcome        *openrtb_ext.PriceFloorsOutcome
+	RateSnapshotID       string
 }
 
 // Loggable object of a transaction at /openrtb2/amp endpoint
@@ -45,6 +48,9 @@ type AmpObject struct {
 	HookExecutionOutcome []hookexecution.StageOutcome
 	SeatNonBid           []openrtb_ext.SeatNonBid
 	RequestWrapper       *openrtb_ext.RequestWrapper
+	PrivacyAudit         openrtb_ext.ExtPrivacyAudit
+	FloorsOutcome        *openrtb_ext.PriceFloorsOutcome
+	RateSnapshotID       string
 }
 
 // Loggable object of a transaction at /openrtb2/video endpoint
@@ -57,6 +63,8 @@ type VideoObject struct {
 	StartTime      time.Time
 	SeatNonBid     []openrtb_ext.SeatNonBid
 	RequestWrapper *openrtb_ext.RequestWrapper
+	PrivacyAudit   openrtb_ext.ExtPrivacyAudit
+	FloorsOutcome  *openrtb_ext.PriceFloorsOutcome
 }
 
 // Loggable object of a transaction at /setuid
@@ -5,9 +5,10 @@ type EventType string
 
 // Possible values of events Prebid Server can receive for an ad.
 const (
-	Win  EventType = "win"
-	Imp  EventType = "imp"
-	Vast EventType = "vast"
+	Win     EventType = "win"
+	Imp     EventType = "imp"
+	Vast    EventType = "vast"
+	Billing EventType = "billing"
 )
 
 // ResponseFormat enumerates the values of a Prebid Server event.
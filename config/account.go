@@ -3,12 +3,16 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/prebid/go-gdpr/consentconstants"
 	"github.com/prebid/prebid-server/v4/openrtb_ext"
 	"github.com/prebid/prebid-server/v4/util/iputil"
+	"golang.org/x/text/currency"
 )
 
 // ChannelType enumerates the values of integrations Prebid Server can configure for an account
@@ -54,6 +58,9 @@ type Account struct {
 	Privacy                 AccountPrivacy                              `mapstructure:"privacy" json:"privacy"`
 	PreferredMediaType      openrtb_ext.PreferredMediaType              `mapstructure:"preferredmediatype" json:"preferredmediatype"`
 	TargetingPrefix         string                                      `mapstructure:"targeting_prefix" json:"targeting_prefix"`
+	AdditionalCurrencies    []string                                    `mapstructure:"additional_currencies" json:"additional_currencies"`
+	AuctionMacros           AccountAuctionMacros                        `mapstructure:"auction_macros" json:"auction_macros"`
+	Notifications           AccountNotifications                        `mapstructure:"notifications" json:"notifications"`
 }
 
 // CookieSync represents the account-level defaults for the cookie sync endpoint.
@@ -62,6 +69,8 @@ type CookieSync struct {
 	MaxLimit        *int       `mapstructure:"max_limit" json:"max_limit"`
 	DefaultCoopSync *bool      `mapstructure:"default_coop_sync" json:"default_coop_sync"`
 	PriorityGroups  [][]string `mapstructure:"priority_groups" json:"priority_groups"`
+	// Partitioned overrides host_cookie.partitioned.enabled for writing a partitioned copy of the uids cookie.
+	Partitioned *bool `mapstructure:"partitioned" json:"partitioned,omitempty"`
 }
 
 // AccountCCPA represents account-specific CCPA configuration
@@ -70,21 +79,51 @@ type AccountCCPA struct {
 	ChannelEnabled AccountChannel `mapstructure:"channel_enabled" json:"channel_enabled"`
 }
 
+// AccountAuctionMacros represents the substitution of the OpenRTB auction macros, such as ${AUCTION_PRICE}, in the
+// bids adm, nurl and burl once the auction clears
+type AccountAuctionMacros struct {
+	Enabled bool `mapstructure:"enabled" json:"enabled"`
+	// SecondPrice substitutes ${AUCTION_PRICE} of the highest bid of each imp with the second highest bid plus one
+	// cent, bounded by the floor and the bid price, instead of the bid price
+	SecondPrice bool `mapstructure:"second_price" json:"second_price"`
+	// ExcludedBidders lists the bidders opting out of the substitution, which substitute the macros themselves
+	ExcludedBidders []string `mapstructure:"excluded_bidders" json:"excluded_bidders,omitempty"`
+}
+
+// IsBidderExcluded returns true if the bidder opted out of the auction macros substitution
+func (m AccountAuctionMacros) IsBidderExcluded(bidder string) bool {
+	for _, excludedBidder := range m.ExcludedBidders {
+		if strings.EqualFold(excludedBidder, bidder) {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountNotifications represents PBS firing the win and billing notice URLs of the bids on behalf of the clients
+type AccountNotifications struct {
+	Enabled bool `mapstructure:"enabled" json:"enabled"`
+}
+
 type AccountPriceFloors struct {
-	Enabled                bool              `mapstructure:"enabled" json:"enabled"`
-	EnforceFloorsRate      int               `mapstructure:"enforce_floors_rate" json:"enforce_floors_rate"`
-	AdjustForBidAdjustment bool              `mapstructure:"adjust_for_bid_adjustment" json:"adjust_for_bid_adjustment"`
-	EnforceDealFloors      bool              `mapstructure:"enforce_deal_floors" json:"enforce_deal_floors"`
-	UseDynamicData         bool              `mapstructure:"use_dynamic_data" json:"use_dynamic_data"`
-	MaxRule                int               `mapstructure:"max_rules" json:"max_rules"`
-	MaxSchemaDims          int               `mapstructure:"max_schema_dims" json:"max_schema_dims"`
-	Fetcher                AccountFloorFetch `mapstructure:"fetch" json:"fetch"`
+	Enabled                bool `mapstructure:"enabled" json:"enabled"`
+	EnforceFloorsRate      int  `mapstructure:"enforce_floors_rate" json:"enforce_floors_rate"`
+	AdjustForBidAdjustment bool `mapstructure:"adjust_for_bid_adjustment" json:"adjust_for_bid_adjustment"`
+	EnforceDealFloors      bool `mapstructure:"enforce_deal_floors" json:"enforce_deal_floors"`
+	UseDynamicData         bool `mapstructure:"use_dynamic_data" json:"use_dynamic_data"`
+	MaxRule                int  `mapstructure:"max_rules" json:"max_rules"`
+	MaxSchemaDims          int  `mapstructure:"max_schema_dims" json:"max_schema_dims"`
+	// Timezone is the IANA timezone of the hourOfDay and dayOfWeek schema dimensions
+	Timezone  string                `mapstructure:"timezone" json:"timezone"`
+	Fetcher   AccountFloorFetch     `mapstructure:"fetch" json:"fetch"`
+	Optimizer AccountFloorOptimizer `mapstructure:"optimizer" json:"optimizer"`
 }
 
 // AccountFloorFetch defines the configuration for dynamic floors fetching.
 type AccountFloorFetch struct {
 	Enabled       bool   `mapstructure:"enabled" json:"enabled"`
 	URL           string `mapstructure:"url" json:"url"`
+	FloorsID      string `mapstructure:"floors_id" json:"floors_id"`
 	Timeout       int    `mapstructure:"timeout_ms" json:"timeout_ms"`
 	MaxFileSizeKB int    `mapstructure:"max_file_size_kb" json:"max_file_size_kb"`
 	MaxRules      int    `mapstructure:"max_rules" json:"max_rules"`
@@ -94,6 +133,24 @@ type AccountFloorFetch struct {
 	AccountID     string `mapstructure:"accountID" json:"accountID"`
 }
 
+// AccountFloorOptimizer defines the configuration of the floors learned from the bids of the auctions of the
+// account. The optimizer is used when no fetched floors data is.
+type AccountFloorOptimizer struct {
+	Enabled bool `mapstructure:"enabled" json:"enabled"`
+	// SchemaFields are the dimensions of the rules learned
+	SchemaFields []string `mapstructure:"schema_fields" json:"schema_fields"`
+	// PricePoints are the floors the optimizer chooses from in addition to no floor, in ascending order
+	PricePoints []float64 `mapstructure:"price_points" json:"price_points"`
+	// WindowSec is the duration of the sliding window of auctions the floors are learned from
+	WindowSec int `mapstructure:"window_sec" json:"window_sec"`
+	// MinAuctions is the number of auctions with a floor needed to consider it
+	MinAuctions int `mapstructure:"min_auctions" json:"min_auctions"`
+	// ExplorationRate is the percentage of auctions with floors next to the best floors known
+	ExplorationRate int `mapstructure:"exploration_rate" json:"exploration_rate"`
+	// HoldoutRate is the percentage of auctions without floors, to measure the lift of the optimizer
+	HoldoutRate int `mapstructure:"holdout_rate" json:"holdout_rate"`
+}
+
 func (pf *AccountPriceFloors) validate(errs []error) []error {
 	if pf.EnforceFloorsRate < 0 || pf.EnforceFloorsRate > 100 {
 		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.enforce_floors_rate should be between 0 and 100`))
@@ -107,6 +164,10 @@ func (pf *AccountPriceFloors) validate(errs []error) []error {
 		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.max_schema_dims should be between 0 and 20`))
 	}
 
+	if _, err := time.LoadLocation(pf.Timezone); err != nil {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.timezone is invalid: %v`, err))
+	}
+
 	if pf.Fetcher.Period > pf.Fetcher.MaxAge {
 		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.fetch.period_sec should be less than account_defaults.price_floors.fetch.max_age_sec`))
 	}
@@ -135,6 +196,40 @@ func (pf *AccountPriceFloors) validate(errs []error) []error {
 		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.fetch.max_schema_dims should not be less than 0 and greater than 20`))
 	}
 
+	return pf.Optimizer.validate(errs)
+}
+
+func (o *AccountFloorOptimizer) validate(errs []error) []error {
+	if !o.Enabled {
+		return errs
+	}
+
+	if len(o.SchemaFields) == 0 {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.optimizer.schema_fields should not be empty`))
+	}
+
+	if len(o.PricePoints) == 0 {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.optimizer.price_points should not be empty`))
+	}
+	for i, price := range o.PricePoints {
+		if price <= 0 || (i > 0 && price <= o.PricePoints[i-1]) {
+			errs = append(errs, fmt.Errorf(`account_defaults.price_floors.optimizer.price_points should be positive and in ascending order`))
+			break
+		}
+	}
+
+	if o.WindowSec < 60 {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.optimizer.window_sec should not be less than 60 seconds`))
+	}
+
+	if o.MinAuctions < 1 {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.optimizer.min_auctions should be greater than 0`))
+	}
+
+	if o.ExplorationRate < 0 || o.HoldoutRate < 0 || o.ExplorationRate+o.HoldoutRate >= 100 {
+		errs = append(errs, fmt.Errorf(`account_defaults.price_floors.optimizer.exploration_rate and holdout_rate should not be negative and should add up to less than 100`))
+	}
+
 	return errs
 }
 
@@ -349,10 +444,91 @@ func (m AccountModules) ModuleConfig(id string) (json.RawMessage, error) {
 
 type AccountPrivacy struct {
 	AllowActivities *AllowActivities `mapstructure:"allowactivities" json:"allowactivities"`
+	Audit           PrivacyAudit     `mapstructure:"audit" json:"audit"`
 	DSA             *AccountDSA      `mapstructure:"dsa" json:"dsa"`
 	IPv6Config      IPv6             `mapstructure:"ipv6" json:"ipv6"`
 	IPv4Config      IPv4             `mapstructure:"ipv4" json:"ipv4"`
 	PrivacySandbox  PrivacySandbox   `mapstructure:"privacysandbox" json:"privacysandbox"`
+	USNat           USNat            `mapstructure:"usnat" json:"usnat"`
+	// PrecisionProfiles are named degrees of generalisation of the geolocation and IP addresses of the user,
+	// referenced by activity rules and by PrecisionPolicies
+	PrecisionProfiles map[string]PrecisionProfile `mapstructure:"precision_profiles" json:"precision_profiles"`
+	PrecisionPolicies PrecisionPolicies           `mapstructure:"precision_policies" json:"precision_policies"`
+}
+
+// PrecisionProfile replaces the default rounding of the geolocation and masking of the IP addresses of the
+// user when precise geolocation can't be transmitted. Fields left unset keep the default treatment.
+type PrecisionProfile struct {
+	LatLonDecimals *int `mapstructure:"latlon_decimals" json:"latlon_decimals"`
+	DropCity       bool `mapstructure:"drop_city" json:"drop_city"`
+	DropZip        bool `mapstructure:"drop_zip" json:"drop_zip"`
+	DropMetro      bool `mapstructure:"drop_metro" json:"drop_metro"`
+	DropGeo        bool `mapstructure:"drop_geo" json:"drop_geo"`
+	IPv4KeepBits   *int `mapstructure:"ipv4_keep_bits" json:"ipv4_keep_bits"`
+	IPv6KeepBits   *int `mapstructure:"ipv6_keep_bits" json:"ipv6_keep_bits"`
+}
+
+// ValidateAdditionalCurrencies checks the additional currencies are ISO 4217 currency codes. The errors name
+// the fields relative to the account config.
+func (a *Account) ValidateAdditionalCurrencies(errs []error) []error {
+	for i, cur := range a.AdditionalCurrencies {
+		if _, err := currency.ParseISO(cur); err != nil {
+			errs = append(errs, fmt.Errorf("additional_currencies[%d]: currency code %s is not recognized or malformed", i, cur))
+		}
+	}
+	return errs
+}
+
+// PrecisionPolicies names the precision profiles applied when GDPR or the GPP US sections restrict precise
+// geolocation. An empty name keeps the default treatment.
+type PrecisionPolicies struct {
+	GDPR  string `mapstructure:"gdpr" json:"gdpr"`
+	USNat string `mapstructure:"usnat" json:"usnat"`
+}
+
+// ValidatePrecision checks the precision profiles and that every profile referenced is defined. The errors name
+// the fields relative to the account privacy config.
+func (p *AccountPrivacy) ValidatePrecision(errs []error) []error {
+	for _, name := range slices.Sorted(maps.Keys(p.PrecisionProfiles)) {
+		profile := p.PrecisionProfiles[name]
+		if profile.LatLonDecimals != nil && (*profile.LatLonDecimals < 0 || *profile.LatLonDecimals > maxLatLonDecimals) {
+			errs = append(errs, fmt.Errorf("precision_profiles.%s.latlon_decimals must be in the range [0, %d]", name, maxLatLonDecimals))
+		}
+		if profile.IPv4KeepBits != nil && (*profile.IPv4KeepBits < 0 || *profile.IPv4KeepBits > iputil.IPv4BitSize) {
+			errs = append(errs, fmt.Errorf("precision_profiles.%s.ipv4_keep_bits must be in the range [0, %d]", name, iputil.IPv4BitSize))
+		}
+		if profile.IPv6KeepBits != nil && (*profile.IPv6KeepBits < 0 || *profile.IPv6KeepBits > iputil.IPv6BitSize) {
+			errs = append(errs, fmt.Errorf("precision_profiles.%s.ipv6_keep_bits must be in the range [0, %d]", name, iputil.IPv6BitSize))
+		}
+	}
+
+	references := [][2]string{
+		{"precision_policies.gdpr", p.PrecisionPolicies.GDPR},
+		{"precision_policies.usnat", p.PrecisionPolicies.USNat},
+	}
+	if p.AllowActivities != nil {
+		for i, rule := range p.AllowActivities.TransmitPreciseGeo.Rules {
+			references = append(references, [2]string{fmt.Sprintf("allowactivities.transmitPreciseGeo.rules[%d].precision_profile", i), rule.PrecisionProfile})
+		}
+	}
+	for _, reference := range references {
+		field, name := reference[0], reference[1]
+		if _, ok := p.PrecisionProfiles[name]; name != "" && !ok {
+			errs = append(errs, fmt.Errorf("%s references undefined precision profile %q", field, name))
+		}
+	}
+	return errs
+}
+
+// maxLatLonDecimals is the finest precision of a precision profile, about 10cm
+const maxLatLonDecimals = 6
+
+// PrivacyAudit configures recording of the activity decisions and the scrubbing applied to each
+// component of a request. DryRunActivities is a rule set evaluated alongside the enforced one, whose
+// decisions are recorded but never enforced. Setting it implies the audit is enabled.
+type PrivacyAudit struct {
+	Enabled          bool             `mapstructure:"enabled" json:"enabled"`
+	DryRunActivities *AllowActivities `mapstructure:"dry_run_activities" json:"dry_run_activities"`
 }
 
 type PrivacySandbox struct {
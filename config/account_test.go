@@ -7,6 +7,7 @@ import (
 
 	"github.com/prebid/go-gdpr/consentconstants"
 	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/prebid/prebid-server/v4/util/ptrutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -956,6 +957,70 @@ func TestAccountPriceFloorsValidate(t *testing.T) {
 			},
 			want: []error{errors.New("account_defaults.price_floors.fetch.max_schema_dims should not be less than 0 and greater than 20")},
 		},
+		{
+			description: "Invalid timezone",
+			pf: &AccountPriceFloors{
+				EnforceFloorsRate: 100,
+				MaxRule:           200,
+				MaxSchemaDims:     10,
+				Timezone:          "Mars/Olympus_Mons",
+				Fetcher: AccountFloorFetch{
+					Period:  300,
+					MaxAge:  600,
+					Timeout: 12,
+				},
+			},
+			want: []error{errors.New("account_defaults.price_floors.timezone is invalid: unknown time zone Mars/Olympus_Mons")},
+		},
+		{
+			description: "Valid optimizer",
+			pf: &AccountPriceFloors{
+				EnforceFloorsRate: 100,
+				MaxRule:           200,
+				MaxSchemaDims:     10,
+				Fetcher: AccountFloorFetch{
+					Period:  300,
+					MaxAge:  600,
+					Timeout: 12,
+				},
+				Optimizer: AccountFloorOptimizer{
+					Enabled:         true,
+					SchemaFields:    []string{"mediaType", "size"},
+					PricePoints:     []float64{0.5, 1, 2},
+					WindowSec:       3600,
+					MinAuctions:     50,
+					ExplorationRate: 10,
+					HoldoutRate:     5,
+				},
+			},
+		},
+		{
+			description: "Invalid optimizer",
+			pf: &AccountPriceFloors{
+				EnforceFloorsRate: 100,
+				MaxRule:           200,
+				MaxSchemaDims:     10,
+				Fetcher: AccountFloorFetch{
+					Period:  300,
+					MaxAge:  600,
+					Timeout: 12,
+				},
+				Optimizer: AccountFloorOptimizer{
+					Enabled:         true,
+					PricePoints:     []float64{1, 0.5},
+					WindowSec:       30,
+					ExplorationRate: 60,
+					HoldoutRate:     40,
+				},
+			},
+			want: []error{
+				errors.New("account_defaults.price_floors.optimizer.schema_fields should not be empty"),
+				errors.New("account_defaults.price_floors.optimizer.price_points should be positive and in ascending order"),
+				errors.New("account_defaults.price_floors.optimizer.window_sec should not be less than 60 seconds"),
+				errors.New("account_defaults.price_floors.optimizer.min_auctions should be greater than 0"),
+				errors.New("account_defaults.price_floors.optimizer.exploration_rate and holdout_rate should not be negative and should add up to less than 100"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.description, func(t *testing.T) {
@@ -1011,3 +1076,135 @@ func TestIPMaskingValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestUSNatValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		usnat USNat
+		want  []error
+	}{
+		{
+			name:  "default-mapping",
+			usnat: USNat{Enabled: true},
+		},
+		{
+			name: "valid-override",
+			usnat: USNat{
+				Enabled: true,
+				Activities: USNatActivities{
+					SyncUser:        []USNatSignal{USNatSignalSaleOptOut, USNatSignalGPC},
+					TransmitUserFPD: []USNatSignal{},
+				},
+			},
+		},
+		{
+			name: "unknown-signal",
+			usnat: USNat{
+				Enabled: true,
+				Activities: USNatActivities{
+					TransmitPreciseGeo: []USNatSignal{USNatSignalPreciseGeo, "geo"},
+				},
+			},
+			want: []error{
+				errors.New(`account_defaults.privacy.usnat.activities.transmitPreciseGeo has unknown signal "geo"`),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.usnat.Validate("account_defaults.privacy.usnat", nil)
+			assert.ElementsMatch(t, errs, tt.want)
+		})
+	}
+}
+
+func TestAccountValidateAdditionalCurrencies(t *testing.T) {
+	tests := []struct {
+		name       string
+		currencies []string
+		want       []error
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:       "valid",
+			currencies: []string{"EUR", "gbp"},
+		},
+		{
+			name:       "malformed",
+			currencies: []string{"EUR", "EURO", ""},
+			want: []error{
+				errors.New("additional_currencies[1]: currency code EURO is not recognized or malformed"),
+				errors.New("additional_currencies[2]: currency code  is not recognized or malformed"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := Account{AdditionalCurrencies: tt.currencies}
+			assert.Equal(t, tt.want, account.ValidateAdditionalCurrencies(nil))
+		})
+	}
+}
+
+func TestAccountPrivacyValidatePrecision(t *testing.T) {
+	tests := []struct {
+		name    string
+		privacy AccountPrivacy
+		want    []error
+	}{
+		{
+			name:    "none",
+			privacy: AccountPrivacy{},
+		},
+		{
+			name: "valid",
+			privacy: AccountPrivacy{
+				PrecisionProfiles: map[string]PrecisionProfile{
+					"coarse": {LatLonDecimals: ptrutil.ToPtr(1), DropZip: true, IPv4KeepBits: ptrutil.ToPtr(16), IPv6KeepBits: ptrutil.ToPtr(48)},
+					"none":   {DropGeo: true},
+				},
+				PrecisionPolicies: PrecisionPolicies{GDPR: "none", USNat: "coarse"},
+				AllowActivities: &AllowActivities{
+					TransmitPreciseGeo: Activity{Rules: []ActivityRule{{Allow: false, PrecisionProfile: "coarse"}}},
+				},
+			},
+		},
+		{
+			name: "out-of-range",
+			privacy: AccountPrivacy{
+				PrecisionProfiles: map[string]PrecisionProfile{
+					"bad": {LatLonDecimals: ptrutil.ToPtr(7), IPv4KeepBits: ptrutil.ToPtr(33), IPv6KeepBits: ptrutil.ToPtr(-1)},
+				},
+			},
+			want: []error{
+				errors.New("precision_profiles.bad.latlon_decimals must be in the range [0, 6]"),
+				errors.New("precision_profiles.bad.ipv4_keep_bits must be in the range [0, 32]"),
+				errors.New("precision_profiles.bad.ipv6_keep_bits must be in the range [0, 128]"),
+			},
+		},
+		{
+			name: "undefined-references",
+			privacy: AccountPrivacy{
+				PrecisionPolicies: PrecisionPolicies{GDPR: "missing"},
+				AllowActivities: &AllowActivities{
+					TransmitPreciseGeo: Activity{Rules: []ActivityRule{{}, {PrecisionProfile: "other"}}},
+				},
+			},
+			want: []error{
+				errors.New(`precision_policies.gdpr references undefined precision profile "missing"`),
+				errors.New(`allowactivities.transmitPreciseGeo.rules[1].precision_profile references undefined precision profile "other"`),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.privacy.ValidatePrecision(nil)
+			assert.Equal(t, tt.want, errs)
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package config
 
+import (
+	"fmt"
+	"slices"
+)
+
 type AllowActivities struct {
 	SyncUser                 Activity `mapstructure:"syncUser" json:"syncUser"`
 	FetchBids                Activity `mapstructure:"fetchBids" json:"fetchBids"`
@@ -9,6 +14,7 @@ type AllowActivities struct {
 	TransmitPreciseGeo       Activity `mapstructure:"transmitPreciseGeo" json:"transmitPreciseGeo"`
 	TransmitUniqueRequestIds Activity `mapstructure:"transmitUniqueRequestIds" json:"transmitUniqueRequestIds"`
 	TransmitTids             Activity `mapstructure:"transmitTid" json:"transmitTid"`
+	TransmitEids             Activity `mapstructure:"transmitEids" json:"transmitEids"`
 }
 
 type Activity struct {
@@ -19,9 +25,86 @@ type Activity struct {
 type ActivityRule struct {
 	Condition ActivityCondition `mapstructure:"condition" json:"condition"`
 	Allow     bool              `mapstructure:"allow" json:"allow"`
+	// PrecisionProfile names the precision profile applied when the rule denies transmitPreciseGeo
+	PrecisionProfile string `mapstructure:"precision_profile" json:"precision_profile"`
 }
 
 type ActivityCondition struct {
 	ComponentName []string `mapstructure:"componentName" json:"componentName"`
 	ComponentType []string `mapstructure:"componentType" json:"componentType"`
+	// EIDSource matches the source of the eid being transmitted, so only applies to the transmitEids activity
+	EIDSource []string `mapstructure:"eidSource" json:"eidSource"`
+}
+
+// USNat configures enforcement of the GPP US National and US state sections. Each activity lists the
+// consent signals which deny it. An activity left unset uses the default mapping and an empty list
+// disables enforcement of that activity.
+type USNat struct {
+	Enabled    bool            `mapstructure:"enabled" json:"enabled"`
+	Activities USNatActivities `mapstructure:"activities" json:"activities"`
+}
+
+type USNatActivities struct {
+	SyncUser                 []USNatSignal `mapstructure:"syncUser" json:"syncUser"`
+	EnrichUserFPD            []USNatSignal `mapstructure:"enrichUfpd" json:"enrichUfpd"`
+	TransmitUserFPD          []USNatSignal `mapstructure:"transmitUfpd" json:"transmitUfpd"`
+	TransmitPreciseGeo       []USNatSignal `mapstructure:"transmitPreciseGeo" json:"transmitPreciseGeo"`
+	TransmitUniqueRequestIds []USNatSignal `mapstructure:"transmitUniqueRequestIds" json:"transmitUniqueRequestIds"`
+	TransmitEids             []USNatSignal `mapstructure:"transmitEids" json:"transmitEids"`
+}
+
+// USNatSignal is a consent signal of the GPP US National and US state sections.
+type USNatSignal string
+
+const (
+	USNatSignalSaleOptOut                USNatSignal = "sale_opt_out"
+	USNatSignalSharingOptOut             USNatSignal = "sharing_opt_out"
+	USNatSignalTargetedAdvertisingOptOut USNatSignal = "targeted_advertising_opt_out"
+	USNatSignalMissingNotice             USNatSignal = "missing_notice"
+	USNatSignalSensitiveData             USNatSignal = "sensitive_data"
+	USNatSignalPreciseGeo                USNatSignal = "precise_geo"
+	USNatSignalKnownChild                USNatSignal = "known_child"
+	USNatSignalPersonalData              USNatSignal = "personal_data"
+	USNatSignalGPC                       USNatSignal = "gpc"
+	USNatSignalServiceProviderMode       USNatSignal = "service_provider_mode"
+)
+
+func USNatSignals() []USNatSignal {
+	return []USNatSignal{
+		USNatSignalSaleOptOut,
+		USNatSignalSharingOptOut,
+		USNatSignalTargetedAdvertisingOptOut,
+		USNatSignalMissingNotice,
+		USNatSignalSensitiveData,
+		USNatSignalPreciseGeo,
+		USNatSignalKnownChild,
+		USNatSignalPersonalData,
+		USNatSignalGPC,
+		USNatSignalServiceProviderMode,
+	}
+}
+
+// Validate checks the activities list known signals only. The errors are prefixed with the path of the
+// config, which differs between the host account defaults and the stored accounts.
+func (u *USNat) Validate(prefix string, errs []error) []error {
+	activities := []struct {
+		name    string
+		signals []USNatSignal
+	}{
+		{"syncUser", u.Activities.SyncUser},
+		{"enrichUfpd", u.Activities.EnrichUserFPD},
+		{"transmitUfpd", u.Activities.TransmitUserFPD},
+		{"transmitPreciseGeo", u.Activities.TransmitPreciseGeo},
+		{"transmitUniqueRequestIds", u.Activities.TransmitUniqueRequestIds},
+		{"transmitEids", u.Activities.TransmitEids},
+	}
+
+	for _, activity := range activities {
+		for _, signal := range activity.signals {
+			if !slices.Contains(USNatSignals(), signal) {
+				errs = append(errs, fmt.Errorf("%s.activities.%s has unknown signal %q", prefix, activity.name, signal))
+			}
+		}
+	}
+	return errs
 }
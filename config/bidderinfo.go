@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 
@@ -36,6 +37,9 @@ type BidderInfo struct {
 	Debug                   *DebugInfo        `yaml:"debug" mapstructure:"debug"`
 	Geoscope                []string          `yaml:"geoscope" mapstructure:"geoscope"`
 	GVLVendorID             uint16            `yaml:"gvlVendorID" mapstructure:"gvlVendorID"`
+	// ATPProviderID is the id of the bidder in Google's Ad Tech Providers list, which is used to enforce
+	// the Google Additional Consent string for bidders which are not registered with the IAB.
+	ATPProviderID int `yaml:"atpProviderID" mapstructure:"atpProviderID"`
 
 	Syncer *Syncer `yaml:"userSync" mapstructure:"userSync"`
 
@@ -103,6 +107,32 @@ type OpenRTBInfo struct {
 	Version              string `yaml:"version" mapstructure:"version"`
 	GPPSupported         bool   `yaml:"gpp-supported" mapstructure:"gpp-supported"`
 	MultiformatSupported *bool  `yaml:"multiformat-supported" mapstructure:"multiformat-supported"`
+	// PrivacySignals lists the formats of privacy signals read by the bidder. If empty, the bidder reads the
+	// legacy TCF and US Privacy signals, and the GPP signals too if GPPSupported is set. If set without GPP,
+	// the legacy signals of the bidder's requests conflicting with GPP are replaced by the GPP ones.
+	PrivacySignals []PrivacySignal `yaml:"privacy-signals" mapstructure:"privacy-signals"`
+}
+
+// PrivacySignal is a format of privacy signals of a bid request
+type PrivacySignal string
+
+// Formats of privacy signals a bidder may read. TCF covers regs.gdpr and user.consent, USP regs.us_privacy
+// and GPP regs.gpp and regs.gpp_sid.
+const (
+	PrivacySignalGPP PrivacySignal = "gpp"
+	PrivacySignalTCF PrivacySignal = "tcf"
+	PrivacySignalUSP PrivacySignal = "usp"
+)
+
+// ReadsPrivacySignal returns true if a bidder with the OpenRTB info reads the format of privacy signals
+func (info *OpenRTBInfo) ReadsPrivacySignal(signal PrivacySignal) bool {
+	if info == nil {
+		return signal != PrivacySignalGPP
+	}
+	if len(info.PrivacySignals) == 0 {
+		return signal != PrivacySignalGPP || info.GPPSupported
+	}
+	return slices.Contains(info.PrivacySignals, signal)
 }
 
 // Syncer specifies the user sync settings for a bidder. This struct is shared by the account config,
@@ -135,6 +165,9 @@ type Syncer struct {
 
 	// SkipWhen allows bidders to specify when they don't want to sync
 	SkipWhen *SkipWhen `yaml:"skipwhen" mapstructure:"skipwhen"`
+
+	// UIDPolicy allows bidders to specify how long their user ids live and when they should be refreshed
+	UIDPolicy *SyncerUIDPolicy `yaml:"uidPolicy" mapstructure:"uid_policy"`
 }
 
 func (s *Syncer) Equal(other *Syncer) bool {
@@ -153,7 +186,31 @@ func (s *Syncer) Equal(other *Syncer) bool {
 		s.ExternalURL == other.ExternalURL &&
 		s.FormatOverride == other.FormatOverride &&
 		ptrutil.Equal(s.Enabled, other.Enabled) &&
-		s.SkipWhen.Equal(other.SkipWhen)
+		s.SkipWhen.Equal(other.SkipWhen) &&
+		s.UIDPolicy.Equal(other.UIDPolicy)
+}
+
+// DefaultUIDTTLHours is the Prebid Server default lifetime of the user ids stored for a syncer
+const DefaultUIDTTLHours = 14 * 24
+
+// SyncerUIDPolicy configures the lifetime of the user ids stored for a syncer. A zero TTLHours uses the
+// Prebid Server default. Ids within RefreshWindowHours of expiring are eligible to be synced again.
+type SyncerUIDPolicy struct {
+	TTLHours           int `yaml:"ttlHours" mapstructure:"ttl_hours"`
+	RefreshWindowHours int `yaml:"refreshWindowHours" mapstructure:"refresh_window_hours"`
+}
+
+func (p *SyncerUIDPolicy) Equal(other *SyncerUIDPolicy) bool {
+	if p == nil && other == nil {
+		return true
+	}
+
+	if p == nil || other == nil {
+		return false
+	}
+
+	return p.TTLHours == other.TTLHours &&
+		p.RefreshWindowHours == other.RefreshWindowHours
 }
 
 type SkipWhen struct {
@@ -261,7 +318,8 @@ func (s *Syncer) Defined() bool {
 		s.Redirect != nil ||
 		s.ExternalURL != "" ||
 		s.FormatOverride != "" ||
-		s.SkipWhen != nil
+		s.SkipWhen != nil ||
+		s.UIDPolicy != nil
 }
 
 type InfoReader interface {
@@ -370,7 +428,9 @@ func processBidderAliases(aliasNillableFieldsByBidder map[string]aliasNillableFi
 		parentBidderInfo := bidderInfos[aliasBidderInfo.AliasOf]
 		// Note: The aliasBidderInfo.GVLVendorID is intentionally never set to the parent's
 		// GVLVendorID. Each alias must declare its own GVL Vendor ID, as inheriting from the
-		// parent is not safe for legal reasons.
+		// parent is not safe for legal reasons. The ATPProviderID isn't set to the parent's either,
+		// but an alias without its own is checked with the parent's by the setuid endpoint and the
+		// auction, as the alias is operated through the parent's adapter.
 		if aliasBidderInfo.AppSecret == "" {
 			aliasBidderInfo.AppSecret = parentBidderInfo.AppSecret
 		}
@@ -435,6 +495,18 @@ func (infos BidderInfos) ToGVLVendorIDMap() map[openrtb_ext.BidderName]uint16 {
 	return gvlVendorIds
 }
 
+// ToATPProviderIDMap transforms a BidderInfos object to a map of bidder names to Google ATP provider id.
+// Disabled bidders are omitted from the result.
+func (infos BidderInfos) ToATPProviderIDMap() map[openrtb_ext.BidderName]int {
+	atpProviderIds := make(map[openrtb_ext.BidderName]int)
+	for name, info := range infos {
+		if info.IsEnabled() && info.ATPProviderID != 0 {
+			atpProviderIds[openrtb_ext.BidderName(name)] = info.ATPProviderID
+		}
+	}
+	return atpProviderIds
+}
+
 // validateBidderInfos validates bidder endpoint, info and syncer data
 func (infos BidderInfos) validate(errs []error) []error {
 	for bidderName, bidder := range infos {
@@ -529,6 +601,9 @@ func validateInfo(bidder BidderInfo, infos BidderInfos, bidderName string) error
 	if err := validateCapabilities(bidder.Capabilities, bidderName); err != nil {
 		return err
 	}
+	if err := validateOpenRTB(bidder.OpenRTB, bidderName); err != nil {
+		return err
+	}
 	if len(bidder.AliasOf) > 0 {
 		if err := validateAliasCapabilities(bidder, infos, bidderName); err != nil {
 			return err
@@ -628,6 +703,20 @@ func validateCapabilities(info *CapabilitiesInfo, bidderName string) error {
 	return nil
 }
 
+func validateOpenRTB(info *OpenRTBInfo, bidderName string) error {
+	if info == nil {
+		return nil
+	}
+
+	for index, signal := range info.PrivacySignals {
+		if signal != PrivacySignalGPP && signal != PrivacySignalTCF && signal != PrivacySignalUSP {
+			return fmt.Errorf("unrecognized openrtb.privacy-signals format at index %d: %s for adapter: %s", index, signal, bidderName)
+		}
+	}
+
+	return nil
+}
+
 func validatePlatformInfo(info *PlatformInfo) error {
 	if len(info.MediaTypes) == 0 {
 		return errors.New("at least one media type needs to be specified")
@@ -689,6 +778,22 @@ func validateSyncer(bidderInfo BidderInfo) error {
 		}
 	}
 
+	if policy := bidderInfo.Syncer.UIDPolicy; policy != nil {
+		if policy.TTLHours < 0 {
+			return fmt.Errorf("syncer could not be created, uid policy ttl hours must be positive or zero: %d", policy.TTLHours)
+		}
+		if policy.RefreshWindowHours < 0 {
+			return fmt.Errorf("syncer could not be created, uid policy refresh window hours must be positive or zero: %d", policy.RefreshWindowHours)
+		}
+		ttlHours := policy.TTLHours
+		if ttlHours == 0 {
+			ttlHours = DefaultUIDTTLHours
+		}
+		if policy.RefreshWindowHours >= ttlHours {
+			return fmt.Errorf("syncer could not be created, uid policy refresh window hours must be less than ttl hours %d: %d", ttlHours, policy.RefreshWindowHours)
+		}
+	}
+
 	return nil
 }
 
@@ -725,6 +830,9 @@ func applyBidderInfoConfigOverrides(configBidderInfos nillableFieldBidderInfos,
 		if configBidderInfo.bidderInfo.GVLVendorID > 0 {
 			mergedBidderInfo.GVLVendorID = configBidderInfo.bidderInfo.GVLVendorID
 		}
+		if configBidderInfo.bidderInfo.ATPProviderID > 0 {
+			mergedBidderInfo.ATPProviderID = configBidderInfo.bidderInfo.ATPProviderID
+		}
 		if configBidderInfo.bidderInfo.XAPI.Username != "" {
 			mergedBidderInfo.XAPI.Username = configBidderInfo.bidderInfo.XAPI.Username
 		}
@@ -804,6 +912,10 @@ func (s *Syncer) Override(original *Syncer) *Syncer {
 		copy.Enabled = s.Enabled
 	}
 
+	if s.UIDPolicy != nil {
+		copy.UIDPolicy = s.UIDPolicy
+	}
+
 	return &copy
 }
 
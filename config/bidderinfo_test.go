@@ -574,6 +574,22 @@ func TestToGVLVendorIDMap(t *testing.T) {
 	assert.Equal(t, expectedGVLVendorIDMap, result)
 }
 
+func TestToATPProviderIDMap(t *testing.T) {
+	givenBidderInfos := BidderInfos{
+		"bidderA": BidderInfo{Disabled: false, ATPProviderID: 0},
+		"bidderB": BidderInfo{Disabled: false, ATPProviderID: 100},
+		"bidderC": BidderInfo{Disabled: true, ATPProviderID: 0},
+		"bidderD": BidderInfo{Disabled: true, ATPProviderID: 200},
+	}
+
+	expectedATPProviderIDMap := map[openrtb_ext.BidderName]int{
+		"bidderB": 100,
+	}
+
+	result := givenBidderInfos.ToATPProviderIDMap()
+	assert.Equal(t, expectedATPProviderIDMap, result)
+}
+
 const bidderInfoRelativePath = "../static/bidder-info"
 
 // TestBidderInfoFiles ensures each bidder has a valid static/bidder-info/bidder.yaml file. Validation is performed directly
@@ -1392,6 +1408,102 @@ func TestBidderInfoValidationNegative(t *testing.T) {
 				errors.New("syncer could not be created, invalid format override value: x"),
 			},
 		},
+		{
+			"Invalid uid policy refresh window",
+			BidderInfos{
+				"bidderB": BidderInfo{
+					Endpoint: "http://bidderA.com/openrtb2",
+					Maintainer: &MaintainerInfo{
+						Email: "maintainer@bidderA.com",
+					},
+					Capabilities: &CapabilitiesInfo{
+						Site: &PlatformInfo{
+							MediaTypes: []openrtb_ext.BidType{
+								openrtb_ext.BidTypeBanner,
+							},
+						},
+					},
+					Syncer: &Syncer{
+						UIDPolicy: &SyncerUIDPolicy{TTLHours: 168, RefreshWindowHours: 168},
+					},
+				},
+			},
+			[]error{
+				errors.New("syncer could not be created, uid policy refresh window hours must be less than ttl hours 168: 168"),
+			},
+		},
+		{
+			"Invalid uid policy refresh window for the default ttl",
+			BidderInfos{
+				"bidderB": BidderInfo{
+					Endpoint: "http://bidderA.com/openrtb2",
+					Maintainer: &MaintainerInfo{
+						Email: "maintainer@bidderA.com",
+					},
+					Capabilities: &CapabilitiesInfo{
+						Site: &PlatformInfo{
+							MediaTypes: []openrtb_ext.BidType{
+								openrtb_ext.BidTypeBanner,
+							},
+						},
+					},
+					Syncer: &Syncer{
+						UIDPolicy: &SyncerUIDPolicy{RefreshWindowHours: 400},
+					},
+				},
+			},
+			[]error{
+				errors.New("syncer could not be created, uid policy refresh window hours must be less than ttl hours 336: 400"),
+			},
+		},
+		{
+			"Negative uid policy ttl",
+			BidderInfos{
+				"bidderB": BidderInfo{
+					Endpoint: "http://bidderA.com/openrtb2",
+					Maintainer: &MaintainerInfo{
+						Email: "maintainer@bidderA.com",
+					},
+					Capabilities: &CapabilitiesInfo{
+						Site: &PlatformInfo{
+							MediaTypes: []openrtb_ext.BidType{
+								openrtb_ext.BidTypeBanner,
+							},
+						},
+					},
+					Syncer: &Syncer{
+						UIDPolicy: &SyncerUIDPolicy{TTLHours: -1},
+					},
+				},
+			},
+			[]error{
+				errors.New("syncer could not be created, uid policy ttl hours must be positive or zero: -1"),
+			},
+		},
+		{
+			"Unknown privacy signal",
+			BidderInfos{
+				"bidderA": BidderInfo{
+					Endpoint: "http://bidderA.com/openrtb2",
+					Maintainer: &MaintainerInfo{
+						Email: "maintainer@bidderA.com",
+					},
+					Capabilities: &CapabilitiesInfo{
+						Site: &PlatformInfo{
+							MediaTypes: []openrtb_ext.BidType{
+								openrtb_ext.BidTypeBanner,
+							},
+						},
+					},
+					OpenRTB: &OpenRTBInfo{
+						PrivacySignals: []PrivacySignal{PrivacySignalGPP, "ccpa"},
+					},
+				},
+			},
+			[]error{
+				errors.New("unrecognized openrtb.privacy-signals format at index 1: ccpa for adapter: bidderA"),
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -1437,6 +1549,12 @@ func TestSyncerOverride(t *testing.T) {
 			givenOverride: &Syncer{},
 			expected:      &Syncer{Key: "anyKey"},
 		},
+		{
+			description:   "Override UIDPolicy",
+			givenOriginal: &Syncer{UIDPolicy: &SyncerUIDPolicy{TTLHours: 336}},
+			givenOverride: &Syncer{UIDPolicy: &SyncerUIDPolicy{TTLHours: 168, RefreshWindowHours: 24}},
+			expected:      &Syncer{UIDPolicy: &SyncerUIDPolicy{TTLHours: 168, RefreshWindowHours: 24}},
+		},
 		{
 			description:   "Override Key",
 			givenOriginal: &Syncer{Key: "original"},
@@ -2081,6 +2199,11 @@ func TestSyncerDefined(t *testing.T) {
 			givenSyncer: &Syncer{SkipWhen: &SkipWhen{}},
 			expected:    true,
 		},
+		{
+			name:        "uidpolicy-only",
+			givenSyncer: &Syncer{UIDPolicy: &SyncerUIDPolicy{}},
+			expected:    true,
+		},
 		{
 			name:        "supports-only",
 			givenSyncer: &Syncer{Supports: []string{"anySupports"}},
@@ -2534,3 +2657,50 @@ func TestValidateGeoscope(t *testing.T) {
 		})
 	}
 }
+
+func TestReadsPrivacySignal(t *testing.T) {
+	testCases := []struct {
+		name        string
+		info        *OpenRTBInfo
+		expectedGPP bool
+		expectedTCF bool
+		expectedUSP bool
+	}{
+		{
+			name:        "nil",
+			info:        nil,
+			expectedGPP: false,
+			expectedTCF: true,
+			expectedUSP: true,
+		},
+		{
+			name:        "gpp-not-supported",
+			info:        &OpenRTBInfo{},
+			expectedGPP: false,
+			expectedTCF: true,
+			expectedUSP: true,
+		},
+		{
+			name:        "gpp-supported",
+			info:        &OpenRTBInfo{GPPSupported: true},
+			expectedGPP: true,
+			expectedTCF: true,
+			expectedUSP: true,
+		},
+		{
+			name:        "privacy-signals",
+			info:        &OpenRTBInfo{GPPSupported: false, PrivacySignals: []PrivacySignal{PrivacySignalGPP, PrivacySignalUSP}},
+			expectedGPP: true,
+			expectedTCF: false,
+			expectedUSP: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedGPP, test.info.ReadsPrivacySignal(PrivacySignalGPP), "gpp")
+			assert.Equal(t, test.expectedTCF, test.info.ReadsPrivacySignal(PrivacySignalTCF), "tcf")
+			assert.Equal(t, test.expectedUSP, test.info.ReadsPrivacySignal(PrivacySignalUSP), "usp")
+		})
+	}
+}
@@ -56,6 +56,9 @@ type Configuration struct {
 	// Note that StoredVideo refers to stored video requests, and has nothing to do with caching video creatives.
 	StoredVideo     StoredRequests `mapstructure:"stored_video_req"`
 	StoredResponses StoredRequests `mapstructure:"stored_responses"`
+	// StoredFloors holds the price floors data referenced by ID from the account or the request. As they
+	// are fetched during the auction, the http and database backends require an in_memory_cache
+	StoredFloors StoredRequests `mapstructure:"stored_floors"`
 	// StoredRequestsTimeout defines the number of milliseconds before a timeout occurs with stored requests fetch
 	StoredRequestsTimeout int `mapstructure:"stored_requests_timeout_ms"`
 
@@ -105,6 +108,9 @@ type Configuration struct {
 	Hooks       Hooks       `mapstructure:"hooks"`
 	Validations Validations `mapstructure:"validations"`
 	PriceFloors PriceFloors `mapstructure:"price_floors"`
+
+	// Notifications configures the win and billing notice URLs of the bids fired by PBS on behalf of the clients
+	Notifications Notifications `mapstructure:"notifications"`
 }
 
 type Admin struct {
@@ -123,6 +129,50 @@ type PriceFloorFetcher struct {
 	MaxRetries int        `mapstructure:"max_retries"`
 }
 
+// Notifications configures the worker pool firing the win (nurl) and billing (burl) notice URLs of the bids
+type Notifications struct {
+	Enabled    bool `mapstructure:"enabled"`
+	Worker     int  `mapstructure:"worker"`
+	Capacity   int  `mapstructure:"capacity"`
+	MaxRetries int  `mapstructure:"max_retries"`
+	// RetryBackoffMS is the delay before the first retry of a failed notification, doubled on every retry
+	RetryBackoffMS int `mapstructure:"retry_backoff_ms"`
+	TimeoutMS      int `mapstructure:"timeout_ms"`
+	// BillingTTLSeconds is how long the billing notice URL of a bid is kept waiting for the billing event of the bid.
+	// The billing notice URLs are only fired when generate_bid_id is enabled, as the bid IDs of the bidders aren't
+	// unique across auctions.
+	BillingTTLSeconds int `mapstructure:"billing_ttl_seconds"`
+	CacheSize         int `mapstructure:"cache_size_mb"`
+}
+
+func (cfg *Notifications) validate(errs []error) []error {
+	if !cfg.Enabled {
+		return errs
+	}
+	if cfg.Worker <= 0 {
+		errs = append(errs, fmt.Errorf("notifications.worker must be > 0. Got %d", cfg.Worker))
+	}
+	if cfg.Capacity <= 0 {
+		errs = append(errs, fmt.Errorf("notifications.capacity must be > 0. Got %d", cfg.Capacity))
+	}
+	if cfg.MaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("notifications.max_retries must be >= 0. Got %d", cfg.MaxRetries))
+	}
+	if cfg.RetryBackoffMS < 0 {
+		errs = append(errs, fmt.Errorf("notifications.retry_backoff_ms must be >= 0. Got %d", cfg.RetryBackoffMS))
+	}
+	if cfg.TimeoutMS <= 0 {
+		errs = append(errs, fmt.Errorf("notifications.timeout_ms must be > 0. Got %d", cfg.TimeoutMS))
+	}
+	if cfg.BillingTTLSeconds <= 0 {
+		errs = append(errs, fmt.Errorf("notifications.billing_ttl_seconds must be > 0. Got %d", cfg.BillingTTLSeconds))
+	}
+	if cfg.CacheSize <= 0 {
+		errs = append(errs, fmt.Errorf("notifications.cache_size_mb must be > 0. Got %d", cfg.CacheSize))
+	}
+	return errs
+}
+
 const MIN_COOKIE_SIZE_BYTES = 500
 
 type HTTPClient struct {
@@ -166,6 +216,7 @@ func (cfg *Configuration) validate(v *viper.Viper) []error {
 	errs = cfg.CategoryMapping.validate(errs)
 	errs = cfg.StoredVideo.validate(errs)
 	errs = cfg.Metrics.validate(errs)
+	errs = cfg.HostCookie.validate(errs)
 	if cfg.MaxRequestSize < 0 {
 		errs = append(errs, fmt.Errorf("cfg.max_request_size must be >= 0. Got %d", cfg.MaxRequestSize))
 	}
@@ -174,6 +225,7 @@ func (cfg *Configuration) validate(v *viper.Viper) []error {
 	errs = cfg.Debug.validate(errs)
 	errs = cfg.ExtCacheURL.validate(errs)
 	errs = cfg.AccountDefaults.PriceFloors.validate(errs)
+	errs = cfg.Notifications.validate(errs)
 	if cfg.AccountDefaults.Disabled {
 		logger.Warnf(`With account_defaults.disabled=true, host-defined accounts must exist and have "disabled":false. All other requests will be rejected.`)
 	}
@@ -184,8 +236,17 @@ func (cfg *Configuration) validate(v *viper.Viper) []error {
 
 	errs = cfg.Experiment.validate(errs)
 	errs = cfg.BidderInfos.validate(errs)
+	errs = cfg.UserSync.IDStore.validate(cfg.HostCookie, errs)
+	errs = cfg.UserSync.ValueRanking.validate(errs)
 	errs = cfg.AccountDefaults.Privacy.IPv6Config.Validate(errs)
 	errs = cfg.AccountDefaults.Privacy.IPv4Config.Validate(errs)
+	errs = cfg.AccountDefaults.Privacy.USNat.Validate("account_defaults.privacy.usnat", errs)
+	for _, err := range cfg.AccountDefaults.Privacy.ValidatePrecision(nil) {
+		errs = append(errs, fmt.Errorf("account_defaults.privacy.%w", err))
+	}
+	for _, err := range cfg.AccountDefaults.ValidateAdditionalCurrencies(nil) {
+		errs = append(errs, fmt.Errorf("account_defaults.%w", err))
+	}
 
 	return errs
 }
@@ -274,6 +335,9 @@ type GDPR struct {
 	TCF2                    TCF2 `mapstructure:"tcf2"`
 	AMPException            bool `mapstructure:"amp_exception"` // Deprecated: Use account-level GDPR settings (gdpr.integration_enabled.amp) instead
 	LiveGVLRefreshInterval  int  `mapstructure:"live_gvl_refresh_interval_seconds"`
+	// VendorListDir is a directory of Global Vendor List JSON files loaded at startup, before any list is
+	// fetched from the network. Lists uploaded through the admin endpoint are saved to it.
+	VendorListDir string `mapstructure:"vendorlist_dir"`
 	// EEACountries (EEA = European Economic Area) are a list of countries where we should assume GDPR applies.
 	// If the gdpr flag is unset in a request, but geo.country is set, we will assume GDPR applies if and only
 	// if the country matches one on this list. If both the GDPR flag and country are not set, we default
@@ -486,6 +550,31 @@ type CurrencyConverter struct {
 	FetchTimeoutMilliseconds int    `mapstructure:"fetch_timeout_ms"`
 	FetchIntervalSeconds     int    `mapstructure:"fetch_interval_seconds"`
 	StaleRatesSeconds        int    `mapstructure:"stale_rates_seconds"`
+	// Sources lists the rates sources tried in order, the next one being used when a source fails or returns stale
+	// rates. FetchURL is the only source when empty.
+	Sources []CurrencyRatesSource `mapstructure:"sources"`
+	// SnapshotFile is the file the last known good rates are persisted to and loaded from at startup
+	SnapshotFile string `mapstructure:"snapshot_file"`
+	// HistoryRetentionSeconds is how long the rates snapshots are kept for conversions as of a past time
+	HistoryRetentionSeconds int `mapstructure:"history_retention_seconds"`
+}
+
+// CurrencyRatesSourceType is the format of a currency rates source
+type CurrencyRatesSourceType string
+
+const (
+	// CurrencyRatesSourceJSON is a URL returning the Prebid currency file JSON format
+	CurrencyRatesSourceJSON CurrencyRatesSourceType = "json"
+	// CurrencyRatesSourceECB is a URL returning the European Central Bank reference rates XML format
+	CurrencyRatesSourceECB CurrencyRatesSourceType = "ecb"
+	// CurrencyRatesSourceFile is a local file in the Prebid currency file JSON format
+	CurrencyRatesSourceFile CurrencyRatesSourceType = "file"
+)
+
+type CurrencyRatesSource struct {
+	Type CurrencyRatesSourceType `mapstructure:"type"`
+	URL  string                  `mapstructure:"url"`
+	Path string                  `mapstructure:"path"`
 }
 
 func (cfg *CurrencyConverter) validate(errs []error) []error {
@@ -495,6 +584,23 @@ func (cfg *CurrencyConverter) validate(errs []error) []error {
 	if cfg.FetchTimeoutMilliseconds < 0 {
 		errs = append(errs, fmt.Errorf("currency_converter.fetch_timeout_ms must be 0 or greater. Got %d", cfg.FetchTimeoutMilliseconds))
 	}
+	if cfg.HistoryRetentionSeconds < 0 {
+		errs = append(errs, fmt.Errorf("currency_converter.history_retention_seconds must be 0 or greater. Got %d", cfg.HistoryRetentionSeconds))
+	}
+	for i, source := range cfg.Sources {
+		switch source.Type {
+		case CurrencyRatesSourceJSON, CurrencyRatesSourceECB:
+			if len(source.URL) == 0 {
+				errs = append(errs, fmt.Errorf("currency_converter.sources[%d].url is required for the %s source type", i, source.Type))
+			}
+		case CurrencyRatesSourceFile:
+			if len(source.Path) == 0 {
+				errs = append(errs, fmt.Errorf("currency_converter.sources[%d].path is required for the %s source type", i, source.Type))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("currency_converter.sources[%d].type must be one of %s, %s or %s. Got %q", i, CurrencyRatesSourceJSON, CurrencyRatesSourceECB, CurrencyRatesSourceFile, source.Type))
+		}
+	}
 	return errs
 }
 
@@ -566,21 +672,50 @@ type HostCookie struct {
 	OptOutCookie       Cookie `mapstructure:"optout_cookie"`
 	// Cookie timeout in days
 	TTL int64 `mapstructure:"ttl_days"`
+	// Format used when writing the uids cookie. Both formats are always accepted when reading.
+	UIDsEncoding string `mapstructure:"uids_encoding"`
+	// Partitioned configures CHIPS partitioned copies of the uids and host cookies.
+	Partitioned HostCookiePartitioned `mapstructure:"partitioned"`
 }
 
+// HostCookiePartitioned configures CHIPS partitioned cookies, which remain available in browsers that
+// restrict third party cookies.
+type HostCookiePartitioned struct {
+	// Enabled writes a partitioned copy of the uids cookie alongside the unpartitioned cookie. Accounts
+	// may override this with cookie_sync.partitioned.
+	Enabled bool `mapstructure:"enabled"`
+	// HostCookieName is the name of a partitioned copy of the host cookie, read when the host cookie is absent.
+	HostCookieName string `mapstructure:"host_cookie_name"`
+}
+
+const (
+	UIDsEncodingBase64  = "base64"
+	UIDsEncodingCompact = "compact"
+)
+
 func (cfg *HostCookie) TTLDuration() time.Duration {
 	return time.Duration(cfg.TTL) * time.Hour * 24
 }
 
+func (cfg *HostCookie) validate(errs []error) []error {
+	switch cfg.UIDsEncoding {
+	case "", UIDsEncodingBase64, UIDsEncodingCompact:
+	default:
+		errs = append(errs, fmt.Errorf("host_cookie.uids_encoding must be one of [%s, %s]. Got %s", UIDsEncodingBase64, UIDsEncodingCompact, cfg.UIDsEncoding))
+	}
+	return errs
+}
+
 type RequestTimeoutHeaders struct {
 	RequestTimeInQueue    string `mapstructure:"request_time_in_queue"`
 	RequestTimeoutInQueue string `mapstructure:"request_timeout_in_queue"`
 }
 
 type Metrics struct {
-	Influxdb   InfluxMetrics     `mapstructure:"influxdb"`
-	Prometheus PrometheusMetrics `mapstructure:"prometheus"`
-	Disabled   DisabledMetrics   `mapstructure:"disabled_metrics"`
+	Influxdb          InfluxMetrics     `mapstructure:"influxdb"`
+	Prometheus        PrometheusMetrics `mapstructure:"prometheus"`
+	Disabled          DisabledMetrics   `mapstructure:"disabled_metrics"`
+	AccountLabelLimit AccountLabelLimit `mapstructure:"account_label_limit"`
 }
 
 type DisabledMetrics struct {
@@ -612,6 +747,9 @@ type DisabledMetrics struct {
 }
 
 func (cfg *Metrics) validate(errs []error) []error {
+	if cfg.AccountLabelLimit.MaxValues < 0 {
+		errs = append(errs, fmt.Errorf("metrics.account_label_limit.max_values must be positive or zero. Got %d", cfg.AccountLabelLimit.MaxValues))
+	}
 	return cfg.Prometheus.validate(errs)
 }
 
@@ -632,6 +770,18 @@ type PrometheusMetrics struct {
 	TimeoutMillisRaw int    `mapstructure:"timeout_ms"`
 }
 
+// AccountLabelLimit bounds the number of distinct account label values tracked by each
+// account-level metric family, in Prometheus and in the go-metrics registry. Accounts seen
+// after the limit is reached are folded into a shared "other" label value, so the "other"
+// account ID is rejected while the limit is enabled.
+type AccountLabelLimit struct {
+	// Maximum number of distinct account values per metric family. Zero means no limit.
+	MaxValues int `mapstructure:"max_values"`
+	// Accounts which are always reported under their own label value and do not count
+	// towards MaxValues.
+	Allowlist []string `mapstructure:"allowlist"`
+}
+
 func (cfg *PrometheusMetrics) validate(errs []error) []error {
 	if cfg.Port > 0 && cfg.TimeoutMillisRaw <= 0 {
 		errs = append(errs, fmt.Errorf("metrics.prometheus.timeout_ms must be positive if metrics.prometheus.port is defined. Got timeout=%d and port=%d", cfg.TimeoutMillisRaw, cfg.Port))
@@ -972,6 +1122,9 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("host_cookie.value", "")
 	v.SetDefault("host_cookie.ttl_days", 90)
 	v.SetDefault("host_cookie.max_cookie_size_bytes", 0)
+	v.SetDefault("host_cookie.uids_encoding", UIDsEncodingBase64)
+	v.SetDefault("host_cookie.partitioned.enabled", false)
+	v.SetDefault("host_cookie.partitioned.host_cookie_name", "")
 	v.SetDefault("host_schain_node", nil)
 	v.SetDefault("validations.banner_creative_max_size", ValidationSkip)
 	v.SetDefault("validations.secure_markup", ValidationSkip)
@@ -1017,6 +1170,8 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("metrics.prometheus.namespace", "")
 	v.SetDefault("metrics.prometheus.subsystem", "")
 	v.SetDefault("metrics.prometheus.timeout_ms", 10000)
+	v.SetDefault("metrics.account_label_limit.max_values", 0)
+	v.SetDefault("metrics.account_label_limit.allowlist", []string{})
 	v.SetDefault("category_mapping.filesystem.enabled", true)
 	v.SetDefault("category_mapping.filesystem.directorypath", "./static/category-mapping")
 	v.SetDefault("category_mapping.http.endpoint", "")
@@ -1122,6 +1277,38 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("stored_responses.http_events.endpoint", "")
 	v.SetDefault("stored_responses.http_events.refresh_rate_seconds", 0)
 	v.SetDefault("stored_responses.http_events.timeout_ms", 0)
+	v.SetDefault("stored_floors.database.connection.driver", "")
+	v.SetDefault("stored_floors.database.connection.dbname", "")
+	v.SetDefault("stored_floors.database.connection.host", "")
+	v.SetDefault("stored_floors.database.connection.port", 0)
+	v.SetDefault("stored_floors.database.connection.user", "")
+	v.SetDefault("stored_floors.database.connection.password", "")
+	v.SetDefault("stored_floors.database.connection.query_string", "")
+	v.SetDefault("stored_floors.database.connection.tls.root_cert", "")
+	v.SetDefault("stored_floors.database.connection.tls.client_cert", "")
+	v.SetDefault("stored_floors.database.connection.tls.client_key", "")
+	v.SetDefault("stored_floors.database.fetcher.query", "")
+	v.SetDefault("stored_floors.database.fetcher.amp_query", "")
+	v.SetDefault("stored_floors.database.initialize_caches.timeout_ms", 0)
+	v.SetDefault("stored_floors.database.initialize_caches.query", "")
+	v.SetDefault("stored_floors.database.initialize_caches.amp_query", "")
+	v.SetDefault("stored_floors.database.poll_for_updates.refresh_rate_seconds", 0)
+	v.SetDefault("stored_floors.database.poll_for_updates.timeout_ms", 0)
+	v.SetDefault("stored_floors.database.poll_for_updates.query", "")
+	v.SetDefault("stored_floors.database.poll_for_updates.amp_query", "")
+	v.SetDefault("stored_floors.filesystem.enabled", false)
+	v.SetDefault("stored_floors.filesystem.directorypath", "")
+	v.SetDefault("stored_floors.http.endpoint", "")
+	v.SetDefault("stored_floors.in_memory_cache.type", "none")
+	v.SetDefault("stored_floors.in_memory_cache.ttl_seconds", 0)
+	v.SetDefault("stored_floors.in_memory_cache.request_cache_size_bytes", 0)
+	v.SetDefault("stored_floors.in_memory_cache.imp_cache_size_bytes", 0)
+	v.SetDefault("stored_floors.in_memory_cache.resp_cache_size_bytes", 0)
+	v.SetDefault("stored_floors.cache_events.enabled", false)
+	v.SetDefault("stored_floors.cache_events.endpoint", "/storedrequests/floors")
+	v.SetDefault("stored_floors.http_events.endpoint", "")
+	v.SetDefault("stored_floors.http_events.refresh_rate_seconds", 0)
+	v.SetDefault("stored_floors.http_events.timeout_ms", 0)
 
 	v.SetDefault("vtrack.timeout_ms", 2000)
 	v.SetDefault("vtrack.allow_unknown_bidder", true)
@@ -1132,6 +1319,26 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("video.enable_deprecated_endpoint", false)
 
 	v.SetDefault("user_sync.priority_groups", [][]string{})
+	v.SetDefault("user_sync.id_store.enabled", false)
+	v.SetDefault("user_sync.id_store.type", "memory")
+	v.SetDefault("user_sync.id_store.ttl_days", 90)
+	v.SetDefault("user_sync.id_store.timeout_ms", 50)
+	v.SetDefault("user_sync.id_store.memory.max_host_ids", 1000000)
+	v.SetDefault("user_sync.id_store.memory.sweep_interval_seconds", 600)
+	v.SetDefault("user_sync.id_store.database.connection.driver", "")
+	v.SetDefault("user_sync.id_store.database.connection.dbname", "")
+	v.SetDefault("user_sync.id_store.database.connection.host", "")
+	v.SetDefault("user_sync.id_store.database.connection.port", 0)
+	v.SetDefault("user_sync.id_store.database.connection.user", "")
+	v.SetDefault("user_sync.id_store.database.connection.password", "")
+	v.SetDefault("user_sync.id_store.database.connection.query_string", "")
+	v.SetDefault("user_sync.id_store.database.get_query", "")
+	v.SetDefault("user_sync.id_store.database.set_query", "")
+	v.SetDefault("user_sync.id_store.database.delete_query", "")
+	v.SetDefault("user_sync.id_store.database.delete_all_query", "")
+	v.SetDefault("user_sync.value_ranking.enabled", false)
+	v.SetDefault("user_sync.value_ranking.half_life_minutes", 60)
+	v.SetDefault("user_sync.value_ranking.max_accounts", 10000)
 
 	v.SetDefault("accounts.filesystem.enabled", false)
 	v.SetDefault("accounts.filesystem.directorypath", "./stored_requests/data/by_id")
@@ -1178,6 +1385,7 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("gdpr.timeouts_ms.active_vendorlist_fetch", 0)
 	v.SetDefault("gdpr.non_standard_publishers", []string{""})
 	v.SetDefault("gdpr.live_gvl_refresh_interval_seconds", 86400) // 1 day
+	v.SetDefault("gdpr.vendorlist_dir", "")
 	v.SetDefault("gdpr.tcf2.enabled", true)
 	v.SetDefault("gdpr.tcf2.purpose1.enforce_vendors", true)
 	v.SetDefault("gdpr.tcf2.purpose2.enforce_vendors", true)
@@ -1210,6 +1418,8 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("currency_converter.fetch_timeout_ms", 60000)      // 60 seconds
 	v.SetDefault("currency_converter.fetch_interval_seconds", 1800) // fetch currency rates every 30 minutes
 	v.SetDefault("currency_converter.stale_rates_seconds", 0)
+	v.SetDefault("currency_converter.snapshot_file", "")
+	v.SetDefault("currency_converter.history_retention_seconds", 86400) // keep the rates snapshots of the last day
 	v.SetDefault("default_request.type", "")
 	v.SetDefault("default_request.file.name", "")
 	v.SetDefault("default_request.alias_info", false)
@@ -1224,14 +1434,25 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("account_defaults.price_floors.use_dynamic_data", false)
 	v.SetDefault("account_defaults.price_floors.max_rules", 100)
 	v.SetDefault("account_defaults.price_floors.max_schema_dims", 3)
+	v.SetDefault("account_defaults.price_floors.timezone", "UTC")
 	v.SetDefault("account_defaults.price_floors.fetch.enabled", false)
 	v.SetDefault("account_defaults.price_floors.fetch.url", "")
+	v.SetDefault("account_defaults.price_floors.fetch.floors_id", "")
 	v.SetDefault("account_defaults.price_floors.fetch.timeout_ms", 3000)
 	v.SetDefault("account_defaults.price_floors.fetch.max_file_size_kb", 100)
 	v.SetDefault("account_defaults.price_floors.fetch.max_rules", 1000)
 	v.SetDefault("account_defaults.price_floors.fetch.max_age_sec", 86400)
 	v.SetDefault("account_defaults.price_floors.fetch.period_sec", 3600)
 	v.SetDefault("account_defaults.price_floors.fetch.max_schema_dims", 0)
+	v.SetDefault("account_defaults.price_floors.optimizer.enabled", false)
+	v.SetDefault("account_defaults.price_floors.optimizer.schema_fields", []string{"mediaType", "size"})
+	v.SetDefault("account_defaults.price_floors.optimizer.price_points", []float64{0.05, 0.1, 0.25, 0.5, 0.75, 1, 1.5, 2, 3, 5, 10})
+	v.SetDefault("account_defaults.price_floors.optimizer.window_sec", 3600)
+	v.SetDefault("account_defaults.price_floors.optimizer.min_auctions", 50)
+	v.SetDefault("account_defaults.price_floors.optimizer.exploration_rate", 10)
+	v.SetDefault("account_defaults.price_floors.optimizer.holdout_rate", 5)
+	v.SetDefault("account_defaults.auction_macros.enabled", false)
+	v.SetDefault("account_defaults.auction_macros.second_price", false)
 	v.SetDefault("account_defaults.privacy.privacysandbox.topicsdomain", "")
 	v.SetDefault("account_defaults.privacy.privacysandbox.cookiedeprecation.enabled", false)
 	v.SetDefault("account_defaults.privacy.privacysandbox.cookiedeprecation.ttl_sec", 604800)
@@ -1241,6 +1462,8 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.BindEnv("account_defaults.privacy.dsa.gdpr_only")
 	v.SetDefault("account_defaults.privacy.ipv6.anon_keep_bits", 56)
 	v.SetDefault("account_defaults.privacy.ipv4.anon_keep_bits", 24)
+	v.SetDefault("account_defaults.privacy.usnat.enabled", false)
+	v.SetDefault("account_defaults.privacy.audit.enabled", false)
 
 	//Defaults for Price floor fetcher
 	v.SetDefault("price_floors.fetcher.worker", 20)
@@ -1256,6 +1479,16 @@ func SetupViper(v *viper.Viper, filename string, bidderInfos BidderInfos) {
 	v.SetDefault("price_floors.fetcher.http_client.dialer.keep_alive_seconds", 15)
 	v.SetDefault("price_floors.fetcher.max_retries", 10)
 
+	v.SetDefault("notifications.enabled", false)
+	v.SetDefault("notifications.worker", 20)
+	v.SetDefault("notifications.capacity", 20000)
+	v.SetDefault("notifications.max_retries", 3)
+	v.SetDefault("notifications.retry_backoff_ms", 500)
+	v.SetDefault("notifications.timeout_ms", 1000)
+	v.SetDefault("notifications.billing_ttl_seconds", 3600)
+	v.SetDefault("notifications.cache_size_mb", 16)
+	v.SetDefault("account_defaults.notifications.enabled", false)
+
 	v.SetDefault("account_defaults.events_enabled", false)
 	v.SetDefault("compression.response.enable_gzip", false)
 	v.SetDefault("compression.request.enable_gzip", false)
@@ -1380,6 +1613,7 @@ func setBidderDefaults(v *viper.Viper, bidder string) {
 	v.BindEnv(adapterCfgPrefix + ".endpointCompression")
 	v.BindEnv(adapterCfgPrefix + ".openrtb.version")
 	v.BindEnv(adapterCfgPrefix + ".openrtb.gpp-supported")
+	v.BindEnv(adapterCfgPrefix + ".openrtb.privacy-signals")
 
 	v.BindEnv(adapterCfgPrefix + ".usersync.enabled")
 	v.BindEnv(adapterCfgPrefix + ".usersync.key")
@@ -152,6 +152,8 @@ func TestDefaults(t *testing.T) {
 	cmpInts(t, "host_cookie.max_cookie_size_bytes", 0, cfg.HostCookie.MaxCookieSizeBytes)
 	cmpInts(t, "currency_converter.fetch_interval_seconds", 1800, cfg.CurrencyConverter.FetchIntervalSeconds)
 	cmpStrings(t, "currency_converter.fetch_url", "https://cdn.jsdelivr.net/gh/prebid/currency-file@1/latest.json", cfg.CurrencyConverter.FetchURL)
+	cmpStrings(t, "currency_converter.snapshot_file", "", cfg.CurrencyConverter.SnapshotFile)
+	cmpInts(t, "currency_converter.history_retention_seconds", 86400, cfg.CurrencyConverter.HistoryRetentionSeconds)
 	cmpBools(t, "account_required", false, cfg.AccountRequired)
 	cmpInts(t, "metrics.influxdb.collection_rate_seconds", 20, cfg.Metrics.Influxdb.MetricSendInterval)
 	cmpBools(t, "account_adapter_details", false, cfg.Metrics.Disabled.AccountAdapterDetails)
@@ -181,6 +183,11 @@ func TestDefaults(t *testing.T) {
 	cmpStrings(t, "accounts.http_events.endpoint", "", cfg.Accounts.HTTPEvents.Endpoint)
 	cmpInts(t, "accounts.http_events.refresh_rate_seconds", 0, int(cfg.Accounts.HTTPEvents.RefreshRate))
 	cmpInts(t, "accounts.http_events.timeout_ms", 0, int(cfg.Accounts.HTTPEvents.Timeout))
+	cmpBools(t, "stored_floors.filesystem.enabled", false, cfg.StoredFloors.Files.Enabled)
+	cmpStrings(t, "stored_floors.http.endpoint", "", cfg.StoredFloors.HTTP.Endpoint)
+	cmpStrings(t, "stored_floors.in_memory_cache.type", "none", cfg.StoredFloors.InMemoryCache.Type)
+	cmpBools(t, "stored_floors.cache_events.enabled", false, cfg.StoredFloors.CacheEvents.Enabled)
+	cmpStrings(t, "stored_floors.cache_events.endpoint", "/storedrequests/floors", cfg.StoredFloors.CacheEvents.Endpoint)
 	cmpBools(t, "auto_gen_source_tid", true, cfg.AutoGenSourceTID)
 	cmpBools(t, "generate_bid_id", false, cfg.GenerateBidID)
 	cmpStrings(t, "experiment.adscert.mode", "off", cfg.Experiment.AdCerts.Mode)
@@ -203,18 +210,30 @@ func TestDefaults(t *testing.T) {
 	cmpInts(t, "price_floors.fetcher.http_client.max_idle_connections_per_host", 2, cfg.PriceFloors.Fetcher.HttpClient.MaxIdleConnsPerHost)
 	cmpInts(t, "price_floors.fetcher.http_client.idle_connection_timeout_seconds", 60, cfg.PriceFloors.Fetcher.HttpClient.IdleConnTimeout)
 	cmpInts(t, "price_floors.fetcher.max_retries", 10, cfg.PriceFloors.Fetcher.MaxRetries)
+	cmpBools(t, "notifications.enabled", false, cfg.Notifications.Enabled)
+	cmpInts(t, "notifications.worker", 20, cfg.Notifications.Worker)
+	cmpInts(t, "notifications.capacity", 20000, cfg.Notifications.Capacity)
+	cmpInts(t, "notifications.max_retries", 3, cfg.Notifications.MaxRetries)
+	cmpInts(t, "notifications.retry_backoff_ms", 500, cfg.Notifications.RetryBackoffMS)
+	cmpInts(t, "notifications.timeout_ms", 1000, cfg.Notifications.TimeoutMS)
+	cmpInts(t, "notifications.billing_ttl_seconds", 3600, cfg.Notifications.BillingTTLSeconds)
+	cmpInts(t, "notifications.cache_size_mb", 16, cfg.Notifications.CacheSize)
+	cmpBools(t, "account_defaults.notifications.enabled", false, cfg.AccountDefaults.Notifications.Enabled)
 
 	// Assert compression related defaults
 	cmpBools(t, "compression.request.enable_gzip", false, cfg.Compression.Request.GZIP)
 	cmpBools(t, "compression.response.enable_gzip", false, cfg.Compression.Response.GZIP)
 
 	cmpBools(t, "account_defaults.price_floors.enabled", false, cfg.AccountDefaults.PriceFloors.Enabled)
+	cmpBools(t, "account_defaults.auction_macros.enabled", false, cfg.AccountDefaults.AuctionMacros.Enabled)
+	cmpBools(t, "account_defaults.auction_macros.second_price", false, cfg.AccountDefaults.AuctionMacros.SecondPrice)
 	cmpInts(t, "account_defaults.price_floors.enforce_floors_rate", 100, cfg.AccountDefaults.PriceFloors.EnforceFloorsRate)
 	cmpBools(t, "account_defaults.price_floors.adjust_for_bid_adjustment", true, cfg.AccountDefaults.PriceFloors.AdjustForBidAdjustment)
 	cmpBools(t, "account_defaults.price_floors.enforce_deal_floors", false, cfg.AccountDefaults.PriceFloors.EnforceDealFloors)
 	cmpBools(t, "account_defaults.price_floors.use_dynamic_data", false, cfg.AccountDefaults.PriceFloors.UseDynamicData)
 	cmpInts(t, "account_defaults.price_floors.max_rules", 100, cfg.AccountDefaults.PriceFloors.MaxRule)
 	cmpInts(t, "account_defaults.price_floors.max_schema_dims", 3, cfg.AccountDefaults.PriceFloors.MaxSchemaDims)
+	cmpStrings(t, "account_defaults.price_floors.timezone", "UTC", cfg.AccountDefaults.PriceFloors.Timezone)
 	cmpBools(t, "account_defaults.price_floors.fetch.enabled", false, cfg.AccountDefaults.PriceFloors.Fetcher.Enabled)
 	cmpStrings(t, "account_defaults.price_floors.fetch.url", "", cfg.AccountDefaults.PriceFloors.Fetcher.URL)
 	cmpInts(t, "account_defaults.price_floors.fetch.timeout_ms", 3000, cfg.AccountDefaults.PriceFloors.Fetcher.Timeout)
@@ -223,6 +242,13 @@ func TestDefaults(t *testing.T) {
 	cmpInts(t, "account_defaults.price_floors.fetch.period_sec", 3600, cfg.AccountDefaults.PriceFloors.Fetcher.Period)
 	cmpInts(t, "account_defaults.price_floors.fetch.max_age_sec", 86400, cfg.AccountDefaults.PriceFloors.Fetcher.MaxAge)
 	cmpInts(t, "account_defaults.price_floors.fetch.max_schema_dims", 0, cfg.AccountDefaults.PriceFloors.Fetcher.MaxSchemaDims)
+	cmpBools(t, "account_defaults.price_floors.optimizer.enabled", false, cfg.AccountDefaults.PriceFloors.Optimizer.Enabled)
+	assert.Equal(t, []string{"mediaType", "size"}, cfg.AccountDefaults.PriceFloors.Optimizer.SchemaFields, "account_defaults.price_floors.optimizer.schema_fields")
+	assert.Equal(t, []float64{0.05, 0.1, 0.25, 0.5, 0.75, 1, 1.5, 2, 3, 5, 10}, cfg.AccountDefaults.PriceFloors.Optimizer.PricePoints, "account_defaults.price_floors.optimizer.price_points")
+	cmpInts(t, "account_defaults.price_floors.optimizer.window_sec", 3600, cfg.AccountDefaults.PriceFloors.Optimizer.WindowSec)
+	cmpInts(t, "account_defaults.price_floors.optimizer.min_auctions", 50, cfg.AccountDefaults.PriceFloors.Optimizer.MinAuctions)
+	cmpInts(t, "account_defaults.price_floors.optimizer.exploration_rate", 10, cfg.AccountDefaults.PriceFloors.Optimizer.ExplorationRate)
+	cmpInts(t, "account_defaults.price_floors.optimizer.holdout_rate", 5, cfg.AccountDefaults.PriceFloors.Optimizer.HoldoutRate)
 	cmpStrings(t, "account_defaults.privacy.topicsdomain", "", cfg.AccountDefaults.Privacy.PrivacySandbox.TopicsDomain)
 	cmpBools(t, "account_defaults.privacy.privacysandbox.cookiedeprecation.enabled", false, cfg.AccountDefaults.Privacy.PrivacySandbox.CookieDeprecation.Enabled)
 	cmpInts(t, "account_defaults.privacy.privacysandbox.cookiedeprecation.ttl_sec", 604800, cfg.AccountDefaults.Privacy.PrivacySandbox.CookieDeprecation.TTLSec)
@@ -245,6 +271,8 @@ func TestDefaults(t *testing.T) {
 
 	cmpInts(t, "account_defaults.privacy.ipv6.anon_keep_bits", 56, cfg.AccountDefaults.Privacy.IPv6Config.AnonKeepBits)
 	cmpInts(t, "account_defaults.privacy.ipv4.anon_keep_bits", 24, cfg.AccountDefaults.Privacy.IPv4Config.AnonKeepBits)
+	cmpBools(t, "account_defaults.privacy.usnat.enabled", false, cfg.AccountDefaults.Privacy.USNat.Enabled)
+	cmpBools(t, "account_defaults.privacy.audit.enabled", false, cfg.AccountDefaults.Privacy.Audit.Enabled)
 
 	//Assert purpose VendorExceptionMap hash tables were built correctly
 	cmpBools(t, "analytics.agma.enabled", false, cfg.Analytics.Agma.Enabled)
@@ -256,6 +284,7 @@ func TestDefaults(t *testing.T) {
 	cmpStrings(t, "analytics.agma.buffers.timeout", "15m", cfg.Analytics.Agma.Buffers.Timeout)
 	cmpInts(t, "analytics.agma.accounts", 0, len(cfg.Analytics.Agma.Accounts))
 	cmpInts(t, "gdpr.live_gvl_refresh_interval_seconds", 86400, cfg.GDPR.LiveGVLRefreshInterval)
+	cmpStrings(t, "gdpr.vendorlist_dir", "", cfg.GDPR.VendorListDir)
 	expectedTCF2 := TCF2{
 		Enabled: true,
 		Purpose1: TCF2Purpose{
@@ -1269,6 +1298,12 @@ func TestNegativePrometheusTimeout(t *testing.T) {
 	assertOneError(t, cfg.validate(v), "metrics.prometheus.timeout_ms must be positive if metrics.prometheus.port is defined. Got timeout=0 and port=8001")
 }
 
+func TestNegativeAccountLabelLimit(t *testing.T) {
+	cfg, v := newDefaultConfig(t)
+	cfg.Metrics.AccountLabelLimit.MaxValues = -1
+	assertOneError(t, cfg.validate(v), "metrics.account_label_limit.max_values must be positive or zero. Got -1")
+}
+
 func TestInvalidHostVendorID(t *testing.T) {
 	tests := []struct {
 		description  string
@@ -1366,6 +1401,110 @@ func TestOverflowedCurrencyConverterFetchInterval(t *testing.T) {
 	assert.NotNil(t, err, "cfg.currency_converter.fetch_interval_seconds prevent values over %d, but it doesn't", 0xffff)
 }
 
+func TestNegativeCurrencyConverterHistoryRetention(t *testing.T) {
+	cfg := CurrencyConverter{HistoryRetentionSeconds: -1}
+	errs := cfg.validate(nil)
+	assert.Equal(t, []error{errors.New("currency_converter.history_retention_seconds must be 0 or greater. Got -1")}, errs)
+}
+
+func TestCurrencyConverterSourcesValidation(t *testing.T) {
+	testCases := []struct {
+		name         string
+		sources      []CurrencyRatesSource
+		expectedErrs []error
+	}{
+		{
+			name: "valid_sources",
+			sources: []CurrencyRatesSource{
+				{Type: CurrencyRatesSourceJSON, URL: "https://currency.prebid.org"},
+				{Type: CurrencyRatesSourceECB, URL: "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"},
+				{Type: CurrencyRatesSourceFile, Path: "/etc/prebid/rates.json"},
+			},
+		},
+		{
+			name:    "missing_url",
+			sources: []CurrencyRatesSource{{Type: CurrencyRatesSourceECB}},
+			expectedErrs: []error{
+				errors.New("currency_converter.sources[0].url is required for the ecb source type"),
+			},
+		},
+		{
+			name:    "missing_path",
+			sources: []CurrencyRatesSource{{Type: CurrencyRatesSourceJSON, URL: "https://currency.prebid.org"}, {Type: CurrencyRatesSourceFile}},
+			expectedErrs: []error{
+				errors.New("currency_converter.sources[1].path is required for the file source type"),
+			},
+		},
+		{
+			name:    "invalid_type",
+			sources: []CurrencyRatesSource{{Type: "csv", URL: "https://currency.prebid.org"}},
+			expectedErrs: []error{
+				errors.New(`currency_converter.sources[0].type must be one of json, ecb or file. Got "csv"`),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := CurrencyConverter{Sources: tc.sources}
+			errs := cfg.validate(nil)
+			assert.Equal(t, tc.expectedErrs, errs)
+		})
+	}
+}
+
+func TestNotificationsValidation(t *testing.T) {
+	valid := Notifications{Enabled: true, Worker: 20, Capacity: 20000, MaxRetries: 3, RetryBackoffMS: 500, TimeoutMS: 1000, BillingTTLSeconds: 3600, CacheSize: 16}
+
+	testCases := []struct {
+		name          string
+		notifications func() Notifications
+		expectedErrs  []error
+	}{
+		{
+			name:          "valid",
+			notifications: func() Notifications { return valid },
+		},
+		{
+			name: "disabled_not_validated",
+			notifications: func() Notifications {
+				return Notifications{}
+			},
+		},
+		{
+			name: "invalid_pool",
+			notifications: func() Notifications {
+				cfg := valid
+				cfg.Worker = 0
+				cfg.MaxRetries = -1
+				return cfg
+			},
+			expectedErrs: []error{
+				errors.New("notifications.worker must be > 0. Got 0"),
+				errors.New("notifications.max_retries must be >= 0. Got -1"),
+			},
+		},
+		{
+			name: "invalid_billing_ttl",
+			notifications: func() Notifications {
+				cfg := valid
+				cfg.BillingTTLSeconds = 0
+				return cfg
+			},
+			expectedErrs: []error{
+				errors.New("notifications.billing_ttl_seconds must be > 0. Got 0"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := tc.notifications()
+			assert.Equal(t, tc.expectedErrs, cfg.validate(nil))
+		})
+	}
+}
+
 func TestLimitTimeout(t *testing.T) {
 	doTimeoutTest(t, 10, 15, 10, 0)
 	doTimeoutTest(t, 10, 0, 10, 0)
@@ -2031,3 +2170,104 @@ func TestUnpackDSADefault(t *testing.T) {
 		})
 	}
 }
+
+func TestUserSyncIDStoreValidation(t *testing.T) {
+	testCases := []struct {
+		description  string
+		idStore      UserSyncIDStore
+		hostCookie   HostCookie
+		expectedErrs []error
+	}{
+		{
+			description: "disabled",
+			idStore:     UserSyncIDStore{Enabled: false, Type: "invalid"},
+		},
+		{
+			description: "valid-memory",
+			idStore:     UserSyncIDStore{Enabled: true, Type: UserSyncIDStoreTypeMemory, TTL: 1, TimeoutMS: 10},
+			hostCookie:  HostCookie{CookieName: "host"},
+		},
+		{
+			description: "valid-database",
+			idStore: UserSyncIDStore{Enabled: true, Type: UserSyncIDStoreTypeDatabase, TTL: 1, TimeoutMS: 10,
+				Database: UserSyncIDStoreDatabase{
+					ConnectionInfo: DatabaseConnection{Driver: "postgres"},
+					GetQuery:       "get", SetQuery: "set", DeleteQuery: "delete", DeleteAllQuery: "delete_all",
+				},
+			},
+			hostCookie: HostCookie{CookieName: "host"},
+		},
+		{
+			description: "missing-host-cookie",
+			idStore:     UserSyncIDStore{Enabled: true, Type: UserSyncIDStoreTypeMemory, TTL: 1, TimeoutMS: 10},
+			expectedErrs: []error{
+				errors.New("user_sync.id_store requires host_cookie.cookie_name to be defined"),
+			},
+		},
+		{
+			description: "invalid-values",
+			idStore:     UserSyncIDStore{Enabled: true, Type: "invalid"},
+			hostCookie:  HostCookie{CookieName: "host"},
+			expectedErrs: []error{
+				errors.New("user_sync.id_store.ttl_days must be positive. Got 0"),
+				errors.New("user_sync.id_store.timeout_ms must be positive. Got 0"),
+				errors.New("user_sync.id_store.type must be one of [memory, database]. Got invalid"),
+			},
+		},
+		{
+			description: "invalid-memory-settings",
+			idStore: UserSyncIDStore{Enabled: true, Type: UserSyncIDStoreTypeMemory, TTL: 1, TimeoutMS: 10,
+				Memory: UserSyncIDStoreMemory{MaxHostIDs: -1, SweepIntervalSeconds: -1},
+			},
+			hostCookie: HostCookie{CookieName: "host"},
+			expectedErrs: []error{
+				errors.New("user_sync.id_store.memory.max_host_ids must be >= 0. Got -1"),
+				errors.New("user_sync.id_store.memory.sweep_interval_seconds must be >= 0. Got -1"),
+			},
+		},
+		{
+			description: "database-missing-settings",
+			idStore:     UserSyncIDStore{Enabled: true, Type: UserSyncIDStoreTypeDatabase, TTL: 1, TimeoutMS: 10},
+			hostCookie:  HostCookie{CookieName: "host"},
+			expectedErrs: []error{
+				errors.New("user_sync.id_store.database.connection.driver is required for the database store"),
+				errors.New("user_sync.id_store.database queries are required for the database store"),
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		errs := test.idStore.validate(test.hostCookie, nil)
+		assert.ElementsMatch(t, test.expectedErrs, errs, test.description)
+	}
+}
+
+func TestUserSyncValueRankingValidation(t *testing.T) {
+	testCases := []struct {
+		description  string
+		valueRanking UserSyncValueRanking
+		expectedErrs []error
+	}{
+		{
+			description:  "disabled",
+			valueRanking: UserSyncValueRanking{Enabled: false, HalfLifeMinutes: -1},
+		},
+		{
+			description:  "valid",
+			valueRanking: UserSyncValueRanking{Enabled: true, HalfLifeMinutes: 60, MaxAccounts: 100},
+		},
+		{
+			description:  "invalid-values",
+			valueRanking: UserSyncValueRanking{Enabled: true},
+			expectedErrs: []error{
+				errors.New("user_sync.value_ranking.half_life_minutes must be positive. Got 0"),
+				errors.New("user_sync.value_ranking.max_accounts must be positive. Got 0"),
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		errs := test.valueRanking.validate(nil)
+		assert.ElementsMatch(t, test.expectedErrs, errs, test.description)
+	}
+}
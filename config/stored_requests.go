@@ -18,6 +18,8 @@ const (
 	AMPRequestDataType DataType = "AMP Request"
 	AccountDataType    DataType = "Account"
 	ResponseDataType   DataType = "Response"
+	FloorsDataType     DataType = "Floors"
+	UserSyncIDDataType DataType = "User Sync ID"
 )
 
 // Section returns the config section this type is defined in
@@ -29,6 +31,8 @@ func (dataType DataType) Section() string {
 		AMPRequestDataType: "stored_amp_req",
 		AccountDataType:    "accounts",
 		ResponseDataType:   "stored_responses",
+		FloorsDataType:     "stored_floors",
+		UserSyncIDDataType: "user_sync.id_store",
 	}[dataType]
 }
 
@@ -135,6 +139,7 @@ func resolvedStoredRequestsConfig(cfg *Configuration) {
 	cfg.CategoryMapping.dataType = CategoryDataType
 	cfg.Accounts.dataType = AccountDataType
 	cfg.StoredResponses.dataType = ResponseDataType
+	cfg.StoredFloors.dataType = FloorsDataType
 }
 
 func (cfg *StoredRequests) validate(errs []error) []error {
@@ -164,6 +169,11 @@ func (cfg *StoredRequests) validate(errs []error) []error {
 		if cfg.Database.CacheInitialization.Query != "" {
 			errs = append(errs, fmt.Errorf("%s: database.initialize_caches.query must be empty if in_memory_cache=none", cfg.Section()))
 		}
+
+		// Stored floors are fetched on the auction path so the remote backends must be cached
+		if cfg.DataType() == FloorsDataType && (cfg.HTTP.Endpoint != "" || cfg.Database.ConnectionInfo.Database != "") {
+			errs = append(errs, fmt.Errorf("%s: in_memory_cache must not be none if stored floors are fetched via http or database", cfg.Section()))
+		}
 	}
 	errs = cfg.InMemoryCache.validate(cfg.DataType(), errs)
 	return errs
@@ -103,6 +103,57 @@ func TestInMemoryCacheValidationSingleCache(t *testing.T) {
 	}).validate(AccountDataType, nil))
 }
 
+func TestStoredFloorsCacheValidation(t *testing.T) {
+	tests := []struct {
+		description string
+		cfg         StoredRequests
+		expectErr   bool
+	}{
+		{
+			description: "Filesystem without cache",
+			cfg: StoredRequests{
+				Files:         FileFetcherConfig{Enabled: true},
+				InMemoryCache: InMemoryCache{Type: "none"},
+			},
+		},
+		{
+			description: "HTTP with cache",
+			cfg: StoredRequests{
+				HTTP:          HTTPFetcherConfig{Endpoint: "http://floors.test"},
+				InMemoryCache: InMemoryCache{Type: "unbounded"},
+			},
+		},
+		{
+			description: "HTTP without cache",
+			cfg: StoredRequests{
+				HTTP:          HTTPFetcherConfig{Endpoint: "http://floors.test"},
+				InMemoryCache: InMemoryCache{Type: "none"},
+			},
+			expectErr: true,
+		},
+		{
+			description: "Database without cache",
+			cfg: StoredRequests{
+				Database:      DatabaseConfig{ConnectionInfo: DatabaseConnection{Database: "db"}},
+				InMemoryCache: InMemoryCache{Type: "none"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			tt.cfg.dataType = FloorsDataType
+			errs := tt.cfg.validate(nil)
+			if tt.expectErr {
+				assert.Equal(t, []error{errors.New("stored_floors: in_memory_cache must not be none if stored floors are fetched via http or database")}, errs)
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
 func TestDatabaseConfigValidation(t *testing.T) {
 	tests := []struct {
 		description            string
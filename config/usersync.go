@@ -1,14 +1,140 @@
 package config
 
+import (
+	"fmt"
+	"time"
+)
+
 // UserSync specifies the static global user sync configuration.
 type UserSync struct {
-	Cooperative    UserSyncCooperative `mapstructure:"coop_sync"`
-	ExternalURL    string              `mapstructure:"external_url"`
-	RedirectURL    string              `mapstructure:"redirect_url"`
-	PriorityGroups [][]string          `mapstructure:"priority_groups"`
+	Cooperative    UserSyncCooperative  `mapstructure:"coop_sync"`
+	ExternalURL    string               `mapstructure:"external_url"`
+	RedirectURL    string               `mapstructure:"redirect_url"`
+	PriorityGroups [][]string           `mapstructure:"priority_groups"`
+	IDStore        UserSyncIDStore      `mapstructure:"id_store"`
+	ValueRanking   UserSyncValueRanking `mapstructure:"value_ranking"`
 }
 
 // UserSyncCooperative specifies the static global default cooperative cookie sync
 type UserSyncCooperative struct {
 	EnabledByDefault bool `mapstructure:"default"`
 }
+
+// UserSyncValueRanking configures the optional value based bidder prioritization of /cookie_sync. When
+// enabled, bidders are ranked by the bid rate and revenue they returned for the account in recent live
+// auctions instead of being shuffled randomly.
+type UserSyncValueRanking struct {
+	Enabled bool `mapstructure:"enabled"`
+	// HalfLifeMinutes is the time after which an observed auction outcome counts half as much.
+	HalfLifeMinutes int `mapstructure:"half_life_minutes"`
+	// MaxAccounts bounds the number of accounts tracked in memory. Auctions of further accounts are ignored.
+	MaxAccounts int `mapstructure:"max_accounts"`
+}
+
+func (cfg *UserSyncValueRanking) validate(errs []error) []error {
+	if !cfg.Enabled {
+		return errs
+	}
+
+	if cfg.HalfLifeMinutes <= 0 {
+		errs = append(errs, fmt.Errorf("user_sync.value_ranking.half_life_minutes must be positive. Got %d", cfg.HalfLifeMinutes))
+	}
+	if cfg.MaxAccounts <= 0 {
+		errs = append(errs, fmt.Errorf("user_sync.value_ranking.max_accounts must be positive. Got %d", cfg.MaxAccounts))
+	}
+
+	return errs
+}
+
+// HalfLife returns the decay half life of observed auction outcomes.
+func (cfg *UserSyncValueRanking) HalfLife() time.Duration {
+	return time.Duration(cfg.HalfLifeMinutes) * time.Minute
+}
+
+// UserSyncIDStore configures the optional server side store of bidder user IDs. Stored IDs are keyed by
+// the first party host ID of the user, read from the host cookie, and supplement the IDs held in the
+// uids cookie.
+type UserSyncIDStore struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Type is the storage backend to use. Supported values are "memory" and "database".
+	Type string `mapstructure:"type"`
+	// TTL of a stored ID in days.
+	TTL       int64                   `mapstructure:"ttl_days"`
+	TimeoutMS int                     `mapstructure:"timeout_ms"`
+	Memory    UserSyncIDStoreMemory   `mapstructure:"memory"`
+	Database  UserSyncIDStoreDatabase `mapstructure:"database"`
+}
+
+// UserSyncIDStoreMemory bounds the memory held by the in-memory backend of the user ID store.
+type UserSyncIDStoreMemory struct {
+	// MaxHostIDs caps the number of users kept, evicting the least recently written ones. Use 0 for no cap.
+	MaxHostIDs int `mapstructure:"max_host_ids"`
+	// SweepIntervalSeconds is how often the expired IDs of all users are purged. Use 0 to never purge them.
+	SweepIntervalSeconds int `mapstructure:"sweep_interval_seconds"`
+}
+
+// UserSyncIDStoreDatabase configures the database backend of the user ID store. Queries may reference the
+// $HOST_ID, $SYNCER_KEY, $UID and $EXPIRES parameters.
+type UserSyncIDStoreDatabase struct {
+	ConnectionInfo DatabaseConnection `mapstructure:"connection"`
+	// GetQuery returns the syncer key, uid and expiration columns for all IDs of $HOST_ID.
+	GetQuery string `mapstructure:"get_query"`
+	// SetQuery inserts or updates the ID of $SYNCER_KEY for $HOST_ID.
+	SetQuery string `mapstructure:"set_query"`
+	// DeleteQuery removes the ID of $SYNCER_KEY for $HOST_ID.
+	DeleteQuery string `mapstructure:"delete_query"`
+	// DeleteAllQuery removes all IDs of $HOST_ID.
+	DeleteAllQuery string `mapstructure:"delete_all_query"`
+}
+
+const (
+	UserSyncIDStoreTypeMemory   = "memory"
+	UserSyncIDStoreTypeDatabase = "database"
+)
+
+func (cfg *UserSyncIDStore) validate(hostCookie HostCookie, errs []error) []error {
+	if !cfg.Enabled {
+		return errs
+	}
+
+	if hostCookie.CookieName == "" {
+		errs = append(errs, fmt.Errorf("user_sync.id_store requires host_cookie.cookie_name to be defined"))
+	}
+	if cfg.TTL <= 0 {
+		errs = append(errs, fmt.Errorf("user_sync.id_store.ttl_days must be positive. Got %d", cfg.TTL))
+	}
+	if cfg.TimeoutMS <= 0 {
+		errs = append(errs, fmt.Errorf("user_sync.id_store.timeout_ms must be positive. Got %d", cfg.TimeoutMS))
+	}
+
+	switch cfg.Type {
+	case UserSyncIDStoreTypeMemory:
+		if cfg.Memory.MaxHostIDs < 0 {
+			errs = append(errs, fmt.Errorf("user_sync.id_store.memory.max_host_ids must be >= 0. Got %d", cfg.Memory.MaxHostIDs))
+		}
+		if cfg.Memory.SweepIntervalSeconds < 0 {
+			errs = append(errs, fmt.Errorf("user_sync.id_store.memory.sweep_interval_seconds must be >= 0. Got %d", cfg.Memory.SweepIntervalSeconds))
+		}
+	case UserSyncIDStoreTypeDatabase:
+		if cfg.Database.ConnectionInfo.Driver == "" {
+			errs = append(errs, fmt.Errorf("user_sync.id_store.database.connection.driver is required for the database store"))
+		}
+		if cfg.Database.GetQuery == "" || cfg.Database.SetQuery == "" || cfg.Database.DeleteQuery == "" || cfg.Database.DeleteAllQuery == "" {
+			errs = append(errs, fmt.Errorf("user_sync.id_store.database queries are required for the database store"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("user_sync.id_store.type must be one of [%s, %s]. Got %s", UserSyncIDStoreTypeMemory, UserSyncIDStoreTypeDatabase, cfg.Type))
+	}
+
+	return errs
+}
+
+// TTLDuration returns the time a stored ID remains valid.
+func (cfg *UserSyncIDStore) TTLDuration() time.Duration {
+	return time.Duration(cfg.TTL) * time.Hour * 24
+}
+
+// Timeout returns the maximum time to wait for a store operation.
+func (cfg *UserSyncIDStore) Timeout() time.Duration {
+	return time.Duration(cfg.TimeoutMS) * time.Millisecond
+}
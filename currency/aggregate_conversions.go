@@ -1,5 +1,7 @@
 package currency
 
+import "time"
+
 // AggregateConversions contains both the request-defined currency rate
 // map found in request.ext.prebid.currency and the currencies conversion
 // rates fetched with the RateConverter object defined in rate_converter.go
@@ -34,6 +36,28 @@ func (re *AggregateConversions) GetRate(from string, to string) (float64, error)
 	return re.serverRates.GetRate(from, to)
 }
 
+// GetRateAsOf returns the conversion rate between two currencies as it was at the given time, prioritizing
+// the customRates currency rate over that of the PBS currency rate service. The custom rates, as well as
+// PBS rates that don't keep a history, have no past rates so their current rate is used.
+func (re *AggregateConversions) GetRateAsOf(from string, to string, asOf time.Time) (float64, error) {
+	rate, err := re.customRates.GetRate(from, to)
+	if err == nil {
+		return rate, nil
+	} else if _, isMissingRateErr := err.(ConversionNotFoundError); !isMissingRateErr {
+		return 0, err
+	}
+
+	if serverRates, ok := re.serverRates.(SnapshotConversions); ok {
+		return serverRates.GetRateAsOf(from, to, asOf)
+	}
+	return re.serverRates.GetRate(from, to)
+}
+
+// SnapshotID returns the snapshot ID of the PBS currency rates
+func (re *AggregateConversions) SnapshotID() string {
+	return SnapshotID(re.serverRates)
+}
+
 // GetRates is not implemented for AggregateConversions . There is no need to call
 // this function for this scenario.
 func (r *AggregateConversions) GetRates() *map[string]map[string]float64 {
@@ -3,6 +3,7 @@ package currency
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -93,3 +94,24 @@ func TestGroupedGetRate(t *testing.T) {
 		}
 	}
 }
+
+func TestGroupedGetRateAsOf(t *testing.T) {
+	source := &mockRatesSource{name: "primary", rates: NewRates(map[string]map[string]float64{"USD": {"GBP": 0.77}})}
+	currencyConverter := NewRateConverterWithSources(time.Second, []RatesSource{source}, 0, time.Hour, "")
+	currencyConverter.time = &FakeTime{time: time.Date(2018, time.September, 12, 12, 0, 0, 0, time.UTC)}
+	assert.NoError(t, currencyConverter.Run())
+
+	customRates := NewRates(map[string]map[string]float64{"USD": {"EUR": 0.85}})
+	aggregateConversions := NewAggregateConversions(customRates, currencyConverter.Conversions())
+	asOf := time.Date(2018, time.September, 12, 13, 0, 0, 0, time.UTC)
+
+	rate, err := aggregateConversions.GetRateAsOf("USD", "EUR", asOf)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.85, rate)
+
+	rate, err = aggregateConversions.GetRateAsOf("USD", "GBP", asOf)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.77, rate)
+
+	assert.Equal(t, "20180912T120000.000Z", aggregateConversions.SnapshotID())
+}
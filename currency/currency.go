@@ -11,7 +11,7 @@ func GetAuctionCurrencyRates(currencyConverter *RateConverter, requestRates *ope
 
 	if requestRates == nil {
 		// No bidRequest.ext.currency field was found, use PBS rates as usual
-		return currencyConverter.Rates()
+		return currencyConverter.Conversions()
 	}
 
 	// currencyConverter will never be nil, refer main.serve(), adding this check for future usecases
@@ -33,10 +33,10 @@ func GetAuctionCurrencyRates(currencyConverter *RateConverter, requestRates *ope
 	// Both PBS and custom rates can be used, check if ConversionRates is not empty
 	if len(requestRates.ConversionRates) == 0 {
 		// Custom rates map is empty, use PBS rates only
-		return currencyConverter.Rates()
+		return currencyConverter.Conversions()
 	}
 
 	// Return an AggregateConversions object that includes both custom and PBS currency rates but will
 	// prioritize custom rates over PBS rates whenever a currency rate is found in both
-	return NewAggregateConversions(NewRates(requestRates.ConversionRates), currencyConverter.Rates())
+	return NewAggregateConversions(NewRates(requestRates.ConversionRates), currencyConverter.Conversions())
 }
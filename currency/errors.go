@@ -1,6 +1,9 @@
 package currency
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // ConversionNotFoundError is thrown by the currency.Conversions GetRate(from string, to string) method
 // when the conversion rate between the two currencies, nor its reciprocal, can be found.
@@ -11,3 +14,13 @@ type ConversionNotFoundError struct {
 func (err ConversionNotFoundError) Error() string {
 	return fmt.Sprintf("Currency conversion rate not found: '%s' => '%s'", err.FromCur, err.ToCur)
 }
+
+// SnapshotNotFoundError is thrown by the currency.SnapshotConversions GetRateAsOf(from string, to string, asOf time.Time)
+// method when no rates snapshot in use at that time is retained anymore.
+type SnapshotNotFoundError struct {
+	AsOf time.Time
+}
+
+func (err SnapshotNotFoundError) Error() string {
+	return fmt.Sprintf("Currency rates snapshot not found as of %s", err.AsOf.UTC().Format(time.RFC3339))
+}
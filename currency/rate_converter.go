@@ -2,13 +2,15 @@ package currency
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
-	"github.com/prebid/prebid-server/v4/errortypes"
 	"github.com/prebid/prebid-server/v4/logger"
 	"github.com/prebid/prebid-server/v4/util/jsonutil"
 	"github.com/prebid/prebid-server/v4/util/timeutil"
@@ -16,83 +18,128 @@ import (
 
 // RateConverter holds the currencies conversion rates dictionary
 type RateConverter struct {
-	httpClient          httpClient
 	httpTimeout         time.Duration
 	staleRatesThreshold time.Duration
-	syncSourceURL       string
+	sources             []RatesSource
+	historyRetention    time.Duration
+	snapshotFile        string
 	rates               atomic.Value // Should only hold Rates struct
+	currentSnapshot     atomic.Value // Should only hold *ratesSnapshot
 	lastUpdated         atomic.Value // Should only hold time.Time
+	activeSource        atomic.Value // Should only hold string
+	sourceErrors        sync.Map     // Holds the last fetch error of each source, by name
+	history             []*ratesSnapshot
+	historyMutex        sync.RWMutex
 	constantRates       Conversions
 	time                timeutil.Time
 }
 
-// NewRateConverter returns a new RateConverter
+// NewRateConverter returns a new RateConverter fetching the rates from a single URL
 func NewRateConverter(
 	httpClient httpClient,
 	httpTimeout time.Duration,
 	syncSourceURL string,
 	staleRatesThreshold time.Duration,
 ) *RateConverter {
-	return &RateConverter{
-		httpClient:          httpClient,
+	return NewRateConverterWithSources(
+		httpTimeout,
+		[]RatesSource{NewHTTPRatesSource(httpClient, syncSourceURL)},
+		staleRatesThreshold,
+		0,
+		"",
+	)
+}
+
+// NewRateConverterWithSources returns a new RateConverter fetching the rates from the first of the sources not failing
+// nor returning stale rates. The rates fetched are kept as snapshots for the history retention so conversions can be
+// made as of a past time. When a snapshot file is provided, the rates last fetched are persisted to it and loaded
+// from it at startup until the sources are fetched.
+func NewRateConverterWithSources(
+	httpTimeout time.Duration,
+	sources []RatesSource,
+	staleRatesThreshold time.Duration,
+	historyRetention time.Duration,
+	snapshotFile string,
+) *RateConverter {
+	rc := &RateConverter{
 		httpTimeout:         httpTimeout,
 		staleRatesThreshold: staleRatesThreshold,
-		syncSourceURL:       syncSourceURL,
+		historyRetention:    historyRetention,
+		sources:             sources,
+		snapshotFile:        snapshotFile,
 		rates:               atomic.Value{},
+		currentSnapshot:     atomic.Value{},
 		lastUpdated:         atomic.Value{},
+		activeSource:        atomic.Value{},
 		constantRates:       NewConstantRates(),
 		time:                &timeutil.RealTime{},
 	}
+	rc.loadSnapshot()
+	return rc
 }
 
-// fetch allows to retrieve the currencies rates from the syncSourceURL provided
-func (rc *RateConverter) fetch() (*Rates, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), rc.httpTimeout)
-	defer cancel()
+// fetch allows to retrieve the currencies rates from the sources provided, in order. A source failing or returning
+// rates older than the stale rates threshold is skipped, the first stale rates being used if no source is up to date.
+func (rc *RateConverter) fetch() (*Rates, string, error) {
+	var staleRates *Rates
+	var staleSource string
+	var errs []string
 
-	request, err := http.NewRequestWithContext(ctx, "GET", rc.syncSourceURL, nil)
-	if err != nil {
-		return nil, err
-	}
+	for _, source := range rc.sources {
+		rates, dataAsOf, err := rc.fetchSource(source)
+		if err != nil {
+			rc.sourceErrors.Store(source.Name(), err.Error())
+			errs = append(errs, fmt.Sprintf("%s: %v", source.Name(), err))
+			continue
+		}
 
-	response, err := rc.httpClient.Do(request)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		// read the entire response body to ensure full connection reuse if there's an
-		// invalid status code
-		if _, err := io.Copy(io.Discard, response.Body); err != nil {
-			logger.Errorf("error draining conversion rates response body: %v", err)
+		if rc.isStale(dataAsOf) {
+			rc.sourceErrors.Store(source.Name(), fmt.Sprintf("stale rates as of %s", dataAsOf.Format(time.RFC3339)))
+			if staleRates == nil {
+				staleRates, staleSource = rates, source.Name()
+			}
+			continue
 		}
-		response.Body.Close()
-	}()
 
-	if response.StatusCode >= 400 {
-		message := fmt.Sprintf("the currency rates request failed with status code %d", response.StatusCode)
-		return nil, &errortypes.BadServerResponse{Message: message}
+		rc.sourceErrors.Delete(source.Name())
+		return rates, source.Name(), nil
 	}
 
-	bytesJSON, err := io.ReadAll(response.Body)
-	if err != nil {
-		return nil, fmt.Errorf("the currency rates request failed: %v", err)
+	if staleRates != nil {
+		return staleRates, staleSource, nil
 	}
-
-	updatedRates := &Rates{}
-	err = jsonutil.UnmarshalValid(bytesJSON, updatedRates)
-	if err != nil {
-		return nil, fmt.Errorf("the currency rates request failed to parse json: %v", err)
+	if len(errs) == 0 {
+		return nil, "", errors.New("no currency rates source configured")
 	}
+	return nil, "", errors.New(strings.Join(errs, "; "))
+}
 
-	return updatedRates, err
+func (rc *RateConverter) fetchSource(source RatesSource) (*Rates, time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), rc.httpTimeout)
+	defer cancel()
+
+	return source.Fetch(ctx)
+}
+
+// isStale checks if rates as of the given date are older than the stale rates threshold
+func (rc *RateConverter) isStale(dataAsOf time.Time) bool {
+	if rc.staleRatesThreshold <= 0 || dataAsOf.IsZero() {
+		return false
+	}
+	return rc.time.Now().UTC().Sub(dataAsOf.UTC()) > rc.staleRatesThreshold
 }
 
 // Update updates the internal currencies rates from remote sources
 func (rc *RateConverter) update() error {
-	rates, err := rc.fetch()
+	rates, source, err := rc.fetch()
 	if err == nil {
+		now := rc.time.Now()
+		snapshot := &ratesSnapshot{id: newSnapshotID(now), timestamp: now, rates: rates}
+		rc.addSnapshot(snapshot)
 		rc.rates.Store(rates)
-		rc.lastUpdated.Store(rc.time.Now())
+		rc.lastUpdated.Store(now)
+		rc.activeSource.Store(source)
+		rc.saveSnapshot(snapshot, source)
 	} else {
 		if rc.checkStaleRates() {
 			rc.clearRates()
@@ -109,6 +156,158 @@ func (rc *RateConverter) Run() error {
 	return rc.update()
 }
 
+// ratesSnapshot holds the rates fetched at a given time
+type ratesSnapshot struct {
+	id        string
+	timestamp time.Time
+	rates     *Rates
+}
+
+// newSnapshotID returns the ID of the snapshot of the rates fetched at the given time
+func newSnapshotID(timestamp time.Time) string {
+	return timestamp.UTC().Format("20060102T150405.000Z")
+}
+
+// addSnapshot makes the snapshot the current one and adds it to the history, dropping the snapshots no longer in
+// use since before the history retention
+func (rc *RateConverter) addSnapshot(snapshot *ratesSnapshot) {
+	rc.historyMutex.Lock()
+	defer rc.historyMutex.Unlock()
+
+	rc.history = append(rc.history, snapshot)
+	// a snapshot is in use until the next one is fetched
+	cutoff := snapshot.timestamp.Add(-rc.historyRetention)
+	expired := 0
+	for expired < len(rc.history)-1 && !rc.history[expired+1].timestamp.After(cutoff) {
+		expired++
+	}
+	rc.history = append([]*ratesSnapshot(nil), rc.history[expired:]...)
+
+	rc.currentSnapshot.Store(snapshot)
+}
+
+// snapshotAsOf returns the snapshot in use at the given time, or nil if it isn't retained anymore
+func (rc *RateConverter) snapshotAsOf(asOf time.Time) *ratesSnapshot {
+	rc.historyMutex.RLock()
+	defer rc.historyMutex.RUnlock()
+
+	for i := len(rc.history) - 1; i >= 0; i-- {
+		if !rc.history[i].timestamp.After(asOf) {
+			return rc.history[i]
+		}
+	}
+	return nil
+}
+
+// Conversions returns the current conversions rates along with the ID of their snapshot, looking up the history for
+// the conversions as of a past time
+func (rc *RateConverter) Conversions() SnapshotConversions {
+	if snapshot, ok := rc.currentSnapshot.Load().(*ratesSnapshot); ok && snapshot != nil {
+		return &snapshotConversions{Conversions: snapshot.rates, snapshotID: snapshot.id, converter: rc}
+	}
+	return &snapshotConversions{Conversions: rc.constantRates, converter: rc}
+}
+
+// RatesAsOf returns the conversions rates of the snapshot in use at the given time
+func (rc *RateConverter) RatesAsOf(asOf time.Time) (SnapshotConversions, error) {
+	snapshot := rc.snapshotAsOf(asOf)
+	if snapshot == nil {
+		return nil, SnapshotNotFoundError{AsOf: asOf}
+	}
+	return &snapshotConversions{Conversions: snapshot.rates, snapshotID: snapshot.id, converter: rc}, nil
+}
+
+// snapshotConversions are the conversions rates of a RateConverter snapshot
+type snapshotConversions struct {
+	Conversions
+	snapshotID string
+	converter  *RateConverter
+}
+
+// GetRateAsOf returns the conversion rate between two currencies of the snapshot in use at the given time
+func (c *snapshotConversions) GetRateAsOf(from string, to string, asOf time.Time) (float64, error) {
+	rates, err := c.converter.RatesAsOf(asOf)
+	if err != nil {
+		return 0, err
+	}
+	return rates.GetRate(from, to)
+}
+
+// SnapshotID returns the ID of the snapshot the rates come from
+func (c *snapshotConversions) SnapshotID() string {
+	return c.snapshotID
+}
+
+// persistedSnapshot holds the last known good rates persisted on disk
+type persistedSnapshot struct {
+	ID          string                        `json:"id"`
+	Source      string                        `json:"source"`
+	LastUpdated time.Time                     `json:"lastUpdated"`
+	Conversions map[string]map[string]float64 `json:"conversions"`
+}
+
+// loadSnapshot loads the rates persisted in the snapshot file, if any
+func (rc *RateConverter) loadSnapshot() {
+	if len(rc.snapshotFile) == 0 {
+		return
+	}
+
+	data, err := os.ReadFile(rc.snapshotFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("Error reading conversion rates snapshot %s: %v", rc.snapshotFile, err)
+		}
+		return
+	}
+
+	persisted := persistedSnapshot{}
+	if err := jsonutil.UnmarshalValid(data, &persisted); err != nil {
+		logger.Warnf("Error parsing conversion rates snapshot %s: %v", rc.snapshotFile, err)
+		return
+	}
+
+	snapshot := &ratesSnapshot{
+		id:        persisted.ID,
+		timestamp: persisted.LastUpdated,
+		rates:     NewRates(persisted.Conversions),
+	}
+	if len(snapshot.id) == 0 {
+		snapshot.id = newSnapshotID(snapshot.timestamp)
+	}
+	rc.addSnapshot(snapshot)
+	rc.rates.Store(snapshot.rates)
+	rc.lastUpdated.Store(persisted.LastUpdated)
+	rc.activeSource.Store(persisted.Source)
+}
+
+// saveSnapshot persists the rates to the snapshot file, writing to a temporary file first so a crash never leaves
+// a partial snapshot behind
+func (rc *RateConverter) saveSnapshot(snapshot *ratesSnapshot, source string) {
+	if len(rc.snapshotFile) == 0 {
+		return
+	}
+
+	data, err := jsonutil.Marshal(persistedSnapshot{
+		ID:          snapshot.id,
+		Source:      source,
+		LastUpdated: snapshot.timestamp,
+		Conversions: snapshot.rates.Conversions,
+	})
+	if err != nil {
+		logger.Errorf("Error encoding conversion rates snapshot: %v", err)
+		return
+	}
+
+	tmpFile := rc.snapshotFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		logger.Errorf("Error writing conversion rates snapshot %s: %v", tmpFile, err)
+		return
+	}
+	if err := os.Rename(tmpFile, rc.snapshotFile); err != nil {
+		logger.Errorf("Error writing conversion rates snapshot %s: %v", rc.snapshotFile, err)
+	}
+}
+
 // LastUpdated returns time when currencies rates were updated
 func (rc *RateConverter) LastUpdated() time.Time {
 	if lastUpdated := rc.lastUpdated.Load(); lastUpdated != nil {
@@ -131,6 +330,7 @@ func (rc *RateConverter) Rates() Conversions {
 func (rc *RateConverter) clearRates() {
 	// atomic.Value field rates must be of type *Rates so we cast nil to that type
 	rc.rates.Store((*Rates)(nil))
+	rc.currentSnapshot.Store((*ratesSnapshot)(nil))
 }
 
 // checkStaleRates checks if loaded third party conversion rates are stale
@@ -153,10 +353,51 @@ func (rc *RateConverter) checkStaleRates() bool {
 func (rc *RateConverter) GetInfo() ConverterInfo {
 	var rates *map[string]map[string]float64 = rc.Rates().GetRates()
 	return converterInfo{
-		source:      rc.syncSourceURL,
-		lastUpdated: rc.LastUpdated(),
-		rates:       rates,
+		source:         rc.ActiveSource(),
+		lastUpdated:    rc.LastUpdated(),
+		rates:          rates,
+		additionalInfo: rc.sourcesInfo(),
+	}
+}
+
+// ActiveSource returns the name of the source the current rates were fetched from. It falls back to the first source
+// when no rates were fetched yet.
+func (rc *RateConverter) ActiveSource() string {
+	if source, ok := rc.activeSource.Load().(string); ok {
+		return source
+	}
+	if len(rc.sources) > 0 {
+		return rc.sources[0].Name()
 	}
+	return ""
+}
+
+// SourceInfo holds the status of a currency rates source
+type SourceInfo struct {
+	Source    string `json:"source"`
+	Active    bool   `json:"active"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+// sourcesInfo returns the status of the currency rates sources, in order, when more than one is configured
+func (rc *RateConverter) sourcesInfo() interface{} {
+	if len(rc.sources) < 2 {
+		return nil
+	}
+
+	activeSource, _ := rc.activeSource.Load().(string)
+	sources := make([]SourceInfo, 0, len(rc.sources))
+	for _, source := range rc.sources {
+		info := SourceInfo{
+			Source: source.Name(),
+			Active: source.Name() == activeSource,
+		}
+		if lastError, ok := rc.sourceErrors.Load(source.Name()); ok {
+			info.LastError = lastError.(string)
+		}
+		sources = append(sources, info)
+	}
+	return sources
 }
 
 type httpClient interface {
@@ -170,3 +411,21 @@ type Conversions interface {
 	GetRate(from string, to string) (float64, error)
 	GetRates() *map[string]map[string]float64
 }
+
+// SnapshotConversions are the Conversions of a RateConverter snapshot, which keeps the history of its rates.
+type SnapshotConversions interface {
+	Conversions
+	// GetRateAsOf returns the conversion rate between two currencies as it was at the given time.
+	GetRateAsOf(from string, to string, asOf time.Time) (float64, error)
+	// SnapshotID identifies the rates snapshot the conversions come from.
+	SnapshotID() string
+}
+
+// SnapshotID returns the ID of the rates snapshot the conversions come from, empty when the rates
+// are not fetched by a RateConverter.
+func SnapshotID(conversions Conversions) string {
+	if snapshotConversions, ok := conversions.(SnapshotConversions); ok {
+		return snapshotConversions.SnapshotID()
+	}
+	return ""
+}
@@ -1,9 +1,13 @@
 package currency
 
 import (
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -381,3 +385,192 @@ func (m *mockHttpClient) Do(req *http.Request) (*http.Response, error) {
 		Body:       io.NopCloser(strings.NewReader(m.responseBody)),
 	}, nil
 }
+
+// mockRatesSource is a rates source returning constant rates or an error
+type mockRatesSource struct {
+	name     string
+	rates    *Rates
+	dataAsOf time.Time
+	err      error
+}
+
+func (m *mockRatesSource) Name() string {
+	return m.name
+}
+
+func (m *mockRatesSource) Fetch(ctx context.Context) (*Rates, time.Time, error) {
+	return m.rates, m.dataAsOf, m.err
+}
+
+func TestRateConverterSourcesFailover(t *testing.T) {
+	now := time.Date(2018, time.September, 12, 12, 0, 0, 0, time.UTC)
+	primaryRates := NewRates(map[string]map[string]float64{"USD": {"GBP": 0.77}})
+	secondaryRates := NewRates(map[string]map[string]float64{"USD": {"GBP": 0.78}})
+	failing := &mockRatesSource{name: "failing", err: errors.New("source unavailable")}
+
+	testCases := []struct {
+		name             string
+		giveSources      []RatesSource
+		wantErr          bool
+		wantRates        Conversions
+		wantActiveSource string
+		wantSourcesInfo  interface{}
+	}{
+		{
+			name: "first_source_up_to_date",
+			giveSources: []RatesSource{
+				&mockRatesSource{name: "primary", rates: primaryRates, dataAsOf: now},
+				&mockRatesSource{name: "secondary", rates: secondaryRates, dataAsOf: now},
+			},
+			wantRates:        primaryRates,
+			wantActiveSource: "primary",
+			wantSourcesInfo:  []SourceInfo{{Source: "primary", Active: true}, {Source: "secondary"}},
+		},
+		{
+			name: "first_source_failing",
+			giveSources: []RatesSource{
+				failing,
+				&mockRatesSource{name: "secondary", rates: secondaryRates, dataAsOf: now},
+			},
+			wantRates:        secondaryRates,
+			wantActiveSource: "secondary",
+			wantSourcesInfo:  []SourceInfo{{Source: "failing", LastError: "source unavailable"}, {Source: "secondary", Active: true}},
+		},
+		{
+			name: "first_source_stale",
+			giveSources: []RatesSource{
+				&mockRatesSource{name: "primary", rates: primaryRates, dataAsOf: now.Add(-48 * time.Hour)},
+				&mockRatesSource{name: "secondary", rates: secondaryRates, dataAsOf: now},
+			},
+			wantRates:        secondaryRates,
+			wantActiveSource: "secondary",
+			wantSourcesInfo:  []SourceInfo{{Source: "primary", LastError: "stale rates as of 2018-09-10T12:00:00Z"}, {Source: "secondary", Active: true}},
+		},
+		{
+			name: "all_sources_stale_or_failing_uses_first_stale",
+			giveSources: []RatesSource{
+				failing,
+				&mockRatesSource{name: "primary", rates: primaryRates, dataAsOf: now.Add(-48 * time.Hour)},
+				&mockRatesSource{name: "secondary", rates: secondaryRates, dataAsOf: now.Add(-72 * time.Hour)},
+			},
+			wantRates:        primaryRates,
+			wantActiveSource: "primary",
+			wantSourcesInfo: []SourceInfo{
+				{Source: "failing", LastError: "source unavailable"},
+				{Source: "primary", Active: true, LastError: "stale rates as of 2018-09-10T12:00:00Z"},
+				{Source: "secondary", LastError: "stale rates as of 2018-09-09T12:00:00Z"},
+			},
+		},
+		{
+			name:             "all_sources_failing",
+			giveSources:      []RatesSource{failing, &mockRatesSource{name: "other", err: errors.New("timeout")}},
+			wantErr:          true,
+			wantRates:        &ConstantRates{},
+			wantActiveSource: "failing",
+			wantSourcesInfo:  []SourceInfo{{Source: "failing", LastError: "source unavailable"}, {Source: "other", LastError: "timeout"}},
+		},
+		{
+			name:             "single_source",
+			giveSources:      []RatesSource{&mockRatesSource{name: "primary", rates: primaryRates}},
+			wantRates:        primaryRates,
+			wantActiveSource: "primary",
+			wantSourcesInfo:  nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			currencyConverter := NewRateConverterWithSources(time.Second, tc.giveSources, 24*time.Hour, 0, "")
+			currencyConverter.time = &FakeTime{time: now}
+
+			err := currencyConverter.Run()
+
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.wantRates, currencyConverter.Rates())
+			info := currencyConverter.GetInfo()
+			assert.Equal(t, tc.wantActiveSource, info.Source())
+			assert.Equal(t, tc.wantSourcesInfo, info.AdditionalInfo())
+		})
+	}
+}
+
+func TestRateConverterSnapshot(t *testing.T) {
+	snapshotFile := filepath.Join(t.TempDir(), "rates.json")
+	now := time.Date(2018, time.September, 12, 12, 0, 0, 0, time.UTC)
+	rates := NewRates(map[string]map[string]float64{"USD": {"GBP": 0.77}})
+
+	// no snapshot yet, constant rates are used until the sources are fetched
+	currencyConverter := NewRateConverterWithSources(time.Second, []RatesSource{&mockRatesSource{name: "primary", rates: rates}}, 0, 0, snapshotFile)
+	currencyConverter.time = &FakeTime{time: now}
+	assert.Equal(t, &ConstantRates{}, currencyConverter.Rates())
+
+	assert.NoError(t, currencyConverter.Run())
+	assert.FileExists(t, snapshotFile)
+
+	// the snapshot is loaded at startup while the sources are failing
+	restarted := NewRateConverterWithSources(time.Second, []RatesSource{&mockRatesSource{name: "failing", err: errors.New("source unavailable")}}, 0, 0, snapshotFile)
+	assert.Equal(t, rates, restarted.Rates())
+	assert.Equal(t, now, restarted.LastUpdated())
+	assert.Equal(t, "primary", restarted.GetInfo().Source())
+
+	assert.Error(t, restarted.Run())
+	assert.Equal(t, rates, restarted.Rates())
+
+	// an invalid snapshot is ignored
+	assert.NoError(t, os.WriteFile(snapshotFile, []byte(`{"conversions":`), 0644))
+	invalid := NewRateConverterWithSources(time.Second, []RatesSource{&mockRatesSource{name: "primary", rates: rates}}, 0, 0, snapshotFile)
+	assert.Equal(t, &ConstantRates{}, invalid.Rates())
+}
+
+func TestRateConverterHistory(t *testing.T) {
+	firstTime := time.Date(2018, time.September, 12, 12, 0, 0, 0, time.UTC)
+	source := &mockRatesSource{name: "primary", rates: NewRates(map[string]map[string]float64{"USD": {"GBP": 0.77}})}
+	fakeTime := &FakeTime{time: firstTime}
+
+	currencyConverter := NewRateConverterWithSources(time.Second, []RatesSource{source}, 0, time.Hour, "")
+	currencyConverter.time = fakeTime
+
+	// no rates fetched yet
+	conversions := currencyConverter.Conversions()
+	assert.Equal(t, "", conversions.SnapshotID())
+	_, err := conversions.GetRateAsOf("USD", "GBP", firstTime)
+	assert.Equal(t, SnapshotNotFoundError{AsOf: firstTime}, err)
+
+	assert.NoError(t, currencyConverter.Run())
+	firstConversions := currencyConverter.Conversions()
+	assert.Equal(t, "20180912T120000.000Z", firstConversions.SnapshotID())
+
+	fakeTime.time = firstTime.Add(30 * time.Minute)
+	source.rates = NewRates(map[string]map[string]float64{"USD": {"GBP": 0.78}})
+	assert.NoError(t, currencyConverter.Run())
+
+	conversions = currencyConverter.Conversions()
+	assert.Equal(t, "20180912T123000.000Z", conversions.SnapshotID())
+
+	// the conversions of an auction keep the rates of their snapshot
+	rate, err := firstConversions.GetRate("USD", "GBP")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.77, rate)
+
+	rate, err = conversions.GetRateAsOf("USD", "GBP", firstTime.Add(10*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 0.77, rate)
+
+	rate, err = conversions.GetRateAsOf("USD", "GBP", firstTime.Add(40*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 0.78, rate)
+
+	_, err = conversions.GetRateAsOf("USD", "GBP", firstTime.Add(-time.Minute))
+	assert.Error(t, err)
+
+	// the first snapshot is dropped once it's been replaced for longer than the retention
+	fakeTime.time = firstTime.Add(2 * time.Hour)
+	assert.NoError(t, currencyConverter.Run())
+
+	_, err = currencyConverter.RatesAsOf(firstTime.Add(10 * time.Minute))
+	assert.Error(t, err)
+
+	asOfRates, err := currencyConverter.RatesAsOf(firstTime.Add(40 * time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, "20180912T123000.000Z", asOfRates.SnapshotID())
+}
@@ -0,0 +1,160 @@
+package currency
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prebid/prebid-server/v4/errortypes"
+	"github.com/prebid/prebid-server/v4/logger"
+	"github.com/prebid/prebid-server/v4/util/jsonutil"
+)
+
+// RatesSource is a source the currencies conversion rates are fetched from
+type RatesSource interface {
+	// Name identifies the source in the logs and the converter info
+	Name() string
+	// Fetch returns the rates and the date they are as of, which is zero when the source doesn't tell
+	Fetch(ctx context.Context) (*Rates, time.Time, error)
+}
+
+// ratesFile holds the rates as represented on https://cdn.jsdelivr.net/gh/prebid/currency-file@1/latest.json
+type ratesFile struct {
+	DataAsOf    string                        `json:"dataAsOf"`
+	Conversions map[string]map[string]float64 `json:"conversions"`
+}
+
+// parseRatesFile parses rates in the Prebid currency file format
+func parseRatesFile(data []byte) (*Rates, time.Time, error) {
+	file := ratesFile{}
+	if err := jsonutil.UnmarshalValid(data, &file); err != nil {
+		return nil, time.Time{}, fmt.Errorf("the currency rates request failed to parse json: %v", err)
+	}
+	return NewRates(file.Conversions), parseDataAsOf(file.DataAsOf), nil
+}
+
+// parseDataAsOf parses the date the rates are as of, which is either a date or a timestamp
+func parseDataAsOf(dataAsOf string) time.Time {
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if asOf, err := time.Parse(layout, dataAsOf); err == nil {
+			return asOf
+		}
+	}
+	return time.Time{}
+}
+
+// NewHTTPRatesSource returns a source fetching rates in the Prebid currency file format from a URL
+func NewHTTPRatesSource(client httpClient, url string) RatesSource {
+	return &httpRatesSource{
+		client: client,
+		url:    url,
+		parse:  parseRatesFile,
+	}
+}
+
+// NewECBRatesSource returns a source fetching rates in the European Central Bank XML format from a URL,
+// such as https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml
+func NewECBRatesSource(client httpClient, url string) RatesSource {
+	return &httpRatesSource{
+		client: client,
+		url:    url,
+		parse:  parseECBRates,
+	}
+}
+
+type httpRatesSource struct {
+	client httpClient
+	url    string
+	parse  func(data []byte) (*Rates, time.Time, error)
+}
+
+func (s *httpRatesSource) Name() string {
+	return s.url
+}
+
+func (s *httpRatesSource) Fetch(ctx context.Context) (*Rates, time.Time, error) {
+	request, err := http.NewRequestWithContext(ctx, "GET", s.url, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer func() {
+		// read the entire response body to ensure full connection reuse if there's an
+		// invalid status code
+		if _, err := io.Copy(io.Discard, response.Body); err != nil {
+			logger.Errorf("error draining conversion rates response body: %v", err)
+		}
+		response.Body.Close()
+	}()
+
+	if response.StatusCode >= 400 {
+		message := fmt.Sprintf("the currency rates request failed with status code %d", response.StatusCode)
+		return nil, time.Time{}, &errortypes.BadServerResponse{Message: message}
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("the currency rates request failed: %v", err)
+	}
+
+	return s.parse(data)
+}
+
+// ecbEnvelope holds the rates as represented in the European Central Bank reference rates XML, the rates of each day
+// being against the euro
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string  `xml:"currency,attr"`
+			Rate     float64 `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// parseECBRates parses the latest day of rates of the European Central Bank XML format
+func parseECBRates(data []byte) (*Rates, time.Time, error) {
+	envelope := ecbEnvelope{}
+	if err := xml.Unmarshal(data, &envelope); err != nil {
+		return nil, time.Time{}, fmt.Errorf("the currency rates request failed to parse xml: %v", err)
+	}
+	if len(envelope.Days) == 0 {
+		return nil, time.Time{}, fmt.Errorf("the currency rates request returned no rates")
+	}
+
+	latest := envelope.Days[0]
+	euroRates := make(map[string]float64, len(latest.Rates))
+	for _, rate := range latest.Rates {
+		euroRates[rate.Currency] = rate.Rate
+	}
+	return NewRates(map[string]map[string]float64{"EUR": euroRates}), parseDataAsOf(latest.Time), nil
+}
+
+// NewFileRatesSource returns a source reading rates in the Prebid currency file format from a local file
+func NewFileRatesSource(path string) RatesSource {
+	return &fileRatesSource{path: path}
+}
+
+type fileRatesSource struct {
+	path string
+}
+
+func (s *fileRatesSource) Name() string {
+	return "file://" + s.path
+}
+
+func (s *fileRatesSource) Fetch(ctx context.Context) (*Rates, time.Time, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("the currency rates file could not be read: %v", err)
+	}
+	return parseRatesFile(data)
+}
@@ -0,0 +1,138 @@
+package currency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getMockECBRates() []byte {
+	return []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2018-09-12">
+			<Cube currency="USD" rate="1.1598"/>
+			<Cube currency="GBP" rate="0.89125"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`)
+}
+
+func TestHTTPRatesSource(t *testing.T) {
+	testCases := []struct {
+		name         string
+		giveResponse []byte
+		giveStatus   int
+		wantErr      bool
+		wantRates    *Rates
+		wantDataAsOf time.Time
+	}{
+		{
+			name:         "valid_rates",
+			giveResponse: getMockRates(),
+			giveStatus:   http.StatusOK,
+			wantRates:    NewRates(map[string]map[string]float64{"USD": {"GBP": 0.77208}, "GBP": {"USD": 1.2952}}),
+			wantDataAsOf: time.Date(2018, time.September, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "no_data_as_of",
+			giveResponse: []byte(`{"conversions":{"USD":{"GBP":0.77208}}}`),
+			giveStatus:   http.StatusOK,
+			wantRates:    NewRates(map[string]map[string]float64{"USD": {"GBP": 0.77208}}),
+		},
+		{
+			name:         "invalid_json",
+			giveResponse: []byte(`{"conversions":`),
+			giveStatus:   http.StatusOK,
+			wantErr:      true,
+		},
+		{
+			name:         "server_error",
+			giveResponse: getMockRates(),
+			giveStatus:   http.StatusInternalServerError,
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(tc.giveStatus)
+				rw.Write(tc.giveResponse)
+			}))
+			defer server.Close()
+
+			source := NewHTTPRatesSource(&http.Client{}, server.URL)
+			rates, dataAsOf, err := source.Fetch(context.Background())
+
+			assert.Equal(t, server.URL, source.Name())
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.wantRates, rates)
+			assert.Equal(t, tc.wantDataAsOf, dataAsOf)
+		})
+	}
+}
+
+func TestECBRatesSource(t *testing.T) {
+	testCases := []struct {
+		name         string
+		giveResponse []byte
+		wantErr      bool
+		wantRates    *Rates
+		wantDataAsOf time.Time
+	}{
+		{
+			name:         "valid_rates",
+			giveResponse: getMockECBRates(),
+			wantRates:    NewRates(map[string]map[string]float64{"EUR": {"USD": 1.1598, "GBP": 0.89125}}),
+			wantDataAsOf: time.Date(2018, time.September, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "no_rates",
+			giveResponse: []byte(`<Envelope><Cube></Cube></Envelope>`),
+			wantErr:      true,
+		},
+		{
+			name:         "invalid_xml",
+			giveResponse: []byte(`<Envelope><Cube>`),
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Write(tc.giveResponse)
+			}))
+			defer server.Close()
+
+			rates, dataAsOf, err := NewECBRatesSource(&http.Client{}, server.URL).Fetch(context.Background())
+
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.wantRates, rates)
+			assert.Equal(t, tc.wantDataAsOf, dataAsOf)
+		})
+	}
+}
+
+func TestFileRatesSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	assert.NoError(t, os.WriteFile(path, getMockRates(), 0644))
+
+	source := NewFileRatesSource(path)
+	rates, dataAsOf, err := source.Fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "file://"+path, source.Name())
+	assert.Equal(t, NewRates(map[string]map[string]float64{"USD": {"GBP": 0.77208}, "GBP": {"USD": 1.2952}}), rates)
+	assert.Equal(t, time.Date(2018, time.September, 12, 0, 0, 0, 0, time.UTC), dataAsOf)
+
+	_, _, err = NewFileRatesSource(filepath.Join(t.TempDir(), "missing.json")).Fetch(context.Background())
+	assert.Error(t, err)
+}
@@ -32,3 +32,14 @@ func ValidateCustomRates(bidReqCurrencyRates *openrtb_ext.ExtRequestCurrency) er
 	}
 	return nil
 }
+
+// ValidateCurrencies throws a bad input error if any of the 3-digit currency codes is invalid, malformed
+// or does not represent any actual currency.
+func ValidateCurrencies(currencies []string) error {
+	for _, cur := range currencies {
+		if _, err := currency.ParseISO(cur); err != nil {
+			return &errortypes.BadInput{Message: fmt.Sprintf("currency code %s is not recognized or malformed", cur)}
+		}
+	}
+	return nil
+}
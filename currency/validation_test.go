@@ -114,3 +114,31 @@ func TestValidateCustomRates(t *testing.T) {
 		assert.Equal(t, tc.outCurrencyError, actualErr, tc.desc)
 	}
 }
+
+func TestValidateCurrencies(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		inCurrencies  []string
+		expectedError error
+	}{
+		{
+			desc:          "nil input, no errors expected",
+			inCurrencies:  nil,
+			expectedError: nil,
+		},
+		{
+			desc:          "valid currencies, no errors expected",
+			inCurrencies:  []string{"EUR", "GBP"},
+			expectedError: nil,
+		},
+		{
+			desc:          "malformed currency, bad input error expected",
+			inCurrencies:  []string{"EUR", "EURO"},
+			expectedError: &errortypes.BadInput{Message: "currency code EURO is not recognized or malformed"},
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedError, ValidateCurrencies(tc.inCurrencies), tc.desc)
+	}
+}
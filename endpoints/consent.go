@@ -0,0 +1,454 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/prebid/go-gdpr/consentconstants"
+	"github.com/prebid/go-gdpr/vendorconsent"
+	tcf2 "github.com/prebid/go-gdpr/vendorconsent/tcf2"
+	gpplib "github.com/prebid/go-gpp"
+	gppConstants "github.com/prebid/go-gpp/constants"
+	accountService "github.com/prebid/prebid-server/v4/account"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/gdpr"
+	"github.com/prebid/prebid-server/v4/logger"
+	"github.com/prebid/prebid-server/v4/metrics"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/prebid/prebid-server/v4/privacy"
+	"github.com/prebid/prebid-server/v4/privacy/ccpa"
+	gppPrivacy "github.com/prebid/prebid-server/v4/privacy/gpp"
+	"github.com/prebid/prebid-server/v4/stored_requests"
+	"github.com/prebid/prebid-server/v4/util/jsonutil"
+	stringutil "github.com/prebid/prebid-server/v4/util/stringutil"
+)
+
+// TCF v2 encodes at most 24 purposes and 12 special features
+const (
+	consentDecodeMaxPurpose        = 24
+	consentDecodeMaxSpecialFeature = 12
+)
+
+// consentDecodeActivities are the activities evaluated for each bidder by the consent decoding endpoint
+var consentDecodeActivities = []privacy.Activity{
+	privacy.ActivitySyncUser,
+	privacy.ActivityFetchBids,
+	privacy.ActivityEnrichUserFPD,
+	privacy.ActivityReportAnalytics,
+	privacy.ActivityTransmitUserFPD,
+	privacy.ActivityTransmitPreciseGeo,
+	privacy.ActivityTransmitUniqueRequestIDs,
+	privacy.ActivityTransmitTIDs,
+	privacy.ActivityTransmitEIDs,
+}
+
+// NewConsentDecodeEndpoint returns an endpoint which decodes the TCF, GPP and US Privacy strings of the request
+// and reports the privacy decisions Prebid Server would make for each bidder under the account, to help debug
+// publisher consent issues.
+func NewConsentDecodeEndpoint(
+	cfg *config.Configuration,
+	gdprPermsBuilder gdpr.PermissionsBuilder,
+	tcf2CfgBuilder gdpr.TCF2ConfigBuilder,
+	metricsEngine metrics.MetricsEngine,
+	accountsFetcher stored_requests.AccountFetcher,
+	bidders map[string]openrtb_ext.BidderName) httprouter.Handle {
+
+	return (&consentDecodeEndpoint{
+		config:           cfg,
+		gdprPermsBuilder: gdprPermsBuilder,
+		tcf2CfgBuilder:   tcf2CfgBuilder,
+		metrics:          metricsEngine,
+		accountsFetcher:  accountsFetcher,
+		bidders:          bidders,
+	}).Handle
+}
+
+type consentDecodeEndpoint struct {
+	config           *config.Configuration
+	gdprPermsBuilder gdpr.PermissionsBuilder
+	tcf2CfgBuilder   gdpr.TCF2ConfigBuilder
+	metrics          metrics.MetricsEngine
+	accountsFetcher  stored_requests.AccountFetcher
+	bidders          map[string]openrtb_ext.BidderName
+}
+
+type consentDecodeRequest struct {
+	GDPR              *int     `json:"gdpr"`
+	GDPRConsent       string   `json:"gdpr_consent"`
+	AdditionalConsent string   `json:"addtl_consent"`
+	USPrivacy         string   `json:"us_privacy"`
+	GPP               string   `json:"gpp"`
+	GPPSID            string   `json:"gpp_sid"`
+	Account           string   `json:"account"`
+	Bidders           []string `json:"bidders"`
+}
+
+type consentDecodeResponse struct {
+	GDPRApplies bool                           `json:"gdpr_applies"`
+	TCF         *consentDecodeTCF              `json:"tcf,omitempty"`
+	GPP         *consentDecodeGPP              `json:"gpp,omitempty"`
+	USPrivacy   *consentDecodeUSPrivacy        `json:"us_privacy,omitempty"`
+	Bidders     map[string]consentDecodeBidder `json:"bidders"`
+	Errors      []string                       `json:"errors,omitempty"`
+}
+
+type consentDecodeTCF struct {
+	Version               uint8     `json:"version"`
+	Created               time.Time `json:"created"`
+	LastUpdated           time.Time `json:"last_updated"`
+	CmpID                 uint16    `json:"cmp_id"`
+	CmpVersion            uint16    `json:"cmp_version"`
+	ConsentScreen         uint8     `json:"consent_screen"`
+	ConsentLanguage       string    `json:"consent_language"`
+	VendorListVersion     uint16    `json:"vendor_list_version"`
+	TCFPolicyVersion      uint8     `json:"tcf_policy_version"`
+	PurposeOneTreatment   bool      `json:"purpose_one_treatment"`
+	PurposeConsents       []int     `json:"purpose_consents"`
+	PurposeLITransparency []int     `json:"purpose_li_transparency"`
+	SpecialFeatureOptIns  []int     `json:"special_feature_opt_ins"`
+	VendorConsents        []int     `json:"vendor_consents"`
+	VendorLegitInterests  []int     `json:"vendor_legitimate_interests"`
+}
+
+type consentDecodeGPP struct {
+	Version    int                       `json:"version"`
+	SectionIDs []int                     `json:"section_ids"`
+	Sections   []consentDecodeGPPSection `json:"sections"`
+}
+
+type consentDecodeGPPSection struct {
+	SID   int                     `json:"sid"`
+	Name  string                  `json:"name,omitempty"`
+	Value string                  `json:"value"`
+	US    *consentDecodeUSSection `json:"us,omitempty"`
+}
+
+// consentDecodeUSSection holds the fields of a GPP US National or US state section, normalized across the
+// sections. Fields a section doesn't define are reported as 0, not applicable.
+type consentDecodeUSSection struct {
+	SharingNotice                   byte  `json:"sharing_notice"`
+	SaleOptOutNotice                byte  `json:"sale_opt_out_notice"`
+	SharingOptOutNotice             byte  `json:"sharing_opt_out_notice"`
+	TargetedAdvertisingOptOutNotice byte  `json:"targeted_advertising_opt_out_notice"`
+	SaleOptOut                      byte  `json:"sale_opt_out"`
+	SharingOptOut                   byte  `json:"sharing_opt_out"`
+	TargetedAdvertisingOptOut       byte  `json:"targeted_advertising_opt_out"`
+	SensitiveDataProcessing         []int `json:"sensitive_data_processing"`
+	KnownChildSensitiveDataConsents []int `json:"known_child_sensitive_data_consents"`
+	PersonalDataConsents            byte  `json:"personal_data_consents"`
+	MspaServiceProviderMode         byte  `json:"mspa_service_provider_mode"`
+	Gpc                             bool  `json:"gpc"`
+}
+
+type consentDecodeUSPrivacy struct {
+	Version                string `json:"version"`
+	NoticeGiven            string `json:"notice_given"`
+	OptOutSale             string `json:"opt_out_sale"`
+	LSPACoveredTransaction string `json:"lspa_covered_transaction"`
+}
+
+type consentDecodeBidder struct {
+	GVLVendorID       uint16          `json:"gvl_vendor_id,omitempty"`
+	SyncAllowed       bool            `json:"sync_allowed"`
+	BidRequestAllowed bool            `json:"bid_request_allowed"`
+	PassGeo           bool            `json:"pass_geo"`
+	PassID            bool            `json:"pass_id"`
+	PubRestrictions   []string        `json:"publisher_restrictions,omitempty"`
+	Activities        map[string]bool `json:"activities"`
+	Errors            []string        `json:"errors,omitempty"`
+}
+
+func (e *consentDecodeEndpoint) Handle(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, e.config.MaxRequestSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, fmt.Sprintf("request size exceeded max size of %d bytes.", e.config.MaxRequestSize), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	request := consentDecodeRequest{}
+	if err := jsonutil.UnmarshalValid(body, &request); err != nil {
+		http.Error(w, fmt.Sprintf("JSON parsing failed: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	if request.Account == "" {
+		request.Account = metrics.PublisherUnknown
+	}
+	account, fetchErrs := accountService.GetAccount(context.Background(), e.config, e.accountsFetcher, request.Account, e.metrics)
+	if len(fetchErrs) > 0 {
+		http.Error(w, combineErrors(fetchErrs).Error(), http.StatusBadRequest)
+		return
+	}
+
+	gppSID, err := stringutil.StrToInt8Slice(request.GPPSID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid gpp_sid: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	response := e.decode(request, gppSID, account)
+
+	jsonOutput, err := jsonutil.Marshal(response)
+	if err != nil {
+		logger.Errorf("/info/consent Critical error when trying to marshal consentDecodeResponse: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(jsonOutput)
+}
+
+func (e *consentDecodeEndpoint) decode(request consentDecodeRequest, gppSID []int8, account *config.Account) consentDecodeResponse {
+	response := consentDecodeResponse{
+		Bidders: make(map[string]consentDecodeBidder),
+	}
+
+	var gpp gpplib.GppContainer
+	if request.GPP != "" {
+		var errs []error
+		gpp, errs = gpplib.Parse(request.GPP)
+		for _, err := range errs {
+			response.Errors = append(response.Errors, err.Error())
+		}
+		response.GPP = decodeGPP(gpp)
+	}
+
+	gdprConsent := request.GDPRConsent
+	if i := gppPrivacy.IndexOfSID(gpp, gppConstants.SectionTCFEU2); i >= 0 {
+		gdprConsent = gpp.Sections[i].GetValue()
+	}
+	if gdprConsent != "" {
+		tcf, err := decodeTCF(gdprConsent)
+		if err != nil {
+			response.Errors = append(response.Errors, err.Error())
+		}
+		response.TCF = tcf
+	}
+
+	uspString, err := ccpa.SelectCCPAConsent(request.USPrivacy, gpp, gppSID)
+	if err != nil {
+		response.Errors = append(response.Errors, err.Error())
+	}
+	if uspString != "" {
+		usp, err := decodeUSPrivacy(uspString)
+		if err != nil {
+			response.Errors = append(response.Errors, err.Error())
+		}
+		response.USPrivacy = usp
+	}
+
+	gdprSignal, _, err := extractGDPRSignal(request.GDPR, gppSID)
+	if err != nil {
+		response.Errors = append(response.Errors, err.Error())
+	}
+	gdprSignal = gdpr.SignalNormalize(gdprSignal, e.config.GDPR.DefaultValue)
+	response.GDPRApplies = gdprSignal == gdpr.SignalYes
+
+	tcf2Cfg := e.tcf2CfgBuilder(e.config.GDPR.TCF2, account.GDPR)
+	gdprPerms := e.gdprPermsBuilder(tcf2Cfg, gdpr.RequestInfo{
+		AdditionalConsent: request.AdditionalConsent,
+		Consent:           gdprConsent,
+		GDPRSignal:        gdprSignal,
+		PublisherID:       request.Account,
+	})
+	activityControl := privacy.NewActivityControl(&account.Privacy)
+	activityRequest := privacy.NewRequestFromPolicies(privacy.Policies{GPP: request.GPP, GPPSID: gppSID})
+
+	for _, bidder := range e.selectBidders(request.Bidders, &response) {
+		response.Bidders[string(bidder)] = e.decideBidder(bidder, gdprPerms, activityControl, activityRequest)
+	}
+	return response
+}
+
+// selectBidders returns the requested bidders, or all the active bidders if none were requested. Unknown
+// bidders are reported as errors.
+func (e *consentDecodeEndpoint) selectBidders(requested []string, response *consentDecodeResponse) []openrtb_ext.BidderName {
+	if len(requested) == 0 {
+		bidders := make([]openrtb_ext.BidderName, 0, len(e.bidders))
+		for _, bidder := range e.bidders {
+			bidders = append(bidders, bidder)
+		}
+		slices.Sort(bidders)
+		return bidders
+	}
+
+	bidders := make([]openrtb_ext.BidderName, 0, len(requested))
+	for _, name := range requested {
+		bidder, ok := e.bidders[name]
+		if !ok {
+			if normalized, found := openrtb_ext.NormalizeBidderName(name); found {
+				bidder, ok = e.bidders[normalized.String()]
+			}
+		}
+		if !ok {
+			response.Errors = append(response.Errors, fmt.Sprintf("unknown bidder: %s", name))
+			continue
+		}
+		bidders = append(bidders, bidder)
+	}
+	return bidders
+}
+
+func (e *consentDecodeEndpoint) decideBidder(bidder openrtb_ext.BidderName, gdprPerms gdpr.Permissions, activityControl privacy.ActivityControl, activityRequest privacy.ActivityRequest) consentDecodeBidder {
+	bidderInfo := e.config.BidderInfos[string(bidder)]
+	coreBidder := bidder
+	if len(bidderInfo.AliasOf) > 0 {
+		coreBidder = openrtb_ext.BidderName(bidderInfo.AliasOf)
+	}
+
+	decision := consentDecodeBidder{
+		GVLVendorID: bidderInfo.GVLVendorID,
+		Activities:  make(map[string]bool, len(consentDecodeActivities)),
+	}
+
+	syncAllowed, err := gdprPerms.BidderSyncAllowed(context.Background(), bidder)
+	if err != nil {
+		decision.Errors = append(decision.Errors, err.Error())
+	}
+	decision.SyncAllowed = err == nil && syncAllowed
+
+	auctionPerms := gdprPerms.AuctionActivitiesAllowed(context.Background(), coreBidder, bidder)
+	decision.BidRequestAllowed = auctionPerms.AllowBidRequest
+	decision.PassGeo = auctionPerms.PassGeo
+	decision.PassID = auctionPerms.PassID
+	for _, restriction := range auctionPerms.PubRestrictions {
+		decision.PubRestrictions = append(decision.PubRestrictions, restriction.String())
+	}
+
+	component := privacy.Component{Type: privacy.ComponentTypeBidder, Name: string(bidder)}
+	for _, activity := range consentDecodeActivities {
+		decision.Activities[activity.String()] = activityControl.Allow(activity, component, activityRequest)
+	}
+	return decision
+}
+
+func decodeTCF(consent string) (*consentDecodeTCF, error) {
+	parsed, err := vendorconsent.ParseString(consent)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing TCF consent string: %s", err.Error())
+	}
+	metadata, ok := parsed.(tcf2.ConsentMetadata)
+	if !ok {
+		return nil, fmt.Errorf("error parsing TCF consent string: encoding format version %d is not supported", parsed.Version())
+	}
+
+	tcf := &consentDecodeTCF{
+		Version:               metadata.Version(),
+		Created:               metadata.Created(),
+		LastUpdated:           metadata.LastUpdated(),
+		CmpID:                 metadata.CmpID(),
+		CmpVersion:            metadata.CmpVersion(),
+		ConsentScreen:         metadata.ConsentScreen(),
+		ConsentLanguage:       metadata.ConsentLanguage(),
+		VendorListVersion:     metadata.VendorListVersion(),
+		TCFPolicyVersion:      metadata.TCFPolicyVersion(),
+		PurposeOneTreatment:   metadata.PurposeOneTreatment(),
+		PurposeConsents:       []int{},
+		PurposeLITransparency: []int{},
+		SpecialFeatureOptIns:  []int{},
+		VendorConsents:        []int{},
+		VendorLegitInterests:  []int{},
+	}
+	for purpose := 1; purpose <= consentDecodeMaxPurpose; purpose++ {
+		if metadata.PurposeAllowed(consentconstants.Purpose(purpose)) {
+			tcf.PurposeConsents = append(tcf.PurposeConsents, purpose)
+		}
+		if metadata.PurposeLITransparency(consentconstants.Purpose(purpose)) {
+			tcf.PurposeLITransparency = append(tcf.PurposeLITransparency, purpose)
+		}
+	}
+	for feature := 1; feature <= consentDecodeMaxSpecialFeature; feature++ {
+		if metadata.SpecialFeatureOptIn(uint16(feature)) {
+			tcf.SpecialFeatureOptIns = append(tcf.SpecialFeatureOptIns, feature)
+		}
+	}
+	for vendor := 1; vendor <= int(metadata.MaxVendorID()); vendor++ {
+		if metadata.VendorConsent(uint16(vendor)) {
+			tcf.VendorConsents = append(tcf.VendorConsents, vendor)
+		}
+	}
+	for vendor := 1; vendor <= int(metadata.VendorLegitInterestMaxID()); vendor++ {
+		if metadata.VendorLegitInterest(uint16(vendor)) {
+			tcf.VendorLegitInterests = append(tcf.VendorLegitInterests, vendor)
+		}
+	}
+	return tcf, nil
+}
+
+func decodeGPP(gpp gpplib.GppContainer) *consentDecodeGPP {
+	decoded := &consentDecodeGPP{
+		Version:    gpp.Version,
+		SectionIDs: make([]int, 0, len(gpp.SectionTypes)),
+		Sections:   make([]consentDecodeGPPSection, 0, len(gpp.Sections)),
+	}
+	for _, sid := range gpp.SectionTypes {
+		decoded.SectionIDs = append(decoded.SectionIDs, int(sid))
+	}
+	for _, section := range gpp.Sections {
+		if section == nil {
+			continue
+		}
+		sid := int(section.GetID())
+		decodedSection := consentDecodeGPPSection{
+			SID:   sid,
+			Name:  gppConstants.SectionNamesByID[sid],
+			Value: section.GetValue(),
+		}
+		if us := gppPrivacy.ReadUSSections(gpplib.GppContainer{Sections: []gpplib.Section{section}}, []int8{int8(sid)}); len(us) == 1 {
+			decodedSection.US = decodeUSSection(us[0])
+		}
+		decoded.Sections = append(decoded.Sections, decodedSection)
+	}
+	return decoded
+}
+
+func decodeUSSection(section gppPrivacy.USSection) *consentDecodeUSSection {
+	return &consentDecodeUSSection{
+		SharingNotice:                   section.SharingNotice,
+		SaleOptOutNotice:                section.SaleOptOutNotice,
+		SharingOptOutNotice:             section.SharingOptOutNotice,
+		TargetedAdvertisingOptOutNotice: section.TargetedAdvertisingOptOutNotice,
+		SaleOptOut:                      section.SaleOptOut,
+		SharingOptOut:                   section.SharingOptOut,
+		TargetedAdvertisingOptOut:       section.TargetedAdvertisingOptOut,
+		SensitiveDataProcessing:         bytesToInts(section.SensitiveDataProcessing),
+		KnownChildSensitiveDataConsents: bytesToInts(section.KnownChildSensitiveDataConsents),
+		PersonalDataConsents:            section.PersonalDataConsents,
+		MspaServiceProviderMode:         section.MspaServiceProviderMode,
+		Gpc:                             section.Gpc,
+	}
+}
+
+// bytesToInts converts the byte fields of the GPP sections, which would otherwise be marshaled as base64
+func bytesToInts(values []byte) []int {
+	ints := make([]int, 0, len(values))
+	for _, v := range values {
+		ints = append(ints, int(v))
+	}
+	return ints
+}
+
+func decodeUSPrivacy(consent string) (*consentDecodeUSPrivacy, error) {
+	if !ccpa.ValidateConsent(consent) {
+		return nil, fmt.Errorf("invalid US Privacy string: %s", consent)
+	}
+	return &consentDecodeUSPrivacy{
+		Version:                consent[0:1],
+		NoticeGiven:            consent[1:2],
+		OptOutSale:             consent[2:3],
+		LSPACoveredTransaction: consent[3:4],
+	}, nil
+}
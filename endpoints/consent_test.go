@@ -0,0 +1,142 @@
+package endpoints
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gpplib "github.com/prebid/go-gpp"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/gdpr"
+	"github.com/prebid/prebid-server/v4/metrics"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/prebid/prebid-server/v4/privacy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsentDecodeEndpoint(t *testing.T) {
+	testCases := []struct {
+		name               string
+		givenBody          string
+		givenAccountData   map[string]json.RawMessage
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "tcf_and_usp",
+			givenBody:          `{"gdpr":1,"gdpr_consent":"CPerMsAPerMsAAAAAAENCfCAAEAAAAAAAAAAAQAAAAAEAAAAAAAA","us_privacy":"1YYN","bidders":["bidderA"]}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"gdpr_applies":true,` +
+				`"tcf":{"version":2,"created":"2022-09-02T00:00:00Z","last_updated":"2022-09-02T00:00:00Z","cmp_id":0,"cmp_version":0,"consent_screen":0,"consent_language":"EN",` +
+				`"vendor_list_version":159,"tcf_policy_version":2,"purpose_one_treatment":false,"purpose_consents":[2],"purpose_li_transparency":[],"special_feature_opt_ins":[],` +
+				`"vendor_consents":[32],"vendor_legitimate_interests":[]},` +
+				`"us_privacy":{"version":"1","notice_given":"Y","opt_out_sale":"Y","lspa_covered_transaction":"N"},` +
+				`"bidders":{"bidderA":{"gvl_vendor_id":32,"sync_allowed":true,"bid_request_allowed":true,"pass_geo":true,"pass_id":true,` +
+				`"activities":{"syncUser":true,"fetchBids":true,"enrichUfpd":true,"reportAnalytics":true,"transmitUfpd":true,"transmitPreciseGeo":true,"transmitUniqueRequestIds":true,"transmitTid":true,"transmitEids":true}}}}`,
+		},
+		{
+			name:      "gpp_with_account_activities",
+			givenBody: `{"gpp":"DBABLA~BVVaqqqqAWA.YA","gpp_sid":"7","account":"acct","bidders":["bidderB","unknown"]}`,
+			givenAccountData: map[string]json.RawMessage{
+				"acct": json.RawMessage(`{"privacy":{"allowactivities":{"syncUser":{"default":true,"rules":[{"condition":{"componentName":["bidderB"]},"allow":false}]}}}}`),
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"gdpr_applies":false,` +
+				`"gpp":{"version":1,"section_ids":[7],"sections":[{"sid":7,"name":"uspnat","value":"BVVaqqqqAWA.YA","us":{"sharing_notice":1,"sale_opt_out_notice":1,"sharing_opt_out_notice":1,` +
+				`"targeted_advertising_opt_out_notice":1,"sale_opt_out":1,"sharing_opt_out":2,"targeted_advertising_opt_out":2,"sensitive_data_processing":[2,2,2,2,2,2,2,2,2,2,2,2],` +
+				`"known_child_sensitive_data_consents":[0,0],"personal_data_consents":0,"mspa_service_provider_mode":2,"gpc":true}}]},` +
+				`"bidders":{"bidderB":{"sync_allowed":true,"bid_request_allowed":true,"pass_geo":true,"pass_id":true,` +
+				`"activities":{"syncUser":false,"fetchBids":true,"enrichUfpd":true,"reportAnalytics":true,"transmitUfpd":true,"transmitPreciseGeo":true,"transmitUniqueRequestIds":true,"transmitTid":true,"transmitEids":true}}},` +
+				`"errors":["unknown bidder: unknown"]}`,
+		},
+		{
+			name:               "malformed_strings",
+			givenBody:          `{"gdpr_consent":"malformed","us_privacy":"invalid","bidders":["bidderB"]}`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"gdpr_applies":false,` +
+				`"bidders":{"bidderB":{"sync_allowed":true,"bid_request_allowed":true,"pass_geo":true,"pass_id":true,` +
+				`"activities":{"syncUser":true,"fetchBids":true,"enrichUfpd":true,"reportAnalytics":true,"transmitUfpd":true,"transmitPreciseGeo":true,"transmitUniqueRequestIds":true,"transmitTid":true,"transmitEids":true}}},` +
+				`"errors":["error parsing TCF consent string: illegal base64 data at input byte 8","invalid US Privacy string: invalid"]}`,
+		},
+		{
+			name:               "disabled_account",
+			givenBody:          `{"account":"disabled"}`,
+			givenAccountData:   map[string]json.RawMessage{"disabled": json.RawMessage(`{"disabled":true}`)},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       "account is disabled, please reach out to the prebid server host\n",
+		},
+		{
+			name:               "malformed_request",
+			givenBody:          `{`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "request_too_large",
+			givenBody:          `{"bidders":["` + strings.Repeat("a", 1024) + `"]}`,
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedBody:       "request size exceeded max size of 1024 bytes.\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &config.Configuration{
+				MaxRequestSize: 1024,
+				GDPR:           config.GDPR{DefaultValue: "0"},
+				BidderInfos:    config.BidderInfos{"bidderA": {GVLVendorID: 32}, "bidderB": {}},
+			}
+			require.NoError(t, cfg.MarshalAccountDefaults())
+
+			gdprPermsBuilder := fakePermissionsBuilder{
+				permissions: &fakePermsSetUID{allowBidder: true, personalInfoAllowed: true},
+			}.Builder
+			tcf2ConfigBuilder := fakeTCF2ConfigBuilder{
+				cfg: gdpr.NewTCF2Config(config.TCF2{}, config.AccountGDPR{}),
+			}.Builder
+			bidders := map[string]openrtb_ext.BidderName{"bidderA": "bidderA", "bidderB": "bidderB"}
+
+			endpoint := NewConsentDecodeEndpoint(cfg, gdprPermsBuilder, tcf2ConfigBuilder, &metrics.MetricsEngineMock{}, FakeAccountsFetcher{AccountData: test.givenAccountData}, bidders)
+
+			writer := httptest.NewRecorder()
+			endpoint(writer, httptest.NewRequest("POST", "/info/consent", strings.NewReader(test.givenBody)), nil)
+
+			assert.Equal(t, test.expectedStatusCode, writer.Code)
+			if test.expectedStatusCode == http.StatusOK {
+				assert.JSONEq(t, test.expectedBody, writer.Body.String())
+			} else if test.expectedBody != "" {
+				assert.Equal(t, test.expectedBody, writer.Body.String())
+			}
+		})
+	}
+}
+
+func TestConsentDecodeBidderAlias(t *testing.T) {
+	endpoint := &consentDecodeEndpoint{
+		config: &config.Configuration{
+			BidderInfos: config.BidderInfos{"bidderA": {GVLVendorID: 32}, "aliasA": {AliasOf: "bidderA", GVLVendorID: 32}},
+		},
+	}
+	perms := &fakePermsSetUID{allowBidder: true, personalInfoAllowed: true}
+
+	decision := endpoint.decideBidder("aliasA", perms, privacy.ActivityControl{}, privacy.ActivityRequest{})
+
+	assert.Equal(t, openrtb_ext.BidderName("bidderA"), perms.checkedCoreBidder)
+	assert.Equal(t, uint16(32), decision.GVLVendorID)
+	assert.True(t, decision.BidRequestAllowed)
+}
+
+func TestDecodeGPPUnsupportedSection(t *testing.T) {
+	gpp, errs := gpplib.Parse("DBABMA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA")
+	require.Empty(t, errs)
+
+	decoded := decodeGPP(gpp)
+
+	assert.Equal(t, &consentDecodeGPP{
+		Version:    1,
+		SectionIDs: []int{2},
+		Sections:   []consentDecodeGPPSection{{SID: 2, Name: "tcfeu2", Value: "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"}},
+	}, decoded)
+}
@@ -58,7 +58,9 @@ func NewCookieSyncEndpoint(
 	metrics metrics.MetricsEngine,
 	analyticsRunner analytics.Runner,
 	accountsFetcher stored_requests.AccountFetcher,
-	bidders map[string]openrtb_ext.BidderName) HTTPRouterHandler {
+	bidders map[string]openrtb_ext.BidderName,
+	idStore usersync.IDStore,
+	valueTracker *usersync.BidderValueTracker) HTTPRouterHandler {
 
 	bidderHashSet := make(map[string]struct{}, len(bidders))
 	for _, bidder := range bidders {
@@ -66,7 +68,7 @@ func NewCookieSyncEndpoint(
 	}
 
 	return &cookieSyncEndpoint{
-		chooser: usersync.NewChooser(syncersByBidder, bidderHashSet, config.BidderInfos),
+		chooser: usersync.NewChooser(syncersByBidder, bidderHashSet, config.BidderInfos, valueTracker),
 		config:  config,
 		privacyConfig: usersyncPrivacyConfig{
 			gdprConfig:             config.GDPR,
@@ -78,6 +80,7 @@ func NewCookieSyncEndpoint(
 		metrics:         metrics,
 		pbsAnalytics:    analyticsRunner,
 		accountsFetcher: accountsFetcher,
+		idStore:         idStore,
 		time:            &timeutil.RealTime{},
 	}
 }
@@ -89,6 +92,7 @@ type cookieSyncEndpoint struct {
 	metrics         metrics.MetricsEngine
 	pbsAnalytics    analytics.Runner
 	accountsFetcher stored_requests.AccountFetcher
+	idStore         usersync.IDStore
 	time            timeutil.Time
 }
 
@@ -100,10 +104,14 @@ func (c *cookieSyncEndpoint) Handle(w http.ResponseWriter, r *http.Request, _ ht
 		c.handleError(w, err, http.StatusBadRequest)
 		return
 	}
-	decoder := usersync.Base64Decoder{}
+	decoder := usersync.CompactDecoder{}
 
 	cookie := usersync.ReadCookie(r, decoder, &c.config.HostCookie)
+	c.metrics.RecordUIDsCookieJar(metrics.NewCookieJar(usersync.ReadCookieJars(r)))
 	usersync.SyncHostCookie(r, cookie, &c.config.HostCookie)
+	if err := loadStoredUIDs(r, cookie, c.idStore, c.config.UserSync.IDStore, &c.config.HostCookie); err != nil {
+		logger.Warnf("Failed to load stored user ids: %v", err)
+	}
 
 	result := c.chooser.Choose(request, cookie)
 
@@ -168,8 +176,9 @@ func (c *cookieSyncEndpoint) parseRequest(r *http.Request) (usersync.Request, ma
 	}
 
 	gdprRequestInfo := gdpr.RequestInfo{
-		Consent:    privacyMacros.GDPRConsent,
-		GDPRSignal: gdprSignal,
+		AdditionalConsent: request.AdditionalConsent,
+		Consent:           privacyMacros.GDPRConsent,
+		GDPRSignal:        gdprSignal,
 	}
 
 	tcf2Cfg := c.privacyConfig.tcf2ConfigBuilder(c.privacyConfig.gdprConfig.TCF2, account.GDPR)
@@ -181,6 +190,7 @@ func (c *cookieSyncEndpoint) parseRequest(r *http.Request) (usersync.Request, ma
 	}
 
 	rx := usersync.Request{
+		Account: request.Account,
 		Bidders: request.Bidders,
 		Cooperative: usersync.Cooperative{
 			Enabled:        (request.CooperativeSync != nil && *request.CooperativeSync) || (request.CooperativeSync == nil && c.config.UserSync.Cooperative.EnabledByDefault),
@@ -252,6 +262,7 @@ func extractPrivacyPolicies(request cookieSyncRequest, usersyncDefaultGDPRValue
 	}
 
 	privacyPolicies := privacy.Policies{
+		GPP:    request.GPP,
 		GPPSID: gppSID,
 	}
 
@@ -566,17 +577,18 @@ func getDebugMessage(status usersync.Status) string {
 }
 
 type cookieSyncRequest struct {
-	Bidders         []string                         `json:"bidders"`
-	GDPR            *int                             `json:"gdpr"`
-	GDPRConsent     string                           `json:"gdpr_consent"`
-	USPrivacy       string                           `json:"us_privacy"`
-	Limit           *int                             `json:"limit"`
-	GPP             string                           `json:"gpp"`
-	GPPSID          string                           `json:"gpp_sid"`
-	CooperativeSync *bool                            `json:"coopSync"`
-	FilterSettings  *cookieSyncRequestFilterSettings `json:"filterSettings"`
-	Account         string                           `json:"account"`
-	Debug           bool                             `json:"debug"`
+	Bidders           []string                         `json:"bidders"`
+	GDPR              *int                             `json:"gdpr"`
+	GDPRConsent       string                           `json:"gdpr_consent"`
+	AdditionalConsent string                           `json:"addtl_consent"`
+	USPrivacy         string                           `json:"us_privacy"`
+	Limit             *int                             `json:"limit"`
+	GPP               string                           `json:"gpp"`
+	GPPSID            string                           `json:"gpp_sid"`
+	CooperativeSync   *bool                            `json:"coopSync"`
+	FilterSettings    *cookieSyncRequestFilterSettings `json:"filterSettings"`
+	Account           string                           `json:"account"`
+	Debug             bool                             `json:"debug"`
 }
 
 type cookieSyncRequestFilterSettings struct {
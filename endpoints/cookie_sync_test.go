@@ -75,11 +75,13 @@ func TestNewCookieSyncEndpoint(t *testing.T) {
 		&analytics,
 		&fetcher,
 		bidders,
+		nil,
+		nil,
 	)
 	result := endpoint.(*cookieSyncEndpoint)
 
 	expected := &cookieSyncEndpoint{
-		chooser: usersync.NewChooser(syncersByBidder, biddersKnown, bidderInfo),
+		chooser: usersync.NewChooser(syncersByBidder, biddersKnown, bidderInfo, nil),
 		config: &config.Configuration{
 			UserSync:    configUserSync,
 			HostCookie:  configHostCookie,
@@ -338,6 +340,7 @@ func TestCookieSyncHandle(t *testing.T) {
 
 	for _, test := range testCases {
 		mockMetrics := metrics.MetricsEngineMock{}
+		mockMetrics.On("RecordUIDsCookieJar", mock.Anything).Maybe()
 		test.setMetricsExpectations(&mockMetrics)
 
 		mockAnalytics := MockAnalyticsRunner{}
@@ -566,7 +569,7 @@ func TestExtractPrivacyPolicies(t *testing.T) {
 					GPPSID:      "6",
 				},
 				gdprSignal: gdpr.SignalNo,
-				policies:   privacy.Policies{GPPSID: []int8{6}},
+				policies:   privacy.Policies{GPP: "DBACNYA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN", GPPSID: []int8{6}},
 				err:        nil,
 			},
 		},
@@ -627,6 +630,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 				GPPSID:      "2",
 			},
 			expectedRequest: usersync.Request{
+				Account: "unknown",
 				Bidders: []string{"a", "b"},
 				Cooperative: usersync.Cooperative{
 					Enabled:        true,
@@ -636,7 +640,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 				Privacy: usersyncPrivacy{
 					gdprPermissions:  &fakePermissions{},
 					ccpaParsedPolicy: expectedCCPAParsedPolicy,
-					activityRequest:  privacy.NewRequestFromPolicies(privacy.Policies{GPPSID: []int8{2}}),
+					activityRequest:  privacy.NewRequestFromPolicies(privacy.Policies{GPP: "DBABMA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA", GPPSID: []int8{2}}),
 					gdprSignal:       1,
 				},
 				SyncTypeFilter: usersync.SyncTypeFilter{
@@ -669,6 +673,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 				USPrivacy:   "1NYN",
 			},
 			expectedRequest: usersync.Request{
+				Account: "unknown",
 				Bidders: []string{"a", "b"},
 				Cooperative: usersync.Cooperative{
 					Enabled:        false,
@@ -694,7 +699,8 @@ func TestCookieSyncParseRequest(t *testing.T) {
 			givenCCPAEnabled: true,
 			expectedPrivacy:  macros.UserSyncPrivacy{},
 			expectedRequest: usersync.Request{
-				Limit: math.MaxInt,
+				Account: "unknown",
+				Limit:   math.MaxInt,
 				Privacy: usersyncPrivacy{
 					gdprPermissions: &fakePermissions{},
 					activityRequest: emptyActivityPoliciesRequest,
@@ -719,6 +725,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 			},
 			expectedPrivacy: macros.UserSyncPrivacy{},
 			expectedRequest: usersync.Request{
+				Account: "unknown",
 				Cooperative: usersync.Cooperative{
 					Enabled:        true,
 					PriorityGroups: [][]string{{"a", "b", "c"}},
@@ -748,6 +755,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 			},
 			expectedPrivacy: macros.UserSyncPrivacy{},
 			expectedRequest: usersync.Request{
+				Account: "unknown",
 				Cooperative: usersync.Cooperative{
 					Enabled:        false,
 					PriorityGroups: [][]string{{"a", "b", "c"}},
@@ -777,6 +785,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 			},
 			expectedPrivacy: macros.UserSyncPrivacy{},
 			expectedRequest: usersync.Request{
+				Account: "unknown",
 				Cooperative: usersync.Cooperative{
 					Enabled:        false,
 					PriorityGroups: [][]string{{"a", "b", "c"}},
@@ -806,6 +815,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 			},
 			expectedPrivacy: macros.UserSyncPrivacy{},
 			expectedRequest: usersync.Request{
+				Account: "unknown",
 				Cooperative: usersync.Cooperative{
 					Enabled:        false,
 					PriorityGroups: [][]string{{"a", "b", "c"}},
@@ -835,6 +845,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 			},
 			expectedPrivacy: macros.UserSyncPrivacy{},
 			expectedRequest: usersync.Request{
+				Account: "unknown",
 				Cooperative: usersync.Cooperative{
 					Enabled:        true,
 					PriorityGroups: [][]string{{"a", "b", "c"}},
@@ -864,6 +875,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 			},
 			expectedPrivacy: macros.UserSyncPrivacy{},
 			expectedRequest: usersync.Request{
+				Account: "unknown",
 				Cooperative: usersync.Cooperative{
 					Enabled:        true,
 					PriorityGroups: [][]string{{"a", "b", "c"}},
@@ -887,7 +899,8 @@ func TestCookieSyncParseRequest(t *testing.T) {
 			givenCCPAEnabled: true,
 			expectedPrivacy:  macros.UserSyncPrivacy{},
 			expectedRequest: usersync.Request{
-				Limit: math.MaxInt,
+				Account: "unknown",
+				Limit:   math.MaxInt,
 				Privacy: usersyncPrivacy{
 					gdprPermissions: &fakePermissions{},
 					activityRequest: emptyActivityPoliciesRequest,
@@ -908,7 +921,8 @@ func TestCookieSyncParseRequest(t *testing.T) {
 				USPrivacy: "1NYN",
 			},
 			expectedRequest: usersync.Request{
-				Limit: math.MaxInt,
+				Account: "unknown",
+				Limit:   math.MaxInt,
 				Privacy: usersyncPrivacy{
 					gdprPermissions: &fakePermissions{},
 					activityRequest: emptyActivityPoliciesRequest,
@@ -950,7 +964,8 @@ func TestCookieSyncParseRequest(t *testing.T) {
 				GDPR: "0",
 			},
 			expectedRequest: usersync.Request{
-				Limit: math.MaxInt,
+				Account: "unknown",
+				Limit:   math.MaxInt,
 				Privacy: usersyncPrivacy{
 					gdprPermissions: &fakePermissions{},
 					activityRequest: emptyActivityPoliciesRequest,
@@ -978,7 +993,8 @@ func TestCookieSyncParseRequest(t *testing.T) {
 				GDPR: "",
 			},
 			expectedRequest: usersync.Request{
-				Limit: math.MaxInt,
+				Account: "unknown",
+				Limit:   math.MaxInt,
 				Privacy: usersyncPrivacy{
 					gdprPermissions: &fakePermissions{},
 					activityRequest: emptyActivityPoliciesRequest,
@@ -1022,6 +1038,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 			givenAccountCoopDisabled: true,
 			expectedPrivacy:          macros.UserSyncPrivacy{},
 			expectedRequest: usersync.Request{
+				Account: "TestAccount",
 				Bidders: []string{"a", "b"},
 				Cooperative: usersync.Cooperative{
 					Enabled:        true,
@@ -1056,6 +1073,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 			givenAccountCoopDisabled: true,
 			expectedPrivacy:          macros.UserSyncPrivacy{},
 			expectedRequest: usersync.Request{
+				Account: "TestAccount",
 				Bidders: []string{"a", "b"},
 				Cooperative: usersync.Cooperative{
 					Enabled:        true,
@@ -1089,6 +1107,7 @@ func TestCookieSyncParseRequest(t *testing.T) {
 			},
 			expectedPrivacy: macros.UserSyncPrivacy{},
 			expectedRequest: usersync.Request{
+				Account: "unknown",
 				Bidders: []string{"a", "b"},
 				Cooperative: usersync.Cooperative{
 					Enabled:        true,
@@ -1528,6 +1547,7 @@ func TestCookieSyncWriteParseRequestErrorMetrics(t *testing.T) {
 
 	for _, test := range testCases {
 		mockMetrics := metrics.MetricsEngineMock{}
+		mockMetrics.On("RecordUIDsCookieJar", mock.Anything).Maybe()
 		test.setExpectations(&mockMetrics)
 
 		endpoint := &cookieSyncEndpoint{metrics: &mockMetrics}
@@ -1775,6 +1795,7 @@ func TestCookieSyncWriteBidderMetrics(t *testing.T) {
 
 	for _, test := range testCases {
 		mockMetrics := metrics.MetricsEngineMock{}
+		mockMetrics.On("RecordUIDsCookieJar", mock.Anything).Maybe()
 		test.setExpectations(&mockMetrics)
 
 		endpoint := &cookieSyncEndpoint{metrics: &mockMetrics}
@@ -2282,6 +2303,11 @@ func (m *MockSyncer) GetSync(syncTypes []usersync.SyncType, privacyMacros macros
 	return args.Get(0).(usersync.Sync), args.Error(1)
 }
 
+func (m *MockSyncer) UIDPolicy() usersync.UIDPolicy {
+	args := m.Called()
+	return args.Get(0).(usersync.UIDPolicy)
+}
+
 type MockAnalyticsRunner struct {
 	mock.Mock
 }
@@ -2624,14 +2650,17 @@ func TestCookieSyncPriorityGroupsIntegration(t *testing.T) {
 	syncerA.On("GetSync", mock.Anything, mock.Anything).Return(usersync.Sync{URL: "https://sync.bidderA.com", Type: usersync.SyncTypeRedirect}, nil).Maybe()
 	syncerA.On("Key").Return("appnexus").Maybe()
 	syncerA.On("SupportsType", mock.Anything).Return(true).Maybe()
+	syncerA.On("UIDPolicy").Return(usersync.UIDPolicy{}).Maybe()
 	syncerB := MockSyncer{}
 	syncerB.On("GetSync", mock.Anything, mock.Anything).Return(usersync.Sync{URL: "https://sync.bidderB.com", Type: usersync.SyncTypeRedirect}, nil).Maybe()
 	syncerB.On("Key").Return("rubicon").Maybe()
 	syncerB.On("SupportsType", mock.Anything).Return(true).Maybe()
+	syncerB.On("UIDPolicy").Return(usersync.UIDPolicy{}).Maybe()
 	syncerC := MockSyncer{}
 	syncerC.On("GetSync", mock.Anything, mock.Anything).Return(usersync.Sync{URL: "https://sync.bidderC.com", Type: usersync.SyncTypeRedirect}, nil).Maybe()
 	syncerC.On("Key").Return("pubmatic").Maybe()
 	syncerC.On("SupportsType", mock.Anything).Return(true).Maybe()
+	syncerC.On("UIDPolicy").Return(usersync.UIDPolicy{}).Maybe()
 	// Need to choose real bidder names because the standard chooser is hardcoded to validate against them
 	syncersByBidder := map[string]usersync.Syncer{
 		"appnexus": &syncerA,
@@ -2722,6 +2751,7 @@ func TestCookieSyncPriorityGroupsIntegration(t *testing.T) {
 			mockAnalytics.On("LogCookieSyncObject", mock.AnythingOfType("*analytics.CookieSyncObject")).Return()
 
 			mockMetrics := metrics.MetricsEngineMock{}
+			mockMetrics.On("RecordUIDsCookieJar", mock.Anything).Maybe()
 			mockMetrics.On("RecordCookieSync", mock.Anything, mock.Anything, mock.Anything).Return()
 			mockMetrics.On("RecordSyncerRequest", mock.Anything, mock.Anything, mock.Anything).Return()
 
@@ -2754,6 +2784,8 @@ func TestCookieSyncPriorityGroupsIntegration(t *testing.T) {
 					},
 				},
 				bidders,
+				nil,
+				nil,
 			)
 			// Create test request
 			request := httptest.NewRequest("POST", "/cookie_sync", strings.NewReader(tc.givenRequestBody))
@@ -2790,6 +2822,7 @@ func TestCookieSyncPriorityGroupsEdgeCases(t *testing.T) {
 	syncerA.On("Key").Return("bidderA")
 	syncerA.On("GetSync", mock.Anything, mock.Anything).Return(usersync.Sync{URL: "https://sync.bidderA.com", Type: usersync.SyncTypeRedirect}, nil).Maybe()
 	syncerA.On("SupportsType", mock.Anything).Return(true).Maybe()
+	syncerA.On("UIDPolicy").Return(usersync.UIDPolicy{}).Maybe()
 
 	syncersByBidder := map[string]usersync.Syncer{
 		"sovrn": &syncerA,
@@ -2895,6 +2928,7 @@ func TestCookieSyncPriorityGroupsEdgeCases(t *testing.T) {
 			mockAnalytics.On("LogCookieSyncObject", mock.AnythingOfType("*analytics.CookieSyncObject")).Return()
 
 			mockMetrics := metrics.MetricsEngineMock{}
+			mockMetrics.On("RecordUIDsCookieJar", mock.Anything).Maybe()
 			mockMetrics.On("RecordCookieSync", mock.Anything, mock.Anything, mock.Anything).Return()
 			mockMetrics.On("RecordSyncerRequest", mock.Anything, mock.Anything, mock.Anything).Return()
 
@@ -2927,6 +2961,8 @@ func TestCookieSyncPriorityGroupsEdgeCases(t *testing.T) {
 					},
 				},
 				bidders,
+				nil,
+				nil,
 			)
 
 			// Create test request
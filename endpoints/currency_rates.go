@@ -57,9 +57,10 @@ func newCurrencyRatesInfo(rateConverter rateConverter, fetchingInterval time.Dur
 
 // NewCurrencyRatesEndpoint returns current currency rates applied by the PBS server.
 func NewCurrencyRatesEndpoint(rateConverter rateConverter, fetchingInterval time.Duration) http.HandlerFunc {
-	currencyRateInfo := newCurrencyRatesInfo(rateConverter, fetchingInterval)
-
 	return func(w http.ResponseWriter, _ *http.Request) {
+		// the active source changes on failover so the info is read on each request
+		currencyRateInfo := newCurrencyRatesInfo(rateConverter, fetchingInterval)
+
 		jsonOutput, err := jsonutil.Marshal(currencyRateInfo)
 		if err != nil {
 			logger.Errorf("/currency/rates Critical error when trying to marshal currencyRateInfo: %v", err)
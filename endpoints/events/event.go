@@ -18,6 +18,7 @@ import (
 	"github.com/prebid/prebid-server/v4/config"
 	"github.com/prebid/prebid-server/v4/errortypes"
 	"github.com/prebid/prebid-server/v4/metrics"
+	"github.com/prebid/prebid-server/v4/notifications"
 	"github.com/prebid/prebid-server/v4/privacy"
 	"github.com/prebid/prebid-server/v4/stored_requests"
 	"github.com/prebid/prebid-server/v4/util/httputil"
@@ -47,15 +48,18 @@ type eventEndpoint struct {
 	Cfg           *config.Configuration
 	TrackingPixel *httputil.Pixel
 	MetricsEngine metrics.MetricsEngine
+	// Notifier fires the billing notice URLs of the bids on their billing event
+	Notifier notifications.Notifier
 }
 
-func NewEventEndpoint(cfg *config.Configuration, accounts stored_requests.AccountFetcher, analytics analytics.Runner, me metrics.MetricsEngine) httprouter.Handle {
+func NewEventEndpoint(cfg *config.Configuration, accounts stored_requests.AccountFetcher, analytics analytics.Runner, me metrics.MetricsEngine, notifier notifications.Notifier) httprouter.Handle {
 	ee := &eventEndpoint{
 		Accounts:      accounts,
 		Analytics:     analytics,
 		Cfg:           cfg,
 		TrackingPixel: &httputil.Pixel1x1PNG,
 		MetricsEngine: me,
+		Notifier:      notifier,
 	}
 
 	return ee.Handle
@@ -86,7 +90,7 @@ func (e *eventEndpoint) Handle(w http.ResponseWriter, r *http.Request, _ httprou
 	}
 	eventRequest.AccountID = accountId
 
-	if eventRequest.Analytics != analytics.Enabled {
+	if eventRequest.Analytics != analytics.Enabled && !e.isBillingEvent(eventRequest) {
 		w.WriteHeader(http.StatusNoContent)
 		return
 	}
@@ -117,13 +121,20 @@ func (e *eventEndpoint) Handle(w http.ResponseWriter, r *http.Request, _ httprou
 		return
 	}
 
-	activities := privacy.NewActivityControl(&account.Privacy)
+	// fire the billing notice URL of the bid on behalf of the client
+	if account.Notifications.Enabled && e.isBillingEvent(eventRequest) {
+		e.Notifier.Billing(eventRequest.AccountID, openrtb_ext.BidderName(eventRequest.Bidder), eventRequest.BidID)
+	}
+
+	if eventRequest.Analytics == analytics.Enabled {
+		activities := privacy.NewActivityControl(&account.Privacy)
 
-	// handle notification event
-	e.Analytics.LogNotificationEventObject(&analytics.NotificationEvent{
-		Request: eventRequest,
-		Account: account,
-	}, activities)
+		// handle notification event
+		e.Analytics.LogNotificationEventObject(&analytics.NotificationEvent{
+			Request: eventRequest,
+			Account: account,
+		}, activities)
+	}
 
 	// Add tracking pixel if format == image
 	if eventRequest.Format == analytics.Image {
@@ -137,6 +148,12 @@ func (e *eventEndpoint) Handle(w http.ResponseWriter, r *http.Request, _ httprou
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// isBillingEvent returns true if the event triggers the billing notice URL of the bid, which is the case of the
+// impression and billing events when PBS fires the notice URLs
+func (e *eventEndpoint) isBillingEvent(eventRequest *analytics.EventRequest) bool {
+	return e.Notifier != nil && (eventRequest.Type == analytics.Imp || eventRequest.Type == analytics.Billing)
+}
+
 // EventRequestToUrl converts an analytics.EventRequest to an URL
 func EventRequestToUrl(externalUrl string, request *analytics.EventRequest) string {
 	s := fmt.Sprintf(TemplateUrl, externalUrl, request.Type, request.BidID, request.AccountID)
@@ -290,6 +307,9 @@ func readType(er *analytics.EventRequest, httpRequest *http.Request) error {
 	case string(analytics.Vast):
 		er.Type = analytics.Vast
 		return nil
+	case string(analytics.Billing):
+		er.Type = analytics.Billing
+		return nil
 	default:
 		return &errortypes.BadInput{Message: fmt.Sprintf("unknown type: '%s'", t)}
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/prebid/prebid-server/v4/config"
 	"github.com/prebid/prebid-server/v4/errortypes"
 	"github.com/prebid/prebid-server/v4/metrics"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
 	"github.com/prebid/prebid-server/v4/privacy"
 	"github.com/prebid/prebid-server/v4/stored_requests"
 	"github.com/stretchr/testify/assert"
@@ -69,6 +70,7 @@ func (e *eventsMockAnalyticsModule) Shutdown() {}
 var mockAccountData = map[string]json.RawMessage{
 	"events_enabled":  json.RawMessage(`{"events": {"enabled":true}}`),
 	"events_disabled": json.RawMessage(`{"events": {"enabled":false}}`),
+	"notifications":   json.RawMessage(`{"events": {"enabled":true}, "notifications": {"enabled":true}}`),
 	"malformed_acct":  json.RawMessage(`{"events": {"enabled":"invalid type"}}`),
 	"disabled_acct":   json.RawMessage(`{"disabled": true}`),
 }
@@ -123,7 +125,7 @@ func TestShouldReturnBadRequestWhenTypeIsMissing(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?b=test", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -159,7 +161,7 @@ func TestShouldReturnBadRequestWhenTypeIsInvalid(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=test&b=t", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccounts, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccounts, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -195,7 +197,7 @@ func TestShouldReturnBadRequestWhenBidIdIsMissing(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -231,7 +233,7 @@ func TestShouldReturnBadRequestWhenTimestampIsInvalid(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=q", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -267,7 +269,7 @@ func TestShouldReturnUnauthorizedWhenAccountIsMissing(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -303,7 +305,7 @@ func TestShouldReturnBadRequestWhenFormatValueIsInvalid(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=q", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -339,7 +341,7 @@ func TestShouldReturnBadRequestWhenAnalyticsValueIsInvalid(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=b&x=4", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -378,7 +380,7 @@ func TestShouldNotPassEventToAnalyticsReporterWhenAccountNotFoundAndDefaultIsFal
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=b&x=1&a=testacc", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -412,7 +414,7 @@ func TestShouldReturnBadRequestWhenIntegrationValueIsInvalid(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=win&b=bidId&f=b&ts=1000&x=1&a=accountId&bidder=bidder&int=Te$tIntegrationType", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -451,7 +453,7 @@ func TestShouldNotPassEventToAnalyticsReporterWhenAccountEventNotEnabled(t *test
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=b&x=1&a=events_disabled", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -489,7 +491,7 @@ func TestShouldPassEventToAnalyticsReporterWhenAccountEventEnabled(t *testing.T)
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=b&x=1&a=events_enabled", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -523,7 +525,7 @@ func TestShouldNotPassEventToAnalyticsReporterWhenAnalyticsValueIsZero(t *testin
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=b&x=0&a=events_enabled", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -557,7 +559,7 @@ func TestShouldRespondWithPixelAndContentTypeWhenRequestFormatIsImage(t *testing
 	req := httptest.NewRequest("GET", "/event?t=win&b=test&ts=1234&f=i&x=1&a=events_enabled", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -598,7 +600,7 @@ func TestShouldRespondWithNoContentWhenRequestFormatIsNotDefined(t *testing.T) {
 	req := httptest.NewRequest("GET", "/event?t=imp&b=test&ts=1234&x=1&a=events_enabled", strings.NewReader(reqData))
 	recorder := httptest.NewRecorder()
 
-	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+	e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 
 	// execute
 	e(recorder, req, nil)
@@ -615,6 +617,74 @@ func TestShouldRespondWithNoContentWhenRequestFormatIsNotDefined(t *testing.T) {
 	assert.Equal(t, 0, len(d))
 }
 
+type mockNotifier struct {
+	billedBids []string
+}
+
+func (n *mockNotifier) Win(accountID, auctionID string, bidder openrtb_ext.BidderName, bidID, nurl string) {
+}
+
+func (n *mockNotifier) RecordBilling(accountID string, bidder openrtb_ext.BidderName, bidID, burl string) {
+}
+
+func (n *mockNotifier) Billing(accountID string, bidder openrtb_ext.BidderName, bidID string) {
+	n.billedBids = append(n.billedBids, accountID+":"+bidder.String()+":"+bidID)
+}
+
+func (n *mockNotifier) Stop() {}
+
+func TestShouldFireBillingNotification(t *testing.T) {
+	testCases := []struct {
+		name               string
+		url                string
+		expectedBilledBids []string
+		expectedAnalytics  bool
+	}{
+		{
+			name:               "imp_event",
+			url:                "/event?t=imp&b=bid-1&x=1&a=notifications&bidder=AppNexus",
+			expectedBilledBids: []string{"notifications:appnexus:bid-1"},
+			expectedAnalytics:  true,
+		},
+		{
+			name:               "billing_event_without_analytics",
+			url:                "/event?t=billing&b=bid-1&x=0&a=notifications&bidder=pubmatic",
+			expectedBilledBids: []string{"notifications:pubmatic:bid-1"},
+			expectedAnalytics:  false,
+		},
+		{
+			name:              "win_event",
+			url:               "/event?t=win&b=bid-1&x=1&a=notifications",
+			expectedAnalytics: true,
+		},
+		{
+			name:              "notifications_disabled_for_account",
+			url:               "/event?t=imp&b=bid-1&x=1&a=events_enabled",
+			expectedAnalytics: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAnalyticsModule := &eventsMockAnalyticsModule{}
+			notifier := &mockNotifier{}
+			cfg := &config.Configuration{
+				AccountDefaults: config.Account{},
+			}
+
+			req := httptest.NewRequest("GET", tc.url, strings.NewReader(""))
+			recorder := httptest.NewRecorder()
+
+			e := NewEventEndpoint(cfg, &mockAccountsFetcher{}, mockAnalyticsModule, &metrics.MetricsEngineMock{}, notifier)
+			e(recorder, req, nil)
+
+			assert.Equal(t, http.StatusNoContent, recorder.Result().StatusCode)
+			assert.Equal(t, tc.expectedBilledBids, notifier.billedBids)
+			assert.Equal(t, tc.expectedAnalytics, mockAnalyticsModule.Invoked)
+		})
+	}
+}
+
 func TestShouldParseEventCorrectly(t *testing.T) {
 
 	tests := map[string]struct {
@@ -815,7 +885,7 @@ func TestShouldReturnBadRequestWhenVTypeIsInvalid(t *testing.T) {
 
 		recorder := httptest.NewRecorder()
 
-		e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{})
+		e := NewEventEndpoint(cfg, mockAccountsFetcher, mockAnalyticsModule, &metrics.MetricsEngineMock{}, nil)
 		e(recorder, test.req, nil)
 
 		d, err := io.ReadAll(recorder.Result().Body)
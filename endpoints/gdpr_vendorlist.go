@@ -0,0 +1,62 @@
+package endpoints
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/prebid/prebid-server/v4/gdpr"
+	"github.com/prebid/prebid-server/v4/logger"
+	"github.com/prebid/prebid-server/v4/util/jsonutil"
+)
+
+// maxVendorListUploadSize limits the size of uploaded vendor lists. Current lists are a few MB.
+const maxVendorListUploadSize = 32 << 20
+
+// vendorListUploadInfo describes the vendor list added by an upload.
+type vendorListUploadInfo struct {
+	SpecVersion uint16 `json:"specVersion"`
+	ListVersion uint16 `json:"listVersion"`
+}
+
+// NewVendorListUploadEndpoint returns an endpoint which adds the Global Vendor List posted in the body of the request
+// to the vendor lists used for TCF enforcement.
+func NewVendorListUploadEndpoint(upload gdpr.VendorListUploader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxVendorListUploadSize+1))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Failed to read the vendor list: %v", err)
+			return
+		}
+		if len(body) > maxVendorListUploadSize {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			fmt.Fprintf(w, "The vendor list exceeds the maximum size of %d bytes", maxVendorListUploadSize)
+			return
+		}
+
+		list, err := upload(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Failed to add the vendor list: %v", err)
+			return
+		}
+		logger.Infof("GDPR vendor list spec version %d list version %d uploaded", list.SpecVersion(), list.Version())
+
+		jsonOutput, err := jsonutil.Marshal(vendorListUploadInfo{SpecVersion: list.SpecVersion(), ListVersion: list.Version()})
+		if err != nil {
+			logger.Errorf("/gdpr/vendorlist Critical error when trying to marshal vendorListUploadInfo: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(jsonOutput)
+	}
+}
@@ -0,0 +1,72 @@
+package endpoints
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prebid/go-gdpr/api"
+	"github.com/prebid/go-gdpr/vendorlist2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVendorListUploadEndpoint(t *testing.T) {
+	const vendorList = `{"gvlSpecificationVersion":3,"vendorListVersion":12,"vendors":{}}`
+
+	upload := func(body []byte) (api.VendorList, error) {
+		list, err := vendorlist2.ParseEagerly(body)
+		if err != nil {
+			return nil, errors.New("malformed vendor list")
+		}
+		return list, nil
+	}
+
+	testCases := []struct {
+		name         string
+		method       string
+		body         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "uploaded",
+			method:       http.MethodPost,
+			body:         vendorList,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"specVersion":3,"listVersion":12}`,
+		},
+		{
+			name:         "invalid",
+			method:       http.MethodPost,
+			body:         `{`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "Failed to add the vendor list: malformed vendor list",
+		},
+		{
+			name:         "too_large",
+			method:       http.MethodPost,
+			body:         strings.Repeat(" ", maxVendorListUploadSize+1),
+			expectedCode: http.StatusRequestEntityTooLarge,
+			expectedBody: "The vendor list exceeds the maximum size of 33554432 bytes",
+		},
+		{
+			name:         "wrong_method",
+			method:       http.MethodGet,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			handler := NewVendorListUploadEndpoint(upload)
+			w := httptest.NewRecorder()
+
+			handler(w, httptest.NewRequest(test.method, "/gdpr/vendorlist", strings.NewReader(test.body)))
+
+			assert.Equal(t, test.expectedCode, w.Code)
+			assert.Equal(t, test.expectedBody, w.Body.String())
+		})
+	}
+}
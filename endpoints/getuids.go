@@ -5,6 +5,7 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/logger"
 	"github.com/prebid/prebid-server/v4/usersync"
 
 	"encoding/json"
@@ -15,11 +16,14 @@ type userSyncs struct {
 }
 
 // NewGetUIDsEndpoint implements the /getuid endpoint which
-// returns all the existing syncs for the user
-func NewGetUIDsEndpoint(cfg config.HostCookie) httprouter.Handle {
+// returns all the existing syncs for the user, including those held in the server side ID store
+func NewGetUIDsEndpoint(cfg config.HostCookie, idStoreCfg config.UserSyncIDStore, idStore usersync.IDStore) httprouter.Handle {
 	return httprouter.Handle(func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-		cookie := usersync.ReadCookie(r, usersync.Base64Decoder{}, &cfg)
+		cookie := usersync.ReadCookie(r, usersync.CompactDecoder{}, &cfg)
 		usersync.SyncHostCookie(r, cookie, &cfg)
+		if err := loadStoredUIDs(r, cookie, idStore, idStoreCfg, &cfg); err != nil {
+			logger.Warnf("Failed to load stored user ids: %v", err)
+		}
 
 		userSyncs := new(userSyncs)
 		userSyncs.BuyerUIDs = cookie.GetUIDs()
@@ -1,17 +1,20 @@
 package endpoints
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/usersync"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestGetUIDs(t *testing.T) {
 	req := makeRequest("/getuids", map[string]string{"adnxs": "123", "audienceNetwork": "456"})
-	endpoint := NewGetUIDsEndpoint(config.HostCookie{})
+	endpoint := NewGetUIDsEndpoint(config.HostCookie{}, config.UserSyncIDStore{}, nil)
 	res := httptest.NewRecorder()
 	endpoint(res, req, nil)
 
@@ -22,7 +25,7 @@ func TestGetUIDs(t *testing.T) {
 
 func TestGetUIDsWithNoSyncs(t *testing.T) {
 	req := makeRequest("/getuids", map[string]string{})
-	endpoint := NewGetUIDsEndpoint(config.HostCookie{})
+	endpoint := NewGetUIDsEndpoint(config.HostCookie{}, config.UserSyncIDStore{}, nil)
 	res := httptest.NewRecorder()
 	endpoint(res, req, nil)
 
@@ -32,10 +35,27 @@ func TestGetUIDsWithNoSyncs(t *testing.T) {
 
 func TestGetUIDWIthNoCookie(t *testing.T) {
 	req := httptest.NewRequest("GET", "/getuids", nil)
-	endpoint := NewGetUIDsEndpoint(config.HostCookie{})
+	endpoint := NewGetUIDsEndpoint(config.HostCookie{}, config.UserSyncIDStore{}, nil)
 	res := httptest.NewRecorder()
 	endpoint(res, req, nil)
 
 	assert.Equal(t, http.StatusOK, res.Code)
 	assert.JSONEq(t, `{}`, res.Body.String(), "GetUIDs endpoint shouldn't return anything if there doesn't exist a PBS cookie")
 }
+
+func TestGetUIDsWithIDStore(t *testing.T) {
+	hostCookie := config.HostCookie{CookieName: "host", Family: "host"}
+	idStoreCfg := config.UserSyncIDStore{Enabled: true, Type: config.UserSyncIDStoreTypeMemory, TTL: 1, TimeoutMS: 100}
+	idStore := usersync.NewMemoryIDStore(config.UserSyncIDStoreMemory{})
+	idStore.Set(context.Background(), "hostID", "rubicon", usersync.UIDEntry{UID: "789", Expires: time.Now().Add(time.Hour)})
+
+	req := makeRequest("/getuids", map[string]string{"adnxs": "123"})
+	req.AddCookie(&http.Cookie{Name: "host", Value: "hostID"})
+	endpoint := NewGetUIDsEndpoint(hostCookie, idStoreCfg, idStore)
+	res := httptest.NewRecorder()
+	endpoint(res, req, nil)
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, `{"buyeruids": {"adnxs": "123", "host": "hostID", "rubicon": "789"}}`,
+		res.Body.String(), "GetUIDs endpoint should return user IDs from both the cookie and the ID store")
+}
@@ -0,0 +1,49 @@
+package endpoints
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/usersync"
+)
+
+// loadStoredUIDs merges the UIDs held in the server side ID store for the user's host ID into the
+// cookie. It is a no-op if the store is disabled or the user has no host ID.
+func loadStoredUIDs(r *http.Request, cookie *usersync.Cookie, idStore usersync.IDStore, cfg config.UserSyncIDStore, host *config.HostCookie) error {
+	if idStore == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), cfg.Timeout())
+	defer cancel()
+
+	return usersync.LoadStoredUIDs(ctx, idStore, usersync.HostID(r, host), cookie)
+}
+
+// storeUID writes the uid for the syncer key to the server side ID store, or removes it if the uid
+// is empty. Stored uids never outlive the syncer's uid policy. It is a no-op if the store is disabled
+// or the user has no host ID.
+func storeUID(idStore usersync.IDStore, cfg config.UserSyncIDStore, hostID, key, uid string, policy usersync.UIDPolicy) error {
+	if idStore == nil || hostID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout())
+	defer cancel()
+
+	if uid == "" {
+		return idStore.Delete(ctx, hostID, key)
+	}
+
+	ttl := cfg.TTLDuration()
+	if policy.TTL > 0 && policy.TTL < ttl {
+		ttl = policy.TTL
+	}
+
+	return idStore.Set(ctx, hostID, key, usersync.UIDEntry{
+		UID:     uid,
+		Expires: time.Now().Add(ttl),
+	})
+}
@@ -0,0 +1,144 @@
+package endpoints
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	analyticsBuild "github.com/prebid/prebid-server/v4/analytics/build"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/gdpr"
+	metricsConf "github.com/prebid/prebid-server/v4/metrics/config"
+	"github.com/prebid/prebid-server/v4/usersync"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreUID(t *testing.T) {
+	cfg := config.UserSyncIDStore{Enabled: true, Type: config.UserSyncIDStoreTypeMemory, TTL: 1, TimeoutMS: 100}
+
+	testCases := []struct {
+		name            string
+		givenStored     map[string]string
+		givenHostID     string
+		givenUID        string
+		expectedEntries map[string]string
+	}{
+		{
+			name:            "set",
+			givenStored:     map[string]string{},
+			givenHostID:     "host",
+			givenUID:        "123",
+			expectedEntries: map[string]string{"adnxs": "123"},
+		},
+		{
+			name:            "overwrite",
+			givenStored:     map[string]string{"adnxs": "old"},
+			givenHostID:     "host",
+			givenUID:        "123",
+			expectedEntries: map[string]string{"adnxs": "123"},
+		},
+		{
+			name:            "clear",
+			givenStored:     map[string]string{"adnxs": "old"},
+			givenHostID:     "host",
+			givenUID:        "",
+			expectedEntries: map[string]string{},
+		},
+		{
+			name:            "no-host-id",
+			givenStored:     map[string]string{},
+			givenHostID:     "",
+			givenUID:        "123",
+			expectedEntries: map[string]string{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			idStore := usersync.NewMemoryIDStore(config.UserSyncIDStoreMemory{})
+			for key, uid := range test.givenStored {
+				idStore.Set(context.Background(), "host", key, usersync.UIDEntry{UID: uid, Expires: time.Now().Add(time.Hour)})
+			}
+
+			err := storeUID(idStore, cfg, test.givenHostID, "adnxs", test.givenUID, usersync.UIDPolicy{})
+			assert.NoError(t, err)
+
+			entries, _ := idStore.Get(context.Background(), "host")
+			uids := make(map[string]string, len(entries))
+			for key, entry := range entries {
+				uids[key] = entry.UID
+			}
+			assert.Equal(t, test.expectedEntries, uids)
+		})
+	}
+}
+
+func TestStoreUIDPolicyTTL(t *testing.T) {
+	cfg := config.UserSyncIDStore{Enabled: true, Type: config.UserSyncIDStoreTypeMemory, TTL: 90, TimeoutMS: 100}
+	idStore := usersync.NewMemoryIDStore(config.UserSyncIDStoreMemory{})
+
+	err := storeUID(idStore, cfg, "host", "adnxs", "123", usersync.UIDPolicy{TTL: 7 * 24 * time.Hour})
+	assert.NoError(t, err)
+
+	entries, _ := idStore.Get(context.Background(), "host")
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), entries["adnxs"].Expires, time.Minute)
+}
+
+func TestStoreUIDDisabled(t *testing.T) {
+	assert.NoError(t, storeUID(nil, config.UserSyncIDStore{}, "host", "adnxs", "123", usersync.UIDPolicy{}))
+}
+
+func TestSetUIDStoresWrittenUIDs(t *testing.T) {
+	testCases := []struct {
+		name               string
+		givenMaxCookieSize int
+		expectedStatusCode int
+		expectedEntries    map[string]string
+	}{
+		{
+			name:               "cookie-written",
+			givenMaxCookieSize: 0,
+			expectedStatusCode: http.StatusOK,
+			expectedEntries:    map[string]string{"pubmatic": "123"},
+		},
+		{
+			name:               "cookie-not-written",
+			givenMaxCookieSize: 1,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedEntries:    map[string]string{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.Configuration{
+				HostCookie: config.HostCookie{CookieName: "host", MaxCookieSizeBytes: test.givenMaxCookieSize},
+				UserSync: config.UserSync{
+					IDStore: config.UserSyncIDStore{Enabled: true, Type: config.UserSyncIDStoreTypeMemory, TTL: 1, TimeoutMS: 100},
+				},
+			}
+			cfg.MarshalAccountDefaults()
+
+			syncersByBidder := map[string]usersync.Syncer{"pubmatic": fakeSyncer{key: "pubmatic", defaultSyncType: usersync.SyncTypeIFrame}}
+			gdprPermsBuilder := fakePermissionsBuilder{permissions: &fakePermsSetUID{allowHost: true, personalInfoAllowed: true}}.Builder
+			tcf2ConfigBuilder := fakeTCF2ConfigBuilder{cfg: gdpr.NewTCF2Config(config.TCF2{}, config.AccountGDPR{})}.Builder
+			idStore := usersync.NewMemoryIDStore(config.UserSyncIDStoreMemory{})
+
+			endpoint := NewSetUIDEndpoint(&cfg, syncersByBidder, gdprPermsBuilder, tcf2ConfigBuilder, analyticsBuild.New(&config.Analytics{}), FakeAccountsFetcher{}, &metricsConf.NilMetricsEngine{}, idStore)
+			request := httptest.NewRequest("GET", "/setuid?bidder=pubmatic&uid=123&f=b", nil)
+			request.AddCookie(&http.Cookie{Name: "host", Value: "hostID"})
+			response := httptest.NewRecorder()
+			endpoint(response, request, nil)
+
+			assert.Equal(t, test.expectedStatusCode, response.Code)
+			entries, _ := idStore.Get(context.Background(), "hostID")
+			uids := make(map[string]string, len(entries))
+			for key, entry := range entries {
+				uids[key] = entry.UID
+			}
+			assert.Equal(t, test.expectedEntries, uids)
+		})
+	}
+}
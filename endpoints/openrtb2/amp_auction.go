@@ -70,6 +70,7 @@ func NewAmpEndpoint(
 	storedRespFetcher stored_requests.Fetcher,
 	hookExecutionPlanBuilder hooks.ExecutionPlanBuilder,
 	tmaxAdjustments *exchange.TmaxAdjustmentsPreprocessed,
+	idStore usersync.IDStore,
 ) (httprouter.Handle, error) {
 
 	if ex == nil || requestValidator == nil || requestsById == nil || accounts == nil || cfg == nil || metricsEngine == nil {
@@ -104,6 +105,7 @@ func NewAmpEndpoint(
 		hookExecutionPlanBuilder,
 		tmaxAdjustments,
 		openrtb_ext.NormalizeBidderName,
+		idStore,
 	}).AmpAuction), nil
 
 }
@@ -137,6 +139,7 @@ func (deps *endpointDeps) AmpAuction(w http.ResponseWriter, r *http.Request, _ h
 	defer func() {
 		deps.metricsEngine.RecordRequest(labels)
 		deps.metricsEngine.RecordRequestTime(labels, time.Since(start))
+		ao.PrivacyAudit = activityControl.AuditReport()
 		deps.analytics.LogAmpObject(&ao, activityControl)
 	}()
 
@@ -187,8 +190,10 @@ func (deps *endpointDeps) AmpAuction(w http.ResponseWriter, r *http.Request, _ h
 	defer cancel()
 
 	// Read UserSyncs/Cookie from Request
-	usersyncs := usersync.ReadCookie(r, usersync.Base64Decoder{}, &deps.cfg.HostCookie)
+	usersyncs := usersync.ReadCookie(r, usersync.CompactDecoder{}, &deps.cfg.HostCookie)
+	deps.metricsEngine.RecordUIDsCookieJar(metrics.NewCookieJar(usersync.ReadCookieJars(r)))
 	usersync.SyncHostCookie(r, usersyncs, &deps.cfg.HostCookie)
+	deps.loadStoredUIDs(r, usersyncs)
 	if usersyncs.HasAnyLiveSyncs() {
 		labels.CookieFlag = metrics.CookieFlagYes
 	} else {
@@ -288,6 +293,8 @@ func (deps *endpointDeps) AmpAuction(w http.ResponseWriter, r *http.Request, _ h
 		response = auctionResponse.BidResponse
 	}
 	ao.SeatNonBid = auctionResponse.GetSeatNonBid()
+	ao.FloorsOutcome = auctionResponse.GetFloorsOutcome()
+	ao.RateSnapshotID = auctionResponse.GetRateSnapshotID()
 	ao.AuctionResponse = response
 	rejectErr, isRejectErr := hookexecution.CastRejectErr(err)
 	if err != nil && !isRejectErr {
@@ -98,6 +98,7 @@ func NewEndpoint(
 	storedRespFetcher stored_requests.Fetcher,
 	hookExecutionPlanBuilder hooks.ExecutionPlanBuilder,
 	tmaxAdjustments *exchange.TmaxAdjustmentsPreprocessed,
+	idStore usersync.IDStore,
 ) (httprouter.Handle, error) {
 	if ex == nil || requestValidator == nil || requestsById == nil || accounts == nil || cfg == nil || metricsEngine == nil {
 		return nil, errors.New("NewEndpoint requires non-nil arguments.")
@@ -130,7 +131,8 @@ func NewEndpoint(
 		storedRespFetcher,
 		hookExecutionPlanBuilder,
 		tmaxAdjustments,
-		openrtb_ext.NormalizeBidderName}).Auction), nil
+		openrtb_ext.NormalizeBidderName,
+		idStore}).Auction), nil
 }
 
 type endpointDeps struct {
@@ -154,6 +156,23 @@ type endpointDeps struct {
 	hookExecutionPlanBuilder  hooks.ExecutionPlanBuilder
 	tmaxAdjustments           *exchange.TmaxAdjustmentsPreprocessed
 	normalizeBidderName       openrtb_ext.BidderNameNormalizer
+	idStore                   usersync.IDStore
+}
+
+// loadStoredUIDs merges the UIDs held in the server side ID store for the user's host ID into
+// the request cookie so they are available when building bidder requests.
+func (deps *endpointDeps) loadStoredUIDs(r *http.Request, usersyncs *usersync.Cookie) {
+	if deps.idStore == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), deps.cfg.UserSync.IDStore.Timeout())
+	defer cancel()
+
+	hostID := usersync.HostID(r, &deps.cfg.HostCookie)
+	if err := usersync.LoadStoredUIDs(ctx, deps.idStore, hostID, usersyncs); err != nil {
+		logger.Warnf("Failed to load stored user ids: %v", err)
+	}
 }
 
 func (deps *endpointDeps) Auction(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -185,6 +204,7 @@ func (deps *endpointDeps) Auction(w http.ResponseWriter, r *http.Request, _ http
 	defer func() {
 		deps.metricsEngine.RecordRequest(labels)
 		deps.metricsEngine.RecordRequestTime(labels, time.Since(start))
+		ao.PrivacyAudit = activityControl.AuditReport()
 		deps.analytics.LogAuctionObject(&ao, activityControl)
 	}()
 
@@ -220,9 +240,11 @@ func (deps *endpointDeps) Auction(w http.ResponseWriter, r *http.Request, _ http
 	errL = append(errL, gdprErrs...)
 
 	// Read Usersyncs/Cookie
-	decoder := usersync.Base64Decoder{}
+	decoder := usersync.CompactDecoder{}
 	usersyncs := usersync.ReadCookie(r, decoder, &deps.cfg.HostCookie)
+	deps.metricsEngine.RecordUIDsCookieJar(metrics.NewCookieJar(usersync.ReadCookieJars(r)))
 	usersync.SyncHostCookie(r, usersyncs, &deps.cfg.HostCookie)
+	deps.loadStoredUIDs(r, usersyncs)
 
 	if req.Site != nil {
 		if usersyncs.HasAnyLiveSyncs() {
@@ -278,6 +300,8 @@ func (deps *endpointDeps) Auction(w http.ResponseWriter, r *http.Request, _ http
 	}
 	ao.Response = response
 	ao.SeatNonBid = auctionResponse.GetSeatNonBid()
+	ao.FloorsOutcome = auctionResponse.GetFloorsOutcome()
+	ao.RateSnapshotID = auctionResponse.GetRateSnapshotID()
 	rejectErr, isRejectErr := hookexecution.CastRejectErr(err)
 	if err != nil && !isRejectErr {
 		if errortypes.ReadCode(err) == errortypes.BadInputErrorCode {
@@ -1170,6 +1194,10 @@ func validateTargeting(t *openrtb_ext.ExtRequestTargeting) error {
 		}
 	}
 
+	if err := currency.ValidateCurrencies(t.AdditionalCurrencies); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -58,6 +58,7 @@ func NewVideoEndpoint(
 	bidderMap map[string]openrtb_ext.BidderName,
 	cache prebid_cache_client.Client,
 	tmaxAdjustments *exchange.TmaxAdjustmentsPreprocessed,
+	idStore usersync.IDStore,
 ) (httprouter.Handle, error) {
 
 	if ex == nil || requestValidator == nil || requestsById == nil || accounts == nil || cfg == nil || met == nil {
@@ -93,7 +94,8 @@ func NewVideoEndpoint(
 		empty_fetcher.EmptyFetcher{},
 		hooks.EmptyPlanBuilder{},
 		tmaxAdjustments,
-		openrtb_ext.NormalizeBidderName}).VideoAuctionEndpoint), nil
+		openrtb_ext.NormalizeBidderName,
+		idStore}).VideoAuctionEndpoint), nil
 }
 
 /*
@@ -167,6 +169,7 @@ func (deps *endpointDeps) VideoAuctionEndpoint(w http.ResponseWriter, r *http.Re
 		}
 		deps.metricsEngine.RecordRequest(labels)
 		deps.metricsEngine.RecordRequestTime(labels, time.Since(start))
+		vo.PrivacyAudit = activityControl.AuditReport()
 		deps.analytics.LogVideoObject(&vo, activityControl)
 	}()
 
@@ -286,9 +289,11 @@ func (deps *endpointDeps) VideoAuctionEndpoint(w http.ResponseWriter, r *http.Re
 	}
 
 	// Read Usersyncs/Cookie
-	decoder := usersync.Base64Decoder{}
+	decoder := usersync.CompactDecoder{}
 	usersyncs := usersync.ReadCookie(r, decoder, &deps.cfg.HostCookie)
+	deps.metricsEngine.RecordUIDsCookieJar(metrics.NewCookieJar(usersync.ReadCookieJars(r)))
 	usersync.SyncHostCookie(r, usersyncs, &deps.cfg.HostCookie)
+	deps.loadStoredUIDs(r, usersyncs)
 
 	if bidReqWrapper.App != nil {
 		labels.Source = metrics.DemandApp
@@ -361,6 +366,7 @@ func (deps *endpointDeps) VideoAuctionEndpoint(w http.ResponseWriter, r *http.Re
 	}
 	vo.Response = response
 	vo.SeatNonBid = auctionResponse.GetSeatNonBid()
+	vo.FloorsOutcome = auctionResponse.GetFloorsOutcome()
 	if err != nil {
 		errL := []error{err}
 		handleError(&labels, w, errL, &vo, &debugLog)
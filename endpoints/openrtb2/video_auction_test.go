@@ -893,6 +893,22 @@ func TestHandleErrorMetrics(t *testing.T) {
 	assert.Equal(t, "request missing required field: PodConfig.Pods", mod.videoObjects[0].Errors[1].Error(), "Second error in AnalyticsObject should have message regarding Pods")
 }
 
+func TestVideoEndpointFloorsOutcomeAnalytics(t *testing.T) {
+	floorsOutcome := &openrtb_ext.PriceFloorsOutcome{Location: "request", Enforced: true, RejectedBids: 1}
+	ex := &mockExchangeVideo{floorsOutcome: floorsOutcome}
+	reqBody := readVideoTestFile(t, "sample-requests/video/video_valid_sample.json")
+	req := httptest.NewRequest("POST", "/openrtb2/video", strings.NewReader(reqBody))
+	recorder := httptest.NewRecorder()
+
+	deps, _, mod := mockDepsWithMetrics(t, ex)
+	deps.VideoAuctionEndpoint(recorder, req, nil)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	if assert.Len(t, mod.videoObjects, 1) {
+		assert.Equal(t, floorsOutcome, mod.videoObjects[0].FloorsOutcome)
+	}
+}
+
 func TestParseVideoRequestWithUserAgentAndHeader(t *testing.T) {
 	ex := &mockExchangeVideo{}
 	reqBody := readVideoTestFile(t, "sample-requests/video/video_valid_sample_with_device_user_agent.json")
@@ -1287,6 +1303,7 @@ func mockDepsWithMetrics(t *testing.T, ex *mockExchangeVideo) (*endpointDeps, *m
 		hooks.EmptyPlanBuilder{},
 		nil,
 		openrtb_ext.NormalizeBidderName,
+		nil,
 	}
 	return deps, metrics, mockModule
 }
@@ -1338,6 +1355,7 @@ func mockDeps(t *testing.T, ex *mockExchangeVideo) *endpointDeps {
 		hooks.EmptyPlanBuilder{},
 		nil,
 		openrtb_ext.NormalizeBidderName,
+		nil,
 	}
 }
 
@@ -1363,6 +1381,7 @@ func mockDepsAppendBidderNames(t *testing.T, ex *mockExchangeAppendBidderNames)
 		hooks.EmptyPlanBuilder{},
 		nil,
 		openrtb_ext.NormalizeBidderName,
+		nil,
 	}
 
 	return deps
@@ -1390,6 +1409,7 @@ func mockDepsNoBids(t *testing.T, ex *mockExchangeVideoNoBids) *endpointDeps {
 		hooks.EmptyPlanBuilder{},
 		nil,
 		openrtb_ext.NormalizeBidderName,
+		nil,
 	}
 
 	return edep
@@ -1422,8 +1442,9 @@ func (cf mockVideoStoredReqFetcher) FetchResponses(ctx context.Context, ids []st
 }
 
 type mockExchangeVideo struct {
-	lastRequest *openrtb2.BidRequest
-	cache       *mockCacheClient
+	lastRequest   *openrtb2.BidRequest
+	cache         *mockCacheClient
+	floorsOutcome *openrtb_ext.PriceFloorsOutcome
 }
 
 func (m *mockExchangeVideo) HoldAuction(ctx context.Context, r *exchange.AuctionRequest, debugLog *exchange.DebugLog) (*exchange.AuctionResponse, error) {
@@ -1458,7 +1479,7 @@ func (m *mockExchangeVideo) HoldAuction(ctx context.Context, r *exchange.Auction
 				{ID: "16", ImpID: "5_2", Ext: ext},
 			},
 		}},
-	}}, nil
+	}, FloorsOutcome: m.floorsOutcome}, nil
 }
 
 type mockExchangeAppendBidderNames struct {
@@ -1575,6 +1596,7 @@ func TestVideoEndpointDisabled(t *testing.T) {
 		hooks.EmptyPlanBuilder{},
 		nil,
 		openrtb_ext.NormalizeBidderName,
+		nil,
 	}
 
 	deps.VideoAuctionEndpoint(recorder, req, nil)
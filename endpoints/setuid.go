@@ -37,9 +37,10 @@ const (
 
 const uidCookieName = "uids"
 
-func NewSetUIDEndpoint(cfg *config.Configuration, syncersByBidder map[string]usersync.Syncer, gdprPermsBuilder gdpr.PermissionsBuilder, tcf2CfgBuilder gdpr.TCF2ConfigBuilder, analyticsRunner analytics.Runner, accountsFetcher stored_requests.AccountFetcher, metricsEngine metrics.MetricsEngine) httprouter.Handle {
-	encoder := usersync.Base64Encoder{}
-	decoder := usersync.Base64Decoder{}
+func NewSetUIDEndpoint(cfg *config.Configuration, syncersByBidder map[string]usersync.Syncer, gdprPermsBuilder gdpr.PermissionsBuilder, tcf2CfgBuilder gdpr.TCF2ConfigBuilder, analyticsRunner analytics.Runner, accountsFetcher stored_requests.AccountFetcher, metricsEngine metrics.MetricsEngine, idStore usersync.IDStore) httprouter.Handle {
+	encoder := usersync.NewEncoder(&cfg.HostCookie)
+	decoder := usersync.CompactDecoder{}
+	uidPolicies := usersync.UIDPoliciesByKey(syncersByBidder)
 
 	return httprouter.Handle(func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 		so := analytics.SetUIDObject{
@@ -50,11 +51,13 @@ func NewSetUIDEndpoint(cfg *config.Configuration, syncersByBidder map[string]use
 		defer analyticsRunner.LogSetUIDObject(&so)
 
 		cookie := usersync.ReadCookie(r, decoder, &cfg.HostCookie)
+		metricsEngine.RecordUIDsCookieJar(metrics.NewCookieJar(usersync.ReadCookieJars(r)))
 		if !cookie.AllowSyncs() {
 			handleBadStatus(w, http.StatusUnauthorized, metrics.SetUidOptOut, nil, metricsEngine, &so)
 			return
 		}
 		usersync.SyncHostCookie(r, cookie, &cfg.HostCookie)
+		hostID := usersync.HostID(r, &cfg.HostCookie)
 
 		query := r.URL.Query()
 
@@ -107,6 +110,7 @@ func NewSetUIDEndpoint(cfg *config.Configuration, syncersByBidder map[string]use
 		}
 
 		policies := privacy.Policies{
+			GPP:    query.Get("gpp"),
 			GPPSID: gppSID,
 		}
 
@@ -128,6 +132,7 @@ func NewSetUIDEndpoint(cfg *config.Configuration, syncersByBidder map[string]use
 			}
 		}
 
+		gdprRequestInfo.AdditionalConsent = query.Get("addtl_consent")
 		tcf2Cfg := tcf2CfgBuilder(cfg.GDPR.TCF2, account.GDPR)
 
 		if shouldReturn, status, body := preventSyncsGDPR(gdprRequestInfo, gdprPermsBuilder, tcf2Cfg); shouldReturn {
@@ -142,6 +147,11 @@ func NewSetUIDEndpoint(cfg *config.Configuration, syncersByBidder map[string]use
 			return
 		}
 
+		if preventSyncsAdditionalConsent(gdprRequestInfo, gdprPermsBuilder, tcf2Cfg, bidderName, cfg.BidderInfos) {
+			handleBadStatus(w, http.StatusUnavailableForLegalReasons, metrics.SetUidGDPRBidderBlocked, errors.New("The addtl_consent string prevents the bidder from syncing"), metricsEngine, &so)
+			return
+		}
+
 		uid := query.Get("uid")
 		so.UID = uid
 
@@ -150,7 +160,7 @@ func NewSetUIDEndpoint(cfg *config.Configuration, syncersByBidder map[string]use
 			metricsEngine.RecordSetUid(metrics.SetUidOK)
 			metricsEngine.RecordSyncerSet(syncer.Key(), metrics.SyncerSetUidCleared)
 			so.Success = true
-		} else if err = cookie.Sync(syncer.Key(), uid); err == nil {
+		} else if err = cookie.SyncWithPolicy(syncer.Key(), uid, syncer.UIDPolicy()); err == nil {
 			metricsEngine.RecordSetUid(metrics.SetUidOK)
 			metricsEngine.RecordSyncerSet(syncer.Key(), metrics.SyncerSetUidOK)
 			so.Success = true
@@ -159,7 +169,7 @@ func NewSetUIDEndpoint(cfg *config.Configuration, syncersByBidder map[string]use
 		setSiteCookie := siteCookieCheck(r.UserAgent())
 
 		// Priority Ejector Set Up
-		priorityEjector := &usersync.PriorityBidderEjector{PriorityGroups: cfg.UserSync.PriorityGroups, TieEjector: &usersync.OldestEjector{}, SyncersByBidder: syncersByBidder}
+		priorityEjector := &usersync.PriorityBidderEjector{PriorityGroups: cfg.UserSync.PriorityGroups, TieEjector: &usersync.ValueEjector{PoliciesByKey: uidPolicies}, SyncersByBidder: syncersByBidder}
 		priorityEjector.IsSyncerPriority = isSyncerPriority(bidderName, cfg.UserSync.PriorityGroups)
 
 		// Write Cookie
@@ -176,6 +186,16 @@ func NewSetUIDEndpoint(cfg *config.Configuration, syncersByBidder map[string]use
 			}
 		}
 		usersync.WriteCookie(w, encodedCookie, &cfg.HostCookie, setSiteCookie)
+		if partitionedCookieEnabled(cfg.HostCookie, account) {
+			usersync.WritePartitionedCookie(w, encodedCookie, &cfg.HostCookie)
+		}
+
+		// the ID store only keeps the UIDs written to the cookie
+		if so.Success {
+			if err := storeUID(idStore, cfg.UserSync.IDStore, hostID, syncer.Key(), uid, syncer.UIDPolicy()); err != nil {
+				so.Errors = append(so.Errors, err)
+			}
+		}
 
 		switch responseFormat {
 		case "i":
@@ -426,6 +446,45 @@ func preventSyncsGDPR(gdprRequestInfo gdpr.RequestInfo, permsBuilder gdpr.Permis
 	return true, http.StatusUnavailableForLegalReasons, "The gdpr_consent string prevents cookies from being saved"
 }
 
+// preventSyncsAdditionalConsent returns true if the bidder is a Google Ad Tech Provider and the Google
+// Additional Consent string of the request does not permit it to sync. The bidder was already checked
+// against the TCF consent string by the cookie sync endpoint, so bidders which are not Google Ad Tech
+// Providers are not checked again.
+func preventSyncsAdditionalConsent(gdprRequestInfo gdpr.RequestInfo, permsBuilder gdpr.PermissionsBuilder, tcf2Cfg gdpr.TCF2ConfigReader, bidderName string, bidderInfos config.BidderInfos) bool {
+	if gdprRequestInfo.AdditionalConsent == "" {
+		return false
+	}
+
+	atpBidder, ok := getATPBidder(bidderName, bidderInfos)
+	if !ok {
+		return false
+	}
+
+	perms := permsBuilder(tcf2Cfg, gdprRequestInfo)
+	allowed, _ := perms.BidderSyncAllowed(context.Background(), atpBidder)
+	return !allowed
+}
+
+// getATPBidder resolves the bidder of the setuid request, which may be of any case, to the bidder whose Google
+// Ad Tech Provider ID applies to its sync. An alias without an ATP Provider ID of its own syncs with the syncer
+// of its core bidder, so it's checked as its core bidder.
+func getATPBidder(bidderName string, bidderInfos config.BidderInfos) (openrtb_ext.BidderName, bool) {
+	if normalizedName, ok := openrtb_ext.NormalizeBidderName(bidderName); ok {
+		bidderName = normalizedName.String()
+	}
+
+	info := bidderInfos[bidderName]
+	if info.ATPProviderID == 0 && len(info.AliasOf) > 0 {
+		bidderName = info.AliasOf
+		info = bidderInfos[bidderName]
+	}
+
+	if info.ATPProviderID == 0 {
+		return "", false
+	}
+	return openrtb_ext.BidderName(bidderName), true
+}
+
 func handleBadStatus(w http.ResponseWriter, status int, metricValue metrics.SetUidStatus, err error, me metrics.MetricsEngine, so *analytics.SetUIDObject) {
 	w.WriteHeader(status)
 	me.RecordSetUid(metricValue)
@@ -436,3 +495,12 @@ func handleBadStatus(w http.ResponseWriter, status int, metricValue metrics.SetU
 		w.Write([]byte(err.Error()))
 	}
 }
+
+// partitionedCookieEnabled returns true if a partitioned copy of the uids cookie should be written for
+// the account. The account setting takes precedence over the host setting.
+func partitionedCookieEnabled(hostCookie config.HostCookie, account *config.Account) bool {
+	if account != nil && account.CookieSync.Partitioned != nil {
+		return *account.CookieSync.Partitioned
+	}
+	return hostCookie.Partitioned.Enabled
+}
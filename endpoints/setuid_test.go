@@ -21,7 +21,9 @@ import (
 	"github.com/prebid/prebid-server/v4/metrics"
 	"github.com/prebid/prebid-server/v4/openrtb_ext"
 	"github.com/prebid/prebid-server/v4/usersync"
+	"github.com/prebid/prebid-server/v4/util/ptrutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	metricsConf "github.com/prebid/prebid-server/v4/metrics/config"
 )
@@ -1349,6 +1351,7 @@ func TestSetUIDEndpointMetrics(t *testing.T) {
 		test.expectedAnalytics(analyticsEngine)
 
 		metricsEngine := &metrics.MetricsEngineMock{}
+		metricsEngine.On("RecordUIDsCookieJar", mock.Anything).Maybe()
 		test.expectedMetrics(metricsEngine)
 
 		req := httptest.NewRequest("GET", test.uri, nil)
@@ -1639,7 +1642,7 @@ func doRequest(req *http.Request, analytics analytics.Runner, metrics metrics.Me
 		"valid_acct_with_invalid_activities":                 json.RawMessage(`{"privacy":{"allowactivities":{"syncUser":{"rules":[{"condition":{"componentName": ["bidderA.bidderB.bidderC"]}}]}}}}`),
 	}}
 
-	endpoint := NewSetUIDEndpoint(&cfg, syncersByBidder, gdprPermsBuilder, tcf2ConfigBuilder, analytics, fakeAccountsFetcher, metrics)
+	endpoint := NewSetUIDEndpoint(&cfg, syncersByBidder, gdprPermsBuilder, tcf2ConfigBuilder, analytics, fakeAccountsFetcher, metrics, nil)
 	response := httptest.NewRecorder()
 	endpoint(response, req, nil)
 	return response
@@ -1680,6 +1683,9 @@ func (fcr fakeTCF2ConfigBuilder) Builder(hostConfig config.TCF2, accountConfig c
 }
 
 type fakePermsSetUID struct {
+	allowBidder         bool
+	checkedBidder       openrtb_ext.BidderName
+	checkedCoreBidder   openrtb_ext.BidderName
 	allowHost           bool
 	consent             string
 	errorHost           bool
@@ -1698,10 +1704,12 @@ func (g *fakePermsSetUID) HostCookiesAllowed(ctx context.Context) (bool, error)
 }
 
 func (g *fakePermsSetUID) BidderSyncAllowed(ctx context.Context, bidder openrtb_ext.BidderName) (bool, error) {
-	return false, nil
+	g.checkedBidder = bidder
+	return g.allowBidder, nil
 }
 
 func (g *fakePermsSetUID) AuctionActivitiesAllowed(ctx context.Context, bidderCoreName openrtb_ext.BidderName, bidder openrtb_ext.BidderName) gdpr.AuctionPermissions {
+	g.checkedCoreBidder = bidderCoreName
 	return gdpr.AuctionPermissions{
 		AllowBidRequest: g.personalInfoAllowed,
 		PassGeo:         g.personalInfoAllowed,
@@ -1713,6 +1721,7 @@ type fakeSyncer struct {
 	key             string
 	defaultSyncType usersync.SyncType
 	formatOverride  string
+	uidPolicy       usersync.UIDPolicy
 }
 
 func (s fakeSyncer) Key() string {
@@ -1738,6 +1747,10 @@ func (s fakeSyncer) GetSync(syncTypes []usersync.SyncType, privacyMacros macros.
 	return usersync.Sync{}, nil
 }
 
+func (s fakeSyncer) UIDPolicy() usersync.UIDPolicy {
+	return s.uidPolicy
+}
+
 func ToHTTPCookie(cookie *usersync.Cookie) (*http.Cookie, error) {
 	encoder := usersync.Base64Encoder{}
 	encodedCookie, err := encoder.Encode(cookie)
@@ -1766,3 +1779,120 @@ func getUIDFromHeader(setCookieHeader string) string {
 	}
 	return ""
 }
+
+func TestPartitionedCookieEnabled(t *testing.T) {
+	testCases := []struct {
+		name            string
+		givenHostCookie config.HostCookie
+		givenAccount    *config.Account
+		expected        bool
+	}{
+		{
+			name:            "host-disabled-no-account",
+			givenHostCookie: config.HostCookie{},
+			givenAccount:    nil,
+			expected:        false,
+		},
+		{
+			name:            "host-enabled-account-not-set",
+			givenHostCookie: config.HostCookie{Partitioned: config.HostCookiePartitioned{Enabled: true}},
+			givenAccount:    &config.Account{},
+			expected:        true,
+		},
+		{
+			name:            "host-enabled-account-disabled",
+			givenHostCookie: config.HostCookie{Partitioned: config.HostCookiePartitioned{Enabled: true}},
+			givenAccount:    &config.Account{CookieSync: config.CookieSync{Partitioned: ptrutil.ToPtr(false)}},
+			expected:        false,
+		},
+		{
+			name:            "host-disabled-account-enabled",
+			givenHostCookie: config.HostCookie{},
+			givenAccount:    &config.Account{CookieSync: config.CookieSync{Partitioned: ptrutil.ToPtr(true)}},
+			expected:        true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, partitionedCookieEnabled(test.givenHostCookie, test.givenAccount))
+		})
+	}
+}
+
+func TestPreventSyncsAdditionalConsent(t *testing.T) {
+	bidderInfos := config.BidderInfos{
+		"atpBidder":     config.BidderInfo{ATPProviderID: 89},
+		"gvlBidder":     config.BidderInfo{GVLVendorID: 52},
+		"appnexus":      config.BidderInfo{ATPProviderID: 32},
+		"appnexusAlias": config.BidderInfo{AliasOf: "appnexus"},
+	}
+
+	testCases := []struct {
+		name                  string
+		additionalConsent     string
+		bidder                string
+		allowBidder           bool
+		expected              bool
+		expectedCheckedBidder openrtb_ext.BidderName
+	}{
+		{
+			name:              "no-additional-consent",
+			additionalConsent: "",
+			bidder:            "atpBidder",
+			allowBidder:       false,
+			expected:          false,
+		},
+		{
+			name:              "bidder-not-atp",
+			additionalConsent: "2~1~dv.",
+			bidder:            "gvlBidder",
+			allowBidder:       false,
+			expected:          false,
+		},
+		{
+			name:                  "atp-bidder-allowed",
+			additionalConsent:     "2~89~dv.",
+			bidder:                "atpBidder",
+			allowBidder:           true,
+			expected:              false,
+			expectedCheckedBidder: "atpBidder",
+		},
+		{
+			name:                  "atp-bidder-denied",
+			additionalConsent:     "2~1~dv.",
+			bidder:                "atpBidder",
+			allowBidder:           false,
+			expected:              true,
+			expectedCheckedBidder: "atpBidder",
+		},
+		{
+			name:                  "atp-bidder-mixed-case",
+			additionalConsent:     "2~1~dv.",
+			bidder:                "AppNexus",
+			allowBidder:           false,
+			expected:              true,
+			expectedCheckedBidder: "appnexus",
+		},
+		{
+			name:                  "alias-of-atp-bidder",
+			additionalConsent:     "2~1~dv.",
+			bidder:                "appnexusAlias",
+			allowBidder:           false,
+			expected:              true,
+			expectedCheckedBidder: "appnexus",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			perms := &fakePermsSetUID{allowBidder: test.allowBidder}
+			permsBuilder := fakePermissionsBuilder{permissions: perms}.Builder
+			requestInfo := gdpr.RequestInfo{AdditionalConsent: test.additionalConsent, GDPRSignal: gdpr.SignalYes}
+
+			result := preventSyncsAdditionalConsent(requestInfo, permsBuilder, nil, test.bidder, bidderInfos)
+			assert.Equal(t, test.expected, result)
+			assert.Equal(t, test.expectedCheckedBidder, perms.checkedBidder)
+		})
+	}
+}
@@ -13,6 +13,8 @@ import (
 	uuid "github.com/gofrs/uuid"
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/currency"
+	"github.com/prebid/prebid-server/v4/errortypes"
 	"github.com/prebid/prebid-server/v4/exchange/entities"
 	"github.com/prebid/prebid-server/v4/openrtb_ext"
 	"github.com/prebid/prebid-server/v4/prebid_cache_client"
@@ -197,6 +199,90 @@ func (a *auction) setRoundedPrices(targetingData targetData, account config.Acco
 	a.roundedPrices = roundedPrices
 }
 
+// setAdditionalCurrencyPrices converts the price of the top bids into the additional currencies of the targeting, with
+// the conversions used by the auction. A currency the price can't be converted into is skipped with a warning. The
+// price granularity ranges are in the auction currency, so they're converted as well before rounding the prices, or
+// currencies such as JPY would almost always hit the top of the ranges.
+func (a *auction) setAdditionalCurrencyPrices(targetingData targetData, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, conversions currency.Conversions, account config.Account) []error {
+	if len(targetingData.additionalCurrencies) == 0 || conversions == nil {
+		return nil
+	}
+
+	var errs []error
+	failedCurrencies := make(map[string]struct{})
+	convertedTargetingData := make(map[float64]targetData, len(targetingData.additionalCurrencies))
+	additionalRoundedPrices := make(map[*entities.PbsOrtbBid]map[string]string, 5*len(a.winningBids))
+	for _, topBidsPerImp := range a.allBidsByBidder {
+		for bidderName, topBidsPerBidder := range topBidsPerImp {
+			bidCurrency := "USD"
+			if seatBid, ok := seatBids[bidderName]; ok && seatBid != nil && seatBid.Currency != "" {
+				bidCurrency = seatBid.Currency
+			}
+
+			for _, topBid := range topBidsPerBidder {
+				for _, cur := range targetingData.additionalCurrencies {
+					rate, err := conversions.GetRate(bidCurrency, cur)
+					if err != nil {
+						if _, failed := failedCurrencies[cur]; !failed {
+							failedCurrencies[cur] = struct{}{}
+							errs = append(errs, &errortypes.Warning{
+								WarningCode: errortypes.AdditionalCurrencyWarningCode,
+								Message:     fmt.Sprintf("bid prices can't be returned in additional currency %s: %v", cur, err),
+							})
+						}
+						continue
+					}
+
+					convertedBid := *topBid.Bid
+					convertedBid.Price = topBid.Bid.Price * rate
+					if topBid.BidPrices == nil {
+						topBid.BidPrices = make(map[string]float64, len(targetingData.additionalCurrencies))
+					}
+					topBid.BidPrices[cur] = convertedBid.Price
+
+					if additionalRoundedPrices[topBid] == nil {
+						additionalRoundedPrices[topBid] = make(map[string]string, len(targetingData.additionalCurrencies))
+					}
+					convertedTargeting, ok := convertedTargetingData[rate]
+					if !ok {
+						convertedTargeting = convertPriceGranularities(targetingData, rate)
+						convertedTargetingData[rate] = convertedTargeting
+					}
+					additionalRoundedPrices[topBid][cur] = GetPriceBucket(convertedBid, convertedTargeting, account)
+				}
+			}
+		}
+	}
+	a.additionalRoundedPrices = additionalRoundedPrices
+	return errs
+}
+
+// convertPriceGranularities returns a copy of the targeting data with the price granularity ranges converted by the rate
+func convertPriceGranularities(targetingData targetData, rate float64) targetData {
+	targetingData.priceGranularity = convertPriceGranularity(targetingData.priceGranularity, rate)
+	mediaTypes := &targetingData.mediaTypePriceGranularity
+	for _, priceGranularity := range []**openrtb_ext.PriceGranularity{&mediaTypes.Banner, &mediaTypes.Video, &mediaTypes.Native} {
+		if *priceGranularity != nil {
+			converted := convertPriceGranularity(**priceGranularity, rate)
+			*priceGranularity = &converted
+		}
+	}
+	return targetingData
+}
+
+func convertPriceGranularity(priceGranularity openrtb_ext.PriceGranularity, rate float64) openrtb_ext.PriceGranularity {
+	ranges := make([]openrtb_ext.GranularityRange, len(priceGranularity.Ranges))
+	for i, granularityRange := range priceGranularity.Ranges {
+		ranges[i] = openrtb_ext.GranularityRange{
+			Min:       granularityRange.Min * rate,
+			Max:       granularityRange.Max * rate,
+			Increment: granularityRange.Increment * rate,
+		}
+	}
+	priceGranularity.Ranges = ranges
+	return priceGranularity
+}
+
 func (a *auction) doCache(ctx context.Context, cache prebid_cache_client.Client, targData *targetData, evTracking *eventTracking, bidRequest *openrtb2.BidRequest, ttlBuffer int64, defaultTTLs *config.DefaultTTLs, bidCategory map[string]string, debugLog *DebugLog) []error {
 	var bids, vast, includeBidderKeys, includeWinners bool = targData.includeCacheBids, targData.includeCacheVast, targData.includeBidderKeys, targData.includeWinners
 	if !((bids || vast) && (includeBidderKeys || includeWinners)) {
@@ -398,6 +484,8 @@ type auction struct {
 	allBidsByBidder map[string]map[openrtb_ext.BidderName][]*entities.PbsOrtbBid
 	// roundedPrices stores the price strings rounded for each bid according to the price granularity.
 	roundedPrices map[*entities.PbsOrtbBid]string
+	// additionalRoundedPrices stores the price strings rounded for each bid in each of the additional currencies.
+	additionalRoundedPrices map[*entities.PbsOrtbBid]map[string]string
 	// cacheIds stores the UUIDs from Prebid Cache for fetching the full bid JSON.
 	cacheIds map[*openrtb2.Bid]string
 	// vastCacheIds stores UUIDS from Prebid cache for fetching the VAST markup to video bids.
@@ -0,0 +1,111 @@
+package exchange
+
+import (
+	"math"
+	"strings"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/currency"
+	"github.com/prebid/prebid-server/v4/exchange/entities"
+	"github.com/prebid/prebid-server/v4/macros"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+)
+
+// secondPriceIncrement is added to the second highest bid of an imp to get the clearing price in a second price auction
+const secondPriceIncrement = 0.01
+
+// applyAuctionMacros substitutes the OpenRTB auction macros, such as ${AUCTION_PRICE}, in the adm, nurl and burl of the
+// bids once the auction clears, for the bidders which didn't opt out of the substitution. In a second price auction,
+// only the highest bid of each imp clears at the second price. The other bids are substituted with their own price, as
+// the ad server may still render them and they would then be charged their bid.
+func applyAuctionMacros(bidRequest *openrtb_ext.RequestWrapper, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, auctionMacros config.AccountAuctionMacros, conversions currency.Conversions, replacer macros.Replacer) {
+	if !auctionMacros.Enabled {
+		return
+	}
+
+	imps := make(map[string]*openrtb2.Imp, len(bidRequest.Imp))
+	for i := range bidRequest.Imp {
+		imps[bidRequest.Imp[i].ID] = &bidRequest.Imp[i]
+	}
+
+	var impBids map[string][]*entities.PbsOrtbBid
+	var impWinners map[string]*entities.PbsOrtbBid
+	if auctionMacros.SecondPrice {
+		impBids = make(map[string][]*entities.PbsOrtbBid)
+		impWinners = make(map[string]*entities.PbsOrtbBid)
+		for _, seatBid := range seatBids {
+			for _, bid := range seatBid.Bids {
+				impBids[bid.Bid.ImpID] = append(impBids[bid.Bid.ImpID], bid)
+				if winner, ok := impWinners[bid.Bid.ImpID]; !ok || bid.Bid.Price > winner.Bid.Price {
+					impWinners[bid.Bid.ImpID] = bid
+				}
+			}
+		}
+	}
+
+	macroProvider := macros.NewProvider(bidRequest)
+	for bidderName, seatBid := range seatBids {
+		if auctionMacros.IsBidderExcluded(bidderName.String()) {
+			continue
+		}
+		for _, bid := range seatBid.Bids {
+			price := bid.Bid.Price
+			if auctionMacros.SecondPrice && bid.Bid.DealID == "" && impWinners[bid.Bid.ImpID] == bid {
+				floor := getImpFloor(imps[bid.Bid.ImpID], seatBid.Currency, conversions)
+				price = getSecondPrice(bid, impBids[bid.Bid.ImpID], floor)
+			}
+
+			macroProvider.PopulateAuctionMacros(bid, seatBid.Currency, price)
+			bid.Bid.AdM = replaceAuctionMacros(bid.Bid.AdM, macroProvider, replacer)
+			bid.Bid.NURL = replaceAuctionMacros(bid.Bid.NURL, macroProvider, replacer)
+			bid.Bid.BURL = replaceAuctionMacros(bid.Bid.BURL, macroProvider, replacer)
+		}
+	}
+}
+
+// getImpFloor returns the floor of an imp in the currency of the bids, or 0 if it can't be converted
+func getImpFloor(imp *openrtb2.Imp, bidCurrency string, conversions currency.Conversions) float64 {
+	if imp == nil || imp.BidFloor == 0 || conversions == nil {
+		return 0
+	}
+
+	floorCurrency := imp.BidFloorCur
+	if floorCurrency == "" {
+		floorCurrency = "USD"
+	}
+	rate, err := conversions.GetRate(floorCurrency, bidCurrency)
+	if err != nil {
+		return 0
+	}
+	return imp.BidFloor * rate
+}
+
+// getSecondPrice returns the clearing price of a bid in a second price auction, which is the highest other bid of the
+// imp not above the bid plus one cent, no lower than the floor and no higher than the bid itself. Without any lower
+// bid, the bid clears at the floor, or at its own price when the imp has no floor.
+func getSecondPrice(bid *entities.PbsOrtbBid, impBids []*entities.PbsOrtbBid, floor float64) float64 {
+	price := floor
+	hasLowerBid := false
+	for _, otherBid := range impBids {
+		if otherBid == bid || otherBid.Bid.Price > bid.Bid.Price {
+			continue
+		}
+		hasLowerBid = true
+		price = math.Max(price, otherBid.Bid.Price+secondPriceIncrement)
+	}
+	if !hasLowerBid && floor <= 0 {
+		price = bid.Bid.Price
+	}
+	price = math.Min(price, bid.Bid.Price)
+	return math.Round(price*10000) / 10000
+}
+
+func replaceAuctionMacros(value string, macroProvider *macros.MacroProvider, replacer macros.Replacer) string {
+	if !strings.Contains(value, "${") {
+		return value
+	}
+	var result strings.Builder
+	replacer.Replace(&result, value, macroProvider)
+	return result.String()
+}
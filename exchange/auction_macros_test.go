@@ -0,0 +1,146 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/currency"
+	"github.com/prebid/prebid-server/v4/exchange/entities"
+	"github.com/prebid/prebid-server/v4/macros"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyAuctionMacros(t *testing.T) {
+	conversions := currency.NewRates(map[string]map[string]float64{"EUR": {"USD": 2}})
+
+	testCases := []struct {
+		name          string
+		auctionMacros config.AccountAuctionMacros
+		imp           openrtb2.Imp
+		expectedAdM   map[string]string
+		expectedNURL  map[string]string
+		expectedBURL  map[string]string
+	}{
+		{
+			name:          "disabled",
+			auctionMacros: config.AccountAuctionMacros{Enabled: false},
+			imp:           openrtb2.Imp{ID: "imp-1"},
+			expectedAdM:   map[string]string{"bid-1": "<img src='https://win.com?p=${AUCTION_PRICE}&a=${AUCTION_ID}'>", "bid-2": "adm-2 ${AUCTION_PRICE} ${AUCTION_SEAT_ID}", "bid-3": "adm-3 ${AUCTION_PRICE}"},
+			expectedNURL:  map[string]string{"bid-1": "https://nurl.com?imp=${AUCTION_IMP_ID}&seat=${AUCTION_SEAT_ID}&bid=${AUCTION_BID_ID}", "bid-2": "", "bid-3": ""},
+			expectedBURL:  map[string]string{"bid-1": "https://burl.com?p=${AUCTION_PRICE}&c=${AUCTION_CURRENCY}&l=${AUCTION_LOSS}", "bid-2": "", "bid-3": ""},
+		},
+		{
+			name:          "first_price",
+			auctionMacros: config.AccountAuctionMacros{Enabled: true},
+			imp:           openrtb2.Imp{ID: "imp-1"},
+			expectedAdM:   map[string]string{"bid-1": "<img src='https://win.com?p=3&a=some-auction'>", "bid-2": "adm-2 2 ${AUCTION_SEAT_ID}", "bid-3": "adm-3 1.5"},
+			expectedNURL:  map[string]string{"bid-1": "https://nurl.com?imp=imp-1&seat=seat-1&bid=response-1", "bid-2": "", "bid-3": ""},
+			expectedBURL:  map[string]string{"bid-1": "https://burl.com?p=3&c=USD&l=${AUCTION_LOSS}", "bid-2": "", "bid-3": ""},
+		},
+		{
+			name:          "second_price",
+			auctionMacros: config.AccountAuctionMacros{Enabled: true, SecondPrice: true},
+			imp:           openrtb2.Imp{ID: "imp-1"},
+			expectedAdM:   map[string]string{"bid-1": "<img src='https://win.com?p=2.01&a=some-auction'>", "bid-2": "adm-2 2 ${AUCTION_SEAT_ID}", "bid-3": "adm-3 1.5"},
+			expectedNURL:  map[string]string{"bid-1": "https://nurl.com?imp=imp-1&seat=seat-1&bid=response-1", "bid-2": "", "bid-3": ""},
+			expectedBURL:  map[string]string{"bid-1": "https://burl.com?p=2.01&c=USD&l=${AUCTION_LOSS}", "bid-2": "", "bid-3": ""},
+		},
+		{
+			name:          "second_price_with_floor",
+			auctionMacros: config.AccountAuctionMacros{Enabled: true, SecondPrice: true},
+			imp:           openrtb2.Imp{ID: "imp-1", BidFloor: 0.9, BidFloorCur: "EUR"},
+			expectedAdM:   map[string]string{"bid-1": "<img src='https://win.com?p=2.01&a=some-auction'>", "bid-2": "adm-2 2 ${AUCTION_SEAT_ID}", "bid-3": "adm-3 1.5"},
+			expectedNURL:  map[string]string{"bid-1": "https://nurl.com?imp=imp-1&seat=seat-1&bid=response-1", "bid-2": "", "bid-3": ""},
+			expectedBURL:  map[string]string{"bid-1": "https://burl.com?p=2.01&c=USD&l=${AUCTION_LOSS}", "bid-2": "", "bid-3": ""},
+		},
+		{
+			name:          "excluded_bidder",
+			auctionMacros: config.AccountAuctionMacros{Enabled: true, ExcludedBidders: []string{"AppNexus"}},
+			imp:           openrtb2.Imp{ID: "imp-1"},
+			expectedAdM:   map[string]string{"bid-1": "<img src='https://win.com?p=${AUCTION_PRICE}&a=${AUCTION_ID}'>", "bid-2": "adm-2 2 ${AUCTION_SEAT_ID}", "bid-3": "adm-3 1.5"},
+			expectedNURL:  map[string]string{"bid-1": "https://nurl.com?imp=${AUCTION_IMP_ID}&seat=${AUCTION_SEAT_ID}&bid=${AUCTION_BID_ID}", "bid-2": "", "bid-3": ""},
+			expectedBURL:  map[string]string{"bid-1": "https://burl.com?p=${AUCTION_PRICE}&c=${AUCTION_CURRENCY}&l=${AUCTION_LOSS}", "bid-2": "", "bid-3": ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bidRequest := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "some-auction", Imp: []openrtb2.Imp{tc.imp}}}
+			seatBids := map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+				"appnexus": {Currency: "USD", Bids: []*entities.PbsOrtbBid{
+					{Bid: &openrtb2.Bid{ID: "bid-1", ImpID: "imp-1", Price: 3,
+						AdM:  "<img src='https://win.com?p=${AUCTION_PRICE}&a=${AUCTION_ID}'>",
+						NURL: "https://nurl.com?imp=${AUCTION_IMP_ID}&seat=${AUCTION_SEAT_ID}&bid=${AUCTION_BID_ID}",
+						BURL: "https://burl.com?p=${AUCTION_PRICE}&c=${AUCTION_CURRENCY}&l=${AUCTION_LOSS}"},
+						ResponseBidID: "response-1", ResponseSeat: "seat-1"},
+				}},
+				"pubmatic": {Currency: "USD", Bids: []*entities.PbsOrtbBid{
+					{Bid: &openrtb2.Bid{ID: "bid-2", ImpID: "imp-1", Price: 2, AdM: "adm-2 ${AUCTION_PRICE} ${AUCTION_SEAT_ID}"}},
+					{Bid: &openrtb2.Bid{ID: "bid-3", ImpID: "imp-1", Price: 1.5, AdM: "adm-3 ${AUCTION_PRICE}"}},
+				}},
+			}
+
+			applyAuctionMacros(bidRequest, seatBids, tc.auctionMacros, conversions, macros.NewOpenRTBMacroReplacer())
+
+			for _, seatBid := range seatBids {
+				for _, bid := range seatBid.Bids {
+					assert.Equal(t, tc.expectedAdM[bid.Bid.ID], bid.Bid.AdM, "adm of %s", bid.Bid.ID)
+					assert.Equal(t, tc.expectedNURL[bid.Bid.ID], bid.Bid.NURL, "nurl of %s", bid.Bid.ID)
+					assert.Equal(t, tc.expectedBURL[bid.Bid.ID], bid.Bid.BURL, "burl of %s", bid.Bid.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestGetSecondPrice(t *testing.T) {
+	winningBid := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{Price: 2}}
+	testCases := []struct {
+		name     string
+		impBids  []*entities.PbsOrtbBid
+		floor    float64
+		expected float64
+	}{
+		{
+			name:     "single_bid_clears_at_floor",
+			impBids:  []*entities.PbsOrtbBid{winningBid},
+			floor:    1,
+			expected: 1,
+		},
+		{
+			name:     "single_bid_without_floor_clears_at_bid_price",
+			impBids:  []*entities.PbsOrtbBid{winningBid},
+			expected: 2,
+		},
+		{
+			name:     "second_bid_plus_increment",
+			impBids:  []*entities.PbsOrtbBid{winningBid, {Bid: &openrtb2.Bid{Price: 1.234}}},
+			floor:    1,
+			expected: 1.244,
+		},
+		{
+			name:     "floor_above_second_bid",
+			impBids:  []*entities.PbsOrtbBid{winningBid, {Bid: &openrtb2.Bid{Price: 1}}},
+			floor:    1.5,
+			expected: 1.5,
+		},
+		{
+			name:     "capped_at_bid_price",
+			impBids:  []*entities.PbsOrtbBid{winningBid, {Bid: &openrtb2.Bid{Price: 2}}},
+			expected: 2,
+		},
+		{
+			name:     "higher_bids_ignored",
+			impBids:  []*entities.PbsOrtbBid{winningBid, {Bid: &openrtb2.Bid{Price: 5}}, {Bid: &openrtb2.Bid{Price: 0.5}}},
+			expected: 0.51,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getSecondPrice(winningBid, tc.impBids, tc.floor))
+		})
+	}
+}
@@ -9,6 +9,11 @@ import (
 type AuctionResponse struct {
 	*openrtb2.BidResponse
 	ExtBidResponse *openrtb_ext.ExtBidResponse
+	// FloorsOutcome summarises the price floors of the auction, nil when floors are disabled
+	FloorsOutcome *openrtb_ext.PriceFloorsOutcome
+	// RateSnapshotID identifies the currency rates snapshot the bids were converted with, empty when the
+	// rates don't come from the currency converter
+	RateSnapshotID string
 }
 
 // GetSeatNonBid returns array of seat non-bid if present. nil otherwise
@@ -18,3 +23,19 @@ func (ar *AuctionResponse) GetSeatNonBid() []openrtb_ext.SeatNonBid {
 	}
 	return nil
 }
+
+// GetFloorsOutcome returns the price floors outcome of the auction if present. nil otherwise
+func (ar *AuctionResponse) GetFloorsOutcome() *openrtb_ext.PriceFloorsOutcome {
+	if ar != nil {
+		return ar.FloorsOutcome
+	}
+	return nil
+}
+
+// GetRateSnapshotID returns the ID of the currency rates snapshot used by the auction if present. empty otherwise
+func (ar *AuctionResponse) GetRateSnapshotID() string {
+	if ar != nil {
+		return ar.RateSnapshotID
+	}
+	return ""
+}
@@ -377,6 +377,12 @@ func (bidder *BidderAdapter) requestBid(ctx context.Context, bidderRequest Bidde
 				}
 
 				if err == nil {
+					// The rates snapshot is only relevant to reconcile the original bid when it was converted
+					rateSnapshotID := ""
+					if bidResponse.Currency != seatBidMap[bidderRequest.BidderName].Currency {
+						rateSnapshotID = currency.SnapshotID(conversions)
+					}
+
 					// Conversion rate found, using it for conversion
 					for i := 0; i < len(bidResponse.Bids); i++ {
 
@@ -432,7 +438,10 @@ func (bidder *BidderAdapter) requestBid(ctx context.Context, bidderRequest Bidde
 							DealPriority:   bidResponse.Bids[i].DealPriority,
 							OriginalBidCPM: originalBidCpm,
 							OriginalBidCur: bidResponse.Currency,
+							RateSnapshotID: rateSnapshotID,
 							AdapterCode:    bidderRequest.BidderCoreName,
+							ResponseBidID:  bidResponse.BidID,
+							ResponseSeat:   bidResponse.Bids[i].ResponseSeat,
 						})
 						seatBidMap[bidderName].Currency = currencyAfterAdjustments
 					}
@@ -91,6 +91,7 @@ func TestSingleBidder(t *testing.T) {
 					},
 					BidType:      openrtb_ext.BidTypeBanner,
 					DealPriority: 4,
+					ResponseSeat: "seat-1",
 				},
 				{
 					Bid: &openrtb2.Bid{
@@ -100,6 +101,7 @@ func TestSingleBidder(t *testing.T) {
 					DealPriority: 5,
 				},
 			},
+			BidID: "response-1",
 		}
 		bidderImpl.bidResponse = mockBidderResponse
 
@@ -155,6 +157,8 @@ func TestSingleBidder(t *testing.T) {
 			if typedBid.DealPriority != seatBid.Bids[index].DealPriority {
 				t.Errorf("Bid %d did not have the right deal priority. Expected %s, got %s", index, typedBid.BidType, seatBid.Bids[index].BidType)
 			}
+			assert.Equal(t, "response-1", seatBid.Bids[index].ResponseBidID, "Bid %d did not have the bidder response bid ID", index)
+			assert.Equal(t, typedBid.ResponseSeat, seatBid.Bids[index].ResponseSeat, "Bid %d did not have the bidder response seat", index)
 		}
 		bidAdjustment := bidAdjustments["test"]
 		if mockBidderResponse.Bids[0].Bid.Price != bidAdjustment*firstInitialPrice {
@@ -1261,6 +1265,91 @@ func TestMultiCurrencies_RequestCurrencyPick(t *testing.T) {
 	}
 }
 
+func TestRateSnapshotIDOfConvertedBids(t *testing.T) {
+	respStatus := 200
+	getRespBody := "{\"wasPost\":false}"
+	postRespBody := "{\"wasPost\":true}"
+
+	testCases := []struct {
+		description            string
+		bidResponsesCurrency   string
+		expectedRateSnapshotID bool
+	}{
+		{
+			description:            "Bid converted from EUR, the rates snapshot is recorded",
+			bidResponsesCurrency:   "EUR",
+			expectedRateSnapshotID: true,
+		},
+		{
+			description:            "Bid already in USD, no rates snapshot",
+			bidResponsesCurrency:   "USD",
+			expectedRateSnapshotID: false,
+		},
+	}
+
+	server := httptest.NewServer(mockHandler(respStatus, getRespBody, postRespBody))
+	defer server.Close()
+
+	mockedHTTPServer := httptest.NewServer(http.HandlerFunc(
+		func(rw http.ResponseWriter, req *http.Request) {
+			rw.Write([]byte(`{"conversions":{"EUR":{"USD":1.1435678764}}}`))
+		}),
+	)
+	defer mockedHTTPServer.Close()
+
+	currencyConverter := currency.NewRateConverter(&http.Client{}, 60*time.Second, mockedHTTPServer.URL, time.Duration(0))
+	assert.NoError(t, currencyConverter.Run())
+	conversions := currencyConverter.Conversions()
+
+	for _, tc := range testCases {
+		bidderImpl := &goodMultiHTTPCallsBidder{
+			bidResponses: []*adapters.BidderResponse{
+				{
+					Bids:     []*adapters.TypedBid{{Bid: &openrtb2.Bid{Price: 1}, BidType: openrtb_ext.BidTypeBanner}},
+					Currency: tc.bidResponsesCurrency,
+				},
+			},
+		}
+		bidderImpl.httpRequest = []*adapters.RequestData{
+			{
+				Method:  "POST",
+				Uri:     server.URL,
+				Body:    []byte("{\"key\":\"val\"}"),
+				Headers: http.Header{},
+			},
+		}
+
+		bidder := AdaptBidder(bidderImpl, server.Client(), &config.Configuration{}, &metricsConfig.NilMetricsEngine{}, openrtb_ext.BidderAppnexus, nil, "")
+		bidderReq := BidderRequest{
+			BidRequest: &openrtb2.BidRequest{Cur: []string{"USD"}, Imp: []openrtb2.Imp{{ID: "impId"}}},
+			BidderName: "test",
+		}
+		seatBids, _, errs := bidder.requestBid(
+			context.Background(),
+			bidderReq,
+			conversions,
+			&adapters.ExtraRequestInfo{},
+			&adscert.NilSigner{},
+			bidRequestOptions{bidAdjustments: map[string]float64{"test": 1}},
+			openrtb_ext.ExtAlternateBidderCodes{},
+			&hookexecution.EmptyHookExecutor{},
+			nil,
+		)
+
+		assert.Empty(t, errs, tc.description)
+		if assert.Len(t, seatBids, 1, tc.description) && assert.Len(t, seatBids[0].Bids, 1, tc.description) {
+			bid := seatBids[0].Bids[0]
+			assert.Equal(t, tc.bidResponsesCurrency, bid.OriginalBidCur, tc.description)
+			if tc.expectedRateSnapshotID {
+				assert.Equal(t, currency.SnapshotID(conversions), bid.RateSnapshotID, tc.description)
+				assert.NotEmpty(t, bid.RateSnapshotID, tc.description)
+			} else {
+				assert.Empty(t, bid.RateSnapshotID, tc.description)
+			}
+		}
+	}
+}
+
 func TestMakeExt(t *testing.T) {
 	testCases := []struct {
 		description string
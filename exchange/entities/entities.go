@@ -36,6 +36,7 @@ type PbsOrtbSeatBid struct {
 // PbsOrtbBid.DealPriority is optionally provided by adapters and used internally by the exchange to support deal targeted campaigns.
 // PbsOrtbBid.DealTierSatisfied is set to true by exchange.updateHbPbCatDur if deal tier satisfied otherwise it will be set to false
 // PbsOrtbBid.GeneratedBidID is unique Bid id generated by prebid server if generate Bid id option is enabled in config
+// PbsOrtbBid.ResponseBidID and PbsOrtbBid.ResponseSeat are the bidresponse.bidid and the seatbid.seat of the bidder response, when provided by the adapter
 type PbsOrtbBid struct {
 	Bid               *openrtb2.Bid
 	BidMeta           *openrtb_ext.ExtBidPrebidMeta
@@ -44,11 +45,15 @@ type PbsOrtbBid struct {
 	BidVideo          *openrtb_ext.ExtBidPrebidVideo
 	BidEvents         *openrtb_ext.ExtBidPrebidEvents
 	BidFloors         *openrtb_ext.ExtBidPrebidFloors
+	BidPrices         map[string]float64
 	DealPriority      int
 	DealTierSatisfied bool
 	GeneratedBidID    string
 	OriginalBidCPM    float64
 	OriginalBidCur    string
+	RateSnapshotID    string
 	TargetBidderCode  string
 	AdapterCode       openrtb_ext.BidderName
+	ResponseBidID     string
+	ResponseSeat      string
 }
@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"net/url"
 	"runtime/debug"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -30,6 +31,7 @@ import (
 	"github.com/prebid/prebid-server/v4/logger"
 	"github.com/prebid/prebid-server/v4/macros"
 	"github.com/prebid/prebid-server/v4/metrics"
+	"github.com/prebid/prebid-server/v4/notifications"
 	"github.com/prebid/prebid-server/v4/openrtb_ext"
 	"github.com/prebid/prebid-server/v4/ortb"
 	"github.com/prebid/prebid-server/v4/prebid_cache_client"
@@ -81,9 +83,12 @@ type exchange struct {
 	bidValidationEnforcement config.Validations
 	requestSplitter          requestSplitter
 	macroReplacer            macros.Replacer
+	auctionMacroReplacer     macros.Replacer
 	priceFloorEnabled        bool
 	priceFloorFetcher        floors.FloorFetcher
+	floorOptimizer           floors.FloorOptimizer
 	singleFormatBidders      map[openrtb_ext.BidderName]struct{}
+	notifier                 notifications.Notifier
 }
 
 // Container to pass out response ext data from the GetAllBids goroutines back into the main thread
@@ -135,7 +140,7 @@ func (randomDeduplicateBidBooleanGenerator) Generate() bool {
 	return rand.Intn(100) < 50
 }
 
-func NewExchange(adapters map[openrtb_ext.BidderName]AdaptedBidder, cache prebid_cache_client.Client, cfg *config.Configuration, requestValidator ortb.RequestValidator, syncersByBidder map[string]usersync.Syncer, metricsEngine metrics.MetricsEngine, infos config.BidderInfos, gdprPermsBuilder gdpr.PermissionsBuilder, currencyConverter *currency.RateConverter, categoriesFetcher stored_requests.CategoryFetcher, adsCertSigner adscert.Signer, macroReplacer macros.Replacer, priceFloorFetcher floors.FloorFetcher, singleFormatBidders map[openrtb_ext.BidderName]struct{}) Exchange {
+func NewExchange(adapters map[openrtb_ext.BidderName]AdaptedBidder, cache prebid_cache_client.Client, cfg *config.Configuration, requestValidator ortb.RequestValidator, syncersByBidder map[string]usersync.Syncer, metricsEngine metrics.MetricsEngine, infos config.BidderInfos, gdprPermsBuilder gdpr.PermissionsBuilder, currencyConverter *currency.RateConverter, categoriesFetcher stored_requests.CategoryFetcher, adsCertSigner adscert.Signer, macroReplacer macros.Replacer, priceFloorFetcher floors.FloorFetcher, singleFormatBidders map[openrtb_ext.BidderName]struct{}, notifier notifications.Notifier) Exchange {
 	bidderToSyncerKey := map[string]string{}
 	for bidder, syncer := range syncersByBidder {
 		bidderToSyncerKey[bidder] = syncer.Key()
@@ -175,9 +180,12 @@ func NewExchange(adapters map[openrtb_ext.BidderName]AdaptedBidder, cache prebid
 		bidValidationEnforcement: cfg.Validations,
 		requestSplitter:          requestSplitter,
 		macroReplacer:            macroReplacer,
+		auctionMacroReplacer:     macros.NewOpenRTBMacroReplacer(),
 		priceFloorEnabled:        cfg.PriceFloors.Enabled,
 		priceFloorFetcher:        priceFloorFetcher,
+		floorOptimizer:           floors.NewOptimizer(),
 		singleFormatBidders:      singleFormatBidders,
+		notifier:                 notifier,
 	}
 }
 
@@ -277,7 +285,7 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 
 	var floorErrs []error
 	if e.priceFloorEnabled {
-		floorErrs = floors.EnrichWithPriceFloors(r.BidRequestWrapper, r.Account, conversions, e.priceFloorFetcher)
+		floorErrs = floors.EnrichWithPriceFloors(r.BidRequestWrapper, r.Account, conversions, e.priceFloorFetcher, e.floorOptimizer)
 	}
 
 	responseDebugAllow, accountDebugAllow, debugLog := getDebugInfo(r.BidRequestWrapper.Test, requestExtPrebid, r.Account.DebugAllow, debugLog)
@@ -340,6 +348,11 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 			return nil, err
 		}
 	}
+	if !responseDebugAllow {
+		errs = slices.DeleteFunc(errs, func(err error) bool {
+			return errortypes.ReadScope(err) == errortypes.ScopeDebug
+		})
+	}
 	errs = append(errs, floorErrs...)
 
 	mergedBidAdj, err := bidadjustment.Merge(r.BidRequestWrapper, r.Account.BidAdjustments)
@@ -404,9 +417,10 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 	}
 
 	var (
-		auc            *auction
-		cacheErrs      []error
-		bidResponseExt *openrtb_ext.ExtBidResponse
+		auc                *auction
+		cacheErrs          []error
+		bidResponseExt     *openrtb_ext.ExtBidResponse
+		floorsRejectedBids []*entities.PbsOrtbSeatBid
 	)
 
 	if anyBidsReturned {
@@ -415,6 +429,7 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 			var enforceErrs []error
 
 			adapterBids, enforceErrs, rejectedBids = floors.Enforce(r.BidRequestWrapper, adapterBids, r.Account, conversions)
+			floorsRejectedBids = rejectedBids
 			errs = append(errs, enforceErrs...)
 			for _, rejectedBid := range rejectedBids {
 				errs = append(errs, &errortypes.Warning{
@@ -458,6 +473,8 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 
 		r.HookExecutor.ExecuteAllProcessedBidResponsesStage(adapterBids)
 
+		applyAuctionMacros(r.BidRequestWrapper, adapterBids, r.Account.AuctionMacros, conversions, e.auctionMacroReplacer)
+
 		if targData != nil {
 			multiBidMap := buildMultiBidMap(requestExtPrebid)
 
@@ -465,6 +482,9 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 			auc = newAuction(adapterBids, len(r.BidRequestWrapper.Imp), targData.preferDeals)
 			auc.validateAndUpdateMultiBid(adapterBids, targData.preferDeals, r.Account.DefaultBidLimit)
 			auc.setRoundedPrices(*targData, r.Account)
+			for _, err := range auc.setAdditionalCurrencyPrices(*targData, adapterBids, conversions, r.Account) {
+				r.Warnings = append(r.Warnings, err)
+			}
 
 			if requestExtPrebid.SupportDeals {
 				dealErrs := applyDealSupport(r.BidRequestWrapper.BidRequest, auc, bidCategory, multiBidMap)
@@ -498,6 +518,10 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 				targData.setTargeting(auc, env, bidCategory, r.Account.TruncateTargetAttribute, multiBidMap)
 			}
 		}
+
+		if e.notifier != nil && r.Account.Notifications.Enabled {
+			notifyBids(e.notifier, r.Account.ID, r.BidRequestWrapper, adapterBids, auc, e.auctionMacroReplacer)
+		}
 		bidResponseExt = e.makeExtBidResponse(adapterBids, adapterExtra, *r, responseDebugAllow, requestExtPrebid.Passthrough, fledge, errs)
 	} else {
 		bidResponseExt = e.makeExtBidResponse(adapterBids, adapterExtra, *r, responseDebugAllow, requestExtPrebid.Passthrough, fledge, errs)
@@ -513,6 +537,18 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 		}
 	}
 
+	if e.priceFloorEnabled && e.floorOptimizer != nil {
+		e.floorOptimizer.Record(r.BidRequestWrapper, r.Account, adapterBids, conversions)
+	}
+
+	var floorsOutcome *openrtb_ext.PriceFloorsOutcome
+	if e.priceFloorEnabled {
+		floorsOutcome = floors.BuildOutcome(r.BidRequestWrapper, r.Account, floorsRejectedBids, conversions)
+		if floorsOutcome != nil {
+			e.me.RecordFloorsEnforcement(r.PubID, floors.EnforcementStatus(floorsOutcome), floorsOutcome.RejectedBids)
+		}
+	}
+
 	if !accountDebugAllow && !debugLog.DebugOverride {
 		accountDebugDisabledWarning := openrtb_ext.ExtBidderMessage{
 			Code:    errortypes.AccountLevelDebugDisabledWarningCode,
@@ -547,6 +583,8 @@ func (e *exchange) HoldAuction(ctx context.Context, r *AuctionRequest, debugLog
 	return &AuctionResponse{
 		BidResponse:    bidResponse,
 		ExtBidResponse: bidResponseExt,
+		FloorsOutcome:  floorsOutcome,
+		RateSnapshotID: currency.SnapshotID(conversions),
 	}, nil
 }
 
@@ -1242,6 +1280,7 @@ func (e *exchange) makeExtBidResponse(adapterBids map[openrtb_ext.BidderName]*en
 		bidResponseExt.Debug = &openrtb_ext.ExtResponseDebug{
 			HttpCalls:       make(map[openrtb_ext.BidderName][]*openrtb_ext.ExtHttpCall),
 			ResolvedRequest: r.ResolvedBidRequest,
+			PrivacyAudit:    r.Activities.AuditReport(),
 		}
 	}
 
@@ -1343,6 +1382,7 @@ func (e *exchange) makeBid(bids []*entities.PbsOrtbBid, auc *auction, returnCrea
 			Events:            bid.BidEvents,
 			Targeting:         bid.BidTargets,
 			Floors:            bid.BidFloors,
+			Prices:            bid.BidPrices,
 			Type:              bid.BidType,
 			Meta:              bid.BidMeta,
 			Video:             bid.BidVideo,
@@ -1356,7 +1396,7 @@ func (e *exchange) makeBid(bids []*entities.PbsOrtbBid, auc *auction, returnCrea
 			}
 		}
 
-		if bidExtJSON, err := makeBidExtJSON(bid.Bid.Ext, bidExtPrebid, impExtInfoMap, bid.Bid.ImpID, bid.OriginalBidCPM, bid.OriginalBidCur, bid.AdapterCode); err != nil {
+		if bidExtJSON, err := makeBidExtJSON(bid.Bid.Ext, bidExtPrebid, impExtInfoMap, bid.Bid.ImpID, bid.OriginalBidCPM, bid.OriginalBidCur, bid.RateSnapshotID, bid.AdapterCode); err != nil {
 			errs = append(errs, err)
 		} else {
 			result = append(result, *bid.Bid)
@@ -1370,7 +1410,7 @@ func (e *exchange) makeBid(bids []*entities.PbsOrtbBid, auc *auction, returnCrea
 	return result, errs
 }
 
-func makeBidExtJSON(ext json.RawMessage, prebid *openrtb_ext.ExtBidPrebid, impExtInfoMap map[string]ImpExtInfo, impId string, originalBidCpm float64, originalBidCur string, adapter openrtb_ext.BidderName) (json.RawMessage, error) {
+func makeBidExtJSON(ext json.RawMessage, prebid *openrtb_ext.ExtBidPrebid, impExtInfoMap map[string]ImpExtInfo, impId string, originalBidCpm float64, originalBidCur string, rateSnapshotID string, adapter openrtb_ext.BidderName) (json.RawMessage, error) {
 	var extMap map[string]interface{}
 
 	if len(ext) != 0 {
@@ -1391,6 +1431,11 @@ func makeBidExtJSON(ext json.RawMessage, prebid *openrtb_ext.ExtBidPrebid, impEx
 		extMap[openrtb_ext.OriginalBidCurKey] = originalBidCur
 	}
 
+	//ext.ratesnapshotid, the currency rates snapshot origbidcpm was converted with
+	if rateSnapshotID != "" {
+		extMap[openrtb_ext.RateSnapshotIDKey] = rateSnapshotID
+	}
+
 	// ext.prebid
 	if prebid.Meta == nil && maputil.HasElement(extMap, "prebid", "meta") {
 		metaContainer := struct {
@@ -0,0 +1,43 @@
+package exchange
+
+import (
+	"github.com/prebid/prebid-server/v4/exchange/entities"
+	"github.com/prebid/prebid-server/v4/macros"
+	"github.com/prebid/prebid-server/v4/notifications"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+)
+
+// notifyBids fires the win notice URLs of the bids winning their imp and keeps the billing notice URLs of all the bids
+// until their billing event, as the ad server may still render another bid than the PBS winner. The auction is only
+// built when targeting is requested, otherwise the winners are determined here. The billing notice URLs are only kept
+// for the bids with a generated bid ID, as the billing events don't carry the auction and the bid IDs of the bidders
+// aren't unique across auctions. The OpenRTB auction macros left in the notice URLs, as the account or the bidder didn't
+// opt in to their substitution, are substituted with the bid price since PBS fires the notices itself.
+func notifyBids(notifier notifications.Notifier, accountID string, bidRequest *openrtb_ext.RequestWrapper, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, auc *auction, replacer macros.Replacer) {
+	if auc == nil {
+		auc = newAuction(seatBids, len(bidRequest.Imp), false)
+	}
+
+	macroProvider := macros.NewProvider(bidRequest)
+	for bidderName, seatBid := range seatBids {
+		if seatBid == nil {
+			continue
+		}
+		for _, bid := range seatBid.Bids {
+			if bid == nil || bid.Bid == nil {
+				continue
+			}
+			macroProvider.PopulateAuctionMacros(bid, seatBid.Currency, bid.Bid.Price)
+			// same bid ID as in the event URLs, so that the billing event finds the billing notice URL
+			bidID := bid.Bid.ID
+			if len(bid.GeneratedBidID) > 0 {
+				bidID = bid.GeneratedBidID
+				notifier.RecordBilling(accountID, bidderName, bidID, replaceAuctionMacros(bid.Bid.BURL, macroProvider, replacer))
+			}
+			// without markup the win notice URL returns the markup, which only the client can render
+			if auc.winningBids[bid.Bid.ImpID] == bid && bid.Bid.AdM != "" {
+				notifier.Win(accountID, bidRequest.ID, bidderName, bidID, replaceAuctionMacros(bid.Bid.NURL, macroProvider, replacer))
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v4/exchange/entities"
+	"github.com/prebid/prebid-server/v4/macros"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockNotifier struct {
+	wins     map[string]string
+	billings map[string]string
+}
+
+func (n *mockNotifier) Win(accountID, auctionID string, bidder openrtb_ext.BidderName, bidID, nurl string) {
+	n.wins[accountID+":"+auctionID+":"+bidID] = nurl
+}
+
+func (n *mockNotifier) RecordBilling(accountID string, bidder openrtb_ext.BidderName, bidID, burl string) {
+	n.billings[accountID+":"+bidID] = burl
+}
+
+func (n *mockNotifier) Billing(accountID string, bidder openrtb_ext.BidderName, bidID string) {}
+
+func (n *mockNotifier) Stop() {}
+
+func TestNotifyBids(t *testing.T) {
+	seatBids := map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+		"appnexus": {Bids: []*entities.PbsOrtbBid{
+			{Bid: &openrtb2.Bid{ID: "bid-1", ImpID: "imp-1", Price: 3, AdM: "adm", NURL: "nurl-1", BURL: "burl-1"}},
+			{Bid: &openrtb2.Bid{ID: "bid-2", ImpID: "imp-2", Price: 1, NURL: "nurl-2", BURL: "burl-2"}, GeneratedBidID: "generated-2"},
+		}},
+		"pubmatic": {Bids: []*entities.PbsOrtbBid{
+			{Bid: &openrtb2.Bid{ID: "bid-3", ImpID: "imp-1", Price: 2, AdM: "adm", NURL: "nurl-3", BURL: "burl-3"}, GeneratedBidID: "generated-3"},
+		}},
+	}
+
+	testCases := []struct {
+		name         string
+		auc          *auction
+		expectedWins map[string]string
+	}{
+		{
+			name:         "winners_determined_without_targeting",
+			auc:          nil,
+			expectedWins: map[string]string{"account-1:auction-1:bid-1": "nurl-1"},
+		},
+		{
+			name:         "winners_of_the_auction",
+			auc:          &auction{winningBids: map[string]*entities.PbsOrtbBid{"imp-1": seatBids["pubmatic"].Bids[0]}},
+			expectedWins: map[string]string{"account-1:auction-1:generated-3": "nurl-3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			notifier := &mockNotifier{wins: map[string]string{}, billings: map[string]string{}}
+
+			bidRequest := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "auction-1", Imp: []openrtb2.Imp{{ID: "imp-1"}, {ID: "imp-2"}}}}
+
+			notifyBids(notifier, "account-1", bidRequest, seatBids, tc.auc, macros.NewOpenRTBMacroReplacer())
+
+			assert.Equal(t, tc.expectedWins, notifier.wins)
+			// the billing notice URLs of the bids without a generated bid ID aren't kept
+			assert.Equal(t, map[string]string{"account-1:generated-2": "burl-2", "account-1:generated-3": "burl-3"}, notifier.billings)
+		})
+	}
+}
+
+func TestNotifyBidsAuctionMacros(t *testing.T) {
+	seatBids := map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+		"appnexus": {Currency: "EUR", Bids: []*entities.PbsOrtbBid{
+			{
+				Bid: &openrtb2.Bid{
+					ID:    "bid-1",
+					ImpID: "imp-1",
+					Price: 1.5,
+					AdM:   "adm",
+					NURL:  "http://win?price=${AUCTION_PRICE}&cur=${AUCTION_CURRENCY}&loss=${AUCTION_LOSS}",
+					BURL:  "http://bill?price=${AUCTION_PRICE}",
+				},
+				GeneratedBidID: "generated-1",
+			},
+		}},
+	}
+	bidRequest := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "auction-1", Imp: []openrtb2.Imp{{ID: "imp-1"}}}}
+	notifier := &mockNotifier{wins: map[string]string{}, billings: map[string]string{}}
+
+	notifyBids(notifier, "account-1", bidRequest, seatBids, nil, macros.NewOpenRTBMacroReplacer())
+
+	assert.Equal(t, map[string]string{"account-1:auction-1:generated-1": "http://win?price=1.5&cur=EUR&loss=${AUCTION_LOSS}"}, notifier.wins)
+	assert.Equal(t, map[string]string{"account-1:generated-1": "http://bill?price=1.5"}, notifier.billings)
+	// the bid itself is left untouched, as the auction macros aren't enabled for the account
+	assert.Equal(t, "http://win?price=${AUCTION_PRICE}&cur=${AUCTION_CURRENCY}&loss=${AUCTION_LOSS}", seatBids["appnexus"].Bids[0].Bid.NURL)
+}
@@ -33,6 +33,8 @@ type targetData struct {
 	cacheHost string
 	cachePath string
 	prefix    string
+	// additionalCurrencies lists the currencies the winning bids prices are also returned in
+	additionalCurrencies []string
 }
 
 // setTargeting writes all the targeting params into the bids.
@@ -71,6 +73,11 @@ func (targData *targetData) setTargeting(auc *auction, env string, categoryMappi
 				if cpm, ok := auc.roundedPrices[topBid]; ok {
 					targData.addKeys(targets, openrtb_ext.PbKey, cpm, targetingBidderCode, isOverallWinner, truncateTargetAttr, bidHasDeal)
 				}
+				for _, cur := range targData.additionalCurrencies {
+					if cpm, ok := auc.additionalRoundedPrices[topBid][cur]; ok {
+						targData.addKeys(targets, openrtb_ext.PbKey.CurrencyKey(cur), cpm, targetingBidderCode, isOverallWinner, truncateTargetAttr, bidHasDeal)
+					}
+				}
 				targData.addKeys(targets, openrtb_ext.BidderKey, string(targetingBidderCode), targetingBidderCode, isOverallWinner, truncateTargetAttr, bidHasDeal)
 				if hbSize := makeHbSize(topBid.Bid); hbSize != "" {
 					targData.addKeys(targets, openrtb_ext.SizeKey, hbSize, targetingBidderCode, isOverallWinner, truncateTargetAttr, bidHasDeal)
@@ -11,6 +11,7 @@ import (
 	"github.com/prebid/prebid-server/v4/adapters"
 	"github.com/prebid/prebid-server/v4/config"
 	"github.com/prebid/prebid-server/v4/currency"
+	"github.com/prebid/prebid-server/v4/errortypes"
 	"github.com/prebid/prebid-server/v4/exchange/entities"
 	"github.com/prebid/prebid-server/v4/gdpr"
 	"github.com/prebid/prebid-server/v4/hooks/hookexecution"
@@ -1249,3 +1250,95 @@ func TestSetTargeting(t *testing.T) {
 		}
 	}
 }
+
+func TestSetTargetingAdditionalCurrencies(t *testing.T) {
+	appnexusBid := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{Price: 3}, BidType: openrtb_ext.BidTypeBanner}
+	rubiconBid := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{Price: 1}, BidType: openrtb_ext.BidTypeBanner}
+	auc := &auction{
+		winningBids: map[string]*entities.PbsOrtbBid{"ImpId-1": appnexusBid},
+		allBidsByBidder: map[string]map[openrtb_ext.BidderName][]*entities.PbsOrtbBid{
+			"ImpId-1": {
+				openrtb_ext.BidderAppnexus: {appnexusBid},
+				openrtb_ext.BidderRubicon:  {rubiconBid},
+			},
+		},
+	}
+	seatBids := map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+		openrtb_ext.BidderAppnexus: {Bids: []*entities.PbsOrtbBid{appnexusBid}, Currency: "USD"},
+		openrtb_ext.BidderRubicon:  {Bids: []*entities.PbsOrtbBid{rubiconBid}, Currency: "USD"},
+	}
+	conversions := currency.NewRates(map[string]map[string]float64{"USD": {"EUR": 0.5}})
+	targData := targetData{
+		priceGranularity:     lookupPriceGranularity("med"),
+		includeWinners:       true,
+		includeBidderKeys:    true,
+		prefix:               DefaultKeyPrefix,
+		additionalCurrencies: []string{"EUR", "GBP"},
+	}
+
+	auc.setRoundedPrices(targData, config.Account{})
+	errs := auc.setAdditionalCurrencyPrices(targData, seatBids, conversions, config.Account{})
+	targData.setTargeting(auc, "", nil, nil, nil)
+
+	assert.Equal(t, []error{&errortypes.Warning{
+		WarningCode: errortypes.AdditionalCurrencyWarningCode,
+		Message:     "bid prices can't be returned in additional currency GBP: Currency conversion rate not found: 'USD' => 'GBP'",
+	}}, errs)
+	assert.Equal(t, map[string]float64{"EUR": 1.5}, appnexusBid.BidPrices)
+	assert.Equal(t, map[string]float64{"EUR": 0.5}, rubiconBid.BidPrices)
+	assert.Equal(t, map[string]string{
+		"hb_bidder":          "appnexus",
+		"hb_bidder_appnexus": "appnexus",
+		"hb_pb":              "3.00",
+		"hb_pb_appnexus":     "3.00",
+		"hb_pb_EUR":          "1.50",
+		"hb_pb_EUR_appnexus": "1.50",
+	}, appnexusBid.BidTargets)
+	assert.Equal(t, map[string]string{
+		"hb_bidder_rubicon": "rubicon",
+		"hb_pb_rubicon":     "1.00",
+		"hb_pb_EUR_rubicon": "0.50",
+	}, rubiconBid.BidTargets)
+}
+
+func TestSetTargetingAdditionalCurrenciesGranularity(t *testing.T) {
+	bannerPrecision := 0
+	bannerGranularity := openrtb_ext.PriceGranularity{
+		Precision: &bannerPrecision,
+		Ranges:    []openrtb_ext.GranularityRange{{Min: 0, Max: 5, Increment: 1}},
+	}
+	appnexusBid := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{Price: 3.27, MType: openrtb2.MarkupBanner}, BidType: openrtb_ext.BidTypeBanner}
+	rubiconBid := &entities.PbsOrtbBid{Bid: &openrtb2.Bid{Price: 12.34, MType: openrtb2.MarkupVideo}, BidType: openrtb_ext.BidTypeVideo}
+	auc := &auction{
+		winningBids: map[string]*entities.PbsOrtbBid{"ImpId-1": appnexusBid, "ImpId-2": rubiconBid},
+		allBidsByBidder: map[string]map[openrtb_ext.BidderName][]*entities.PbsOrtbBid{
+			"ImpId-1": {openrtb_ext.BidderAppnexus: {appnexusBid}},
+			"ImpId-2": {openrtb_ext.BidderRubicon: {rubiconBid}},
+		},
+	}
+	seatBids := map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+		openrtb_ext.BidderAppnexus: {Bids: []*entities.PbsOrtbBid{appnexusBid}, Currency: "USD"},
+		openrtb_ext.BidderRubicon:  {Bids: []*entities.PbsOrtbBid{rubiconBid}, Currency: "USD"},
+	}
+	conversions := currency.NewRates(map[string]map[string]float64{"USD": {"JPY": 150}})
+	targData := targetData{
+		priceGranularity:          lookupPriceGranularity("med"),
+		mediaTypePriceGranularity: openrtb_ext.MediaTypePriceGranularity{Banner: &bannerGranularity},
+		includeWinners:            true,
+		prefix:                    DefaultKeyPrefix,
+		additionalCurrencies:      []string{"JPY"},
+	}
+
+	auc.setRoundedPrices(targData, config.Account{})
+	errs := auc.setAdditionalCurrencyPrices(targData, seatBids, conversions, config.Account{})
+	targData.setTargeting(auc, "", nil, nil, nil)
+
+	assert.Empty(t, errs)
+	// the ranges are converted to JPY: 0 to 750 by 150 for the banner and 0 to 3000 by 15 for the video
+	assert.Equal(t, "450", appnexusBid.BidTargets["hb_pb_JPY"])
+	assert.Equal(t, "1845.00", rubiconBid.BidTargets["hb_pb_JPY"])
+	// the granularity of the auction currency is left untouched
+	assert.Equal(t, "3", appnexusBid.BidTargets["hb_pb"])
+	assert.Equal(t, "12.30", rubiconBid.BidTargets["hb_pb"])
+	assert.Equal(t, 5.0, bannerGranularity.Ranges[0].Max)
+}
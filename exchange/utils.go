@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/prebid/go-gdpr/vendorconsent"
@@ -22,6 +24,7 @@ import (
 	"github.com/prebid/prebid-server/v4/ortb"
 	"github.com/prebid/prebid-server/v4/privacy"
 	"github.com/prebid/prebid-server/v4/privacy/ccpa"
+	gppPolicy "github.com/prebid/prebid-server/v4/privacy/gpp"
 	"github.com/prebid/prebid-server/v4/privacy/lmt"
 	"github.com/prebid/prebid-server/v4/schain"
 	"github.com/prebid/prebid-server/v4/stored_responses"
@@ -142,15 +145,17 @@ func (rs *requestSplitter) cleanOpenRTBRequests(ctx context.Context,
 		}
 
 		gdprRequestInfo := gdpr.RequestInfo{
-			AliasGVLIDs: requestAliasesGVLIDs,
-			Consent:     consent,
-			GDPRSignal:  auctionReq.GDPRSignal,
-			PublisherID: auctionReq.LegacyLabels.PubID,
+			AdditionalConsent: getAdditionalConsent(req),
+			AliasGVLIDs:       requestAliasesGVLIDs,
+			Consent:           consent,
+			GDPRSignal:        auctionReq.GDPRSignal,
+			PublisherID:       auctionReq.LegacyLabels.PubID,
 		}
 		gdprPerms = rs.gdprPermsBuilder(auctionReq.TCF2Config, gdprRequestInfo)
 	}
 
 	bidderRequests = make([]BidderRequest, 0, len(impsByBidder))
+	replacedPrivacySignals := make(map[string]struct{})
 
 	for bidder, imps := range impsByBidder {
 		fpdUserEIDsPresent := fpdUserEIDExists(req, auctionReq.FirstPartyData, bidder)
@@ -187,6 +192,16 @@ func (rs *requestSplitter) cleanOpenRTBRequests(ctx context.Context,
 		hadSync := prepareUser(reqWrapperCopy, bidder, syncerKey, lowerCaseExplicitBuyerUIDs, auctionReq.UserSyncs)
 
 		auctionPermissions := gdprPerms.AuctionActivitiesAllowed(ctx, coreBidder, openrtb_ext.BidderName(bidder))
+		if auctionReq.GDPREnforced {
+			scope := privacy.Component{Type: privacy.ComponentTypeBidder, Name: bidder}
+			auctionReq.Activities.RecordPolicyDecision(privacy.ActivityFetchBids, scope, auctionPermissions.AllowBidRequest, privacy.AuditPolicyGDPR)
+		}
+		for _, restriction := range auctionPermissions.PubRestrictions {
+			errs = append(errs, &errortypes.DebugWarning{
+				Message:     fmt.Sprintf("bidder %q subject to TCF %s", bidder, restriction),
+				WarningCode: errortypes.TCFPublisherRestrictionWarningCode,
+			})
+		}
 
 		// privacy blocking
 		if rs.isBidderBlockedByPrivacy(reqWrapperCopy, auctionReq.Activities, auctionPermissions, coreBidder, openrtb_ext.BidderName(bidder)) {
@@ -206,10 +221,13 @@ func (rs *requestSplitter) cleanOpenRTBRequests(ctx context.Context,
 			continue
 		}
 
-		// GPP downgrade: always downgrade unless we can confirm GPP is supported
-		if shouldSetLegacyPrivacy(rs.bidderInfo, string(coreBidder)) {
-			setLegacyGDPRFromGPP(reqWrapperCopy, gpp)
-			setLegacyUSPFromGPP(reqWrapperCopy, gpp)
+		// GPP downgrade and upgrade: always downgrade unless we can confirm GPP is supported
+		replaced, err := convertPrivacySignals(reqWrapperCopy, gpp, rs.bidderInfo[string(coreBidder)].OpenRTB)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, field := range replaced {
+			replacedPrivacySignals[field] = struct{}{}
 		}
 
 		// remove imps with stored responses so they aren't sent to the bidder
@@ -262,6 +280,10 @@ func (rs *requestSplitter) cleanOpenRTBRequests(ctx context.Context,
 		bidderRequests = append(bidderRequests, bidderRequest)
 	}
 
+	if len(replacedPrivacySignals) > 0 {
+		errs = append(errs, conflictingPrivacySignalsWarning(replacedPrivacySignals))
+	}
+
 	return
 }
 
@@ -374,16 +396,23 @@ func (rs *requestSplitter) applyPrivacy(reqWrapper *openrtb_ext.RequestWrapper,
 	buyerUIDSet := reqWrapper.User != nil && reqWrapper.User.BuyerUID != ""
 	buyerUIDRemoved := false
 	if !passIDActivityAllowed {
-		privacy.ScrubUserFPD(reqWrapper)
+		scrubbed := privacy.ScrubUserFPD(reqWrapper)
+		auctionReq.Activities.RecordScrub(scope, privacy.ActivityTransmitUserFPD.String(), scrubbed)
 		buyerUIDRemoved = true
 	} else {
+		if auctionReq.GDPREnforced {
+			auctionReq.Activities.RecordPolicyDecision(privacy.ActivityTransmitUserFPD, scope, auctionPermissions.PassID, privacy.AuditPolicyGDPR)
+		}
 		if !auctionPermissions.PassID {
-			privacy.ScrubGdprID(reqWrapper)
+			scrubbed := privacy.ScrubGdprID(reqWrapper)
+			auctionReq.Activities.RecordScrub(scope, privacy.AuditPolicyGDPR, scrubbed)
 			buyerUIDRemoved = true
 		}
 
 		if ccpaEnforcer.ShouldEnforce(bidderName) {
-			privacy.ScrubDeviceIDsIPsUserDemoExt(reqWrapper, ipConf, "eids", false)
+			scrubbed := privacy.ScrubDeviceIDsIPsUserDemoExt(reqWrapper, ipConf, "eids", false)
+			auctionReq.Activities.RecordPolicyDecision(privacy.ActivityTransmitUserFPD, scope, false, privacy.AuditPolicyCCPA)
+			auctionReq.Activities.RecordScrub(scope, privacy.AuditPolicyCCPA, scrubbed)
 			buyerUIDRemoved = true
 		}
 	}
@@ -391,25 +420,46 @@ func (rs *requestSplitter) applyPrivacy(reqWrapper *openrtb_ext.RequestWrapper,
 		rs.me.RecordAdapterBuyerUIDScrubbed(coreBidderName)
 	}
 
-	passGeoActivityAllowed := auctionReq.Activities.Allow(privacy.ActivityTransmitPreciseGeo, scope, privacy.NewRequestFromBidRequest(*reqWrapper))
+	transmitEIDsRequest := privacy.NewRequestFromBidRequest(*reqWrapper)
+	removedEIDSources := privacy.ScrubEIDsBySource(reqWrapper, func(source string) bool {
+		return auctionReq.Activities.Allow(privacy.ActivityTransmitEIDs, scope, transmitEIDsRequest.WithEIDSource(source))
+	})
+	if len(removedEIDSources) > 0 {
+		auctionReq.Activities.RecordScrub(scope, privacy.ActivityTransmitEIDs.String(), privacy.ScrubEIDFields(removedEIDSources))
+	}
+
+	passGeoActivityAllowed, precisionProfile := auctionReq.Activities.AllowPreciseGeo(scope, privacy.NewRequestFromBidRequest(*reqWrapper))
 	if !passGeoActivityAllowed {
-		privacy.ScrubGeoAndDeviceIP(reqWrapper, ipConf)
+		scrubbed := privacy.ScrubGeoAndDeviceIPWithProfile(reqWrapper, ipConf, precisionProfile)
+		auctionReq.Activities.RecordScrub(scope, privacy.ActivityTransmitPreciseGeo.String(), scrubbed)
 	} else {
+		if auctionReq.GDPREnforced {
+			auctionReq.Activities.RecordPolicyDecision(privacy.ActivityTransmitPreciseGeo, scope, auctionPermissions.PassGeo, privacy.AuditPolicyGDPR)
+		}
 		if !auctionPermissions.PassGeo {
-			privacy.ScrubGeoAndDeviceIP(reqWrapper, ipConf)
+			scrubbed := privacy.ScrubGeoAndDeviceIPWithProfile(reqWrapper, ipConf, auctionReq.Activities.PolicyPrecisionProfile(privacy.AuditPolicyGDPR))
+			auctionReq.Activities.RecordScrub(scope, privacy.AuditPolicyGDPR, scrubbed)
 		}
 		if ccpaEnforcer.ShouldEnforce(bidderName) {
-			privacy.ScrubDeviceIDsIPsUserDemoExt(reqWrapper, ipConf, "eids", false)
+			scrubbed := privacy.ScrubDeviceIDsIPsUserDemoExt(reqWrapper, ipConf, "eids", false)
+			auctionReq.Activities.RecordPolicyDecision(privacy.ActivityTransmitPreciseGeo, scope, false, privacy.AuditPolicyCCPA)
+			auctionReq.Activities.RecordScrub(scope, privacy.AuditPolicyCCPA, scrubbed)
 		}
 	}
 
 	if lmt || coppa {
-		privacy.ScrubDeviceIDsIPsUserDemoExt(reqWrapper, ipConf, "eids", coppa)
+		scrubbed := privacy.ScrubDeviceIDsIPsUserDemoExt(reqWrapper, ipConf, "eids", coppa)
+		if coppa {
+			auctionReq.Activities.RecordScrub(scope, privacy.AuditPolicyCOPPA, scrubbed)
+		} else {
+			auctionReq.Activities.RecordScrub(scope, privacy.AuditPolicyLMT, scrubbed)
+		}
 	}
 
 	passTIDAllowed := auctionReq.Activities.Allow(privacy.ActivityTransmitTIDs, scope, privacy.NewRequestFromBidRequest(*reqWrapper))
 	if !passTIDAllowed {
-		privacy.ScrubTID(reqWrapper)
+		scrubbed := privacy.ScrubTID(reqWrapper)
+		auctionReq.Activities.RecordScrub(scope, privacy.ActivityTransmitTIDs.String(), scrubbed)
 	}
 
 	if err := reqWrapper.RebuildRequest(); err != nil {
@@ -420,16 +470,6 @@ func (rs *requestSplitter) applyPrivacy(reqWrapper *openrtb_ext.RequestWrapper,
 	return nil
 }
 
-func shouldSetLegacyPrivacy(bidderInfo config.BidderInfos, bidder string) bool {
-	binfo, defined := bidderInfo[bidder]
-
-	if !defined || binfo.OpenRTB == nil {
-		return true
-	}
-
-	return !binfo.OpenRTB.GPPSupported
-}
-
 func ccpaEnabled(account *config.Account, privacyConfig config.Privacy, requestType config.ChannelType) bool {
 	if accountEnabled := account.CCPA.EnabledForChannelType(requestType); accountEnabled != nil {
 		return *accountEnabled
@@ -464,6 +504,27 @@ func extractLMT(orig *openrtb2.BidRequest, privacyConfig config.Privacy) privacy
 	}
 }
 
+// getAdditionalConsent returns the Google Additional Consent string of the request, found in
+// user.ext.ConsentedProvidersSettings.consented_providers. Without it, the string is built from the list
+// of provider IDs in user.ext.consented_providers_settings.consented_providers, as sent by Prebid.js.
+func getAdditionalConsent(req *openrtb_ext.RequestWrapper) string {
+	userExt, err := req.GetUserExt()
+	if err != nil {
+		return ""
+	}
+	if settings := userExt.GetConsentedProvidersSettingsIn(); settings != nil && settings.ConsentedProvidersString != "" {
+		return settings.ConsentedProvidersString
+	}
+	if settings := userExt.GetConsentedProvidersSettingsOut(); settings != nil && len(settings.ConsentedProvidersList) > 0 {
+		providers := make([]string, len(settings.ConsentedProvidersList))
+		for i, id := range settings.ConsentedProvidersList {
+			providers[i] = strconv.Itoa(id)
+		}
+		return "1~" + strings.Join(providers, ".")
+	}
+	return ""
+}
+
 func ExtractReqExtBidderParamsMap(bidRequest *openrtb2.BidRequest) (map[string]json.RawMessage, error) {
 	if bidRequest == nil {
 		return nil, errors.New("error bidRequest should not be nil")
@@ -946,12 +1007,34 @@ func getExtTargetData(requestExtPrebid *openrtb_ext.ExtRequestPrebid, cacheInstr
 			preferDeals:               requestExtPrebid.Targeting.PreferDeals,
 			priceGranularity:          ptrutil.ValueOrDefault(requestExtPrebid.Targeting.PriceGranularity),
 			prefix:                    prefix,
+			additionalCurrencies:      getAdditionalCurrencies(requestExtPrebid.Targeting.AdditionalCurrencies, account),
 		}, warning
 	}
 
 	return nil, nil
 }
 
+// getAdditionalCurrencies returns the currencies the winning bids prices are also returned in, the request ones
+// taking precedence over the account ones. The currency codes are upper-cased and deduplicated.
+func getAdditionalCurrencies(requestCurrencies []string, account config.Account) []string {
+	currencies := account.AdditionalCurrencies
+	if len(requestCurrencies) > 0 {
+		currencies = requestCurrencies
+	}
+	if len(currencies) == 0 {
+		return nil
+	}
+
+	additionalCurrencies := make([]string, 0, len(currencies))
+	for _, cur := range currencies {
+		cur = strings.ToUpper(cur)
+		if !slices.Contains(additionalCurrencies, cur) {
+			additionalCurrencies = append(additionalCurrencies, cur)
+		}
+	}
+	return additionalCurrencies
+}
+
 func getTargetDataPrefix(requestPrefix string, account config.Account) (string, []*errortypes.Warning) {
 	var warnings []*errortypes.Warning
 
@@ -1077,6 +1160,140 @@ func applyFPD(fpd map[openrtb_ext.BidderName]*firstpartydata.ResolvedFirstPartyD
 	}
 }
 
+// convertPrivacySignals translates the privacy signals of the request into the formats read by the bidder. The
+// legacy TCF and US Privacy signals are derived from GPP for bidders which don't read GPP, and GPP from the
+// legacy signals for bidders which only read GPP. For the bidders configured with privacy-signals not including
+// GPP, GPP also takes precedence over conflicting legacy signals, which are replaced in their request and
+// returned. Bidders without privacy-signals receive the signals of the request unchanged.
+func convertPrivacySignals(r *openrtb_ext.RequestWrapper, gpp gpplib.GppContainer, info *config.OpenRTBInfo) (replaced []string, err error) {
+	readsGPP := info.ReadsPrivacySignal(config.PrivacySignalGPP)
+	readsTCF := info.ReadsPrivacySignal(config.PrivacySignalTCF)
+	readsUSP := info.ReadsPrivacySignal(config.PrivacySignalUSP)
+
+	if !readsGPP {
+		if info != nil && len(info.PrivacySignals) > 0 {
+			if readsTCF {
+				replaced = append(replaced, replaceConflictingLegacyGDPR(r, gpp)...)
+			}
+			if readsUSP {
+				replaced = append(replaced, replaceConflictingLegacyUSP(r, gpp)...)
+			}
+		}
+		if readsTCF {
+			setLegacyGDPRFromGPP(r, gpp)
+		}
+		if readsUSP {
+			setLegacyUSPFromGPP(r, gpp)
+		}
+	} else if !readsTCF || !readsUSP {
+		err = setGPPFromLegacy(r)
+	}
+	return replaced, err
+}
+
+// legacyPrivacyFields are the legacy privacy signals which may conflict with GPP, in the order they are reported
+var legacyPrivacyFields = []string{"regs.gdpr", "user.consent", "regs.us_privacy"}
+
+// conflictingPrivacySignalsWarning reports the legacy privacy signals replaced by GPP in the requests to any
+// bidder, once per auction
+func conflictingPrivacySignalsWarning(replaced map[string]struct{}) error {
+	var fields []string
+	for _, field := range legacyPrivacyFields {
+		if _, ok := replaced[field]; ok {
+			fields = append(fields, field)
+		}
+	}
+	return &errortypes.Warning{
+		Message:     fmt.Sprintf("%s conflicts with GPP (regs.gpp), using regs.gpp in the requests to bidders not reading GPP", strings.Join(fields, ", ")),
+		WarningCode: errortypes.InvalidPrivacyConsentWarningCode,
+	}
+}
+
+// replaceConflictingLegacyGDPR replaces regs.gdpr and user.consent with the values of GPP if they conflict,
+// returning the fields replaced
+func replaceConflictingLegacyGDPR(r *openrtb_ext.RequestWrapper, gpp gpplib.GppContainer) []string {
+	var replaced []string
+
+	if r.Regs != nil && r.Regs.GDPR != nil && r.Regs.GPPSID != nil {
+		gdprSignal := int8(0)
+		if gppPolicy.IsSIDInList(r.Regs.GPPSID, gppConstants.SectionTCFEU2) {
+			gdprSignal = 1
+		}
+		if *r.Regs.GDPR != gdprSignal {
+			regs := *r.Regs
+			regs.GDPR = &gdprSignal
+			r.Regs = &regs
+			replaced = append(replaced, "regs.gdpr")
+		}
+	}
+
+	if r.User != nil && r.User.Consent != "" {
+		if i := gppPolicy.IndexOfSID(gpp, gppConstants.SectionTCFEU2); i >= 0 && gpp.Sections[i].GetValue() != r.User.Consent {
+			user := *r.User
+			user.Consent = gpp.Sections[i].GetValue()
+			r.User = &user
+			replaced = append(replaced, "user.consent")
+		}
+	}
+
+	return replaced
+}
+
+// replaceConflictingLegacyUSP replaces regs.us_privacy with the value of GPP if it conflicts, returning the
+// fields replaced
+func replaceConflictingLegacyUSP(r *openrtb_ext.RequestWrapper, gpp gpplib.GppContainer) []string {
+	if r.Regs == nil || r.Regs.USPrivacy == "" || !gppPolicy.IsSIDInList(r.Regs.GPPSID, gppConstants.SectionUSPV1) {
+		return nil
+	}
+
+	if i := gppPolicy.IndexOfSID(gpp, gppConstants.SectionUSPV1); i >= 0 && gpp.Sections[i].GetValue() != r.Regs.USPrivacy {
+		regs := *r.Regs
+		regs.USPrivacy = gpp.Sections[i].GetValue()
+		r.Regs = &regs
+		return []string{"regs.us_privacy"}
+	}
+	return nil
+}
+
+// setGPPFromLegacy sets regs.gpp and regs.gpp_sid from the legacy TCF and US Privacy signals, unless the
+// request already has GPP
+func setGPPFromLegacy(r *openrtb_ext.RequestWrapper) error {
+	if r.Regs != nil && r.Regs.GPP != "" {
+		return nil
+	}
+
+	var tcfConsent, usPrivacy string
+	if r.User != nil {
+		tcfConsent = r.User.Consent
+	}
+	if r.Regs != nil {
+		usPrivacy = r.Regs.USPrivacy
+	}
+
+	gppString, err := gppPolicy.EncodeLegacy(tcfConsent, usPrivacy)
+	if err != nil {
+		return fmt.Errorf("unable to encode GPP from legacy privacy signals: %v", err)
+	}
+	if gppString == "" {
+		return nil
+	}
+
+	var regs openrtb2.Regs
+	if r.Regs != nil {
+		regs = *r.Regs
+	}
+	regs.GPP = gppString
+	regs.GPPSID = nil
+	if tcfConsent != "" && regs.GDPR != nil && *regs.GDPR == 1 {
+		regs.GPPSID = append(regs.GPPSID, int8(gppConstants.SectionTCFEU2))
+	}
+	if usPrivacy != "" {
+		regs.GPPSID = append(regs.GPPSID, int8(gppConstants.SectionUSPV1))
+	}
+	r.Regs = &regs
+	return nil
+}
+
 func setLegacyGDPRFromGPP(r *openrtb_ext.RequestWrapper, gpp gpplib.GppContainer) {
 	if r.Regs != nil && r.Regs.GDPR == nil {
 		if r.Regs.GPPSID != nil {
@@ -32,6 +32,7 @@ type permissionsMock struct {
 	allowedBidders  []openrtb_ext.BidderName
 	passGeo         bool
 	passID          bool
+	pubRestrictions []gdpr.PubRestriction
 	activitiesError error
 }
 
@@ -45,8 +46,9 @@ func (p *permissionsMock) BidderSyncAllowed(ctx context.Context, bidder openrtb_
 
 func (p *permissionsMock) AuctionActivitiesAllowed(ctx context.Context, bidderCoreName openrtb_ext.BidderName, bidder openrtb_ext.BidderName) gdpr.AuctionPermissions {
 	permissions := gdpr.AuctionPermissions{
-		PassGeo: p.passGeo,
-		PassID:  p.passID,
+		PassGeo:         p.passGeo,
+		PassID:          p.passID,
+		PubRestrictions: p.pubRestrictions,
 	}
 
 	if p.allowAllBidders {
@@ -2071,6 +2073,42 @@ func TestGetExtTargetData(t *testing.T) {
 				prefix:                    DefaultKeyPrefix,
 			},
 		},
+		{
+			name: "additional-currencies-from-account",
+			givenRequestExtPrebid: &openrtb_ext.ExtRequestPrebid{
+				Targeting: &openrtb_ext.ExtRequestTargeting{},
+			},
+			givenAccount: config.Account{AdditionalCurrencies: []string{"EUR"}},
+			givenWarning: nil,
+			expectTargetData: &targetData{
+				prefix:               DefaultKeyPrefix,
+				additionalCurrencies: []string{"EUR"},
+			},
+		},
+		{
+			name: "additional-currencies-request-overrides-account",
+			givenRequestExtPrebid: &openrtb_ext.ExtRequestPrebid{
+				Targeting: &openrtb_ext.ExtRequestTargeting{AdditionalCurrencies: []string{"GBP", "JPY"}},
+			},
+			givenAccount: config.Account{AdditionalCurrencies: []string{"EUR"}},
+			givenWarning: nil,
+			expectTargetData: &targetData{
+				prefix:               DefaultKeyPrefix,
+				additionalCurrencies: []string{"GBP", "JPY"},
+			},
+		},
+		{
+			name: "additional-currencies-upper-cased-and-deduplicated",
+			givenRequestExtPrebid: &openrtb_ext.ExtRequestPrebid{
+				Targeting: &openrtb_ext.ExtRequestTargeting{AdditionalCurrencies: []string{"gbp", "JPY", "GBP", "jpy"}},
+			},
+			givenAccount: config.Account{},
+			givenWarning: nil,
+			expectTargetData: &targetData{
+				prefix:               DefaultKeyPrefix,
+				additionalCurrencies: []string{"GBP", "JPY"},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -2548,6 +2586,211 @@ func TestCleanOpenRTBRequestsGDPRBlockBidRequest(t *testing.T) {
 	}
 }
 
+func TestCleanOpenRTBRequestsGDPRPubRestrictions(t *testing.T) {
+	req := newBidRequest()
+	req.Regs = &openrtb2.Regs{
+		Ext: json.RawMessage(`{"gdpr":1}`),
+	}
+	req.Imp[0].Ext = json.RawMessage(`{"prebid":{"bidder":{"appnexus": {"placementId": 1}}}}`)
+
+	privacyConfig := config.Privacy{}
+	accountConfig := config.Account{}
+
+	auctionReq := AuctionRequest{
+		BidRequestWrapper: &openrtb_ext.RequestWrapper{BidRequest: req},
+		UserSyncs:         &emptyUsersync{},
+		Account:           accountConfig,
+		TCF2Config:        gdpr.NewTCF2Config(privacyConfig.GDPR.TCF2, accountConfig.GDPR),
+		GDPREnforced:      true,
+	}
+
+	gdprPermissionsBuilder := fakePermissionsBuilder{
+		permissions: &permissionsMock{
+			allowAllBidders: true,
+			passGeo:         true,
+			passID:          true,
+			pubRestrictions: []gdpr.PubRestriction{{Purpose: 4, VendorID: 32, Type: gdpr.PubRestrictRequireConsent}},
+		},
+	}.Builder
+
+	reqSplitter := &requestSplitter{
+		bidderToSyncerKey: map[string]string{},
+		me:                &metrics.MetricsEngineMock{},
+		privacyConfig:     privacyConfig,
+		gdprPermsBuilder:  gdprPermissionsBuilder,
+		hostSChainNode:    nil,
+		bidderInfo:        config.BidderInfos{},
+	}
+
+	results, _, errs := reqSplitter.cleanOpenRTBRequests(context.Background(), auctionReq, nil, map[string]float64{})
+
+	expectedErrors := []error{&errortypes.DebugWarning{
+		Message:     `bidder "appnexus" subject to TCF publisher restriction "require consent" for purpose 4 applied to vendor 32`,
+		WarningCode: errortypes.TCFPublisherRestrictionWarningCode,
+	}}
+	assert.Equal(t, expectedErrors, errs)
+	assert.Len(t, results, 1)
+}
+
+func TestCleanOpenRTBRequestsConflictingPrivacySignals(t *testing.T) {
+	req := newBidRequest()
+	req.Regs = &openrtb2.Regs{
+		GDPR:   ptrutil.ToPtr[int8](0),
+		GPP:    "DBABMA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
+		GPPSID: []int8{2},
+	}
+	req.Imp[0].Ext = json.RawMessage(`{"prebid":{"bidder":{"appnexus": {"placementId": 1}, "rubicon": {}, "pubmatic": {}}}}`)
+
+	auctionReq := AuctionRequest{
+		BidRequestWrapper: &openrtb_ext.RequestWrapper{BidRequest: req},
+		UserSyncs:         &emptyUsersync{},
+		TCF2Config:        gdpr.NewTCF2Config(config.TCF2{}, config.AccountGDPR{}),
+	}
+
+	legacyOnly := &config.OpenRTBInfo{Version: "2.6", PrivacySignals: []config.PrivacySignal{config.PrivacySignalTCF, config.PrivacySignalUSP}}
+	reqSplitter := &requestSplitter{
+		bidderToSyncerKey: map[string]string{},
+		me:                &metrics.MetricsEngineMock{},
+		gdprPermsBuilder:  fakePermissionsBuilder{permissions: &permissionsMock{allowAllBidders: true, passGeo: true, passID: true}}.Builder,
+		bidderInfo: config.BidderInfos{
+			"appnexus": config.BidderInfo{OpenRTB: legacyOnly},
+			"rubicon":  config.BidderInfo{OpenRTB: legacyOnly},
+			"pubmatic": config.BidderInfo{OpenRTB: &config.OpenRTBInfo{Version: "2.6", GPPSupported: true}},
+		},
+	}
+
+	results, _, errs := reqSplitter.cleanOpenRTBRequests(context.Background(), auctionReq, nil, map[string]float64{})
+
+	expectedErrors := []error{&errortypes.Warning{
+		Message:     "regs.gdpr conflicts with GPP (regs.gpp), using regs.gpp in the requests to bidders not reading GPP",
+		WarningCode: errortypes.InvalidPrivacyConsentWarningCode,
+	}}
+	assert.Equal(t, expectedErrors, errs, "one warning per request")
+
+	gdprSignals := make(map[openrtb_ext.BidderName]int8, len(results))
+	for _, result := range results {
+		gdprSignals[result.BidderName] = *result.BidRequest.Regs.GDPR
+	}
+	assert.Equal(t, map[openrtb_ext.BidderName]int8{"appnexus": 1, "rubicon": 1, "pubmatic": 0}, gdprSignals)
+}
+
+func TestCleanOpenRTBRequestsPrivacyAudit(t *testing.T) {
+	req := newBidRequest()
+	req.Regs = &openrtb2.Regs{
+		Ext: json.RawMessage(`{"gdpr":1}`),
+	}
+	req.Imp[0].Ext = json.RawMessage(`{"prebid":{"bidder":{"appnexus": {"placementId": 1}}}}`)
+
+	privacyConfig := config.Privacy{}
+	accountConfig := config.Account{
+		Privacy: config.AccountPrivacy{
+			Audit: config.PrivacyAudit{Enabled: true},
+		},
+	}
+	activityControl := privacy.NewActivityControl(&accountConfig.Privacy)
+
+	auctionReq := AuctionRequest{
+		BidRequestWrapper: &openrtb_ext.RequestWrapper{BidRequest: req},
+		UserSyncs:         &emptyUsersync{},
+		Account:           accountConfig,
+		TCF2Config:        gdpr.NewTCF2Config(privacyConfig.GDPR.TCF2, accountConfig.GDPR),
+		GDPREnforced:      true,
+		Activities:        activityControl,
+	}
+
+	gdprPermissionsBuilder := fakePermissionsBuilder{
+		permissions: &permissionsMock{
+			allowAllBidders: true,
+			passGeo:         true,
+			passID:          false,
+		},
+	}.Builder
+
+	metricsMock := metrics.MetricsEngineMock{}
+	metricsMock.Mock.On("RecordAdapterBuyerUIDScrubbed", mock.Anything).Return()
+
+	reqSplitter := &requestSplitter{
+		bidderToSyncerKey: map[string]string{},
+		me:                &metricsMock,
+		privacyConfig:     privacyConfig,
+		gdprPermsBuilder:  gdprPermissionsBuilder,
+		hostSChainNode:    nil,
+		bidderInfo:        config.BidderInfos{},
+	}
+
+	_, _, errs := reqSplitter.cleanOpenRTBRequests(context.Background(), auctionReq, nil, map[string]float64{})
+	assert.Empty(t, errs)
+
+	expectedAudit := openrtb_ext.ExtPrivacyAudit{
+		privacy.ComponentTypeBidder: {
+			"appnexus": {
+				Activities: []openrtb_ext.ExtPrivacyAuditActivity{
+					{Activity: "fetchBids", Allowed: true, Rule: "gdpr"},
+					{Activity: "fetchBids", Allowed: true, Rule: "default"},
+					{Activity: "transmitUfpd", Allowed: true, Rule: "default"},
+					{Activity: "transmitUfpd", Allowed: false, Rule: "gdpr"},
+					{Activity: "transmitEids", Allowed: true, Rule: "default"},
+					{Activity: "transmitPreciseGeo", Allowed: true, Rule: "default"},
+					{Activity: "transmitPreciseGeo", Allowed: true, Rule: "gdpr"},
+					{Activity: "transmitTid", Allowed: true, Rule: "default"},
+				},
+				Scrubbed: []openrtb_ext.ExtPrivacyAuditScrub{
+					{Fields: []string{"device.ids", "user.id", "user.buyeruid", "user.yob", "user.gender"}, Reason: "gdpr"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectedAudit, activityControl.AuditReport())
+}
+
+func TestCleanOpenRTBRequestsPrivacyAuditCCPA(t *testing.T) {
+	req := newBidRequest()
+	req.Regs = &openrtb2.Regs{USPrivacy: "1-Y-"}
+
+	privacyConfig := config.Privacy{CCPA: config.CCPA{Enforce: true}}
+	accountConfig := config.Account{
+		Privacy: config.AccountPrivacy{
+			AllowActivities: &config.AllowActivities{
+				TransmitUserFPD: buildDefaultActivityConfig("appnexus", false),
+			},
+			Audit: config.PrivacyAudit{Enabled: true},
+		},
+	}
+	activityControl := privacy.NewActivityControl(&accountConfig.Privacy)
+
+	auctionReq := AuctionRequest{
+		BidRequestWrapper: &openrtb_ext.RequestWrapper{BidRequest: req},
+		UserSyncs:         &emptyUsersync{},
+		Account:           accountConfig,
+		TCF2Config:        gdpr.NewTCF2Config(privacyConfig.GDPR.TCF2, accountConfig.GDPR),
+		Activities:        activityControl,
+	}
+
+	metricsMock := metrics.MetricsEngineMock{}
+	metricsMock.Mock.On("RecordAdapterBuyerUIDScrubbed", mock.Anything).Return()
+
+	reqSplitter := &requestSplitter{
+		bidderToSyncerKey: map[string]string{},
+		me:                &metricsMock,
+		privacyConfig:     privacyConfig,
+		gdprPermsBuilder:  fakePermissionsBuilder{permissions: &permissionsMock{allowAllBidders: true, passGeo: true, passID: true}}.Builder,
+		hostSChainNode:    nil,
+		bidderInfo:        config.BidderInfos{},
+	}
+
+	_, _, errs := reqSplitter.cleanOpenRTBRequests(context.Background(), auctionReq, nil, map[string]float64{})
+	assert.Empty(t, errs)
+
+	expectedScrubbed := []openrtb_ext.ExtPrivacyAuditScrub{
+		{Fields: []string{"device.ids", "user.id", "user.buyeruid", "user.yob", "user.gender", "user.data", "user.ext.data", "user.eids"}, Reason: "transmitUfpd"},
+		{Fields: []string{"device.ip", "device.geo", "user.geo"}, Reason: "ccpa"},
+	}
+	report := activityControl.AuditReport()
+	if assert.Contains(t, report[privacy.ComponentTypeBidder], "appnexus") {
+		assert.Equal(t, expectedScrubbed, report[privacy.ComponentTypeBidder]["appnexus"].Scrubbed)
+	}
+}
+
 func TestCleanOpenRTBRequestsWithOpenRTBDowngrade(t *testing.T) {
 	emptyTCF2Config := gdpr.NewTCF2Config(config.TCF2{}, config.AccountGDPR{})
 
@@ -4225,6 +4468,47 @@ func TestGetTargetDataPrefix(t *testing.T) {
 	}
 }
 
+func TestGetAdditionalConsent(t *testing.T) {
+	testCases := []struct {
+		description    string
+		userExt        json.RawMessage
+		expectedResult string
+	}{
+		{
+			description:    "No user ext",
+			userExt:        nil,
+			expectedResult: "",
+		},
+		{
+			description:    "String form",
+			userExt:        json.RawMessage(`{"ConsentedProvidersSettings":{"consented_providers":"2~1.35~dv.9"}}`),
+			expectedResult: "2~1.35~dv.9",
+		},
+		{
+			description:    "List form",
+			userExt:        json.RawMessage(`{"consented_providers_settings":{"consented_providers":[1,35,41]}}`),
+			expectedResult: "1~1.35.41",
+		},
+		{
+			description:    "String form preferred over list form",
+			userExt:        json.RawMessage(`{"ConsentedProvidersSettings":{"consented_providers":"1~7"},"consented_providers_settings":{"consented_providers":[1,35]}}`),
+			expectedResult: "1~7",
+		},
+		{
+			description:    "Malformed user ext",
+			userExt:        json.RawMessage(`malformed`),
+			expectedResult: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{User: &openrtb2.User{Ext: tc.userExt}}}
+			assert.Equal(t, tc.expectedResult, getAdditionalConsent(req))
+		})
+	}
+}
+
 type GPPMockSection struct {
 	sectionID constants.SectionID
 	value     string
@@ -4653,6 +4937,145 @@ func TestPrivacyFromGPP(t *testing.T) {
 	}
 }
 
+func TestConvertPrivacySignals(t *testing.T) {
+	gpp := gpplib.GppContainer{
+		SectionTypes: []constants.SectionID{2, 6},
+		Sections: []gpplib.Section{
+			GPPMockSection{sectionID: 2, value: "GPPConsent"},
+			GPPMockSection{sectionID: 6, value: "1YNN"},
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		request          *openrtb2.BidRequest
+		gpp              gpplib.GppContainer
+		info             *config.OpenRTBInfo
+		expectedRegs     *openrtb2.Regs
+		expectedUser     *openrtb2.User
+		expectedReplaced []string
+	}{
+		{
+			name:         "legacy_bidder_downgraded",
+			request:      &openrtb2.BidRequest{Regs: &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{2, 6}}},
+			gpp:          gpp,
+			info:         nil,
+			expectedRegs: &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{2, 6}, GDPR: ptrutil.ToPtr[int8](1), USPrivacy: "1YNN"},
+			expectedUser: &openrtb2.User{Consent: "GPPConsent"},
+		},
+		{
+			name: "legacy_bidder_conflicts_replaced",
+			request: &openrtb2.BidRequest{
+				Regs: &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{6}, GDPR: ptrutil.ToPtr[int8](1), USPrivacy: "1NNN"},
+				User: &openrtb2.User{Consent: "LegacyConsent"},
+			},
+			gpp:              gpp,
+			info:             &config.OpenRTBInfo{PrivacySignals: []config.PrivacySignal{config.PrivacySignalTCF, config.PrivacySignalUSP}},
+			expectedRegs:     &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{6}, GDPR: ptrutil.ToPtr[int8](0), USPrivacy: "1YNN"},
+			expectedUser:     &openrtb2.User{Consent: "GPPConsent"},
+			expectedReplaced: []string{"regs.gdpr", "user.consent", "regs.us_privacy"},
+		},
+		{
+			name: "default_legacy_bidder_conflicts_unchanged",
+			request: &openrtb2.BidRequest{
+				Regs: &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{6}, GDPR: ptrutil.ToPtr[int8](1), USPrivacy: "1NNN"},
+				User: &openrtb2.User{Consent: "LegacyConsent"},
+			},
+			gpp:          gpp,
+			info:         nil,
+			expectedRegs: &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{6}, GDPR: ptrutil.ToPtr[int8](1), USPrivacy: "1NNN"},
+			expectedUser: &openrtb2.User{Consent: "LegacyConsent"},
+		},
+		{
+			name: "default_gpp_bidder_conflicts_unchanged",
+			request: &openrtb2.BidRequest{
+				Regs: &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{6}, GDPR: ptrutil.ToPtr[int8](1), USPrivacy: "1NNN"},
+				User: &openrtb2.User{Consent: "LegacyConsent"},
+			},
+			gpp:          gpp,
+			info:         &config.OpenRTBInfo{GPPSupported: true},
+			expectedRegs: &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{6}, GDPR: ptrutil.ToPtr[int8](1), USPrivacy: "1NNN"},
+			expectedUser: &openrtb2.User{Consent: "LegacyConsent"},
+		},
+		{
+			name: "gpp_and_legacy_bidder_unchanged",
+			request: &openrtb2.BidRequest{
+				Regs: &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{2}},
+			},
+			gpp:          gpp,
+			info:         &config.OpenRTBInfo{GPPSupported: true},
+			expectedRegs: &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{2}},
+		},
+		{
+			name: "gpp_bidder_conflicts_ignored",
+			request: &openrtb2.BidRequest{
+				Regs: &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{2}},
+				User: &openrtb2.User{Consent: "LegacyConsent"},
+			},
+			gpp:          gpp,
+			info:         &config.OpenRTBInfo{PrivacySignals: []config.PrivacySignal{config.PrivacySignalGPP}},
+			expectedRegs: &openrtb2.Regs{GPP: "gpp", GPPSID: []int8{2}},
+			expectedUser: &openrtb2.User{Consent: "LegacyConsent"},
+		},
+		{
+			name: "gpp_bidder_upgraded",
+			request: &openrtb2.BidRequest{
+				Regs: &openrtb2.Regs{GDPR: ptrutil.ToPtr[int8](1), USPrivacy: "1YNN"},
+				User: &openrtb2.User{Consent: "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"},
+			},
+			info: &config.OpenRTBInfo{PrivacySignals: []config.PrivacySignal{config.PrivacySignalGPP}},
+			expectedRegs: &openrtb2.Regs{
+				GDPR:      ptrutil.ToPtr[int8](1),
+				USPrivacy: "1YNN",
+				GPP:       "DBACNYA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN",
+				GPPSID:    []int8{2, 6},
+			},
+			expectedUser: &openrtb2.User{Consent: "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"},
+		},
+		{
+			name: "gpp_bidder_upgraded_gdpr_not_applicable",
+			request: &openrtb2.BidRequest{
+				Regs: &openrtb2.Regs{GDPR: ptrutil.ToPtr[int8](0)},
+				User: &openrtb2.User{Consent: "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"},
+			},
+			info: &config.OpenRTBInfo{PrivacySignals: []config.PrivacySignal{config.PrivacySignalGPP, config.PrivacySignalUSP}},
+			expectedRegs: &openrtb2.Regs{
+				GDPR: ptrutil.ToPtr[int8](0),
+				GPP:  "DBABMA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
+			},
+			expectedUser: &openrtb2.User{Consent: "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"},
+		},
+		{
+			name:         "gpp_bidder_no_legacy_signals",
+			request:      &openrtb2.BidRequest{},
+			info:         &config.OpenRTBInfo{PrivacySignals: []config.PrivacySignal{config.PrivacySignalGPP}},
+			expectedRegs: nil,
+			expectedUser: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			r := &openrtb_ext.RequestWrapper{BidRequest: test.request}
+			replaced, err := convertPrivacySignals(r, test.gpp, test.info)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedReplaced, replaced)
+			assert.Equal(t, test.expectedRegs, r.Regs)
+			assert.Equal(t, test.expectedUser, r.User)
+		})
+	}
+}
+
+func TestConflictingPrivacySignalsWarning(t *testing.T) {
+	warning := conflictingPrivacySignalsWarning(map[string]struct{}{"regs.us_privacy": {}, "regs.gdpr": {}})
+
+	assert.Equal(t, &errortypes.Warning{
+		Message:     "regs.gdpr, regs.us_privacy conflicts with GPP (regs.gpp), using regs.gpp in the requests to bidders not reading GPP",
+		WarningCode: errortypes.InvalidPrivacyConsentWarningCode,
+	}, warning)
+}
+
 func Test_isBidderInExtAlternateBidderCodes(t *testing.T) {
 	type args struct {
 		adapter               string
@@ -5270,6 +5693,36 @@ func TestCleanOpenRTBRequestsActivities(t *testing.T) {
 			},
 			expectedImpExt: json.RawMessage(`{"bidder": {"placementId": 1}}`),
 		},
+		{
+			name:              "transmit_eids_other_source_deny",
+			req:               newBidRequest(),
+			privacyConfig:     getTransmitEIDsActivityConfig("appnexus", "other-source", false),
+			ortbVersion:       "2.6",
+			expectedReqNumber: 1,
+			expectedUser:      expectedUserDefault,
+			expectedDevice:    expectedDeviceDefault,
+			expectedSource:    expectedSourceDefault,
+		},
+		{
+			// remove only the eids of the denied source
+			name:              "transmit_eids_source_deny",
+			req:               newBidRequest(),
+			privacyConfig:     getTransmitEIDsActivityConfig("appnexus", "EIDS-SOURCE", false),
+			ortbVersion:       "2.6",
+			expectedReqNumber: 1,
+			expectedUser: openrtb2.User{
+				ID:       "our-id",
+				BuyerUID: "their-id",
+				Yob:      1982,
+				Gender:   "test",
+				Ext:      json.RawMessage(`{"data": 1, "test": 2}`),
+				Geo:      &openrtb2.Geo{Lat: ptrutil.ToPtr(123.456), Lon: ptrutil.ToPtr(11.278)},
+				EIDs:     nil,
+				Data:     []openrtb2.Data{{ID: "data-id"}},
+			},
+			expectedDevice: expectedDeviceDefault,
+			expectedSource: expectedSourceDefault,
+		},
 	}
 
 	for _, test := range testCases {
@@ -5370,6 +5823,16 @@ func getTransmitTIDActivityConfig(componentName string, allow bool) config.Accou
 	}
 }
 
+func getTransmitEIDsActivityConfig(componentName, eidSource string, allow bool) config.AccountPrivacy {
+	activity := buildDefaultActivityConfig(componentName, allow)
+	activity.Rules[0].Condition.EIDSource = []string{eidSource}
+	return config.AccountPrivacy{
+		AllowActivities: &config.AllowActivities{
+			TransmitEids: activity,
+		},
+	}
+}
+
 func TestApplyBidAdjustmentToFloor(t *testing.T) {
 	type args struct {
 		bidRequestWrapper    *openrtb_ext.RequestWrapper
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"slices"
+	"time"
 
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v4/config"
@@ -27,7 +29,7 @@ func Enforce(bidRequestWrapper *openrtb_ext.RequestWrapper, seatBids map[openrtb
 		return seatBids, nil, rejectedBids
 	}
 
-	if isSignalingSkipped(requestExt) || !isValidImpBidFloorPresent(bidRequestWrapper.BidRequest.Imp) {
+	if isSignalingSkipped(requestExt) || !(isValidImpBidFloorPresent(bidRequestWrapper.BidRequest.Imp) || hasBidLevelDimensions(requestExt)) {
 		return seatBids, nil, rejectedBids
 	}
 
@@ -39,9 +41,10 @@ func Enforce(bidRequestWrapper *openrtb_ext.RequestWrapper, seatBids map[openrtb
 		}
 	}
 	updateBidExt(bidRequestWrapper, seatBids)
+	bidFloors := updateBidLevelFloors(bidRequestWrapper, seatBids, conversions, ruleKeyTime(account))
 	if enforceFloors {
 		enforceDealFloors := account.PriceFloors.EnforceDealFloors && getEnforceDealsFlag(requestExt)
-		seatBids, rejectionErrs, rejectedBids = enforceFloorToBids(bidRequestWrapper, seatBids, conversions, enforceDealFloors)
+		seatBids, rejectionErrs, rejectedBids = enforceFloorToBids(bidRequestWrapper, seatBids, conversions, enforceDealFloors, bidFloors)
 	}
 	return seatBids, rejectionErrs, rejectedBids
 }
@@ -98,9 +101,78 @@ func updateBidExt(bidRequestWrapper *openrtb_ext.RequestWrapper, seatBids map[op
 	}
 }
 
+// hasBidLevelDimensions checks if the schema of the floors in request has dimensions which are only known once the
+// bids are received
+func hasBidLevelDimensions(requestExt *openrtb_ext.RequestExt) bool {
+	floorsExt := getFloorsExt(requestExt)
+	if floorsExt == nil || floorsExt.Data == nil || len(floorsExt.Data.ModelGroups) == 0 {
+		return false
+	}
+	fields := floorsExt.Data.ModelGroups[0].Schema.Fields
+	return slices.Contains(fields, Bidder) || slices.Contains(fields, Deal)
+}
+
+// updateBidLevelFloors looks up the floor of each bid with the bidder and deal of the bid when the schema of the floors
+// in request has these dimensions, updates the floors details of the bids and returns the floors found
+func updateBidLevelFloors(bidRequestWrapper *openrtb_ext.RequestWrapper, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, conversions currency.Conversions, now time.Time) map[*entities.PbsOrtbBid]*openrtb_ext.ExtBidPrebidFloors {
+	requestExt, err := bidRequestWrapper.GetRequestExt()
+	if err != nil || !hasBidLevelDimensions(requestExt) {
+		return nil
+	}
+	floorsExt := getFloorsExt(requestExt)
+	modelGroup := floorsExt.Data.ModelGroups[0]
+	delimiter := modelGroup.Schema.Delimiter
+	if delimiter == "" {
+		delimiter = defaultDelimiter
+	}
+
+	impMap := make(map[string]*openrtb_ext.ImpWrapper, bidRequestWrapper.LenImp())
+	for _, imp := range bidRequestWrapper.GetImp() {
+		impMap[imp.ID] = imp
+	}
+
+	bidFloors := make(map[*entities.PbsOrtbBid]*openrtb_ext.ExtBidPrebidFloors)
+	for bidderName, seatBid := range seatBids {
+		for _, bid := range seatBid.Bids {
+			reqImp, ok := impMap[bid.Bid.ImpID]
+			if !ok {
+				continue
+			}
+
+			desiredRuleKey := createRuleKey(modelGroup.Schema, bidRequestWrapper, reqImp, ruleKeyContext{bidder: bidderName.String(), bid: bid.Bid, now: now})
+			matchedRule, isRuleMatched := findRule(modelGroup.Values, delimiter, desiredRuleKey)
+			if !isRuleMatched && modelGroup.Default == 0.0 {
+				continue
+			}
+			// a matched rule of 0 is a 0 floor for the bid, not the floor of the imp
+			floorRuleVal := modelGroup.Default
+			if isRuleMatched {
+				floorRuleVal = modelGroup.Values[matchedRule]
+			}
+
+			floorMinVal, floorCur, err := getMinFloorValue(floorsExt, reqImp, conversions)
+			if err != nil {
+				continue
+			}
+			floorRuleVal = roundToFourDecimals(floorRuleVal)
+			floorVal := max(floorRuleVal, floorMinVal)
+
+			bid.BidFloors = &openrtb_ext.ExtBidPrebidFloors{
+				FloorRule:      matchedRule,
+				FloorRuleValue: floorRuleVal,
+				FloorValue:     floorVal,
+				FloorCurrency:  floorCur,
+			}
+			bidFloors[bid] = bid.BidFloors
+		}
+	}
+	return bidFloors
+}
+
 // enforceFloorToBids function does floors enforcement for each bid,
-// The bids returned by each partner below bid floor price are rejected and remaining eligible bids are considered for further processing
-func enforceFloorToBids(bidRequestWrapper *openrtb_ext.RequestWrapper, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, conversions currency.Conversions, enforceDealFloors bool) (map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, []error, []*entities.PbsOrtbSeatBid) {
+// The bids returned by each partner below bid floor price are rejected and remaining eligible bids are considered for further processing.
+// The floor of a bid is taken from bidFloors if present, else from the impression
+func enforceFloorToBids(bidRequestWrapper *openrtb_ext.RequestWrapper, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, conversions currency.Conversions, enforceDealFloors bool, bidFloors map[*entities.PbsOrtbBid]*openrtb_ext.ExtBidPrebidFloors) (map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, []error, []*entities.PbsOrtbSeatBid) {
 	errs := []error{}
 	rejectedBids := []*entities.PbsOrtbSeatBid{}
 	impMap := make(map[string]*openrtb_ext.ImpWrapper, bidRequestWrapper.LenImp())
@@ -130,14 +202,19 @@ func enforceFloorToBids(bidRequestWrapper *openrtb_ext.RequestWrapper, seatBids
 					continue
 				}
 
-				rate, err := getCurrencyConversionRate(seatBid.Currency, reqImp.BidFloorCur, conversions)
+				bidFloor, bidFloorCur := reqImp.BidFloor, reqImp.BidFloorCur
+				if floor, ok := bidFloors[bid]; ok {
+					bidFloor, bidFloorCur = floor.FloorValue, floor.FloorCurrency
+				}
+
+				rate, err := getCurrencyConversionRate(seatBid.Currency, bidFloorCur, conversions)
 				if err != nil {
-					errs = append(errs, fmt.Errorf("error in rate conversion from = %s to %s with bidder %s for impression id %s and bid id %s error = %v", seatBid.Currency, reqImp.BidFloorCur, bidderName, bid.Bid.ImpID, bid.Bid.ID, err.Error()))
+					errs = append(errs, fmt.Errorf("error in rate conversion from = %s to %s with bidder %s for impression id %s and bid id %s error = %v", seatBid.Currency, bidFloorCur, bidderName, bid.Bid.ImpID, bid.Bid.ID, err.Error()))
 					continue
 				}
 
 				bidPrice := rate * bid.Bid.Price
-				if (bidPrice + floorPrecision) < reqImp.BidFloor {
+				if (bidPrice + floorPrecision) < bidFloor {
 					rejectedBid := &entities.PbsOrtbSeatBid{
 						Currency: seatBid.Currency,
 						Seat:     seatBid.Seat,
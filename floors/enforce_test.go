@@ -399,7 +399,7 @@ func TestEnforceFloorToBids(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		seatbids, errs, rejBids := enforceFloorToBids(tt.args.bidRequestWrapper, tt.args.seatBids, tt.args.conversions, tt.args.enforceDealFloors)
+		seatbids, errs, rejBids := enforceFloorToBids(tt.args.bidRequestWrapper, tt.args.seatBids, tt.args.conversions, tt.args.enforceDealFloors, nil)
 		assert.Equal(t, tt.expEligibleBids, seatbids, tt.name)
 		assert.Equal(t, tt.expErrs, errs, tt.name)
 		assert.Equal(t, tt.expRejectedBids, rejBids, tt.name)
@@ -720,6 +720,118 @@ func TestEnforce(t *testing.T) {
 			},
 			expErrs: []error{},
 		},
+		{
+			name: "Should enforce bidder and deal specific floors to bids",
+			args: args{
+				bidRequestWrapper: &openrtb_ext.RequestWrapper{
+					BidRequest: &openrtb2.BidRequest{
+						ID: "some-request-id",
+						Imp: []openrtb2.Imp{{
+							ID:     "some-impression-id-1",
+							Banner: &openrtb2.Banner{Format: []openrtb2.Format{{W: 300, H: 250}}},
+						}},
+						Ext: json.RawMessage(`{"prebid":{"floors":{"data":{"currency":"USD","modelgroups":[{"modelversion":"version1","schema":{"fields":["bidder","deal"],"delimiter":"|"},"values":{"pubmatic|false":2,"appnexus|*":5}}]},"enforcement":{"enforcepbs":true,"floordeals":true},"skipped":false}}}`),
+					},
+				},
+				seatBids: map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+					"pubmatic": {
+						Bids: []*entities.PbsOrtbBid{
+							{Bid: &openrtb2.Bid{ID: "some-bid-1", Price: 3, ImpID: "some-impression-id-1"}},
+						},
+						Seat:     "pubmatic",
+						Currency: "USD",
+					},
+					"appnexus": {
+						Bids: []*entities.PbsOrtbBid{
+							{Bid: &openrtb2.Bid{ID: "some-bid-11", Price: 4.5, DealID: "deal_Id_1", ImpID: "some-impression-id-1"}},
+						},
+						Seat:     "appnexus",
+						Currency: "USD",
+					},
+				},
+				conversions:    convert{},
+				priceFloorsCfg: config.AccountPriceFloors{Enabled: true, EnforceFloorsRate: 100, EnforceDealFloors: true},
+			},
+			expEligibleBids: map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+				"pubmatic": {
+					Bids: []*entities.PbsOrtbBid{
+						{Bid: &openrtb2.Bid{ID: "some-bid-1", Price: 3, ImpID: "some-impression-id-1"}, BidFloors: &openrtb_ext.ExtBidPrebidFloors{FloorRule: "pubmatic|false", FloorRuleValue: 2, FloorValue: 2, FloorCurrency: "USD"}},
+					},
+					Seat:     "pubmatic",
+					Currency: "USD",
+				},
+				"appnexus": {
+					Bids:     []*entities.PbsOrtbBid{},
+					Seat:     "appnexus",
+					Currency: "USD",
+				},
+			},
+			expRejectedBids: []*entities.PbsOrtbSeatBid{
+				{
+					Seat:     "appnexus",
+					Currency: "USD",
+					Bids:     []*entities.PbsOrtbBid{{Bid: &openrtb2.Bid{ID: "some-bid-11", Price: 4.5, DealID: "deal_Id_1", ImpID: "some-impression-id-1"}, BidFloors: &openrtb_ext.ExtBidPrebidFloors{FloorRule: "appnexus|*", FloorRuleValue: 5, FloorValue: 5, FloorCurrency: "USD"}}},
+				},
+			},
+			expErrs: []error{},
+		},
+		{
+			name: "Should enforce a bidder specific floor of 0 rather than the floor of the impression",
+			args: args{
+				bidRequestWrapper: &openrtb_ext.RequestWrapper{
+					BidRequest: &openrtb2.BidRequest{
+						ID: "some-request-id",
+						Imp: []openrtb2.Imp{{
+							ID:          "some-impression-id-1",
+							Banner:      &openrtb2.Banner{Format: []openrtb2.Format{{W: 300, H: 250}}},
+							BidFloor:    3,
+							BidFloorCur: "USD",
+						}},
+						Ext: json.RawMessage(`{"prebid":{"floors":{"data":{"currency":"USD","modelgroups":[{"modelversion":"version1","schema":{"fields":["bidder"],"delimiter":"|"},"values":{"appnexus":0,"*":3}}]},"enforcement":{"enforcepbs":true},"skipped":false}}}`),
+					},
+				},
+				seatBids: map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+					"pubmatic": {
+						Bids: []*entities.PbsOrtbBid{
+							{Bid: &openrtb2.Bid{ID: "some-bid-1", Price: 2, ImpID: "some-impression-id-1"}},
+						},
+						Seat:     "pubmatic",
+						Currency: "USD",
+					},
+					"appnexus": {
+						Bids: []*entities.PbsOrtbBid{
+							{Bid: &openrtb2.Bid{ID: "some-bid-11", Price: 1, ImpID: "some-impression-id-1"}},
+						},
+						Seat:     "appnexus",
+						Currency: "USD",
+					},
+				},
+				conversions:    convert{},
+				priceFloorsCfg: config.AccountPriceFloors{Enabled: true, EnforceFloorsRate: 100},
+			},
+			expEligibleBids: map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+				"pubmatic": {
+					Bids:     []*entities.PbsOrtbBid{},
+					Seat:     "pubmatic",
+					Currency: "USD",
+				},
+				"appnexus": {
+					Bids: []*entities.PbsOrtbBid{
+						{Bid: &openrtb2.Bid{ID: "some-bid-11", Price: 1, ImpID: "some-impression-id-1"}, BidFloors: &openrtb_ext.ExtBidPrebidFloors{FloorRule: "appnexus", FloorRuleValue: 0, FloorValue: 0, FloorCurrency: "USD"}},
+					},
+					Seat:     "appnexus",
+					Currency: "USD",
+				},
+			},
+			expRejectedBids: []*entities.PbsOrtbSeatBid{
+				{
+					Seat:     "pubmatic",
+					Currency: "USD",
+					Bids:     []*entities.PbsOrtbBid{{Bid: &openrtb2.Bid{ID: "some-bid-1", Price: 2, ImpID: "some-impression-id-1"}, BidFloors: &openrtb_ext.ExtBidPrebidFloors{FloorRule: "*", FloorRuleValue: 3, FloorValue: 3, FloorCurrency: "USD"}}},
+				},
+			},
+			expErrs: []error{},
+		},
 	}
 	for _, tt := range tests {
 		actEligibleBids, actErrs, actRejecteBids := Enforce(tt.args.bidRequestWrapper, tt.args.seatBids, config.Account{PriceFloors: tt.args.priceFloorsCfg}, tt.args.conversions)
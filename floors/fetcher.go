@@ -1,14 +1,18 @@
 package floors
 
 import (
+	"compress/gzip"
 	"container/heap"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"math"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/alitto/pond"
@@ -23,11 +27,21 @@ import (
 
 var refetchCheckInterval = 300
 
+var errFloorsNotModified = errors.New("floor data not modified")
+
 type fetchInfo struct {
 	config.AccountFloorFetch
 	fetchTime      int64
 	refetchRequest bool
 	retryCount     int
+	validators     fetchValidators
+}
+
+// fetchValidators are the validators of the floor data last fetched from a URL, sent on refetch so the server
+// can respond that the data didn't change
+type fetchValidators struct {
+	etag         string
+	lastModified string
 }
 
 type WorkerPool interface {
@@ -153,22 +167,30 @@ func (f *PriceFloorFetcher) Fetch(config config.AccountPriceFloors) (*openrtb_ex
 }
 
 func (f *PriceFloorFetcher) worker(fetchConfig fetchInfo) {
-	floorData, fetchedMaxAge := f.fetchAndValidate(fetchConfig.AccountFloorFetch)
+	floorData, fetchedMaxAge, validators, status := f.fetchAndValidate(fetchConfig.AccountFloorFetch, fetchConfig.validators)
+	if status == metrics.FloorsFetchNotModified {
+		if err := f.cache.Touch([]byte(fetchConfig.AccountFloorFetch.URL), cacheExpiry(fetchConfig.AccountFloorFetch, fetchedMaxAge)); err != nil {
+			// The cached floor data is gone, so fetch it again without the validators
+			floorData, fetchedMaxAge, validators, status = f.fetchAndValidate(fetchConfig.AccountFloorFetch, fetchValidators{})
+		}
+	}
+	f.metricEngine.RecordFloorsFetch(fetchConfig.AccountFloorFetch.AccountID, status)
+
 	if floorData != nil {
 		// Reset retry count when data is successfully fetched
 		fetchConfig.retryCount = 0
+		fetchConfig.validators = validators
 
 		// Update cache with new floor rules
-		cacheExpiry := fetchConfig.AccountFloorFetch.MaxAge
-		if fetchedMaxAge != 0 {
-			cacheExpiry = fetchedMaxAge
-		}
 		floorData, err := json.Marshal(floorData)
 		if err != nil {
 			logger.Errorf("Error while marshaling fetched floor data for url %s", fetchConfig.AccountFloorFetch.URL)
 		} else {
-			f.SetWithExpiry(fetchConfig.AccountFloorFetch.URL, floorData, cacheExpiry)
+			f.SetWithExpiry(fetchConfig.AccountFloorFetch.URL, floorData, cacheExpiry(fetchConfig.AccountFloorFetch, fetchedMaxAge))
 		}
+	} else if status == metrics.FloorsFetchNotModified {
+		fetchConfig.retryCount = 0
+		fetchConfig.validators = validators
 	} else {
 		fetchConfig.retryCount++
 	}
@@ -181,6 +203,14 @@ func (f *PriceFloorFetcher) worker(fetchConfig fetchInfo) {
 	}
 }
 
+// cacheExpiry returns the max age of the fetched floor data if the server provided it, else the max age configured
+func cacheExpiry(config config.AccountFloorFetch, fetchedMaxAge int) int {
+	if fetchedMaxAge != 0 {
+		return fetchedMaxAge
+	}
+	return config.MaxAge
+}
+
 // Stop terminates price floor fetcher
 func (f *PriceFloorFetcher) Stop() {
 	if f == nil {
@@ -230,46 +260,66 @@ func (f *PriceFloorFetcher) Fetcher() {
 	}
 }
 
-func (f *PriceFloorFetcher) fetchAndValidate(config config.AccountFloorFetch) (*openrtb_ext.PriceFloorRules, int) {
-	floorResp, maxAge, err := f.fetchFloorRulesFromURL(config)
+func (f *PriceFloorFetcher) fetchAndValidate(config config.AccountFloorFetch, validators fetchValidators) (*openrtb_ext.PriceFloorRules, int, fetchValidators, metrics.FloorsFetchStatus) {
+	floorResp, maxAge, validators, err := f.fetchFloorRulesFromURL(config, validators)
+	if errors.Is(err, errFloorsNotModified) {
+		return nil, maxAge, validators, metrics.FloorsFetchNotModified
+	}
 	if floorResp == nil || err != nil {
 		logger.Errorf("Error while fetching floor data from URL: %s, reason : %s", config.URL, err.Error())
-		return nil, 0
+		if isTimeout(err) {
+			return nil, 0, validators, metrics.FloorsFetchTimeout
+		}
+		return nil, 0, validators, metrics.FloorsFetchError
 	}
 
 	if len(floorResp) > (config.MaxFileSizeKB * 1024) {
 		logger.Errorf("Received invalid floor data from URL: %s, reason : floor file size is greater than MaxFileSize", config.URL)
-		return nil, 0
+		return nil, 0, validators, metrics.FloorsFetchTooLarge
 	}
 
 	var priceFloors openrtb_ext.PriceFloorRules
 	if err = json.Unmarshal(floorResp, &priceFloors.Data); err != nil {
 		logger.Errorf("Received invalid price floor json from URL: %s", config.URL)
-		return nil, 0
+		return nil, 0, validators, metrics.FloorsFetchInvalid
 	}
 
 	if err := validateRules(config, &priceFloors); err != nil {
 		logger.Errorf("Validation failed for floor JSON from URL: %s, reason: %s", config.URL, err.Error())
-		return nil, 0
+		return nil, 0, validators, metrics.FloorsFetchInvalid
 	}
 
-	return &priceFloors, maxAge
+	return &priceFloors, maxAge, validators, metrics.FloorsFetchSuccess
+}
+
+// isTimeout checks if the fetch failed because the timeout expired
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
 }
 
-// fetchFloorRulesFromURL returns a price floor JSON and time for which this JSON is valid
-// from provided URL with timeout constraints
-func (f *PriceFloorFetcher) fetchFloorRulesFromURL(config config.AccountFloorFetch) ([]byte, int, error) {
+// fetchFloorRulesFromURL returns a price floor JSON, time for which this JSON is valid and its validators
+// from provided URL with timeout constraints. It returns errFloorsNotModified if the server responds that the
+// JSON with the validators provided didn't change.
+func (f *PriceFloorFetcher) fetchFloorRulesFromURL(config config.AccountFloorFetch, validators fetchValidators) ([]byte, int, fetchValidators, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(config.Timeout)*time.Millisecond)
 	defer cancel()
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, config.URL, nil)
 	if err != nil {
-		return nil, 0, errors.New("error while forming http fetch request : " + err.Error())
+		return nil, 0, validators, errors.New("error while forming http fetch request : " + err.Error())
+	}
+	httpReq.Header.Set("Accept-Encoding", "gzip")
+	if validators.etag != "" {
+		httpReq.Header.Set("If-None-Match", validators.etag)
+	}
+	if validators.lastModified != "" {
+		httpReq.Header.Set("If-Modified-Since", validators.lastModified)
 	}
 
 	httpResp, err := f.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, 0, errors.New("error while getting response from url : " + err.Error())
+		return nil, 0, validators, fmt.Errorf("error while getting response from url : %w", err)
 	}
 	defer func() {
 		// read the entire response body to ensure full connection reuse if there's an
@@ -280,27 +330,64 @@ func (f *PriceFloorFetcher) fetchFloorRulesFromURL(config config.AccountFloorFet
 		httpResp.Body.Close()
 	}()
 
+	if httpResp.StatusCode == http.StatusNotModified {
+		return nil, getMaxAge(httpResp.Header, config), validators, errFloorsNotModified
+	}
+
 	if httpResp.StatusCode != http.StatusOK {
-		return nil, 0, errors.New("no response from server")
+		return nil, 0, validators, errors.New("no response from server")
 	}
 
-	var maxAge int
-	if maxAgeStr := httpResp.Header.Get("max-age"); maxAgeStr != "" {
-		maxAge, err = strconv.Atoi(maxAgeStr)
+	maxAge := getMaxAge(httpResp.Header, config)
+
+	body := io.Reader(httpResp.Body)
+	if httpResp.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(httpResp.Body)
 		if err != nil {
-			logger.Errorf("max-age in header is malformed for url %s", config.URL)
-		}
-		if maxAge <= config.Period || maxAge > math.MaxInt32 {
-			logger.Errorf("Invalid max-age = %s provided, value should be valid integer and should be within (%v, %v)", maxAgeStr, config.Period, math.MaxInt32)
+			return nil, 0, validators, errors.New("unable to read gzip response : " + err.Error())
 		}
+		defer gzipReader.Close()
+		body = gzipReader
+	}
+	if config.MaxFileSizeKB > 0 {
+		// read one byte more than allowed so files that are too large are detected without decompressing them entirely
+		body = io.LimitReader(body, int64(config.MaxFileSizeKB)*1024+1)
 	}
 
-	respBody, err := io.ReadAll(httpResp.Body)
+	respBody, err := io.ReadAll(body)
 	if err != nil {
-		return nil, 0, errors.New("unable to read response")
+		return nil, 0, validators, fmt.Errorf("unable to read response : %w", err)
+	}
+
+	validators = fetchValidators{
+		etag:         httpResp.Header.Get("ETag"),
+		lastModified: httpResp.Header.Get("Last-Modified"),
+	}
+	return respBody, maxAge, validators, nil
+}
+
+// getMaxAge returns the max age of the fetched floor data from the max-age directive of the Cache-Control header,
+// else from the max-age header
+func getMaxAge(header http.Header, config config.AccountFloorFetch) int {
+	maxAgeStr := header.Get("max-age")
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		if value, found := strings.CutPrefix(strings.TrimSpace(directive), "max-age="); found {
+			maxAgeStr = value
+			break
+		}
+	}
+	if maxAgeStr == "" {
+		return 0
 	}
 
-	return respBody, maxAge, nil
+	maxAge, err := strconv.Atoi(maxAgeStr)
+	if err != nil {
+		logger.Errorf("max-age in header is malformed for url %s", config.URL)
+	}
+	if maxAge <= config.Period || maxAge > math.MaxInt32 {
+		logger.Errorf("Invalid max-age = %s provided, value should be valid integer and should be within (%v, %v)", maxAgeStr, config.Period, math.MaxInt32)
+	}
+	return maxAge
 }
 
 func validateRules(config config.AccountFloorFetch, priceFloors *openrtb_ext.PriceFloorRules) error {
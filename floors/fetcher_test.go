@@ -1,6 +1,7 @@
 package floors
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -541,7 +542,7 @@ func TestFetchFloorRulesFromURL(t *testing.T) {
 			pff := PriceFloorFetcher{
 				httpClient: mockHttpServer.Client(),
 			}
-			got, got1, err := pff.fetchFloorRulesFromURL(tt.args.configs)
+			got, got1, _, err := pff.fetchFloorRulesFromURL(tt.args.configs, fetchValidators{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("fetchFloorRulesFromURL() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -612,7 +613,7 @@ func TestFetchFloorRulesFromURLInvalidMaxAge(t *testing.T) {
 			ppf := PriceFloorFetcher{
 				httpClient: mockHttpServer.Client(),
 			}
-			got, got1, err := ppf.fetchFloorRulesFromURL(tt.args.configs)
+			got, got1, _, err := ppf.fetchFloorRulesFromURL(tt.args.configs, fetchValidators{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("fetchFloorRulesFromURL() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -627,6 +628,118 @@ func TestFetchFloorRulesFromURLInvalidMaxAge(t *testing.T) {
 	}
 }
 
+func TestFetchFloorRulesFromURLConditional(t *testing.T) {
+	data := []byte(`{"currency":"USD","modelgroups":[{"values":{"*|*|*":16},"schema":{"fields":["mediaType","size","domain"],"delimiter":"|"}}]}`)
+	lastModified := "Mon, 04 Mar 2024 18:30:00 GMT"
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=600")
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", lastModified)
+		if r.Header.Get("Accept-Encoding") == "gzip" {
+			w.Header().Set("Content-Encoding", "gzip")
+			gzipWriter := gzip.NewWriter(w)
+			gzipWriter.Write(data)
+			gzipWriter.Close()
+			return
+		}
+		w.Write(data)
+	})
+
+	tests := []struct {
+		name           string
+		validators     fetchValidators
+		want           []byte
+		wantMaxAge     int
+		wantValidators fetchValidators
+		wantErr        error
+	}{
+		{
+			name:           "gzip_response_with_validators",
+			want:           data,
+			wantMaxAge:     600,
+			wantValidators: fetchValidators{etag: `"v1"`, lastModified: lastModified},
+		},
+		{
+			name:           "not_modified",
+			validators:     fetchValidators{etag: `"v1"`, lastModified: lastModified},
+			wantMaxAge:     600,
+			wantValidators: fetchValidators{etag: `"v1"`, lastModified: lastModified},
+			wantErr:        errFloorsNotModified,
+		},
+		{
+			name:           "modified",
+			validators:     fetchValidators{etag: `"v0"`, lastModified: lastModified},
+			want:           data,
+			wantMaxAge:     600,
+			wantValidators: fetchValidators{etag: `"v1"`, lastModified: lastModified},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHttpServer := httptest.NewServer(mockHandler)
+			defer mockHttpServer.Close()
+
+			pff := PriceFloorFetcher{
+				httpClient: mockHttpServer.Client(),
+			}
+			configs := config.AccountFloorFetch{URL: mockHttpServer.URL, Timeout: 100, Period: 300, MaxFileSizeKB: 10}
+			got, gotMaxAge, gotValidators, err := pff.fetchFloorRulesFromURL(configs, tt.validators)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantMaxAge, gotMaxAge)
+			assert.Equal(t, tt.wantValidators, gotValidators)
+		})
+	}
+}
+
+func TestGetMaxAge(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   int
+	}{
+		{
+			name:   "no_max_age",
+			header: http.Header{},
+			want:   0,
+		},
+		{
+			name:   "max_age_header",
+			header: http.Header{"Max-Age": []string{"700"}},
+			want:   700,
+		},
+		{
+			name:   "cache_control_max_age",
+			header: http.Header{"Cache-Control": []string{"no-transform, max-age=800"}},
+			want:   800,
+		},
+		{
+			name:   "cache_control_preferred_over_max_age_header",
+			header: http.Header{"Cache-Control": []string{"max-age=800"}, "Max-Age": []string{"700"}},
+			want:   800,
+		},
+		{
+			name:   "cache_control_without_max_age",
+			header: http.Header{"Cache-Control": []string{"no-cache"}, "Max-Age": []string{"700"}},
+			want:   700,
+		},
+		{
+			name:   "malformed_cache_control_max_age",
+			header: http.Header{"Cache-Control": []string{"max-age=abc"}},
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getMaxAge(tt.header, config.AccountFloorFetch{Period: 300}))
+		})
+	}
+}
+
 func TestFetchAndValidate(t *testing.T) {
 	mockHandler := func(mockResponse []byte, mockStatus int) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -646,6 +759,7 @@ func TestFetchAndValidate(t *testing.T) {
 		responseStatus int
 		want           *openrtb_ext.PriceFloorRules
 		want1          int
+		wantStatus     metrics.FloorsFetchStatus
 	}{
 		{
 			name: "Recieved valid price floor rules response",
@@ -670,7 +784,8 @@ func TestFetchAndValidate(t *testing.T) {
 				_ = json.Unmarshal([]byte(data), &res.Data)
 				return &res
 			}(),
-			want1: 30,
+			want1:      30,
+			wantStatus: metrics.FloorsFetchSuccess,
 		},
 		{
 			name: "No response from server",
@@ -688,6 +803,7 @@ func TestFetchAndValidate(t *testing.T) {
 			responseStatus: 500,
 			want:           nil,
 			want1:          0,
+			wantStatus:     metrics.FloorsFetchError,
 		},
 		{
 			name: "File is greater than MaxFileSize",
@@ -708,6 +824,7 @@ func TestFetchAndValidate(t *testing.T) {
 			responseStatus: 200,
 			want:           nil,
 			want1:          0,
+			wantStatus:     metrics.FloorsFetchTooLarge,
 		},
 		{
 			name: "Malformed response : json unmarshalling failed",
@@ -728,6 +845,7 @@ func TestFetchAndValidate(t *testing.T) {
 			responseStatus: 200,
 			want:           nil,
 			want1:          0,
+			wantStatus:     metrics.FloorsFetchInvalid,
 		},
 		{
 			name: "Validations failed for price floor rules response",
@@ -748,6 +866,7 @@ func TestFetchAndValidate(t *testing.T) {
 			responseStatus: 200,
 			want:           nil,
 			want1:          0,
+			wantStatus:     metrics.FloorsFetchInvalid,
 		},
 	}
 	for _, tt := range tests {
@@ -758,13 +877,14 @@ func TestFetchAndValidate(t *testing.T) {
 				httpClient: mockHttpServer.Client(),
 			}
 			tt.args.configs.URL = mockHttpServer.URL
-			got, got1 := ppf.fetchAndValidate(tt.args.configs)
+			got, got1, _, gotStatus := ppf.fetchAndValidate(tt.args.configs, fetchValidators{})
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("fetchAndValidate() got = %v, want %v", got, tt.want)
 			}
 			if got1 != tt.want1 {
 				t.Errorf("fetchAndValidate() got1 = %v, want %v", got1, tt.want1)
 			}
+			assert.Equal(t, tt.wantStatus, gotStatus)
 		})
 	}
 }
@@ -1034,6 +1154,87 @@ func TestPriceFloorFetcherWorker(t *testing.T) {
 
 }
 
+func TestPriceFloorFetcherWorkerNotModified(t *testing.T) {
+	response := []byte(`{"currency":"USD","modelgroups":[{"values":{"*|*|*":16},"schema":{"fields":["mediaType","size","domain"],"delimiter":"|"}}]}`)
+	var floorData openrtb_ext.PriceFloorData
+	_ = json.Unmarshal(response, &floorData)
+
+	mockHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write(response)
+	})
+
+	tests := []struct {
+		name       string
+		cached     bool
+		wantStatus metrics.FloorsFetchStatus
+	}{
+		{
+			name:       "cached_data_kept",
+			cached:     true,
+			wantStatus: metrics.FloorsFetchNotModified,
+		},
+		{
+			name:       "evicted_data_fetched_again",
+			cached:     false,
+			wantStatus: metrics.FloorsFetchSuccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHttpServer := httptest.NewServer(mockHandler)
+			defer mockHttpServer.Close()
+
+			metricEngine := &metrics.MetricsEngineMock{}
+			metricEngine.On("RecordFloorsFetch", "some-account", tt.wantStatus).Return()
+
+			fetcherInstance := PriceFloorFetcher{
+				configReceiver: make(chan fetchInfo, 1),
+				cache:          freecache.NewCache(1 * 1024 * 1024),
+				httpClient:     mockHttpServer.Client(),
+				time:           &timeutil.RealTime{},
+				metricEngine:   metricEngine,
+				maxRetries:     5,
+			}
+			defer close(fetcherInstance.configReceiver)
+
+			cachedData, _ := json.Marshal(&openrtb_ext.PriceFloorRules{Data: &floorData})
+			if tt.cached {
+				fetcherInstance.SetWithExpiry(mockHttpServer.URL, cachedData, 5)
+			}
+
+			fetchConfig := fetchInfo{
+				AccountFloorFetch: config.AccountFloorFetch{
+					Enabled:       true,
+					URL:           mockHttpServer.URL,
+					Timeout:       100,
+					MaxFileSizeKB: 1000,
+					MaxRules:      100,
+					MaxAge:        20,
+					Period:        1,
+					AccountID:     "some-account",
+				},
+				retryCount: 2,
+				validators: fetchValidators{etag: `"v1"`},
+			}
+			fetcherInstance.worker(fetchConfig)
+
+			dataInCache, found := fetcherInstance.Get(mockHttpServer.URL)
+			assert.True(t, found)
+			assert.JSONEq(t, string(cachedData), string(dataInCache))
+			metricEngine.AssertExpectations(t)
+
+			info := <-fetcherInstance.configReceiver
+			assert.Equal(t, 0, info.retryCount)
+			assert.Equal(t, fetchValidators{etag: `"v1"`}, info.validators)
+		})
+	}
+}
+
 func TestPriceFloorFetcherWorkerRetry(t *testing.T) {
 	mockHandler := func(mockResponse []byte, mockStatus int) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -5,6 +5,7 @@ import (
 	"math"
 	"math/rand"
 	"strings"
+	"time"
 
 	"github.com/prebid/prebid-server/v4/config"
 	"github.com/prebid/prebid-server/v4/currency"
@@ -32,9 +33,10 @@ const (
 	dataRateMax      int     = 100
 )
 
-// EnrichWithPriceFloors checks for floors enabled in account and request and selects floors data from dynamic fetched if present
-// else selects floors data from req.ext.prebid.floors and update request with selected floors details
-func EnrichWithPriceFloors(bidRequestWrapper *openrtb_ext.RequestWrapper, account config.Account, conversions currency.Conversions, priceFloorFetcher FloorFetcher) []error {
+// EnrichWithPriceFloors checks for floors enabled in account and request and selects floors data from dynamic fetched if present,
+// else from the floor optimizer if enabled for the account, else selects floors data from req.ext.prebid.floors and update request
+// with selected floors details
+func EnrichWithPriceFloors(bidRequestWrapper *openrtb_ext.RequestWrapper, account config.Account, conversions currency.Conversions, priceFloorFetcher FloorFetcher, floorOptimizer FloorOptimizer) []error {
 	if bidRequestWrapper == nil || bidRequestWrapper.BidRequest == nil {
 		return []error{errors.New("Empty bidrequest")}
 	}
@@ -43,15 +45,15 @@ func EnrichWithPriceFloors(bidRequestWrapper *openrtb_ext.RequestWrapper, accoun
 		return []error{errors.New("Floors feature is disabled at account or in the request")}
 	}
 
-	floors, err := resolveFloors(account, bidRequestWrapper, conversions, priceFloorFetcher)
+	floors, err := resolveFloors(account, bidRequestWrapper, conversions, priceFloorFetcher, floorOptimizer)
 
-	updateReqErrs := updateBidRequestWithFloors(floors, bidRequestWrapper, conversions)
+	updateReqErrs := updateBidRequestWithFloors(floors, bidRequestWrapper, conversions, ruleKeyTime(account))
 	updateFloorsInRequest(bidRequestWrapper, floors)
 	return append(err, updateReqErrs...)
 }
 
 // updateBidRequestWithFloors will update imp.bidfloor and imp.bidfloorcur based on rules matching
-func updateBidRequestWithFloors(extFloorRules *openrtb_ext.PriceFloorRules, request *openrtb_ext.RequestWrapper, conversions currency.Conversions) []error {
+func updateBidRequestWithFloors(extFloorRules *openrtb_ext.PriceFloorRules, request *openrtb_ext.RequestWrapper, conversions currency.Conversions, now time.Time) []error {
 	var (
 		floorErrList []error
 		floorVal     float64
@@ -75,7 +77,7 @@ func updateBidRequestWithFloors(extFloorRules *openrtb_ext.PriceFloorRules, requ
 	floorErrList = validateFloorRulesAndLowerValidRuleKey(modelGroup.Schema, modelGroup.Schema.Delimiter, modelGroup.Values)
 	if len(modelGroup.Values) > 0 {
 		for _, imp := range request.GetImp() {
-			desiredRuleKey := createRuleKey(modelGroup.Schema, request, imp)
+			desiredRuleKey := createRuleKey(modelGroup.Schema, request, imp, ruleKeyContext{now: now})
 			matchedRule, isRuleMatched := findRule(modelGroup.Values, modelGroup.Schema.Delimiter, desiredRuleKey)
 			floorVal = modelGroup.Default
 			if isRuleMatched {
@@ -147,28 +149,41 @@ func useFetchedData(rate *int) bool {
 	return randomNumber < *rate
 }
 
-// resolveFloors does selection of floors fields from request data and dynamic fetched data if dynamic fetch is enabled
-func resolveFloors(account config.Account, bidRequestWrapper *openrtb_ext.RequestWrapper, conversions currency.Conversions, priceFloorFetcher FloorFetcher) (*openrtb_ext.PriceFloorRules, []error) {
+// resolveFloors does selection of floors fields from request data, dynamic fetched data if dynamic fetch is enabled and optimized
+// data if the floor optimizer is enabled
+func resolveFloors(account config.Account, bidRequestWrapper *openrtb_ext.RequestWrapper, conversions currency.Conversions, priceFloorFetcher FloorFetcher, floorOptimizer FloorOptimizer) (*openrtb_ext.PriceFloorRules, []error) {
 	var (
-		errList     []error
-		floorRules  *openrtb_ext.PriceFloorRules
-		fetchResult *openrtb_ext.PriceFloorRules
-		fetchStatus string
+		errList         []error
+		floorRules      *openrtb_ext.PriceFloorRules
+		fetchResult     *openrtb_ext.PriceFloorRules
+		fetchStatus     string
+		optimizedFloors *openrtb_ext.PriceFloorRules
 	)
 
 	reqFloor := extractFloorsFromRequest(bidRequestWrapper)
 	if reqFloor != nil && reqFloor.Location != nil && len(reqFloor.Location.URL) > 0 {
 		account.PriceFloors.Fetcher.URL = reqFloor.Location.URL
 	}
+	if reqFloor != nil && len(reqFloor.FloorsID) > 0 {
+		account.PriceFloors.Fetcher.FloorsID = reqFloor.FloorsID
+	}
 	account.PriceFloors.Fetcher.AccountID = account.ID
 
 	if priceFloorFetcher != nil && account.PriceFloors.UseDynamicData {
 		fetchResult, fetchStatus = priceFloorFetcher.Fetch(account.PriceFloors)
 	}
 
-	if fetchResult != nil && fetchStatus == openrtb_ext.FetchSuccess && useFetchedData(fetchResult.Data.UseFetchDataRate) {
+	useFetchResult := fetchResult != nil && fetchStatus == openrtb_ext.FetchSuccess && useFetchedData(fetchResult.Data.UseFetchDataRate)
+	if !useFetchResult && floorOptimizer != nil {
+		optimizedFloors = floorOptimizer.Floors(account)
+	}
+
+	if useFetchResult {
 		mergedFloor := mergeFloors(reqFloor, fetchResult, conversions)
 		floorRules, errList = createFloorsFrom(mergedFloor, account, fetchStatus, openrtb_ext.FetchLocation)
+	} else if optimizedFloors != nil {
+		mergedFloor := mergeFloors(reqFloor, optimizedFloors, conversions)
+		floorRules, errList = createFloorsFrom(mergedFloor, account, openrtb_ext.FetchNone, openrtb_ext.OptimizerLocation)
 	} else if reqFloor != nil {
 		floorRules, errList = createFloorsFrom(reqFloor, account, openrtb_ext.FetchNone, openrtb_ext.RequestLocation)
 	} else {
@@ -0,0 +1,319 @@
+package floors
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/currency"
+	"github.com/prebid/prebid-server/v4/exchange/entities"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/prebid/prebid-server/v4/util/ptrutil"
+)
+
+// Model versions of the model groups published by the optimizer
+const (
+	optimizerModelVersion        = "optimizer"
+	optimizerExploreModelVersion = "optimizer-explore"
+	optimizerHoldoutModelVersion = "optimizer-holdout"
+	optimizerFloorProvider       = "prebid-server"
+)
+
+const (
+	// optimizerWindowBuckets is the number of buckets of the sliding window of auctions. The floors are
+	// recomputed each time a bucket starts.
+	optimizerWindowBuckets = 12
+	// optimizerMaxRuleKeys limits the rule keys tracked in a bucket, to bound the memory used per account
+	optimizerMaxRuleKeys = 10000
+)
+
+// FloorOptimizer learns floors from the bids of the auctions of the accounts which enable it
+type FloorOptimizer interface {
+	// Floors returns the floors learned for the account, or nil if the account doesn't enable the optimizer
+	Floors(account config.Account) *openrtb_ext.PriceFloorRules
+	// Record adds the bids of an auction whose floors came from the optimizer to those the floors are learned from
+	Record(request *openrtb_ext.RequestWrapper, account config.Account, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, conversions currency.Conversions)
+}
+
+// Optimizer learns the floors of each rule key which maximise the revenue per auction, over a sliding window
+// of auctions. Most auctions use the best floors known, some explore the price points next to them, and a
+// holdout group uses no floors so the lift of the optimizer can be measured.
+type Optimizer struct {
+	lock       sync.Mutex
+	landscapes map[string]*floorLandscape
+	now        func() time.Time
+	randomInt  func(int) int
+}
+
+// floorLandscape is the bid landscape of the auctions of an account per rule key and floor
+type floorLandscape struct {
+	schema      string
+	buckets     []*landscapeBucket
+	model       *openrtb_ext.PriceFloorRules
+	modelExpiry time.Time
+}
+
+type landscapeBucket struct {
+	start time.Time
+	keys  map[string]map[float64]*floorStats
+}
+
+// floorStats aggregates the auctions which used a floor and the revenue of their winning bids
+type floorStats struct {
+	auctions int
+	revenue  float64
+}
+
+// NewOptimizer returns an optimizer with no auctions recorded
+func NewOptimizer() *Optimizer {
+	return &Optimizer{
+		landscapes: make(map[string]*floorLandscape),
+		now:        time.Now,
+		randomInt:  rand.Intn,
+	}
+}
+
+func (o *Optimizer) Floors(account config.Account) *openrtb_ext.PriceFloorRules {
+	cfg := account.PriceFloors.Optimizer
+	if !cfg.Enabled {
+		return nil
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	now := o.now()
+	landscape := o.landscape(account.ID, cfg)
+	if landscape.model == nil || !now.Before(landscape.modelExpiry) {
+		landscape.prune(now, cfg)
+		landscape.model = landscape.publish(now, cfg, account.PriceFloors.MaxRule, o.randomInt)
+		landscape.modelExpiry = now.Add(bucketDuration(cfg))
+	}
+	return landscape.model.DeepCopy()
+}
+
+func (o *Optimizer) Record(request *openrtb_ext.RequestWrapper, account config.Account, seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, conversions currency.Conversions) {
+	cfg := account.PriceFloors.Optimizer
+	if !cfg.Enabled {
+		return
+	}
+
+	requestExt, err := request.GetRequestExt()
+	if err != nil {
+		return
+	}
+	floors := getFloorsExt(requestExt)
+	if floors == nil || floors.PriceFloorLocation != openrtb_ext.OptimizerLocation || floors.Skipped == nil || *floors.Skipped ||
+		floors.Data == nil || len(floors.Data.ModelGroups) == 0 {
+		return
+	}
+	modelGroup := floors.Data.ModelGroups[0]
+	delimiter := modelGroup.Schema.Delimiter
+	if delimiter == "" {
+		delimiter = defaultDelimiter
+	}
+
+	revenues := winningBidRevenues(seatBids, getFloorCurrency(floors), conversions)
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	now := o.now()
+	ctx := ruleKeyContext{now: now.In(floorsLocation(account.PriceFloors.Timezone))}
+	landscape := o.landscape(account.ID, cfg)
+	bucket := landscape.bucket(now, cfg)
+	for _, imp := range request.GetImp() {
+		key := strings.ToLower(strings.Join(createRuleKey(modelGroup.Schema, request, imp, ctx), delimiter))
+		bucket.record(key, modelGroup.Values[key], revenues[imp.ID])
+	}
+}
+
+// landscape returns the landscape of the account, replacing it if the schema of the optimizer changed
+func (o *Optimizer) landscape(accountID string, cfg config.AccountFloorOptimizer) *floorLandscape {
+	schema := strings.Join(cfg.SchemaFields, defaultDelimiter)
+	landscape, ok := o.landscapes[accountID]
+	if !ok || landscape.schema != schema {
+		landscape = &floorLandscape{schema: schema}
+		o.landscapes[accountID] = landscape
+	}
+	return landscape
+}
+
+// winningBidRevenues returns the price of the highest bid of each imp in the currency of the floors
+func winningBidRevenues(seatBids map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid, floorCurrency string, conversions currency.Conversions) map[string]float64 {
+	revenues := make(map[string]float64)
+	for _, seatBid := range seatBids {
+		if seatBid == nil {
+			continue
+		}
+		bidCurrency := seatBid.Currency
+		if bidCurrency == "" {
+			bidCurrency = defaultCurrency
+		}
+		rate, err := getCurrencyConversionRate(bidCurrency, floorCurrency, conversions)
+		if err != nil {
+			continue
+		}
+		for _, bid := range seatBid.Bids {
+			if bid == nil || bid.Bid == nil {
+				continue
+			}
+			if price := bid.Bid.Price * rate; price > revenues[bid.Bid.ImpID] {
+				revenues[bid.Bid.ImpID] = price
+			}
+		}
+	}
+	return revenues
+}
+
+func bucketDuration(cfg config.AccountFloorOptimizer) time.Duration {
+	return time.Duration(cfg.WindowSec) * time.Second / optimizerWindowBuckets
+}
+
+// bucket returns the bucket of the window the time falls into, starting a new bucket if needed
+func (l *floorLandscape) bucket(now time.Time, cfg config.AccountFloorOptimizer) *landscapeBucket {
+	start := now.Truncate(bucketDuration(cfg))
+	if len(l.buckets) > 0 && l.buckets[len(l.buckets)-1].start.Equal(start) {
+		return l.buckets[len(l.buckets)-1]
+	}
+	l.prune(now, cfg)
+	bucket := &landscapeBucket{start: start, keys: make(map[string]map[float64]*floorStats)}
+	l.buckets = append(l.buckets, bucket)
+	return bucket
+}
+
+// prune drops the buckets which fell out of the window
+func (l *floorLandscape) prune(now time.Time, cfg config.AccountFloorOptimizer) {
+	windowStart := now.Add(-time.Duration(cfg.WindowSec) * time.Second)
+	i := 0
+	for i < len(l.buckets) && !l.buckets[i].start.After(windowStart) {
+		i++
+	}
+	l.buckets = l.buckets[i:]
+}
+
+func (b *landscapeBucket) record(key string, floor, revenue float64) {
+	floors, ok := b.keys[key]
+	if !ok {
+		if len(b.keys) >= optimizerMaxRuleKeys {
+			return
+		}
+		floors = make(map[float64]*floorStats)
+		b.keys[key] = floors
+	}
+	stats, ok := floors[floor]
+	if !ok {
+		stats = &floorStats{}
+		floors[floor] = stats
+	}
+	stats.auctions++
+	stats.revenue += revenue
+}
+
+// publish computes the model groups of the optimizer from the auctions of the window
+func (l *floorLandscape) publish(now time.Time, cfg config.AccountFloorOptimizer, maxRules int, randomInt func(int) int) *openrtb_ext.PriceFloorRules {
+	keys := make(map[string]map[float64]floorStats)
+	auctions := make(map[string]int)
+	for _, bucket := range l.buckets {
+		for key, floors := range bucket.keys {
+			if keys[key] == nil {
+				keys[key] = make(map[float64]floorStats)
+			}
+			for floor, stats := range floors {
+				total := keys[key][floor]
+				total.auctions += stats.auctions
+				total.revenue += stats.revenue
+				keys[key][floor] = total
+				auctions[key] += stats.auctions
+			}
+		}
+	}
+
+	ruleKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		ruleKeys = append(ruleKeys, key)
+	}
+	sort.Slice(ruleKeys, func(i, j int) bool {
+		if auctions[ruleKeys[i]] != auctions[ruleKeys[j]] {
+			return auctions[ruleKeys[i]] > auctions[ruleKeys[j]]
+		}
+		return ruleKeys[i] < ruleKeys[j]
+	})
+	if maxRules > 0 && len(ruleKeys) > maxRules {
+		ruleKeys = ruleKeys[:maxRules]
+	}
+
+	pricePoints := append([]float64{0}, cfg.PricePoints...)
+	optimised := make(map[string]float64)
+	explore := make(map[string]float64)
+	for _, key := range ruleKeys {
+		best := bestPricePoint(keys[key], pricePoints, cfg.MinAuctions)
+		if pricePoints[best] > 0 {
+			optimised[key] = pricePoints[best]
+		}
+
+		neighbour := best + 1
+		if best > 0 && (best == len(pricePoints)-1 || randomInt(2) == 0) {
+			neighbour = best - 1
+		}
+		if neighbour < len(pricePoints) && pricePoints[neighbour] > 0 {
+			explore[key] = pricePoints[neighbour]
+		}
+	}
+
+	schema := openrtb_ext.PriceFloorSchema{Fields: cfg.SchemaFields, Delimiter: defaultDelimiter}
+	modelGroups := []openrtb_ext.PriceFloorModelGroup{{
+		Currency:     defaultCurrency,
+		ModelWeight:  ptrutil.ToPtr(100 - cfg.ExplorationRate - cfg.HoldoutRate),
+		ModelVersion: optimizerModelVersion,
+		Schema:       schema,
+		Values:       optimised,
+	}}
+	if cfg.ExplorationRate > 0 {
+		modelGroups = append(modelGroups, openrtb_ext.PriceFloorModelGroup{
+			Currency:     defaultCurrency,
+			ModelWeight:  ptrutil.ToPtr(cfg.ExplorationRate),
+			ModelVersion: optimizerExploreModelVersion,
+			Schema:       schema,
+			Values:       explore,
+		})
+	}
+	if cfg.HoldoutRate > 0 {
+		modelGroups = append(modelGroups, openrtb_ext.PriceFloorModelGroup{
+			Currency:     defaultCurrency,
+			ModelWeight:  ptrutil.ToPtr(cfg.HoldoutRate),
+			ModelVersion: optimizerHoldoutModelVersion,
+			Schema:       schema,
+			Values:       map[string]float64{},
+		})
+	}
+
+	return &openrtb_ext.PriceFloorRules{
+		FloorProvider: optimizerFloorProvider,
+		Data: &openrtb_ext.PriceFloorData{
+			Currency:       defaultCurrency,
+			ModelTimestamp: int(now.Unix()),
+			ModelGroups:    modelGroups,
+			FloorProvider:  optimizerFloorProvider,
+		},
+	}
+}
+
+// bestPricePoint returns the index of the price point with the highest revenue per auction among those used
+// by enough auctions, or of no floor if there are none
+func bestPricePoint(floors map[float64]floorStats, pricePoints []float64, minAuctions int) int {
+	best, bestRevenue := 0, -1.0
+	for i, price := range pricePoints {
+		stats := floors[price]
+		if stats.auctions < minAuctions {
+			continue
+		}
+		if revenue := stats.revenue / float64(stats.auctions); revenue > bestRevenue {
+			best, bestRevenue = i, revenue
+		}
+	}
+	return best
+}
@@ -0,0 +1,170 @@
+package floors
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/exchange/entities"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/prebid/prebid-server/v4/util/ptrutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func getOptimizerAccount() config.Account {
+	return config.Account{
+		ID: "some-account",
+		PriceFloors: config.AccountPriceFloors{
+			Enabled: true,
+			MaxRule: 100,
+			Optimizer: config.AccountFloorOptimizer{
+				Enabled:         true,
+				SchemaFields:    []string{"mediaType", "size"},
+				PricePoints:     []float64{0.5, 1, 2},
+				WindowSec:       120,
+				MinAuctions:     2,
+				ExplorationRate: 10,
+				HoldoutRate:     5,
+			},
+		},
+	}
+}
+
+func getOptimizerRequest(location string, floor float64) *openrtb_ext.RequestWrapper {
+	ext := fmt.Sprintf(`{"prebid":{"floors":{"location":%q,"skipped":false,"data":{"currency":"USD","modelgroups":[{"schema":{"fields":["mediaType","size"]},"values":{"banner|300x250":%v}}]}}}}`, location, floor)
+	return &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+		ID:  "some-request",
+		Imp: []openrtb2.Imp{{ID: "some-imp", Banner: &openrtb2.Banner{Format: []openrtb2.Format{{W: 300, H: 250}}}}},
+		Ext: json.RawMessage(ext),
+	}}
+}
+
+func getOptimizerSeatBids(price float64) map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid {
+	return map[openrtb_ext.BidderName]*entities.PbsOrtbSeatBid{
+		"pubmatic": {Currency: "USD", Bids: []*entities.PbsOrtbBid{{Bid: &openrtb2.Bid{ID: "some-bid", ImpID: "some-imp", Price: price}}}},
+	}
+}
+
+func getOptimizerModelGroups(optimised, explore map[string]float64) []openrtb_ext.PriceFloorModelGroup {
+	schema := openrtb_ext.PriceFloorSchema{Fields: []string{"mediaType", "size"}, Delimiter: "|"}
+	return []openrtb_ext.PriceFloorModelGroup{
+		{Currency: "USD", ModelWeight: ptrutil.ToPtr(85), ModelVersion: "optimizer", Schema: schema, Values: optimised},
+		{Currency: "USD", ModelWeight: ptrutil.ToPtr(10), ModelVersion: "optimizer-explore", Schema: schema, Values: explore},
+		{Currency: "USD", ModelWeight: ptrutil.ToPtr(5), ModelVersion: "optimizer-holdout", Schema: schema, Values: map[string]float64{}},
+	}
+}
+
+func TestOptimizerFloors(t *testing.T) {
+	type auction struct {
+		location string
+		floor    float64
+		price    float64
+	}
+
+	testCases := []struct {
+		name        string
+		auctions    []auction
+		elapsed     time.Duration
+		disabled    bool
+		expectRules *openrtb_ext.PriceFloorRules
+	}{
+		{
+			name:        "optimizer_disabled",
+			disabled:    true,
+			expectRules: nil,
+		},
+		{
+			name: "no_auctions_recorded",
+			expectRules: &openrtb_ext.PriceFloorRules{
+				FloorProvider: "prebid-server",
+				Data: &openrtb_ext.PriceFloorData{
+					Currency:       "USD",
+					ModelTimestamp: 1700000000,
+					FloorProvider:  "prebid-server",
+					ModelGroups:    getOptimizerModelGroups(map[string]float64{}, map[string]float64{}),
+				},
+			},
+		},
+		{
+			name: "best_price_point_with_enough_auctions",
+			auctions: []auction{
+				{location: "optimizer", floor: 0, price: 0.4},
+				{location: "optimizer", floor: 0, price: 0.4},
+				{location: "optimizer", floor: 1, price: 1.2},
+				{location: "optimizer", floor: 1, price: 1.2},
+				{location: "optimizer", floor: 2, price: 3},
+				{location: "fetch", floor: 2, price: 3},
+			},
+			expectRules: &openrtb_ext.PriceFloorRules{
+				FloorProvider: "prebid-server",
+				Data: &openrtb_ext.PriceFloorData{
+					Currency:       "USD",
+					ModelTimestamp: 1700000000,
+					FloorProvider:  "prebid-server",
+					ModelGroups: getOptimizerModelGroups(
+						map[string]float64{"banner|300x250": 1},
+						map[string]float64{"banner|300x250": 0.5},
+					),
+				},
+			},
+		},
+		{
+			name: "auctions_out_of_window",
+			auctions: []auction{
+				{location: "optimizer", floor: 1, price: 1.2},
+				{location: "optimizer", floor: 1, price: 1.2},
+			},
+			elapsed: 130 * time.Second,
+			expectRules: &openrtb_ext.PriceFloorRules{
+				FloorProvider: "prebid-server",
+				Data: &openrtb_ext.PriceFloorData{
+					Currency:       "USD",
+					ModelTimestamp: 1700000130,
+					FloorProvider:  "prebid-server",
+					ModelGroups:    getOptimizerModelGroups(map[string]float64{}, map[string]float64{}),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Unix(1700000000, 0)
+			optimizer := NewOptimizer()
+			optimizer.now = func() time.Time { return now }
+			optimizer.randomInt = func(int) int { return 0 }
+
+			account := getOptimizerAccount()
+			account.PriceFloors.Optimizer.Enabled = !tc.disabled
+			conversions := getCurrencyRates(map[string]map[string]float64{})
+			for _, auction := range tc.auctions {
+				optimizer.Record(getOptimizerRequest(auction.location, auction.floor), account, getOptimizerSeatBids(auction.price), conversions)
+			}
+			now = now.Add(tc.elapsed)
+
+			assert.Equal(t, tc.expectRules, optimizer.Floors(account))
+		})
+	}
+}
+
+func TestOptimizerFloorsMaxRule(t *testing.T) {
+	optimizer := NewOptimizer()
+	optimizer.randomInt = func(int) int { return 0 }
+
+	bucket := &landscapeBucket{start: time.Now(), keys: make(map[string]map[float64]*floorStats)}
+	bucket.record("banner|300x250", 1, 2)
+	bucket.record("banner|300x250", 1, 2)
+	bucket.record("video|640x480", 1, 2)
+	optimizer.landscapes["some-account"] = &floorLandscape{schema: "mediaType|size", buckets: []*landscapeBucket{bucket}}
+
+	account := getOptimizerAccount()
+	account.PriceFloors.MaxRule = 1
+	account.PriceFloors.Optimizer.MinAuctions = 1
+
+	rules := optimizer.Floors(account)
+	assert.Equal(t, map[string]float64{"banner|300x250": 1}, rules.Data.ModelGroups[0].Values)
+	assert.Equal(t, map[string]float64{"banner|300x250": 0.5}, rules.Data.ModelGroups[1].Values)
+}
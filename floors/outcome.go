@@ -0,0 +1,79 @@
+package floors
+
+import (
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/currency"
+	"github.com/prebid/prebid-server/v4/exchange/entities"
+	"github.com/prebid/prebid-server/v4/metrics"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+)
+
+// BuildOutcome summarises the floors resolved for the request, the rule matched for each imp and the bids rejected by
+// the enforcement. It returns nil when floors are disabled for the account or the request.
+func BuildOutcome(bidRequestWrapper *openrtb_ext.RequestWrapper, account config.Account, rejectedBids []*entities.PbsOrtbSeatBid, conversions currency.Conversions) *openrtb_ext.PriceFloorsOutcome {
+	if !isPriceFloorsEnabled(account, bidRequestWrapper) {
+		return nil
+	}
+
+	requestExt, err := bidRequestWrapper.GetRequestExt()
+	if err != nil {
+		return nil
+	}
+	floorsExt := getFloorsExt(requestExt)
+	if floorsExt == nil {
+		return nil
+	}
+
+	outcome := &openrtb_ext.PriceFloorsOutcome{
+		Location:      floorsExt.PriceFloorLocation,
+		FetchStatus:   floorsExt.FetchStatus,
+		FloorProvider: floorsExt.FloorProvider,
+		Skipped:       floorsExt.GetFloorsSkippedFlag(),
+		Enforced:      floorsExt.Enforcement != nil && floorsExt.Enforcement.EnforcePBS != nil && *floorsExt.Enforcement.EnforcePBS,
+	}
+	if floorsExt.Data != nil && len(floorsExt.Data.ModelGroups) > 0 {
+		outcome.ModelVersion = floorsExt.Data.ModelGroups[0].ModelVersion
+	}
+
+	for _, imp := range bidRequestWrapper.GetImp() {
+		impOutcome := openrtb_ext.PriceFloorsImpOutcome{
+			ImpID:         imp.ID,
+			FloorValue:    imp.BidFloor,
+			FloorCurrency: imp.BidFloorCur,
+		}
+		if impExt, err := imp.GetImpExt(); err == nil {
+			if prebidExt := impExt.GetPrebid(); prebidExt != nil && prebidExt.Floors != nil {
+				impOutcome.FloorRule = prebidExt.Floors.FloorRule
+				impOutcome.FloorRuleValue = prebidExt.Floors.FloorRuleValue
+			}
+		}
+		outcome.Imps = append(outcome.Imps, impOutcome)
+	}
+
+	for _, rejectedBid := range rejectedBids {
+		rate, err := conversions.GetRate(rejectedBid.Currency, defaultCurrency)
+		for _, bid := range rejectedBid.Bids {
+			outcome.RejectedBids++
+			if err == nil {
+				outcome.RejectedValue += bid.Bid.Price * rate
+			}
+		}
+	}
+	outcome.RejectedValue = roundToFourDecimals(outcome.RejectedValue)
+
+	return outcome
+}
+
+// EnforcementStatus returns the floors enforcement status of an auction from its floors outcome
+func EnforcementStatus(outcome *openrtb_ext.PriceFloorsOutcome) metrics.FloorsEnforcementStatus {
+	switch {
+	case outcome.Location == openrtb_ext.NoDataLocation:
+		return metrics.FloorsEnforcementNoData
+	case outcome.Skipped:
+		return metrics.FloorsEnforcementSkipped
+	case outcome.Enforced:
+		return metrics.FloorsEnforcementEnforced
+	default:
+		return metrics.FloorsEnforcementNotEnforced
+	}
+}
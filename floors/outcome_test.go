@@ -0,0 +1,121 @@
+package floors
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/exchange/entities"
+	"github.com/prebid/prebid-server/v4/metrics"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildOutcome(t *testing.T) {
+	account := config.Account{PriceFloors: config.AccountPriceFloors{Enabled: true}}
+	conversions := getCurrencyRates(map[string]map[string]float64{"USD": {"EUR": 0.5}})
+
+	testCases := []struct {
+		name         string
+		account      config.Account
+		requestExt   json.RawMessage
+		impExt       json.RawMessage
+		rejectedBids []*entities.PbsOrtbSeatBid
+		expected     *openrtb_ext.PriceFloorsOutcome
+	}{
+		{
+			name:       "floors_disabled_for_account",
+			account:    config.Account{PriceFloors: config.AccountPriceFloors{Enabled: false}},
+			requestExt: json.RawMessage(`{"prebid":{"floors":{"location":"fetch"}}}`),
+			expected:   nil,
+		},
+		{
+			name:       "no_floors_in_request",
+			account:    account,
+			requestExt: json.RawMessage(`{"prebid":{}}`),
+			expected:   nil,
+		},
+		{
+			name:       "enforced_with_rejected_bids",
+			account:    account,
+			requestExt: json.RawMessage(`{"prebid":{"floors":{"location":"fetch","fetchstatus":"success","floorprovider":"some-provider","skipped":false,"enforcement":{"enforcepbs":true},"data":{"modelgroups":[{"modelversion":"model-1"}]}}}}`),
+			impExt:     json.RawMessage(`{"prebid":{"floors":{"floorrule":"banner|300x250","floorrulevalue":1.5,"floorvalue":1.5}}}`),
+			rejectedBids: []*entities.PbsOrtbSeatBid{
+				{Currency: "USD", Seat: "pubmatic", Bids: []*entities.PbsOrtbBid{{Bid: &openrtb2.Bid{ID: "bid-1", ImpID: "some-imp", Price: 1}}}},
+				{Currency: "EUR", Seat: "appnexus", Bids: []*entities.PbsOrtbBid{{Bid: &openrtb2.Bid{ID: "bid-2", ImpID: "some-imp", Price: 0.5}}}},
+			},
+			expected: &openrtb_ext.PriceFloorsOutcome{
+				Location:      "fetch",
+				FetchStatus:   "success",
+				FloorProvider: "some-provider",
+				ModelVersion:  "model-1",
+				Enforced:      true,
+				Imps: []openrtb_ext.PriceFloorsImpOutcome{
+					{ImpID: "some-imp", FloorRule: "banner|300x250", FloorRuleValue: 1.5, FloorValue: 1.5, FloorCurrency: "USD"},
+				},
+				RejectedBids:  2,
+				RejectedValue: 2,
+			},
+		},
+		{
+			name:       "skipped",
+			account:    account,
+			requestExt: json.RawMessage(`{"prebid":{"floors":{"location":"request","skipped":true}}}`),
+			expected: &openrtb_ext.PriceFloorsOutcome{
+				Location: "request",
+				Skipped:  true,
+				Imps: []openrtb_ext.PriceFloorsImpOutcome{
+					{ImpID: "some-imp", FloorValue: 1.5, FloorCurrency: "USD"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+				Imp: []openrtb2.Imp{{ID: "some-imp", BidFloor: 1.5, BidFloorCur: "USD", Ext: tc.impExt}},
+				Ext: tc.requestExt,
+			}}
+
+			outcome := BuildOutcome(request, tc.account, tc.rejectedBids, conversions)
+			assert.Equal(t, tc.expected, outcome)
+		})
+	}
+}
+
+func TestEnforcementStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		outcome  *openrtb_ext.PriceFloorsOutcome
+		expected metrics.FloorsEnforcementStatus
+	}{
+		{
+			name:     "no_data",
+			outcome:  &openrtb_ext.PriceFloorsOutcome{Location: openrtb_ext.NoDataLocation, Enforced: true},
+			expected: metrics.FloorsEnforcementNoData,
+		},
+		{
+			name:     "skipped",
+			outcome:  &openrtb_ext.PriceFloorsOutcome{Location: openrtb_ext.FetchLocation, Skipped: true},
+			expected: metrics.FloorsEnforcementSkipped,
+		},
+		{
+			name:     "enforced",
+			outcome:  &openrtb_ext.PriceFloorsOutcome{Location: openrtb_ext.FetchLocation, Enforced: true},
+			expected: metrics.FloorsEnforcementEnforced,
+		},
+		{
+			name:     "not_enforced",
+			outcome:  &openrtb_ext.PriceFloorsOutcome{Location: openrtb_ext.RequestLocation},
+			expected: metrics.FloorsEnforcementNotEnforced,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, EnforcementStatus(tc.outcome))
+		})
+	}
+}
@@ -4,10 +4,17 @@ import (
 	"fmt"
 	"math/bits"
 	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	gppConstants "github.com/prebid/go-gpp/constants"
+	"github.com/prebid/openrtb/v20/adcom1"
 	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v4/config"
 	"github.com/prebid/prebid-server/v4/currency"
 	"github.com/prebid/prebid-server/v4/logger"
 	"github.com/prebid/prebid-server/v4/openrtb_ext"
@@ -26,6 +33,15 @@ const (
 	AdUnitCode          string = "adUnitCode"
 	Country             string = "country"
 	DeviceType          string = "deviceType"
+	Bidder              string = "bidder"
+	Deal                string = "deal"
+	OS                  string = "os"
+	Browser             string = "browser"
+	ConnectionType      string = "connectionType"
+	HourOfDay           string = "hourOfDay"
+	DayOfWeek           string = "dayOfWeek"
+	UserIDs             string = "userIds"
+	Consent             string = "consent"
 	Tablet              string = "tablet"
 	Desktop             string = "desktop"
 	Phone               string = "phone"
@@ -34,8 +50,44 @@ const (
 	VideoOutstreamMedia string = "video-outstream"
 	AudioMedia          string = "audio"
 	NativeMedia         string = "native"
+	Other               string = "other"
+	ConsentNone         string = "none"
+	ConsentMissing      string = "missing"
+	ConsentTCF          string = "tcf"
+	ConsentGPP          string = "gpp"
 )
 
+// ruleKeyContext holds the values of the schema dimensions which don't come from the request
+type ruleKeyContext struct {
+	// bidder is the bidder the floor is looked up for, empty before the bids are received
+	bidder string
+	// bid is the bid the floor is looked up for, nil before the bids are received
+	bid *openrtb2.Bid
+	// now is the time of the auction in the timezone of the floors of the account
+	now time.Time
+}
+
+var floorsLocations sync.Map
+
+// ruleKeyTime returns the current time in the timezone configured for the floors of the account, UTC if the
+// timezone isn't known
+func ruleKeyTime(account config.Account) time.Time {
+	return time.Now().In(floorsLocation(account.PriceFloors.Timezone))
+}
+
+func floorsLocation(timezone string) *time.Location {
+	if location, ok := floorsLocations.Load(timezone); ok {
+		return location.(*time.Location)
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		logger.Warnf("Invalid price floors timezone %q, using UTC: %v", timezone, err)
+		location = time.UTC
+	}
+	floorsLocations.Store(timezone, location)
+	return location
+}
+
 // getFloorCurrency returns floors currency provided in floors JSON,
 // if currency is not provided then defaults to USD
 func getFloorCurrency(floorExt *openrtb_ext.PriceFloorRules) string {
@@ -192,7 +244,7 @@ func findRule(ruleValues map[string]float64, delimiter string, desiredRuleKey []
 }
 
 // createRuleKey prepares rule keys based on schema dimension and values present in request
-func createRuleKey(floorSchema openrtb_ext.PriceFloorSchema, request *openrtb_ext.RequestWrapper, imp *openrtb_ext.ImpWrapper) []string {
+func createRuleKey(floorSchema openrtb_ext.PriceFloorSchema, request *openrtb_ext.RequestWrapper, imp *openrtb_ext.ImpWrapper, ctx ruleKeyContext) []string {
 	var ruleKeys []string
 
 	for _, field := range floorSchema.Fields {
@@ -220,6 +272,24 @@ func createRuleKey(floorSchema openrtb_ext.PriceFloorSchema, request *openrtb_ex
 			value = getGptSlot(imp)
 		case AdUnitCode:
 			value = getAdUnitCode(imp)
+		case Bidder:
+			value = getBidder(ctx)
+		case Deal:
+			value = getDeal(imp.Imp, ctx)
+		case OS:
+			value = getOS(request)
+		case Browser:
+			value = getBrowser(request)
+		case ConnectionType:
+			value = getConnectionType(request)
+		case HourOfDay:
+			value = strconv.Itoa(ctx.now.Hour())
+		case DayOfWeek:
+			value = strings.ToLower(ctx.now.Weekday().String())
+		case UserIDs:
+			value = getUserIDs(request)
+		case Consent:
+			value = getConsent(request)
 		}
 		ruleKeys = append(ruleKeys, value)
 	}
@@ -429,6 +499,128 @@ func getAdUnitCode(imp *openrtb_ext.ImpWrapper) string {
 	return adUnitCode
 }
 
+// getBidder returns the bidder the floor is looked up for
+func getBidder(ctx ruleKeyContext) string {
+	if ctx.bidder == "" {
+		return catchAll
+	}
+	return ctx.bidder
+}
+
+// getDeal returns whether the bid has a deal once the bids are received, else whether the impression has deals
+func getDeal(imp *openrtb2.Imp, ctx ruleKeyContext) string {
+	if ctx.bid != nil {
+		return strconv.FormatBool(ctx.bid.DealID != "")
+	}
+	return strconv.FormatBool(imp.PMP != nil && len(imp.PMP.Deals) > 0)
+}
+
+// userAgentPattern is the pattern of the user agents of an OS or browser family
+type userAgentPattern struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+var osPatterns = []userAgentPattern{
+	{name: "windows", pattern: regexp.MustCompile(`(?i)Windows`)},
+	{name: "ios", pattern: regexp.MustCompile(`(?i)iPhone|iPad|iPod`)},
+	{name: "android", pattern: regexp.MustCompile(`(?i)Android`)},
+	{name: "macos", pattern: regexp.MustCompile(`(?i)Macintosh|Mac OS X`)},
+	{name: "linux", pattern: regexp.MustCompile(`(?i)Linux|CrOS`)},
+}
+
+// getOS returns the device OS provided into request, else the OS family of the user agent
+func getOS(request *openrtb_ext.RequestWrapper) string {
+	if request.Device == nil {
+		return catchAll
+	}
+	if request.Device.OS != "" {
+		return request.Device.OS
+	}
+	return matchUserAgent(request.Device.UA, osPatterns)
+}
+
+var browserPatterns = []userAgentPattern{
+	{name: "edge", pattern: regexp.MustCompile(`Edg(e|A|iOS)?/`)},
+	{name: "opera", pattern: regexp.MustCompile(`OPR/|Opera`)},
+	{name: "samsung", pattern: regexp.MustCompile(`SamsungBrowser/`)},
+	{name: "chrome", pattern: regexp.MustCompile(`Chrome/|CriOS/`)},
+	{name: "firefox", pattern: regexp.MustCompile(`Firefox/|FxiOS/`)},
+	{name: "safari", pattern: regexp.MustCompile(`Safari/`)},
+}
+
+// getBrowser returns the browser family of the user agent provided into request
+func getBrowser(request *openrtb_ext.RequestWrapper) string {
+	if request.Device == nil {
+		return catchAll
+	}
+	return matchUserAgent(request.Device.UA, browserPatterns)
+}
+
+// matchUserAgent returns the name of the first pattern the user agent matches
+func matchUserAgent(userAgent string, patterns []userAgentPattern) string {
+	if userAgent == "" {
+		return catchAll
+	}
+	for _, p := range patterns {
+		if p.pattern.MatchString(userAgent) {
+			return p.name
+		}
+	}
+	return Other
+}
+
+// getConnectionType returns the connection type provided into request
+func getConnectionType(request *openrtb_ext.RequestWrapper) string {
+	if request.Device == nil || request.Device.ConnectionType == nil {
+		return catchAll
+	}
+	switch *request.Device.ConnectionType {
+	case adcom1.ConnectionEthernet:
+		return "ethernet"
+	case adcom1.ConnectionWIFI:
+		return "wifi"
+	case adcom1.ConnectionCellular:
+		return "cellular"
+	case adcom1.Connection2G:
+		return "2g"
+	case adcom1.Connection3G:
+		return "3g"
+	case adcom1.Connection4G:
+		return "4g"
+	case adcom1.Connection5G:
+		return "5g"
+	}
+	return catchAll
+}
+
+// getUserIDs returns whether extended user IDs are provided into request
+func getUserIDs(request *openrtb_ext.RequestWrapper) string {
+	return strconv.FormatBool(request.User != nil && len(request.User.EIDs) > 0)
+}
+
+// getConsent returns the consent status of request: tcf or gpp if GDPR applies and a consent string is provided
+// in that format, missing if GDPR applies without one, gpp if a GPP string is provided for other regulations and
+// none otherwise
+func getConsent(request *openrtb_ext.RequestWrapper) string {
+	var gdprApplies bool
+	var gpp string
+	if request.Regs != nil {
+		gdprApplies = (request.Regs.GDPR != nil && *request.Regs.GDPR == 1) || slices.Contains(request.Regs.GPPSID, int8(gppConstants.SectionTCFEU2))
+		gpp = request.Regs.GPP
+	}
+
+	switch {
+	case gdprApplies && request.User != nil && request.User.Consent != "":
+		return ConsentTCF
+	case gpp != "":
+		return ConsentGPP
+	case gdprApplies:
+		return ConsentMissing
+	}
+	return ConsentNone
+}
+
 // isMobileDevice returns true if device is mobile
 func isMobileDevice(userAgent string) bool {
 	isMobile, err := regexp.MatchString("(?i)Phone|iPhone|Android.*Mobile|Mobile.*Android", userAgent)
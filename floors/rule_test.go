@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/prebid/openrtb/v20/adcom1"
 	"github.com/prebid/openrtb/v20/openrtb2"
 	"github.com/prebid/prebid-server/v4/currency"
 	"github.com/prebid/prebid-server/v4/openrtb_ext"
@@ -507,12 +509,121 @@ func TestCreateRuleKeys(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			out := createRuleKey(tc.floorSchema, &openrtb_ext.RequestWrapper{BidRequest: tc.request}, &openrtb_ext.ImpWrapper{Imp: &tc.request.Imp[0]})
+			out := createRuleKey(tc.floorSchema, &openrtb_ext.RequestWrapper{BidRequest: tc.request}, &openrtb_ext.ImpWrapper{Imp: &tc.request.Imp[0]}, ruleKeyContext{})
 			assert.Equal(t, out, tc.out, tc.name)
 		})
 	}
 }
 
+func TestCreateRuleKeysWithContext(t *testing.T) {
+	chromeUA := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+	safariUA := "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1"
+	auctionTime := time.Date(2024, time.March, 4, 18, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		floorSchema openrtb_ext.PriceFloorSchema
+		request     *openrtb2.BidRequest
+		ctx         ruleKeyContext
+		out         []string
+	}{
+		{
+			name: "Bidder and deal before bids are received",
+			request: &openrtb2.BidRequest{
+				Imp: []openrtb2.Imp{{ID: "1234", PMP: &openrtb2.PMP{Deals: []openrtb2.Deal{{ID: "deal-1"}}}}},
+			},
+			floorSchema: openrtb_ext.PriceFloorSchema{Delimiter: "|", Fields: []string{"bidder", "deal"}},
+			out:         []string{"*", "true"},
+		},
+		{
+			name: "Bidder and deal of bid",
+			request: &openrtb2.BidRequest{
+				Imp: []openrtb2.Imp{{ID: "1234", PMP: &openrtb2.PMP{Deals: []openrtb2.Deal{{ID: "deal-1"}}}}},
+			},
+			ctx:         ruleKeyContext{bidder: "pubmatic", bid: &openrtb2.Bid{ID: "bid-1"}},
+			floorSchema: openrtb_ext.PriceFloorSchema{Delimiter: "|", Fields: []string{"bidder", "deal"}},
+			out:         []string{"pubmatic", "false"},
+		},
+		{
+			name: "OS, browser and connection type from user agent",
+			request: &openrtb2.BidRequest{
+				Device: &openrtb2.Device{UA: chromeUA, ConnectionType: adcom1.ConnectionWIFI.Ptr()},
+				Imp:    []openrtb2.Imp{{ID: "1234"}},
+			},
+			floorSchema: openrtb_ext.PriceFloorSchema{Delimiter: "|", Fields: []string{"os", "browser", "connectionType"}},
+			out:         []string{"windows", "chrome", "wifi"},
+		},
+		{
+			name: "OS provided into request",
+			request: &openrtb2.BidRequest{
+				Device: &openrtb2.Device{UA: safariUA, OS: "iOS", ConnectionType: adcom1.Connection4G.Ptr()},
+				Imp:    []openrtb2.Imp{{ID: "1234"}},
+			},
+			floorSchema: openrtb_ext.PriceFloorSchema{Delimiter: "|", Fields: []string{"os", "browser", "connectionType"}},
+			out:         []string{"iOS", "safari", "4g"},
+		},
+		{
+			name: "No device",
+			request: &openrtb2.BidRequest{
+				Imp: []openrtb2.Imp{{ID: "1234"}},
+			},
+			floorSchema: openrtb_ext.PriceFloorSchema{Delimiter: "|", Fields: []string{"os", "browser", "connectionType"}},
+			out:         []string{"*", "*", "*"},
+		},
+		{
+			name: "Hour of day and day of week",
+			request: &openrtb2.BidRequest{
+				Imp: []openrtb2.Imp{{ID: "1234"}},
+			},
+			ctx:         ruleKeyContext{now: auctionTime.In(time.FixedZone("UTC+8", 8*60*60))},
+			floorSchema: openrtb_ext.PriceFloorSchema{Delimiter: "|", Fields: []string{"hourOfDay", "dayOfWeek"}},
+			out:         []string{"2", "tuesday"},
+		},
+		{
+			name: "User IDs and TCF consent",
+			request: &openrtb2.BidRequest{
+				Regs: &openrtb2.Regs{GDPR: ptrutil.ToPtr[int8](1)},
+				User: &openrtb2.User{Consent: "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA", EIDs: []openrtb2.EID{{Source: "example.com"}}},
+				Imp:  []openrtb2.Imp{{ID: "1234"}},
+			},
+			floorSchema: openrtb_ext.PriceFloorSchema{Delimiter: "|", Fields: []string{"userIds", "consent"}},
+			out:         []string{"true", "tcf"},
+		},
+		{
+			name: "No user IDs and missing consent",
+			request: &openrtb2.BidRequest{
+				Regs: &openrtb2.Regs{GPPSID: []int8{2}},
+				Imp:  []openrtb2.Imp{{ID: "1234"}},
+			},
+			floorSchema: openrtb_ext.PriceFloorSchema{Delimiter: "|", Fields: []string{"userIds", "consent"}},
+			out:         []string{"false", "missing"},
+		},
+		{
+			name: "GPP consent",
+			request: &openrtb2.BidRequest{
+				Regs: &openrtb2.Regs{GPP: "DBABLA~BVQqAAAAAgA.QA", GPPSID: []int8{7}},
+				Imp:  []openrtb2.Imp{{ID: "1234"}},
+			},
+			floorSchema: openrtb_ext.PriceFloorSchema{Delimiter: "|", Fields: []string{"consent"}},
+			out:         []string{"gpp"},
+		},
+		{
+			name: "No consent signals",
+			request: &openrtb2.BidRequest{
+				Imp: []openrtb2.Imp{{ID: "1234"}},
+			},
+			floorSchema: openrtb_ext.PriceFloorSchema{Delimiter: "|", Fields: []string{"consent"}},
+			out:         []string{"none"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := createRuleKey(tc.floorSchema, &openrtb_ext.RequestWrapper{BidRequest: tc.request}, &openrtb_ext.ImpWrapper{Imp: &tc.request.Imp[0]}, tc.ctx)
+			assert.Equal(t, tc.out, out)
+		})
+	}
+}
+
 func TestShouldSkipFloors(t *testing.T) {
 
 	testCases := []struct {
@@ -0,0 +1,79 @@
+package floors
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/logger"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/prebid/prebid-server/v4/stored_requests"
+)
+
+// StoredFloorFetcher is a FloorFetcher reading the floors data referenced by the floors ID of the account or the
+// request from the stored floors. Without a floors ID, the floors data is fetched by the next FloorFetcher.
+type StoredFloorFetcher struct {
+	fetcher stored_requests.Fetcher
+	next    FloorFetcher
+}
+
+func NewStoredFloorFetcher(fetcher stored_requests.Fetcher, next FloorFetcher) *StoredFloorFetcher {
+	return &StoredFloorFetcher{
+		fetcher: fetcher,
+		next:    next,
+	}
+}
+
+func (f *StoredFloorFetcher) Fetch(config config.AccountPriceFloors) (*openrtb_ext.PriceFloorRules, string) {
+	if f == nil {
+		return nil, openrtb_ext.FetchNone
+	}
+
+	floorsID := config.Fetcher.FloorsID
+	if len(floorsID) == 0 || f.fetcher == nil {
+		if f.next == nil {
+			return nil, openrtb_ext.FetchNone
+		}
+		return f.next.Fetch(config)
+	}
+
+	if !config.UseDynamicData {
+		return nil, openrtb_ext.FetchNone
+	}
+
+	ctx := context.Background()
+	if config.Fetcher.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(config.Fetcher.Timeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	storedFloors, errs := f.fetcher.FetchResponses(ctx, []string{floorsID})
+	for _, err := range errs {
+		logger.Errorf("Error while fetching stored floors %s for account %s, reason : %s", floorsID, config.Fetcher.AccountID, err.Error())
+	}
+	floorsJSON, found := storedFloors[floorsID]
+	if !found {
+		return nil, openrtb_ext.FetchError
+	}
+
+	var priceFloors openrtb_ext.PriceFloorRules
+	if err := json.Unmarshal(floorsJSON, &priceFloors.Data); err != nil {
+		logger.Errorf("Received invalid stored floors json for floors ID: %s", floorsID)
+		return nil, openrtb_ext.FetchError
+	}
+
+	if err := validateRules(config.Fetcher, &priceFloors); err != nil {
+		logger.Errorf("Validation failed for stored floors JSON for floors ID: %s, reason: %s", floorsID, err.Error())
+		return nil, openrtb_ext.FetchError
+	}
+
+	return &priceFloors, openrtb_ext.FetchSuccess
+}
+
+func (f *StoredFloorFetcher) Stop() {
+	if f != nil && f.next != nil {
+		f.next.Stop()
+	}
+}
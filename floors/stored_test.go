@@ -0,0 +1,174 @@
+package floors
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/prebid/prebid-server/v4/stored_requests"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockStoredFloorsFetcher struct {
+	data map[string]json.RawMessage
+}
+
+func (mf *mockStoredFloorsFetcher) FetchRequests(ctx context.Context, requestIDs []string, impIDs []string) (map[string]json.RawMessage, map[string]json.RawMessage, []error) {
+	return nil, nil, nil
+}
+
+func (mf *mockStoredFloorsFetcher) FetchResponses(ctx context.Context, ids []string) (map[string]json.RawMessage, []error) {
+	var errs []error
+	for _, id := range ids {
+		if _, ok := mf.data[id]; !ok {
+			errs = append(errs, stored_requests.NotFoundError{ID: id, DataType: "Floors"})
+		}
+	}
+	return mf.data, errs
+}
+
+func TestStoredFloorFetcherFetch(t *testing.T) {
+	storedFetcher := &mockStoredFloorsFetcher{data: map[string]json.RawMessage{
+		"floors-1":       json.RawMessage(`{"currency":"USD","modelgroups":[{"schema":{"fields":["mediaType"]},"values":{"banner":1.5}}]}`),
+		"invalid-json":   json.RawMessage(`{"currency":`),
+		"invalid-floors": json.RawMessage(`{"currency":"USD","modelgroups":[]}`),
+	}}
+
+	testCases := []struct {
+		name           string
+		fetcher        stored_requests.Fetcher
+		floorsID       string
+		useDynamicData bool
+		expectedRules  *openrtb_ext.PriceFloorRules
+		expectedStatus string
+	}{
+		{
+			name:           "no_floors_id_uses_next_fetcher",
+			fetcher:        storedFetcher,
+			useDynamicData: true,
+			expectedStatus: openrtb_ext.FetchNone,
+		},
+		{
+			name:           "no_stored_floors_fetcher_uses_next_fetcher",
+			floorsID:       "floors-1",
+			useDynamicData: true,
+			expectedStatus: openrtb_ext.FetchNone,
+		},
+		{
+			name:           "dynamic_data_disabled",
+			fetcher:        storedFetcher,
+			floorsID:       "floors-1",
+			useDynamicData: false,
+			expectedStatus: openrtb_ext.FetchNone,
+		},
+		{
+			name:           "stored_floors_found",
+			fetcher:        storedFetcher,
+			floorsID:       "floors-1",
+			useDynamicData: true,
+			expectedRules: &openrtb_ext.PriceFloorRules{
+				Data: &openrtb_ext.PriceFloorData{
+					Currency: "USD",
+					ModelGroups: []openrtb_ext.PriceFloorModelGroup{{
+						Schema: openrtb_ext.PriceFloorSchema{Fields: []string{"mediaType"}},
+						Values: map[string]float64{"banner": 1.5},
+					}},
+				},
+			},
+			expectedStatus: openrtb_ext.FetchSuccess,
+		},
+		{
+			name:           "stored_floors_not_found",
+			fetcher:        storedFetcher,
+			floorsID:       "floors-2",
+			useDynamicData: true,
+			expectedStatus: openrtb_ext.FetchError,
+		},
+		{
+			name:           "stored_floors_invalid_json",
+			fetcher:        storedFetcher,
+			floorsID:       "invalid-json",
+			useDynamicData: true,
+			expectedStatus: openrtb_ext.FetchError,
+		},
+		{
+			name:           "stored_floors_invalid_rules",
+			fetcher:        storedFetcher,
+			floorsID:       "invalid-floors",
+			useDynamicData: true,
+			expectedStatus: openrtb_ext.FetchError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetcher := NewStoredFloorFetcher(tc.fetcher, &mockPriceFloorFetcher{})
+			rules, status := fetcher.Fetch(config.AccountPriceFloors{
+				Enabled:        true,
+				UseDynamicData: tc.useDynamicData,
+				Fetcher: config.AccountFloorFetch{
+					FloorsID: tc.floorsID,
+					Timeout:  100,
+					MaxRules: 10,
+				},
+			})
+			assert.Equal(t, tc.expectedRules, rules)
+			assert.Equal(t, tc.expectedStatus, status)
+		})
+	}
+}
+
+func TestResolveFloorsWithStoredFloors(t *testing.T) {
+	storedFetcher := &mockStoredFloorsFetcher{data: map[string]json.RawMessage{
+		"account-floors": json.RawMessage(`{"currency":"USD","modelgroups":[{"schema":{"fields":["mediaType"]},"values":{"banner":1}}]}`),
+		"request-floors": json.RawMessage(`{"currency":"USD","modelgroups":[{"schema":{"fields":["mediaType"]},"values":{"banner":2}}]}`),
+	}}
+	fetcher := NewStoredFloorFetcher(storedFetcher, &mockPriceFloorFetcher{})
+
+	account := config.Account{
+		PriceFloors: config.AccountPriceFloors{
+			Enabled:        true,
+			UseDynamicData: true,
+			Fetcher: config.AccountFloorFetch{
+				FloorsID: "account-floors",
+				Timeout:  100,
+				MaxRules: 10,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		requestExt    json.RawMessage
+		expectedValue float64
+	}{
+		{
+			name:          "account_floors_id",
+			requestExt:    json.RawMessage(`{"prebid":{"floors":{}}}`),
+			expectedValue: 1,
+		},
+		{
+			name:          "request_floors_id_overrides_account",
+			requestExt:    json.RawMessage(`{"prebid":{"floors":{"floorsid":"request-floors"}}}`),
+			expectedValue: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+				Imp: []openrtb2.Imp{{ID: "1234", Banner: &openrtb2.Banner{Format: []openrtb2.Format{{W: 300, H: 250}}}}},
+				Ext: tc.requestExt,
+			}}
+
+			floors, errs := resolveFloors(account, request, getCurrencyRates(map[string]map[string]float64{}), fetcher, nil)
+			assert.Empty(t, errs)
+			assert.Equal(t, openrtb_ext.FetchLocation, floors.PriceFloorLocation)
+			assert.Equal(t, openrtb_ext.FetchSuccess, floors.FetchStatus)
+			assert.Equal(t, map[string]float64{"banner": tc.expectedValue}, floors.Data.ModelGroups[0].Values)
+		})
+	}
+}
@@ -9,17 +9,26 @@ import (
 )
 
 var validSchemaDimensions = map[string]struct{}{
-	SiteDomain: {},
-	PubDomain:  {},
-	Domain:     {},
-	Bundle:     {},
-	Channel:    {},
-	MediaType:  {},
-	Size:       {},
-	GptSlot:    {},
-	AdUnitCode: {},
-	Country:    {},
-	DeviceType: {},
+	SiteDomain:     {},
+	PubDomain:      {},
+	Domain:         {},
+	Bundle:         {},
+	Channel:        {},
+	MediaType:      {},
+	Size:           {},
+	GptSlot:        {},
+	AdUnitCode:     {},
+	Country:        {},
+	DeviceType:     {},
+	Bidder:         {},
+	Deal:           {},
+	OS:             {},
+	Browser:        {},
+	ConnectionType: {},
+	HourOfDay:      {},
+	DayOfWeek:      {},
+	UserIDs:        {},
+	Consent:        {},
 }
 
 // validateSchemaDimensions validates schema dimesions given in floors JSON
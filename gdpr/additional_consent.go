@@ -0,0 +1,42 @@
+package gdpr
+
+import (
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+)
+
+// parseAdditionalConsent returns the set of Google Ad Tech Providers the user consented to in a Google
+// Additional Consent (AC) string. A malformed string is treated as consent to none of the providers.
+// Returns nil if the request has no AC string.
+func parseAdditionalConsent(additionalConsent string) map[int]struct{} {
+	if additionalConsent == "" {
+		return nil
+	}
+
+	providers := openrtb_ext.ParseConsentedProvidersString(additionalConsent)
+	consented := make(map[int]struct{}, len(providers))
+	for _, id := range providers {
+		consented[id] = struct{}{}
+	}
+	return consented
+}
+
+// atpConsent reports whether the Google Additional Consent string of the request applies to the bidder,
+// which is the case if the request has an AC string and the bidder is a Google Ad Tech Provider, and if
+// so whether the user consented to the bidder. An alias without an ATP Provider ID of its own is checked
+// with the ATP Provider ID of its core bidder.
+func (p *permissionsImpl) atpConsent(bidderCoreName openrtb_ext.BidderName, bidder openrtb_ext.BidderName) (applies bool, consented bool) {
+	if p.additionalConsent == nil {
+		return false, false
+	}
+
+	id, ok := p.atpProviderIDs[bidder]
+	if !ok {
+		id, ok = p.atpProviderIDs[bidderCoreName]
+	}
+	if !ok {
+		return false, false
+	}
+
+	_, consented = p.additionalConsent[id]
+	return true, consented
+}
@@ -0,0 +1,237 @@
+package gdpr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prebid/go-gdpr/vendorlist"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAdditionalConsent(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[int]struct{}
+	}{
+		{
+			name:     "empty",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "v1",
+			input:    "1~1.35.41",
+			expected: map[int]struct{}{1: {}, 35: {}, 41: {}},
+		},
+		{
+			name:     "v2",
+			input:    "2~1.35~dv.9.21",
+			expected: map[int]struct{}{1: {}, 35: {}},
+		},
+		{
+			name:     "malformed",
+			input:    "malformed",
+			expected: map[int]struct{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseAdditionalConsent(tt.input))
+		})
+	}
+}
+
+func TestAllowActivitiesAdditionalConsent(t *testing.T) {
+	// full consents to purposes and vendors 2, 6, 8 and special feature 1 opt-in
+	const fullConsentToPurposesAndVendorsTwoSixEight = "COzTVhaOzTVhaGvAAAENAiCIAP_AAH_AAAAAAEEUACCKAAA"
+
+	vendorListData := MarshalVendorList(buildVendorList34())
+	tcf2AggConfig := allPurposesEnabledTCF2Config()
+
+	perms := permissionsImpl{
+		cfg:          &tcf2AggConfig,
+		hostVendorID: 2,
+		vendorIDs: map[openrtb_ext.BidderName]uint16{
+			openrtb_ext.BidderPubmatic: 6,
+			openrtb_ext.BidderRubicon:  8,
+		},
+		atpProviderIDs: map[openrtb_ext.BidderName]int{
+			openrtb_ext.BidderRubicon:         50,
+			openrtb_ext.BidderAudienceNetwork: 89,
+			"rubiconAliasWithATP":             77,
+		},
+		fetchVendorList: listFetcher(map[uint16]map[uint16]vendorlist.VendorList{
+			2: {
+				34: parseVendorListDataV2(t, vendorListData),
+			},
+		}),
+		purposeEnforcerBuilder: NewPurposeEnforcerBuilder(&tcf2AggConfig),
+		aliasGVLIDs:            map[string]uint16{},
+		consent:                fullConsentToPurposesAndVendorsTwoSixEight,
+		gdprSignal:             SignalYes,
+	}
+
+	tests := []struct {
+		name              string
+		bidderCoreName    openrtb_ext.BidderName
+		bidder            openrtb_ext.BidderName
+		additionalConsent string
+		expected          AuctionPermissions
+	}{
+		{
+			name:              "atp_only_consented",
+			bidder:            openrtb_ext.BidderAudienceNetwork,
+			additionalConsent: "2~89~dv.",
+			expected:          AllowAll,
+		},
+		{
+			name:              "atp_only_not_consented",
+			bidder:            openrtb_ext.BidderAudienceNetwork,
+			additionalConsent: "2~1~dv.",
+			expected:          DenyAll,
+		},
+		{
+			name:              "atp_only_malformed",
+			bidder:            openrtb_ext.BidderAudienceNetwork,
+			additionalConsent: "malformed",
+			expected:          DenyAll,
+		},
+		{
+			name:              "gvl_and_atp_consented",
+			bidder:            openrtb_ext.BidderRubicon,
+			additionalConsent: "2~50~dv.",
+			expected:          AuctionPermissions{AllowBidRequest: true, PassGeo: false, PassID: true},
+		},
+		{
+			name:              "gvl_and_atp_not_consented",
+			bidder:            openrtb_ext.BidderRubicon,
+			additionalConsent: "2~1~dv.",
+			expected:          AuctionPermissions{AllowBidRequest: true, PassGeo: false, PassID: false},
+		},
+		{
+			name:              "gvl_and_atp_no_additional_consent",
+			bidder:            openrtb_ext.BidderRubicon,
+			additionalConsent: "",
+			expected:          AuctionPermissions{AllowBidRequest: true, PassGeo: false, PassID: true},
+		},
+		{
+			name:              "gvl_only",
+			bidder:            openrtb_ext.BidderPubmatic,
+			additionalConsent: "2~1~dv.",
+			expected:          AllowAll,
+		},
+		{
+			name:              "alias_checked_with_core_bidder_atp",
+			bidderCoreName:    openrtb_ext.BidderRubicon,
+			bidder:            "rubiconAlias",
+			additionalConsent: "2~1~dv.",
+			expected:          AuctionPermissions{AllowBidRequest: true, PassGeo: false, PassID: false},
+		},
+		{
+			name:              "alias_checked_with_own_atp",
+			bidderCoreName:    openrtb_ext.BidderRubicon,
+			bidder:            "rubiconAliasWithATP",
+			additionalConsent: "2~50~dv.",
+			expected:          AuctionPermissions{AllowBidRequest: true, PassGeo: false, PassID: false},
+		},
+		{
+			name:              "alias_consented_with_own_atp",
+			bidderCoreName:    openrtb_ext.BidderRubicon,
+			bidder:            "rubiconAliasWithATP",
+			additionalConsent: "2~77~dv.",
+			expected:          AuctionPermissions{AllowBidRequest: true, PassGeo: false, PassID: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			perms.additionalConsent = parseAdditionalConsent(tt.additionalConsent)
+
+			bidderCoreName := tt.bidderCoreName
+			if bidderCoreName == "" {
+				bidderCoreName = tt.bidder
+			}
+			permissions := perms.AuctionActivitiesAllowed(context.Background(), bidderCoreName, tt.bidder)
+			assert.Equal(t, tt.expected, permissions)
+		})
+	}
+}
+
+func TestBidderSyncAllowedAdditionalConsent(t *testing.T) {
+	// full consents to purposes and vendors 2, 6, 8 and special feature 1 opt-in
+	const fullConsentToPurposesAndVendorsTwoSixEight = "COzTVhaOzTVhaGvAAAENAiCIAP_AAH_AAAAAAEEUACCKAAA"
+
+	vendorListData := MarshalVendorList(buildVendorList34())
+	tcf2AggConfig := allPurposesEnabledTCF2Config()
+
+	perms := permissionsImpl{
+		cfg:          &tcf2AggConfig,
+		hostVendorID: 2,
+		vendorIDs: map[openrtb_ext.BidderName]uint16{
+			openrtb_ext.BidderRubicon: 8,
+		},
+		atpProviderIDs: map[openrtb_ext.BidderName]int{
+			openrtb_ext.BidderRubicon:         50,
+			openrtb_ext.BidderAudienceNetwork: 89,
+		},
+		fetchVendorList: listFetcher(map[uint16]map[uint16]vendorlist.VendorList{
+			2: {
+				34: parseVendorListDataV2(t, vendorListData),
+			},
+		}),
+		purposeEnforcerBuilder: NewPurposeEnforcerBuilder(&tcf2AggConfig),
+		consent:                fullConsentToPurposesAndVendorsTwoSixEight,
+		gdprSignal:             SignalYes,
+	}
+
+	tests := []struct {
+		name              string
+		bidder            openrtb_ext.BidderName
+		additionalConsent string
+		expected          bool
+	}{
+		{
+			name:              "atp_only_consented",
+			bidder:            openrtb_ext.BidderAudienceNetwork,
+			additionalConsent: "1~89",
+			expected:          true,
+		},
+		{
+			name:              "atp_only_not_consented",
+			bidder:            openrtb_ext.BidderAudienceNetwork,
+			additionalConsent: "1~1",
+			expected:          false,
+		},
+		{
+			name:              "gvl_and_atp_consented",
+			bidder:            openrtb_ext.BidderRubicon,
+			additionalConsent: "1~50",
+			expected:          true,
+		},
+		{
+			name:              "gvl_and_atp_not_consented",
+			bidder:            openrtb_ext.BidderRubicon,
+			additionalConsent: "1~1",
+			expected:          false,
+		},
+		{
+			name:              "gvl_and_atp_no_additional_consent",
+			bidder:            openrtb_ext.BidderRubicon,
+			additionalConsent: "",
+			expected:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			perms.additionalConsent = parseAdditionalConsent(tt.additionalConsent)
+
+			allowed, err := perms.BidderSyncAllowed(context.Background(), tt.bidder)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
@@ -13,17 +13,27 @@ type BasicEnforcement struct {
 	cfg purposeConfig
 }
 
-// LegalBasis determines if legal basis is satisfied for a given purpose and bidder/analytics adapter based on user consent
-// and legal basis signals.
+// LegalBasis determines if legal basis is satisfied for a given purpose and bidder/analytics adapter based on user consent,
+// legal basis signals and publisher restrictions.
 func (be *BasicEnforcement) LegalBasis(vendorInfo VendorInfo, name string, consent tcf2.ConsentMetadata, overrides Overrides) bool {
 	enforcePurpose, enforceVendors := be.applyEnforceOverrides(overrides)
 
+	restriction, restricted := getPubRestriction(consent, be.cfg.PurposeID, vendorInfo.vendorID)
+	if restricted && restriction.Type == PubRestrictNotAllowed {
+		return false
+	}
 	if !enforcePurpose && !enforceVendors {
 		return true
 	}
 	if be.cfg.vendorException(name) && !overrides.blockVendorExceptions {
 		return true
 	}
+	if restricted && restriction.Type == PubRestrictRequireLegitInterest {
+		return be.legitInterestEstablished(consent, vendorInfo, name, enforcePurpose, enforceVendors)
+	}
+	if restricted && restriction.Type == PubRestrictRequireConsent {
+		overrides.allowLITransparency = false
+	}
 	if !enforcePurpose && be.cfg.basicEnforcementVendor(name) {
 		return true
 	}
@@ -55,3 +65,16 @@ func (be *BasicEnforcement) applyEnforceOverrides(overrides Overrides) (enforceP
 	}
 	return
 }
+
+// legitInterestEstablished determines if legitimate interest has been established for a given purpose and
+// bidder/analytics adapter based on the legitimate interest transparency signals of the user. Basic enforcement
+// vendors are not required to have a vendor legitimate interest signal.
+func (be *BasicEnforcement) legitInterestEstablished(consent tcf2.ConsentMetadata, vendorInfo VendorInfo, name string, enforcePurpose bool, enforceVendors bool) bool {
+	if enforcePurpose && !consent.PurposeLITransparency(be.cfg.PurposeID) {
+		return false
+	}
+	if !enforceVendors || be.cfg.basicEnforcementVendor(name) {
+		return true
+	}
+	return consent.VendorLegitInterest(vendorInfo.vendorID)
+}
@@ -241,3 +241,127 @@ func TestBasicLegalBasis(t *testing.T) {
 		assert.Equal(t, tt.wantResult, result, tt.description)
 	}
 }
+
+func TestBasicLegalBasisWithPubRestrictions(t *testing.T) {
+	var (
+		appnexus   = string(openrtb_ext.BidderAppnexus)
+		appnexusID = uint16(32)
+	)
+
+	P1P2P3PurposeConsentAndV32VendorConsentWithP1P2P3V32RestrictionAllowNone := "CPfMKEAPfMKEAAAAAAENCgCAAOAAAAAAAAAAAQAAAAAEAIAAAAAAAGCAAgAgCAAQAQBgAIAIAAAA"
+	P1P2P3PurposeLIWithP1P2P3V32RestrictionRequireConsent := "CPfFkMAPfFkMAAAAAAENCgCAAAAAAOAAAAAAAQAAAAAAAIAAAAAAAGCgAgAgCQAQAQBoAIAIAAAA"
+	P1P2P3PurposeConsentAndV32VendorConsentWithP1P2P3V32RestrictionRequireConsent := "CPfFkMAPfFkMAAAAAAENCgCAAOAAAAAAAAAAAQAAAAAEAIAAAAAAAGCgAgAgCQAQAQBoAIAIAAAA"
+	P1P2P3PurposeLIWithP1P2P3V32RestrictionRequireLI := "CPfFkMAPfFkMAAAAAAENCgCAAAAAAOAAAAAAAQAAAAAAAIAAAAAAAGDAAgAgCgAQAQBwAIAIAAAA"
+	P1P2P3PurposeConsentAndV32VendorConsentWithP1P2P3V32RestrictionRequireLI := "CPfFkMAPfFkMAAAAAAENCgCAAOAAAAAAAAAAAQAAAAAEAIAAAAAAAGDAAgAgCgAQAQBwAIAIAAAA"
+	P1P2P3PurposeLIAndV32VendorLIWithP1P2P3V32RestrictionRequireLI := "CPfFkMAPfFkMAAAAAAENCgCAAAAAAOAAAAAAAQAAAAAAAIAAAAACAGDAAgAgCgAQAQBwAIAIAAAA"
+
+	tests := []struct {
+		description string
+		config      purposeConfig
+		consent     string
+		overrides   Overrides
+		wantResult  bool
+	}{
+		{
+			description: "restriction allow none, enforce purpose & vendors are off",
+			consent:     P1P2P3PurposeConsentAndV32VendorConsentWithP1P2P3V32RestrictionAllowNone,
+			config: purposeConfig{
+				PurposeID:      consentconstants.Purpose(2),
+				EnforcePurpose: false,
+				EnforceVendors: false,
+			},
+			wantResult: false,
+		},
+		{
+			description: "restriction allow none, enforce purpose & vendors are on, purpose consent Y, vendor consent Y",
+			consent:     P1P2P3PurposeConsentAndV32VendorConsentWithP1P2P3V32RestrictionAllowNone,
+			config: purposeConfig{
+				PurposeID:      consentconstants.Purpose(2),
+				EnforcePurpose: true,
+				EnforceVendors: true,
+			},
+			wantResult: false,
+		},
+		{
+			description: "restriction require consent, enforce purpose on, purpose LI Transparency Y, LI Transparency allowed",
+			consent:     P1P2P3PurposeLIWithP1P2P3V32RestrictionRequireConsent,
+			config: purposeConfig{
+				PurposeID:      consentconstants.Purpose(2),
+				EnforcePurpose: true,
+				EnforceVendors: false,
+			},
+			overrides:  Overrides{allowLITransparency: true},
+			wantResult: false,
+		},
+		{
+			description: "restriction require consent, enforce purpose & vendors are on, purpose consent Y, vendor consent Y",
+			consent:     P1P2P3PurposeConsentAndV32VendorConsentWithP1P2P3V32RestrictionRequireConsent,
+			config: purposeConfig{
+				PurposeID:      consentconstants.Purpose(2),
+				EnforcePurpose: true,
+				EnforceVendors: true,
+			},
+			wantResult: true,
+		},
+		{
+			description: "restriction require LI, enforce purpose & vendors are on, purpose consent Y, vendor consent Y",
+			consent:     P1P2P3PurposeConsentAndV32VendorConsentWithP1P2P3V32RestrictionRequireLI,
+			config: purposeConfig{
+				PurposeID:      consentconstants.Purpose(2),
+				EnforcePurpose: true,
+				EnforceVendors: true,
+			},
+			wantResult: false,
+		},
+		{
+			description: "restriction require LI, enforce purpose & vendors are on, purpose LI Transparency Y, vendor LI Y",
+			consent:     P1P2P3PurposeLIAndV32VendorLIWithP1P2P3V32RestrictionRequireLI,
+			config: purposeConfig{
+				PurposeID:      consentconstants.Purpose(2),
+				EnforcePurpose: true,
+				EnforceVendors: true,
+			},
+			wantResult: true,
+		},
+		{
+			description: "restriction require LI, enforce purpose & vendors are on, purpose LI Transparency Y, vendor LI N",
+			consent:     P1P2P3PurposeLIWithP1P2P3V32RestrictionRequireLI,
+			config: purposeConfig{
+				PurposeID:      consentconstants.Purpose(2),
+				EnforcePurpose: true,
+				EnforceVendors: true,
+			},
+			wantResult: false,
+		},
+		{
+			description: "restriction require LI, enforce purpose & vendors are on, purpose LI Transparency Y, vendor LI N, basic enforcement vendor",
+			consent:     P1P2P3PurposeLIWithP1P2P3V32RestrictionRequireLI,
+			config: purposeConfig{
+				PurposeID:                  consentconstants.Purpose(2),
+				EnforcePurpose:             true,
+				EnforceVendors:             true,
+				BasicEnforcementVendorsMap: map[string]struct{}{appnexus: {}},
+			},
+			wantResult: true,
+		},
+	}
+
+	for _, tt := range tests {
+		// convert consent string to TCF2 object
+		parsedConsent, err := vendorconsent.ParseString(tt.consent)
+		if err != nil {
+			t.Fatalf("Failed to parse consent %s: %s\n", tt.consent, tt.description)
+		}
+		consentMeta, ok := parsedConsent.(tcf2.ConsentMetadata)
+		if !ok {
+			t.Fatalf("Failed to convert consent %s: %s\n", tt.consent, tt.description)
+		}
+
+		enforcer := BasicEnforcement{cfg: tt.config}
+
+		vendorInfo := VendorInfo{vendorID: appnexusID, vendor: nil}
+		result := enforcer.LegalBasis(vendorInfo, appnexus, consentMeta, tt.overrides)
+
+		assert.Equal(t, tt.wantResult, result, tt.description)
+	}
+}
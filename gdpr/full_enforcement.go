@@ -4,12 +4,6 @@ import (
 	tcf2 "github.com/prebid/go-gdpr/vendorconsent/tcf2"
 )
 
-const (
-	pubRestrictNotAllowed           = 0
-	pubRestrictRequireConsent       = 1
-	pubRestrictRequireLegitInterest = 2
-)
-
 // FullEnforcement determines if legal basis is satisfied for a given purpose and bidde/analytics adapterr using
 // the TCF2 full enforcement algorithm. The algorithm is a detailed confirmation that reads the
 // GVL, interprets the consent string and performs legal basis analysis necessary to perform a
@@ -24,7 +18,8 @@ type FullEnforcement struct {
 func (fe *FullEnforcement) LegalBasis(vendorInfo VendorInfo, name string, consent tcf2.ConsentMetadata, overrides Overrides) bool {
 	enforcePurpose, enforceVendors := fe.applyEnforceOverrides(overrides)
 
-	if consent.CheckPubRestriction(uint8(fe.cfg.PurposeID), pubRestrictNotAllowed, vendorInfo.vendorID) {
+	restriction, restricted := getPubRestriction(consent, fe.cfg.PurposeID, vendorInfo.vendorID)
+	if restricted && restriction.Type == PubRestrictNotAllowed {
 		return false
 	}
 	if !enforcePurpose && !enforceVendors {
@@ -37,10 +32,10 @@ func (fe *FullEnforcement) LegalBasis(vendorInfo VendorInfo, name string, consen
 	purposeAllowed := fe.consentEstablished(consent, vendorInfo, enforcePurpose, enforceVendors)
 	legitInterest := fe.legitInterestEstablished(consent, vendorInfo, enforcePurpose, enforceVendors)
 
-	if consent.CheckPubRestriction(uint8(fe.cfg.PurposeID), pubRestrictRequireConsent, vendorInfo.vendorID) {
+	if restricted && restriction.Type == PubRestrictRequireConsent {
 		return purposeAllowed
 	}
-	if consent.CheckPubRestriction(uint8(fe.cfg.PurposeID), pubRestrictRequireLegitInterest, vendorInfo.vendorID) {
+	if restricted && restriction.Type == PubRestrictRequireLegitInterest {
 		return legitInterest
 	}
 
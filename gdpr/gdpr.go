@@ -28,28 +28,30 @@ type Permissions interface {
 type PermissionsBuilder func(TCF2ConfigReader, RequestInfo) Permissions
 
 type RequestInfo struct {
-	AliasGVLIDs map[string]uint16
-	Consent     string
-	GDPRSignal  Signal
-	PublisherID string
+	AdditionalConsent string
+	AliasGVLIDs       map[string]uint16
+	Consent           string
+	GDPRSignal        Signal
+	PublisherID       string
 }
 
 // NewPermissionsBuilder takes host config data used to configure the builder function it returns
-func NewPermissionsBuilder(cfg config.GDPR, gvlVendorIDs map[openrtb_ext.BidderName]uint16, validGVLVendorIDs *LiveGVLVendorIDs, vendorListFetcher VendorListFetcher, me metrics.MetricsEngine) PermissionsBuilder {
+func NewPermissionsBuilder(cfg config.GDPR, gvlVendorIDs map[openrtb_ext.BidderName]uint16, atpProviderIDs map[openrtb_ext.BidderName]int, validGVLVendorIDs *LiveGVLVendorIDs, vendorListFetcher VendorListFetcher, me metrics.MetricsEngine) PermissionsBuilder {
 	return func(tcf2Cfg TCF2ConfigReader, requestInfo RequestInfo) Permissions {
 		purposeEnforcerBuilder := NewPurposeEnforcerBuilder(tcf2Cfg)
 
-		return NewPermissions(cfg, tcf2Cfg, gvlVendorIDs, validGVLVendorIDs, vendorListFetcher, purposeEnforcerBuilder, requestInfo, me)
+		return NewPermissions(cfg, tcf2Cfg, gvlVendorIDs, atpProviderIDs, validGVLVendorIDs, vendorListFetcher, purposeEnforcerBuilder, requestInfo, me)
 	}
 }
 
 // NewPermissions gets a per-request Permissions object that can then be used to check GDPR permissions for a given bidder.
-func NewPermissions(cfg config.GDPR, tcf2Config TCF2ConfigReader, vendorIDs map[openrtb_ext.BidderName]uint16, validGVLVendorIDs *LiveGVLVendorIDs, fetcher VendorListFetcher, purposeEnforcerBuilder PurposeEnforcerBuilder, requestInfo RequestInfo, metricsEngine metrics.MetricsEngine) Permissions {
+func NewPermissions(cfg config.GDPR, tcf2Config TCF2ConfigReader, vendorIDs map[openrtb_ext.BidderName]uint16, atpProviderIDs map[openrtb_ext.BidderName]int, validGVLVendorIDs *LiveGVLVendorIDs, fetcher VendorListFetcher, purposeEnforcerBuilder PurposeEnforcerBuilder, requestInfo RequestInfo, metricsEngine metrics.MetricsEngine) Permissions {
 	if !cfg.Enabled {
 		return &AlwaysAllow{}
 	}
 
 	permissionsImpl := &permissionsImpl{
+		atpProviderIDs:         atpProviderIDs,
 		fetchVendorList:        fetcher,
 		gdprDefaultValue:       cfg.DefaultValue,
 		hostVendorID:           cfg.HostVendorID,
@@ -60,6 +62,7 @@ func NewPermissions(cfg config.GDPR, tcf2Config TCF2ConfigReader, vendorIDs map[
 		publisherID:            requestInfo.PublisherID,
 		gdprSignal:             SignalNormalize(requestInfo.GDPRSignal, cfg.DefaultValue),
 		consent:                requestInfo.Consent,
+		additionalConsent:      parseAdditionalConsent(requestInfo.AdditionalConsent),
 		aliasGVLIDs:            requestInfo.AliasGVLIDs,
 		purposeEnforcerBuilder: purposeEnforcerBuilder,
 		metrics:                metricsEngine,
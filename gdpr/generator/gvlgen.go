@@ -0,0 +1,67 @@
+//go:build ignore
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/prebid/go-gdpr/vendorlist2"
+)
+
+// specVersions are the GVL specification versions supported for TCF enforcement
+var specVersions = []uint16{2, 3}
+
+// main downloads the latest Global Vendor List of each supported specification version into the gvl directory, so
+// that they are embedded into the binary. The lists previously downloaded are replaced.
+func main() {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	for _, specVersion := range specVersions {
+		url := "https://vendor-list.consensu.org/v" + strconv.Itoa(int(specVersion)) + "/vendor-list.json"
+		body, err := download(client, url)
+		if err != nil {
+			panic(fmt.Sprintf("failed to download %s: %s", url, err))
+		}
+
+		list, err := vendorlist2.ParseEagerly(body)
+		if err != nil {
+			panic(fmt.Sprintf("vendor list %s is malformed: %s", url, err))
+		}
+		if list.SpecVersion() != specVersion {
+			panic(fmt.Sprintf("vendor list %s has spec version %d", url, list.SpecVersion()))
+		}
+
+		dir := filepath.Join("gvl", "v"+strconv.Itoa(int(specVersion)))
+		if err := os.RemoveAll(dir); err != nil {
+			panic(fmt.Sprintf("failed to clear %s: %s", dir, err))
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			panic(fmt.Sprintf("failed to create %s: %s", dir, err))
+		}
+
+		fileName := filepath.Join(dir, "vendor-list-v"+strconv.Itoa(int(list.Version()))+".json")
+		if err := os.WriteFile(fileName, body, 0644); err != nil {
+			panic(fmt.Sprintf("failed to write %s: %s", fileName, err))
+		}
+		fmt.Printf("%s successfully downloaded\n", fileName)
+	}
+}
+
+func download(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -17,6 +17,7 @@ const noBidder openrtb_ext.BidderName = ""
 // permissionsImpl implements the Permissions interface
 type permissionsImpl struct {
 	// global
+	atpProviderIDs         map[openrtb_ext.BidderName]int
 	fetchVendorList        VendorListFetcher
 	gdprDefaultValue       string
 	hostVendorID           int
@@ -26,11 +27,12 @@ type permissionsImpl struct {
 	validGVLVendorIDs      *LiveGVLVendorIDs
 	vendorIDs              map[openrtb_ext.BidderName]uint16
 	// request-specific
-	aliasGVLIDs map[string]uint16
-	cfg         TCF2ConfigReader
-	consent     string
-	gdprSignal  Signal
-	publisherID string
+	additionalConsent map[int]struct{}
+	aliasGVLIDs       map[string]uint16
+	cfg               TCF2ConfigReader
+	consent           string
+	gdprSignal        Signal
+	publisherID       string
 }
 
 // HostCookiesAllowed determines whether the host is allowed to set cookies on the user's device
@@ -48,6 +50,15 @@ func (p *permissionsImpl) BidderSyncAllowed(ctx context.Context, bidder openrtb_
 		return true, nil
 	}
 
+	if applies, consented := p.atpConsent(bidder, bidder); applies {
+		if !consented {
+			return false, nil
+		}
+		if _, ok := p.vendorIDs[bidder]; !ok {
+			return true, nil
+		}
+	}
+
 	id, ok := p.vendorIDs[bidder]
 	if ok && p.isValidVendorID(id) {
 		vendorExceptions := p.cfg.PurposeVendorExceptions(consentconstants.Purpose(1))
@@ -68,6 +79,28 @@ func (p *permissionsImpl) AuctionActivitiesAllowed(ctx context.Context, bidderCo
 		return AllowAll
 	}
 
+	if applies, consented := p.atpConsent(bidderCoreName, bidder); applies {
+		// the additional consent string is the only legal basis for providers not registered with the IAB
+		if _, ok := p.resolveVendorID(bidderCoreName, bidder); !ok {
+			if consented {
+				return AllowAll
+			}
+			return DenyAll
+		}
+		if !consented {
+			perms := p.tcf2AuctionActivitiesAllowed(ctx, bidderCoreName, bidder)
+			perms.PassGeo = false
+			perms.PassID = false
+			return perms
+		}
+	}
+
+	return p.tcf2AuctionActivitiesAllowed(ctx, bidderCoreName, bidder)
+}
+
+// tcf2AuctionActivitiesAllowed determines whether auction activities are permitted for a given bidder
+// by the TCF consent string
+func (p *permissionsImpl) tcf2AuctionActivitiesAllowed(ctx context.Context, bidderCoreName openrtb_ext.BidderName, bidder openrtb_ext.BidderName) AuctionPermissions {
 	if p.consent == "" {
 		return p.defaultPermissions()
 	}
@@ -88,6 +121,7 @@ func (p *permissionsImpl) AuctionActivitiesAllowed(ctx context.Context, bidderCo
 		AllowBidRequest: p.allowBidRequest(bidderCoreName, pc.consentMeta, vendorInfo),
 		PassGeo:         p.allowGeo(bidderCoreName, pc.consentMeta, vendor),
 		PassID:          p.allowID(bidderCoreName, pc.consentMeta, vendorInfo),
+		PubRestrictions: getPubRestrictions(pc.consentMeta, consentconstants.Purpose(2), consentconstants.Purpose(10), vendorID),
 	}
 }
 
@@ -152,6 +186,11 @@ func (p *permissionsImpl) allowSync(ctx context.Context, vendorID uint16, bidder
 	}
 	vendorInfo := VendorInfo{vendorID: vendorID, vendor: vendor}
 
+	// a publisher restriction disallowing purpose 1 applies regardless of the enforcement config
+	if restriction, ok := getPubRestriction(pc.consentMeta, consentconstants.Purpose(1), vendorID); ok && restriction.Type == PubRestrictNotAllowed {
+		return false, nil
+	}
+
 	if !p.cfg.PurposeEnforced(consentconstants.Purpose(1)) {
 		return true, nil
 	}
@@ -4,6 +4,8 @@ type AuctionPermissions struct {
 	AllowBidRequest bool
 	PassGeo         bool
 	PassID          bool
+	// PubRestrictions are the TCF publisher restrictions which applied to the bidder, for debugging
+	PubRestrictions []PubRestriction
 }
 
 var AllowAll = AuctionPermissions{
@@ -0,0 +1,63 @@
+package gdpr
+
+import (
+	"fmt"
+
+	"github.com/prebid/go-gdpr/consentconstants"
+	tcf2 "github.com/prebid/go-gdpr/vendorconsent/tcf2"
+)
+
+// PubRestrictionType is the type of a TCF publisher restriction as encoded in the consent string
+type PubRestrictionType uint8
+
+const (
+	PubRestrictNotAllowed           PubRestrictionType = 0
+	PubRestrictRequireConsent       PubRestrictionType = 1
+	PubRestrictRequireLegitInterest PubRestrictionType = 2
+)
+
+func (t PubRestrictionType) String() string {
+	switch t {
+	case PubRestrictNotAllowed:
+		return "not allowed"
+	case PubRestrictRequireConsent:
+		return "require consent"
+	case PubRestrictRequireLegitInterest:
+		return "require legitimate interest"
+	}
+	return "unknown"
+}
+
+// PubRestriction is a TCF publisher restriction set by the CMP of the publisher for a purpose and vendor
+type PubRestriction struct {
+	Purpose  consentconstants.Purpose
+	VendorID uint16
+	Type     PubRestrictionType
+}
+
+func (r PubRestriction) String() string {
+	return fmt.Sprintf("publisher restriction %q for purpose %d applied to vendor %d", r.Type, r.Purpose, r.VendorID)
+}
+
+// getPubRestriction returns the publisher restriction the consent string sets for the purpose and vendor, if any.
+// The TCF allows at most one restriction per purpose and vendor.
+func getPubRestriction(consent tcf2.ConsentMetadata, purpose consentconstants.Purpose, vendorID uint16) (PubRestriction, bool) {
+	for _, restrictType := range []PubRestrictionType{PubRestrictNotAllowed, PubRestrictRequireConsent, PubRestrictRequireLegitInterest} {
+		if consent.CheckPubRestriction(uint8(purpose), uint8(restrictType), vendorID) {
+			return PubRestriction{Purpose: purpose, VendorID: vendorID, Type: restrictType}, true
+		}
+	}
+	return PubRestriction{}, false
+}
+
+// getPubRestrictions returns the publisher restrictions the consent string sets for the vendor on any of
+// the purposes from the first to the last, inclusive
+func getPubRestrictions(consent tcf2.ConsentMetadata, first, last consentconstants.Purpose, vendorID uint16) []PubRestriction {
+	var restrictions []PubRestriction
+	for purpose := first; purpose <= last; purpose++ {
+		if restriction, ok := getPubRestriction(consent, purpose, vendorID); ok {
+			restrictions = append(restrictions, restriction)
+		}
+	}
+	return restrictions
+}
@@ -0,0 +1,135 @@
+package gdpr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prebid/go-gdpr/consentconstants"
+	"github.com/prebid/go-gdpr/vendorconsent"
+	tcf2 "github.com/prebid/go-gdpr/vendorconsent/tcf2"
+	"github.com/prebid/go-gdpr/vendorlist"
+	"github.com/prebid/prebid-server/v4/metrics"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPubRestrictions(t *testing.T) {
+	tests := []struct {
+		name     string
+		consent  string
+		vendorID uint16
+		expected []PubRestriction
+	}{
+		{
+			name:     "none",
+			consent:  "CPfCRQAPfCRQAAAAAAENCgCAAAAAAAAAAAAAAAAAAAAA",
+			vendorID: 32,
+			expected: nil,
+		},
+		{
+			name:     "not-allowed",
+			consent:  "CPfMKEAPfMKEAAAAAAENCgCAAAAAAAAAAAAAAQAAAAAAAIAAAAAAAGCAAgAgCAAQAQBgAIAIAAAA",
+			vendorID: 32,
+			expected: []PubRestriction{
+				{Purpose: 1, VendorID: 32, Type: PubRestrictNotAllowed},
+				{Purpose: 2, VendorID: 32, Type: PubRestrictNotAllowed},
+				{Purpose: 3, VendorID: 32, Type: PubRestrictNotAllowed},
+			},
+		},
+		{
+			name:     "require-consent",
+			consent:  "CPfFkMAPfFkMAAAAAAENCgCAAAAAAAAAAAAAAQAAAAAAAIAAAAAAAGCgAgAgCQAQAQBoAIAIAAAA",
+			vendorID: 32,
+			expected: []PubRestriction{
+				{Purpose: 1, VendorID: 32, Type: PubRestrictRequireConsent},
+				{Purpose: 2, VendorID: 32, Type: PubRestrictRequireConsent},
+				{Purpose: 3, VendorID: 32, Type: PubRestrictRequireConsent},
+			},
+		},
+		{
+			name:     "require-legit-interest",
+			consent:  "CPfFkMAPfFkMAAAAAAENCgCAAAAAAAAAAAAAAQAAAAAAAIAAAAAAAGDAAgAgCgAQAQBwAIAIAAAA",
+			vendorID: 32,
+			expected: []PubRestriction{
+				{Purpose: 1, VendorID: 32, Type: PubRestrictRequireLegitInterest},
+				{Purpose: 2, VendorID: 32, Type: PubRestrictRequireLegitInterest},
+				{Purpose: 3, VendorID: 32, Type: PubRestrictRequireLegitInterest},
+			},
+		},
+		{
+			name:     "other-vendor",
+			consent:  "CPfMKEAPfMKEAAAAAAENCgCAAAAAAAAAAAAAAQAAAAAAAIAAAAAAAGCAAgAgCAAQAQBgAIAIAAAA",
+			vendorID: 33,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsedConsent, err := vendorconsent.ParseString(tt.consent)
+			require.NoError(t, err)
+			consentMeta, ok := parsedConsent.(tcf2.ConsentMetadata)
+			require.True(t, ok)
+
+			result := getPubRestrictions(consentMeta, consentconstants.Purpose(1), consentconstants.Purpose(10), tt.vendorID)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestPubRestrictionString(t *testing.T) {
+	restriction := PubRestriction{Purpose: 2, VendorID: 32, Type: PubRestrictRequireConsent}
+	assert.Equal(t, `publisher restriction "require consent" for purpose 2 applied to vendor 32`, restriction.String())
+}
+
+func TestPubRestrictionsEnforcement(t *testing.T) {
+	const (
+		noConsentsWithRestrictionAllowNone                          = "CPfMKEAPfMKEAAAAAAENCgCAAAAAAAAAAAAAAQAAAAAAAIAAAAAAAGCAAgAgCAAQAQBgAIAIAAAA"
+		purposeConsentAndVendorConsentWithRestrictionRequireConsent = "CPfFkMAPfFkMAAAAAAENCgCAAOAAAAAAAAAAAQAAAAAEAIAAAAAAAGCgAgAgCQAQAQBoAIAIAAAA"
+	)
+
+	vendorList := getVendorList(t)
+	fetcher := func(ctx context.Context, specVersion, listVersion uint16, me metrics.MetricsEngine) (vendorlist.VendorList, error) {
+		return vendorList, nil
+	}
+
+	t.Run("sync-not-allowed-regardless-of-config", func(t *testing.T) {
+		tcf2AggConfig := allPurposesEnabledTCF2Config()
+		tcf2AggConfig.HostConfig.Purpose1.EnforcePurpose = false
+
+		perms := permissionsImpl{
+			cfg:                    &tcf2AggConfig,
+			vendorIDs:              map[openrtb_ext.BidderName]uint16{openrtb_ext.BidderAppnexus: 32},
+			fetchVendorList:        fetcher,
+			purposeEnforcerBuilder: NewPurposeEnforcerBuilder(&tcf2AggConfig),
+			consent:                noConsentsWithRestrictionAllowNone,
+			gdprSignal:             SignalYes,
+		}
+
+		allowed, err := perms.BidderSyncAllowed(context.Background(), openrtb_ext.BidderAppnexus)
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("auction-reports-restrictions", func(t *testing.T) {
+		tcf2AggConfig := allPurposesEnabledTCF2Config()
+
+		perms := permissionsImpl{
+			cfg:                    &tcf2AggConfig,
+			vendorIDs:              map[openrtb_ext.BidderName]uint16{openrtb_ext.BidderAppnexus: 32},
+			fetchVendorList:        fetcher,
+			purposeEnforcerBuilder: NewPurposeEnforcerBuilder(&tcf2AggConfig),
+			consent:                purposeConsentAndVendorConsentWithRestrictionRequireConsent,
+			gdprSignal:             SignalYes,
+		}
+
+		// vendor 32 claims legitimate interest for purpose 2, so the restriction denies the bid request
+		permissions := perms.AuctionActivitiesAllowed(context.Background(), openrtb_ext.BidderAppnexus, openrtb_ext.BidderAppnexus)
+		assert.False(t, permissions.AllowBidRequest)
+		assert.Equal(t, []PubRestriction{
+			{Purpose: 2, VendorID: 32, Type: PubRestrictRequireConsent},
+			{Purpose: 3, VendorID: 32, Type: PubRestrictRequireConsent},
+		}, permissions.PubRestrictions)
+	})
+}
@@ -32,15 +32,20 @@ const latestSpecVersion = 3
 //
 // Nothing in this file is exported. Public APIs can be found in gdpr.go
 
-func NewVendorListFetcher(initCtx context.Context, cfg config.GDPR, client *http.Client, metricsEngine metrics.MetricsEngine, urlMaker func(uint16, uint16) string) VendorListFetcher {
+// NewVendorListFetcher returns a fetcher of vendor lists along with an uploader which adds vendor lists to those
+// known to the fetcher. The lists embedded in the binary and those in the local vendor list directory are loaded
+// before preloading lists over the network.
+func NewVendorListFetcher(initCtx context.Context, cfg config.GDPR, client *http.Client, metricsEngine metrics.MetricsEngine, urlMaker func(uint16, uint16) string) (VendorListFetcher, VendorListUploader) {
 	cacheSave, cacheLoad := newVendorListCache()
 
+	loadLocalVendorLists(cfg.VendorListDir, cacheSave)
+
 	preloadContext, cancel := context.WithTimeout(initCtx, cfg.Timeouts.InitTimeout())
 	defer cancel()
 	preloadCache(preloadContext, client, urlMaker, cacheSave, metricsEngine)
 
 	saveOneRateLimited := newOccasionalSaver(cfg.Timeouts.ActiveTimeout())
-	return func(ctx context.Context, specVersion, listVersion uint16, metricsEngine metrics.MetricsEngine) (vendorlist.VendorList, error) {
+	fetcher := func(ctx context.Context, specVersion, listVersion uint16, metricsEngine metrics.MetricsEngine) (vendorlist.VendorList, error) {
 		// Attempt To Load From Cache
 		if list := cacheLoad(specVersion, listVersion); list != nil {
 			return list, nil
@@ -59,6 +64,7 @@ func NewVendorListFetcher(initCtx context.Context, cfg config.GDPR, client *http
 		// Give Up
 		return nil, makeVendorListNotFoundError(specVersion, listVersion)
 	}
+	return fetcher, newVendorListUploader(cfg.VendorListDir, cacheSave)
 }
 
 func makeVendorListNotFoundError(specVersion, listVersion uint16) error {
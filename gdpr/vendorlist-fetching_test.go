@@ -98,7 +98,7 @@ func TestFetcherThrottling(t *testing.T) {
 
 	m := &metrics.MetricsEngineMock{}
 	m.On("RecordGvlListRequest").Times(3)
-	fetcher := NewVendorListFetcher(context.Background(), testConfig(), server.Client(), m, testURLMaker(server))
+	fetcher, _ := NewVendorListFetcher(context.Background(), testConfig(), server.Client(), m, testURLMaker(server))
 
 	// Dynamically Load List 2 Successfully
 	_, errList1 := fetcher(context.Background(), 3, 2, m)
@@ -123,7 +123,7 @@ func TestMalformedVendorlist(t *testing.T) {
 
 	m := &metrics.MetricsEngineMock{}
 	m.On("RecordGvlListRequest").Times(3)
-	fetcher := NewVendorListFetcher(context.Background(), testConfig(), server.Client(), m, testURLMaker(server))
+	fetcher, _ := NewVendorListFetcher(context.Background(), testConfig(), server.Client(), m, testURLMaker(server))
 	_, err := fetcher(context.Background(), 3, 1, m)
 
 	// Fetching should fail since vendor list could not be unmarshalled.
@@ -138,7 +138,7 @@ func TestServerUrlInvalid(t *testing.T) {
 
 	m := &metrics.MetricsEngineMock{}
 	m.On("RecordGvlListRequest").Times(2)
-	fetcher := NewVendorListFetcher(context.Background(), testConfig(), server.Client(), m, invalidURLGenerator)
+	fetcher, _ := NewVendorListFetcher(context.Background(), testConfig(), server.Client(), m, invalidURLGenerator)
 	_, err := fetcher(context.Background(), 3, 1, m)
 
 	assert.EqualError(t, err, "gdpr vendor list spec version 3 list version 1 does not exist, or has not been loaded yet. Try again in a few minutes")
@@ -150,7 +150,7 @@ func TestServerUnavailable(t *testing.T) {
 
 	m := &metrics.MetricsEngineMock{}
 	m.On("RecordGvlListRequest").Times(2)
-	fetcher := NewVendorListFetcher(context.Background(), testConfig(), server.Client(), m, testURLMaker(server))
+	fetcher, _ := NewVendorListFetcher(context.Background(), testConfig(), server.Client(), m, testURLMaker(server))
 	_, err := fetcher(context.Background(), 3, 1, m)
 
 	assert.EqualError(t, err, "gdpr vendor list spec version 3 list version 1 does not exist, or has not been loaded yet. Try again in a few minutes")
@@ -393,7 +393,7 @@ func runTest(t *testing.T, test test, server *httptest.Server) {
 	config := testConfig()
 	m := &metrics.MetricsEngineMock{}
 	m.On("RecordGvlListRequest").Times(3)
-	fetcher := NewVendorListFetcher(context.Background(), config, server.Client(), m, testURLMaker(server))
+	fetcher, _ := NewVendorListFetcher(context.Background(), config, server.Client(), m, testURLMaker(server))
 	vendorList, err := fetcher(context.Background(), test.setup.specVersion, test.setup.listVersion, m)
 
 	if test.expected.errorMessage != "" {
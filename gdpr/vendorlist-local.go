@@ -0,0 +1,121 @@
+package gdpr
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/prebid/go-gdpr/api"
+	"github.com/prebid/go-gdpr/vendorlist2"
+	"github.com/prebid/prebid-server/v4/logger"
+)
+
+// embeddedVendorLists is the snapshot of Global Vendor Lists compiled into the binary. It's the last resort of
+// hosts without access to vendor-list.consensu.org. The snapshot of each supported spec version is refreshed by
+// go generate, which the build runs before compiling.
+//
+//go:generate go run ./generator/gvlgen.go
+//go:embed gvl
+var embeddedVendorLists embed.FS
+
+// VendorListUploader adds a Global Vendor List uploaded by the host to the lists available for TCF enforcement,
+// returning the list parsed from the body.
+type VendorListUploader func(body []byte) (api.VendorList, error)
+
+// loadVendorListFiles saves all the vendor lists found in the JSON files of the file system, including those in
+// subdirectories. Malformed files are logged and skipped.
+func loadVendorListFiles(fsys fs.FS, saver saveVendors) {
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			logger.Errorf("Error reading GDPR vendor list file %s: %v", name, err)
+			return nil
+		}
+		if entry.IsDir() || path.Ext(name) != ".json" {
+			return nil
+		}
+
+		body, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			logger.Errorf("Error reading GDPR vendor list file %s: %v", name, err)
+			return nil
+		}
+		list, err := vendorlist2.ParseEagerly(body)
+		if err != nil {
+			logger.Errorf("GDPR vendor list file %s is malformed: %v", name, err)
+			return nil
+		}
+		saver(list.SpecVersion(), list.Version(), list)
+		return nil
+	})
+	if err != nil {
+		logger.Errorf("Error loading GDPR vendor list files: %v", err)
+	}
+}
+
+// loadLocalVendorLists saves the vendor lists embedded in the binary followed by those in the local vendor list
+// directory, if configured, so the files of the directory take precedence.
+func loadLocalVendorLists(dir string, saver saveVendors) {
+	embedded, err := fs.Sub(embeddedVendorLists, "gvl")
+	if err != nil {
+		logger.Errorf("Error loading the embedded GDPR vendor lists: %v", err)
+	} else {
+		loadVendorListFiles(embedded, saver)
+	}
+
+	if dir != "" {
+		loadVendorListFiles(os.DirFS(dir), saver)
+	}
+}
+
+// newVendorListUploader returns an uploader which saves the uploaded vendor lists for use by the fetcher. If a
+// local vendor list directory is configured, the lists are also written to it so they are reloaded on restart.
+func newVendorListUploader(dir string, saver saveVendors) VendorListUploader {
+	return func(body []byte) (api.VendorList, error) {
+		list, err := vendorlist2.ParseEagerly(body)
+		if err != nil {
+			return nil, fmt.Errorf("malformed vendor list: %v", err)
+		}
+
+		if dir != "" {
+			if err := writeVendorListFile(dir, list.SpecVersion(), list.Version(), body); err != nil {
+				return nil, err
+			}
+		}
+
+		saver(list.SpecVersion(), list.Version(), list)
+		return list, nil
+	}
+}
+
+// writeVendorListFile writes the vendor list to the directory following the layout of vendor-list.consensu.org,
+// replacing any existing file atomically.
+func writeVendorListFile(dir string, specVersion, listVersion uint16, body []byte) error {
+	specDir := filepath.Join(dir, "v"+strconv.Itoa(int(specVersion)))
+	if err := os.MkdirAll(specDir, 0755); err != nil {
+		return fmt.Errorf("failed to create vendor list directory %s: %v", specDir, err)
+	}
+
+	file, err := os.CreateTemp(specDir, ".vendor-list-*")
+	if err != nil {
+		return fmt.Errorf("failed to write vendor list to %s: %v", specDir, err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(body); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write vendor list to %s: %v", file.Name(), err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write vendor list to %s: %v", file.Name(), err)
+	}
+
+	fileName := filepath.Join(specDir, "vendor-list-v"+strconv.Itoa(int(listVersion))+".json")
+	if err := os.Rename(file.Name(), fileName); err != nil {
+		return fmt.Errorf("failed to write vendor list to %s: %v", fileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package gdpr
+
+import (
+	"context"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/prebid/prebid-server/v4/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadVendorListFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"v3/vendor-list-v1.json": {Data: []byte(vendorList1)},
+		"vendor-list-v2.json":    {Data: []byte(vendorList2)},
+		"v3/malformed.json":      {Data: []byte(`{`)},
+		"README.md":              {Data: []byte(vendorList1)},
+	}
+
+	s := make(saver, 0, 2)
+	loadVendorListFiles(fsys, s.saveVendorLists)
+
+	expectedLoadedVersions := []versionInfo{
+		{specVersion: 3, listVersion: 1},
+		{specVersion: 3, listVersion: 2},
+	}
+	assert.ElementsMatch(t, expectedLoadedVersions, s)
+}
+
+func TestLoadLocalVendorLists(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vendor-list-v2.json"), []byte(vendorList2), 0644))
+
+	s := make(saver, 0, 3)
+	loadLocalVendorLists(dir, s.saveVendorLists)
+
+	// the lists of the directory are saved after the embedded ones, taking precedence
+	require.NotEmpty(t, s)
+	assert.Equal(t, versionInfo{specVersion: 3, listVersion: 2}, s[len(s)-1])
+}
+
+func TestEmbeddedVendorLists(t *testing.T) {
+	embedded, err := fs.Sub(embeddedVendorLists, "gvl")
+	require.NoError(t, err)
+	if files, _ := fs.Glob(embedded, "v*/*.json"); len(files) == 0 {
+		t.Skip("the embedded GVL snapshot is downloaded by go generate ./gdpr")
+	}
+
+	// the embedded snapshot is loaded without any network access
+	s := make(saver, 0, 2)
+	loadLocalVendorLists("", s.saveVendorLists)
+
+	specVersions := make(map[uint16]struct{}, len(s))
+	for _, loaded := range s {
+		specVersions[loaded.specVersion] = struct{}{}
+	}
+	assert.Equal(t, map[uint16]struct{}{2: {}, 3: {}}, specVersions)
+}
+
+func TestVendorListUploader(t *testing.T) {
+	testCases := []struct {
+		name          string
+		body          string
+		expectedSaved saver
+		expectedError string
+	}{
+		{
+			name:          "valid",
+			body:          vendorList2,
+			expectedSaved: saver{{specVersion: 3, listVersion: 2}},
+		},
+		{
+			name:          "malformed",
+			body:          `{`,
+			expectedError: "malformed vendor list",
+		},
+		{
+			name:          "missing_versions",
+			body:          MarshalVendorList(vendorList{Vendors: map[string]*vendor{"12": {ID: 12}}}),
+			expectedError: "malformed vendor list: data.vendorListVersion was 0 or undefined",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := saver{}
+			upload := newVendorListUploader(dir, s.saveVendorLists)
+
+			list, err := upload([]byte(test.body))
+
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				assert.Nil(t, list)
+				assert.Empty(t, s)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedSaved, s)
+
+			reloaded := saver{}
+			loadVendorListFiles(os.DirFS(dir), reloaded.saveVendorLists)
+			assert.Equal(t, test.expectedSaved, reloaded)
+			assert.FileExists(t, filepath.Join(dir, "v3", "vendor-list-v2.json"))
+		})
+	}
+}
+
+func TestFetcherLocalVendorListDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vendor-list-v2.json"), []byte(vendorList2), 0644))
+
+	cfg := testConfig()
+	cfg.VendorListDir = dir
+	invalidURLGenerator := func(uint16, uint16) string { return " http://invalid-url-has-leading-whitespace" }
+	m := &metrics.MetricsEngineMock{}
+	fetcher, upload := NewVendorListFetcher(context.Background(), cfg, http.DefaultClient, m, invalidURLGenerator)
+
+	list, err := fetcher(context.Background(), 3, 2, m)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(2), list.Version())
+
+	_, err = upload([]byte(vendorList1))
+	require.NoError(t, err)
+
+	list, err = fetcher(context.Background(), 3, 1, m)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(1), list.Version())
+}
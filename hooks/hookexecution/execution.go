@@ -336,7 +336,7 @@ func handleModuleActivities[P any](hookCode string, activityControl privacy.Acti
 
 	scopeGeneral := privacy.Component{Type: privacy.ComponentTypeGeneral, Name: hookCode}
 	transmitUserFPDActivityAllowed := activityControl.Allow(privacy.ActivityTransmitUserFPD, scopeGeneral, privacy.ActivityRequest{})
-	transmitPreciseGeoActivityAllowed := activityControl.Allow(privacy.ActivityTransmitPreciseGeo, scopeGeneral, privacy.ActivityRequest{})
+	transmitPreciseGeoActivityAllowed, precisionProfile := activityControl.AllowPreciseGeo(scopeGeneral, privacy.ActivityRequest{})
 
 	if transmitUserFPDActivityAllowed && transmitPreciseGeoActivityAllowed {
 		return payload
@@ -362,7 +362,7 @@ func handleModuleActivities[P any](hookCode string, activityControl privacy.Acti
 				IPV4: config.IPv4{AnonKeepBits: iputil.IPv4DefaultMaskingBitSize}}
 		}
 
-		privacy.ScrubGeoAndDeviceIP(bidderReqCopy, ipConf)
+		privacy.ScrubGeoAndDeviceIPWithProfile(bidderReqCopy, ipConf, precisionProfile)
 	}
 
 	var newPayload = payload
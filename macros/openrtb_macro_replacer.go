@@ -0,0 +1,61 @@
+package macros
+
+import (
+	"strings"
+)
+
+const (
+	openRTBMacroStart = "${"
+	openRTBMacroEnd   = "}"
+)
+
+// openRTBMacros lists the OpenRTB auction macros substituted by the openRTBMacroReplacer
+var openRTBMacros = map[string]struct{}{
+	MacroKeyOpenRTBAuctionID:       {},
+	MacroKeyOpenRTBAuctionBidID:    {},
+	MacroKeyOpenRTBAuctionImpID:    {},
+	MacroKeyOpenRTBAuctionSeatID:   {},
+	MacroKeyOpenRTBAuctionAdID:     {},
+	MacroKeyOpenRTBAuctionPrice:    {},
+	MacroKeyOpenRTBAuctionCurrency: {},
+}
+
+type openRTBMacroReplacer struct{}
+
+// NewOpenRTBMacroReplacer will return instance of the replacer of the OpenRTB auction macros, with the ${MACRO} format.
+// Unlike the string index based replacer, it doesn't cache the templates as it's meant for the bids markup, which
+// is different for every bid.
+func NewOpenRTBMacroReplacer() Replacer {
+	return &openRTBMacroReplacer{}
+}
+
+// Replace function replaces the OpenRTB auction macros in a given string with the data from macroProvider. Other
+// macros, such as ${AUCTION_LOSS} or the encoded ${AUCTION_PRICE:B64}, and the macros without a value are left
+// untouched for the parties downstream to substitute.
+func (s *openRTBMacroReplacer) Replace(result *strings.Builder, url string, macroProvider *MacroProvider) {
+	currentIndex := 0
+	for {
+		start := strings.Index(url[currentIndex:], openRTBMacroStart)
+		if start == -1 {
+			break
+		}
+		start += currentIndex
+		end := strings.Index(url[start+len(openRTBMacroStart):], openRTBMacroEnd)
+		if end == -1 {
+			break
+		}
+		end += start + len(openRTBMacroStart)
+
+		macro := url[start+len(openRTBMacroStart) : end]
+		if _, ok := openRTBMacros[macro]; !ok || macroProvider.macros[macro] == "" {
+			// not a macro to substitute, look for the next one right after its start
+			result.WriteString(url[currentIndex : start+len(openRTBMacroStart)])
+			currentIndex = start + len(openRTBMacroStart)
+			continue
+		}
+		result.WriteString(url[currentIndex:start])
+		result.WriteString(macroProvider.GetMacro(macro))
+		currentIndex = end + len(openRTBMacroEnd)
+	}
+	result.WriteString(url[currentIndex:])
+}
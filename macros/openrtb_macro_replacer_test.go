@@ -0,0 +1,68 @@
+package macros
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v4/exchange/entities"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenRTBMacroReplace(t *testing.T) {
+	getMacroProvider := func() *MacroProvider {
+		macroProvider := NewProvider(&openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "auction-1"}})
+		macroProvider.PopulateAuctionMacros(&entities.PbsOrtbBid{Bid: &openrtb2.Bid{ID: "bid-1", ImpID: "imp-1", AdID: "ad 1"}, ResponseBidID: "response-1", ResponseSeat: "seat-1"}, "EUR", 1.25)
+		return macroProvider
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "all_auction_macros",
+			url:  "http://win.com?id=${AUCTION_ID}&bid=${AUCTION_BID_ID}&imp=${AUCTION_IMP_ID}&seat=${AUCTION_SEAT_ID}&ad=${AUCTION_AD_ID}&price=${AUCTION_PRICE}&cur=${AUCTION_CURRENCY}",
+			want: "http://win.com?id=auction-1&bid=response-1&imp=imp-1&seat=seat-1&ad=ad+1&price=1.25&cur=EUR",
+		},
+		{
+			name: "no_macro",
+			url:  "http://win.com",
+			want: "http://win.com",
+		},
+		{
+			name: "other_macros_untouched",
+			url:  "http://win.com?loss=${AUCTION_LOSS}&price=${AUCTION_PRICE:B64}&pbs=${PBS-BIDDER}&p=${AUCTION_PRICE}",
+			want: "http://win.com?loss=${AUCTION_LOSS}&price=${AUCTION_PRICE:B64}&pbs=${PBS-BIDDER}&p=1.25",
+		},
+		{
+			name: "unterminated_macro",
+			url:  `<img src="http://win.com?p=${AUCTION_PRICE}&x=${AUCTION_PRICE">`,
+			want: `<img src="http://win.com?p=1.25&x=${AUCTION_PRICE">`,
+		},
+		{
+			name: "macro_inside_unknown_macro",
+			url:  "${x ${AUCTION_CURRENCY}",
+			want: "${x EUR",
+		},
+	}
+
+	replacer := NewOpenRTBMacroReplacer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := strings.Builder{}
+			replacer.Replace(&result, tt.url, getMacroProvider())
+			assert.Equal(t, tt.want, result.String())
+		})
+	}
+}
+
+func TestOpenRTBMacroReplaceWithoutAuctionMacros(t *testing.T) {
+	macroProvider := NewProvider(&openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{ID: "auction-1"}})
+
+	result := strings.Builder{}
+	NewOpenRTBMacroReplacer().Replace(&result, "http://win.com?id=${AUCTION_ID}&price=${AUCTION_PRICE}", macroProvider)
+	assert.Equal(t, "http://win.com?id=${AUCTION_ID}&price=${AUCTION_PRICE}", result.String())
+}
@@ -28,6 +28,17 @@ const (
 	MacroKeyVastEvent   = "PBS-VASTEVENT"
 )
 
+// OpenRTB auction macros, substituted in the bids markup and notification URLs once the auction clears
+const (
+	MacroKeyOpenRTBAuctionID       = "AUCTION_ID"
+	MacroKeyOpenRTBAuctionBidID    = "AUCTION_BID_ID"
+	MacroKeyOpenRTBAuctionImpID    = "AUCTION_IMP_ID"
+	MacroKeyOpenRTBAuctionSeatID   = "AUCTION_SEAT_ID"
+	MacroKeyOpenRTBAuctionAdID     = "AUCTION_AD_ID"
+	MacroKeyOpenRTBAuctionPrice    = "AUCTION_PRICE"
+	MacroKeyOpenRTBAuctionCurrency = "AUCTION_CURRENCY"
+)
+
 const (
 	customMacroLength = 100
 	CustomMacroPrefix = "PBS-MACRO-"
@@ -128,6 +139,19 @@ func (b *MacroProvider) PopulateBidMacros(bid *entities.PbsOrtbBid, seat string)
 	b.macros[MacroKeyBidder] = seat
 }
 
+// PopulateAuctionMacros sets the OpenRTB auction macros of a bid, the price being the clearing price of the bid in
+// the auction currency. The bid ID and the seat ID are the ones of the bidder response, and are left empty when the
+// adapter doesn't provide them so that the macros aren't substituted.
+func (b *MacroProvider) PopulateAuctionMacros(bid *entities.PbsOrtbBid, currency string, price float64) {
+	b.macros[MacroKeyOpenRTBAuctionID] = b.macros[MacroKeyAuctionID]
+	b.macros[MacroKeyOpenRTBAuctionBidID] = bid.ResponseBidID
+	b.macros[MacroKeyOpenRTBAuctionImpID] = bid.Bid.ImpID
+	b.macros[MacroKeyOpenRTBAuctionSeatID] = bid.ResponseSeat
+	b.macros[MacroKeyOpenRTBAuctionAdID] = bid.Bid.AdID
+	b.macros[MacroKeyOpenRTBAuctionPrice] = strconv.FormatFloat(price, 'f', -1, 64)
+	b.macros[MacroKeyOpenRTBAuctionCurrency] = currency
+}
+
 func (b *MacroProvider) PopulateEventMacros(vastCreativeID, eventType, vastEvent string) {
 	b.macros[MacroKeyVastCRTID] = vastCreativeID
 	b.macros[MacroKeyEventType] = eventType
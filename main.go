@@ -65,11 +65,33 @@ func loadConfig(bidderInfos config.BidderInfos) (*config.Configuration, error) {
 	return config.New(v, bidderInfos, openrtb_ext.NormalizeBidderName)
 }
 
+// newCurrencyRatesSources returns the currency rates sources in the configured order, falling back to the fetch URL
+func newCurrencyRatesSources(cfg config.CurrencyConverter) []currency.RatesSource {
+	httpClient := &http.Client{}
+	if len(cfg.Sources) == 0 {
+		return []currency.RatesSource{currency.NewHTTPRatesSource(httpClient, cfg.FetchURL)}
+	}
+
+	sources := make([]currency.RatesSource, 0, len(cfg.Sources))
+	for _, source := range cfg.Sources {
+		switch source.Type {
+		case config.CurrencyRatesSourceJSON:
+			sources = append(sources, currency.NewHTTPRatesSource(httpClient, source.URL))
+		case config.CurrencyRatesSourceECB:
+			sources = append(sources, currency.NewECBRatesSource(httpClient, source.URL))
+		case config.CurrencyRatesSourceFile:
+			sources = append(sources, currency.NewFileRatesSource(source.Path))
+		}
+	}
+	return sources
+}
+
 func serve(cfg *config.Configuration) error {
 	httpTimeout := time.Duration(cfg.CurrencyConverter.FetchTimeoutMilliseconds) * time.Millisecond
 	fetchingInterval := time.Duration(cfg.CurrencyConverter.FetchIntervalSeconds) * time.Second
 	staleRatesThreshold := time.Duration(cfg.CurrencyConverter.StaleRatesSeconds) * time.Second
-	currencyConverter := currency.NewRateConverter(&http.Client{}, httpTimeout, cfg.CurrencyConverter.FetchURL, staleRatesThreshold)
+	historyRetention := time.Duration(cfg.CurrencyConverter.HistoryRetentionSeconds) * time.Second
+	currencyConverter := currency.NewRateConverterWithSources(httpTimeout, newCurrencyRatesSources(cfg.CurrencyConverter), staleRatesThreshold, historyRetention, cfg.CurrencyConverter.SnapshotFile)
 
 	currencyConverterTickerTask := task.NewTickerTask(fetchingInterval, currencyConverter)
 	currencyConverterTickerTask.Start()
@@ -80,7 +102,7 @@ func serve(cfg *config.Configuration) error {
 	}
 
 	corsRouter := router.SupportCORS(r)
-	if err := server.Listen(cfg, router.NoCache{Handler: corsRouter}, router.Admin(currencyConverter, fetchingInterval), r.MetricsEngine); err != nil {
+	if err := server.Listen(cfg, router.NoCache{Handler: corsRouter}, router.Admin(currencyConverter, fetchingInterval, r.VendorListUploader), r.MetricsEngine); err != nil {
 		logger.Fatalf("prebid-server returned an error: %v", err)
 	}
 
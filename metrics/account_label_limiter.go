@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prebid/prebid-server/v4/config"
+)
+
+// AccountLabelOther is the account label value used for the accounts which exceed the configured limit. It's
+// reserved: the account ID is rejected while the limit is enabled, so that no account is confused with the bucket.
+const AccountLabelOther = "other"
+
+// AccountLabelLimiter tracks the distinct account label values seen by each metric family and
+// folds values beyond the configured limit into a shared "other" bucket to protect the metrics
+// backends from unbounded label cardinality. A nil limiter doesn't limit the accounts.
+type AccountLabelLimiter struct {
+	maxValues int
+	allowlist map[string]struct{}
+
+	lock   sync.RWMutex
+	values map[string]map[string]struct{}
+}
+
+func NewAccountLabelLimiter(cfg config.AccountLabelLimit) *AccountLabelLimiter {
+	allowlist := make(map[string]struct{}, len(cfg.Allowlist))
+	for _, account := range cfg.Allowlist {
+		allowlist[account] = struct{}{}
+	}
+
+	return &AccountLabelLimiter{
+		maxValues: cfg.MaxValues,
+		allowlist: allowlist,
+		values:    make(map[string]map[string]struct{}),
+	}
+}
+
+// Limit returns the label value to use for the account within the metric family. The second
+// return value is true if the account was folded into the "other" bucket.
+func (l *AccountLabelLimiter) Limit(family, account string) (string, bool) {
+	if l == nil || l.maxValues <= 0 {
+		return account, false
+	}
+
+	if _, ok := l.allowlist[account]; ok {
+		return account, false
+	}
+
+	l.lock.RLock()
+	_, seen := l.values[family][account]
+	l.lock.RUnlock()
+
+	if seen {
+		return account, false
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	familyValues, ok := l.values[family]
+	if !ok {
+		familyValues = make(map[string]struct{})
+		l.values[family] = familyValues
+	}
+
+	if _, ok := familyValues[account]; ok {
+		return account, false
+	}
+
+	if len(familyValues) >= l.maxValues {
+		return AccountLabelOther, true
+	}
+
+	familyValues[account] = struct{}{}
+	return account, false
+}
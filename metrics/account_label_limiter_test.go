@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountLabelLimiter(t *testing.T) {
+	type observation struct {
+		family           string
+		account          string
+		expectedValue    string
+		expectedOverflow bool
+	}
+
+	testCases := []struct {
+		description  string
+		config       config.AccountLabelLimit
+		observations []observation
+	}{
+		{
+			description: "no-limit",
+			config:      config.AccountLabelLimit{MaxValues: 0},
+			observations: []observation{
+				{family: "a", account: "1", expectedValue: "1"},
+				{family: "a", account: "2", expectedValue: "2"},
+			},
+		},
+		{
+			description: "limit-reached",
+			config:      config.AccountLabelLimit{MaxValues: 2},
+			observations: []observation{
+				{family: "a", account: "1", expectedValue: "1"},
+				{family: "a", account: "2", expectedValue: "2"},
+				{family: "a", account: "3", expectedValue: AccountLabelOther, expectedOverflow: true},
+				{family: "a", account: "1", expectedValue: "1"},
+			},
+		},
+		{
+			description: "limit-per-family",
+			config:      config.AccountLabelLimit{MaxValues: 1},
+			observations: []observation{
+				{family: "a", account: "1", expectedValue: "1"},
+				{family: "b", account: "2", expectedValue: "2"},
+				{family: "a", account: "2", expectedValue: AccountLabelOther, expectedOverflow: true},
+			},
+		},
+		{
+			description: "allowlist-bypasses-limit",
+			config:      config.AccountLabelLimit{MaxValues: 1, Allowlist: []string{"vip"}},
+			observations: []observation{
+				{family: "a", account: "vip", expectedValue: "vip"},
+				{family: "a", account: "1", expectedValue: "1"},
+				{family: "a", account: "vip", expectedValue: "vip"},
+				{family: "a", account: "2", expectedValue: AccountLabelOther, expectedOverflow: true},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		limiter := NewAccountLabelLimiter(test.config)
+		for i, o := range test.observations {
+			value, overflow := limiter.Limit(o.family, o.account)
+			assert.Equal(t, o.expectedValue, value, "%s:value:%d", test.description, i)
+			assert.Equal(t, o.expectedOverflow, overflow, "%s:overflow:%d", test.description, i)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/prebid/prebid-server/v4/metrics"
 	prometheusmetrics "github.com/prebid/prebid-server/v4/metrics/prometheus"
 	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/prebid/prebid-server/v4/usersync"
 	gometrics "github.com/rcrowley/go-metrics"
 	influxdb "github.com/vrischmann/go-metrics-influxdb"
 )
@@ -23,6 +24,7 @@ func NewMetricsEngine(cfg *config.Configuration, adapterList []openrtb_ext.Bidde
 	if cfg.Metrics.Influxdb.Host != "" {
 		// Currently use go-metrics as the metrics piece for influx
 		returnEngine.GoMetrics = metrics.NewMetrics(gometrics.NewPrefixedRegistry("prebidserver."), adapterList, cfg.Metrics.Disabled, syncerKeys, moduleStageNames)
+		returnEngine.GoMetrics.AccountLabelLimiter = metrics.NewAccountLabelLimiter(cfg.Metrics.AccountLabelLimit)
 		engineList = append(engineList, returnEngine.GoMetrics)
 
 		// Set up the Influx logger
@@ -40,7 +42,7 @@ func NewMetricsEngine(cfg *config.Configuration, adapterList []openrtb_ext.Bidde
 	}
 	if cfg.Metrics.Prometheus.Port != 0 {
 		// Set up the Prometheus metrics.
-		returnEngine.PrometheusMetrics = prometheusmetrics.NewMetrics(cfg.Metrics.Prometheus, cfg.Metrics.Disabled, syncerKeys, moduleStageNames)
+		returnEngine.PrometheusMetrics = prometheusmetrics.NewMetrics(cfg.Metrics.Prometheus, cfg.Metrics.Disabled, cfg.Metrics.AccountLabelLimit, syncerKeys, moduleStageNames)
 		engineList = append(engineList, returnEngine.PrometheusMetrics)
 	}
 
@@ -56,6 +58,15 @@ func NewMetricsEngine(cfg *config.Configuration, adapterList []openrtb_ext.Bidde
 	return &returnEngine
 }
 
+// WithBidderValueTracker returns a metrics engine which also feeds the adapter outcomes of live auctions
+// to the bidder value tracker, or the engine itself if the tracker is nil.
+func WithBidderValueTracker(engine metrics.MetricsEngine, tracker *usersync.BidderValueTracker) metrics.MetricsEngine {
+	if tracker == nil {
+		return engine
+	}
+	return &MultiMetricsEngine{engine, &BidderValueMetricsEngine{Tracker: tracker}}
+}
+
 // DetailedMetricsEngine is a MultiMetricsEngine that preserves links to underlying metrics engines.
 type DetailedMetricsEngine struct {
 	metrics.MetricsEngine
@@ -210,6 +221,13 @@ func (me *MultiMetricsEngine) RecordSetUid(status metrics.SetUidStatus) {
 	}
 }
 
+// RecordUIDsCookieJar across all engines
+func (me *MultiMetricsEngine) RecordUIDsCookieJar(jar metrics.CookieJar) {
+	for _, thisME := range *me {
+		thisME.RecordUIDsCookieJar(jar)
+	}
+}
+
 // RecordSyncerSet across all engines
 func (me *MultiMetricsEngine) RecordSyncerSet(key string, status metrics.SyncerSetUidStatus) {
 	for _, thisME := range *me {
@@ -305,6 +323,19 @@ func (me *MultiMetricsEngine) RecordLiveGVLFetch(success bool) {
 	}
 }
 
+func (me *MultiMetricsEngine) RecordFloorsFetch(pubID string, status metrics.FloorsFetchStatus) {
+	for _, thisME := range *me {
+		thisME.RecordFloorsFetch(pubID, status)
+	}
+}
+
+// RecordFloorsEnforcement across all engines
+func (me *MultiMetricsEngine) RecordFloorsEnforcement(pubID string, status metrics.FloorsEnforcementStatus, rejectedBids int) {
+	for _, thisME := range *me {
+		thisME.RecordFloorsEnforcement(pubID, status, rejectedBids)
+	}
+}
+
 func (me *MultiMetricsEngine) RecordAdsCertReq(success bool) {
 	for _, thisME := range *me {
 		thisME.RecordAdsCertReq(success)
@@ -390,6 +421,13 @@ func (me *MultiMetricsEngine) RecordAdapterThrottled(adapter openrtb_ext.BidderN
 	}
 }
 
+// RecordNotification across all engines
+func (me *MultiMetricsEngine) RecordNotification(adapter openrtb_ext.BidderName, notificationType metrics.NotificationType, status metrics.NotificationStatus) {
+	for _, thisME := range *me {
+		thisME.RecordNotification(adapter, notificationType, status)
+	}
+}
+
 func (me *MultiMetricsEngine) RecordAdapterConnectionDialError(adapterName openrtb_ext.BidderName) {
 	for _, thisME := range *me {
 		thisME.RecordAdapterConnectionDialError(adapterName)
@@ -490,6 +528,10 @@ func (me *NilMetricsEngine) RecordSyncerRequest(key string, status metrics.Synce
 func (me *NilMetricsEngine) RecordSetUid(status metrics.SetUidStatus) {
 }
 
+// RecordUIDsCookieJar as a noop
+func (me *NilMetricsEngine) RecordUIDsCookieJar(jar metrics.CookieJar) {
+}
+
 // RecordSyncerSet as a noop
 func (me *NilMetricsEngine) RecordSyncerSet(key string, status metrics.SyncerSetUidStatus) {
 }
@@ -543,6 +585,13 @@ func (me *NilMetricsEngine) RecordGvlListRequest() {
 func (me *NilMetricsEngine) RecordLiveGVLFetch(success bool) {
 }
 
+func (me *NilMetricsEngine) RecordFloorsFetch(pubID string, status metrics.FloorsFetchStatus) {
+}
+
+// RecordFloorsEnforcement as a noop
+func (me *NilMetricsEngine) RecordFloorsEnforcement(pubID string, status metrics.FloorsEnforcementStatus, rejectedBids int) {
+}
+
 func (me *NilMetricsEngine) RecordAdsCertReq(success bool) {
 
 }
@@ -588,8 +637,29 @@ func (me *NilMetricsEngine) RecordModuleTimeout(labels metrics.ModuleLabels) {
 func (me *NilMetricsEngine) RecordAdapterThrottled(adapter openrtb_ext.BidderName) {
 }
 
+// RecordNotification as a noop
+func (me *NilMetricsEngine) RecordNotification(adapter openrtb_ext.BidderName, notificationType metrics.NotificationType, status metrics.NotificationStatus) {
+}
+
 func (me *NilMetricsEngine) RecordAdapterConnectionDialError(adapterName openrtb_ext.BidderName) {
 }
 
 func (me *NilMetricsEngine) RecordAdapterConnectionDialTime(adapterName openrtb_ext.BidderName, dialStartTime time.Duration) {
 }
+
+// BidderValueMetricsEngine records adapter requests and bid prices in the bidder value tracker used to
+// rank bidders for cookie syncs. All other metrics are ignored.
+type BidderValueMetricsEngine struct {
+	NilMetricsEngine
+	Tracker *usersync.BidderValueTracker
+}
+
+// RecordAdapterRequest records whether the adapter bid in the auction
+func (me *BidderValueMetricsEngine) RecordAdapterRequest(labels metrics.AdapterLabels) {
+	me.Tracker.RecordRequest(labels.PubID, string(labels.Adapter), labels.AdapterBids == metrics.AdapterBidPresent)
+}
+
+// RecordAdapterPrice records the price of a bid returned by the adapter
+func (me *BidderValueMetricsEngine) RecordAdapterPrice(labels metrics.AdapterLabels, cpm float64) {
+	me.Tracker.RecordRevenue(labels.PubID, string(labels.Adapter), cpm)
+}
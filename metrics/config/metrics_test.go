@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	mainConfig "github.com/prebid/prebid-server/v4/config"
 	"github.com/prebid/prebid-server/v4/metrics"
 	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/prebid/prebid-server/v4/usersync"
 
 	gometrics "github.com/rcrowley/go-metrics"
 )
@@ -213,3 +215,22 @@ func VerifyMetrics(t *testing.T, name string, actual int64, expected int64) {
 		t.Errorf("Error in metric %s: got %d, expected %d.", name, actual, expected)
 	}
 }
+
+func TestWithBidderValueTracker(t *testing.T) {
+	engine := &NilMetricsEngine{}
+	if WithBidderValueTracker(engine, nil) != engine {
+		t.Error("Expected the engine to be returned unchanged without a tracker")
+	}
+
+	tracker := usersync.NewBidderValueTracker(mainConfig.UserSyncValueRanking{Enabled: true, HalfLifeMinutes: 60, MaxAccounts: 1})
+	valueEngine := WithBidderValueTracker(engine, tracker)
+
+	labels := metrics.AdapterLabels{Adapter: openrtb_ext.BidderAppnexus, PubID: "account", AdapterBids: metrics.AdapterBidPresent}
+	valueEngine.RecordAdapterPrice(labels, 2)
+	valueEngine.RecordAdapterRequest(labels)
+
+	value := tracker.Value("account", "appnexus")
+	if math.Abs(value.Revenue-2) > 0.01 || math.Abs(value.BidRate-1) > 0.01 {
+		t.Errorf("Expected the adapter outcome to be tracked, got %+v", value)
+	}
+}
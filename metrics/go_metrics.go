@@ -38,6 +38,9 @@ type Metrics struct {
 	GvlListRequestsMeter           metrics.Meter
 	LiveGVLFetchSuccess            metrics.Meter
 	LiveGVLFetchFailure            metrics.Meter
+	FloorsFetchMeter               map[FloorsFetchStatus]metrics.Meter
+	FloorsEnforcementMeter         map[FloorsEnforcementStatus]metrics.Meter
+	FloorsRejectedBidsMeter        metrics.Meter
 
 	// Metrics for OpenRTB requests specifically
 	RequestStatuses       map[RequestType]map[RequestStatus]metrics.Meter
@@ -49,6 +52,7 @@ type Metrics struct {
 	SetUidMeter           metrics.Meter
 	SetUidStatusMeter     map[SetUidStatus]metrics.Meter
 	SyncerSetsMeter       map[string]map[SyncerSetUidStatus]metrics.Meter
+	UIDsCookieJarMeter    map[CookieJar]metrics.Meter
 
 	// Media types found in the "imp" JSON object
 	ImpsTypeBanner metrics.Meter
@@ -71,6 +75,9 @@ type Metrics struct {
 	// Don't export accountMetrics because we need helper functions here to insure its properly populated dynamically
 	accountMetrics        map[string]*accountMetrics
 	accountMetricsRWMutex sync.RWMutex
+	// AccountLabelLimiter bounds the number of accounts with their own metrics, the others sharing the "other" account
+	AccountLabelLimiter       *AccountLabelLimiter
+	AccountLabelOverflowMeter metrics.Meter
 
 	// adapter name exchanges
 	exchanges []string
@@ -108,6 +115,8 @@ type AdapterMetrics struct {
 	BuyerUIDScrubbed   metrics.Meter
 	GDPRRequestBlocked metrics.Meter
 	ThrottledMeter     metrics.Meter
+	// NotificationMeters count the notice URLs of the bids fired on behalf of the clients by type and status
+	NotificationMeters map[NotificationType]map[NotificationStatus]metrics.Meter
 
 	BidValidationCreativeSizeErrorMeter metrics.Meter
 	BidValidationCreativeSizeWarnMeter  metrics.Meter
@@ -130,6 +139,10 @@ type accountMetrics struct {
 	adapterMetrics       map[string]*AdapterMetrics
 	moduleMetrics        map[string]*ModuleMetrics
 	storedResponsesMeter metrics.Meter
+	floorsFetchMeter     map[FloorsFetchStatus]metrics.Meter
+	// floorsEnforcementMeter and floorsRejectedBidsMeter summarise the floors outcome of the auctions
+	floorsEnforcementMeter  map[FloorsEnforcementStatus]metrics.Meter
+	floorsRejectedBidsMeter metrics.Meter
 
 	bidValidationCreativeSizeMeter     metrics.Meter
 	bidValidationCreativeSizeWarnMeter metrics.Meter
@@ -169,6 +182,7 @@ func NewBlankMetrics(registry metrics.Registry, exchanges []string, disabledMetr
 		ImpMeter:                       blankMeter,
 		AppRequestMeter:                blankMeter,
 		DebugRequestMeter:              blankMeter,
+		AccountLabelOverflowMeter:      blankMeter,
 		NoCookieMeter:                  blankMeter,
 		RequestTimer:                   blankTimer,
 		DNSLookupTimer:                 blankTimer,
@@ -188,10 +202,14 @@ func NewBlankMetrics(registry metrics.Registry, exchanges []string, disabledMetr
 		SetUidMeter:                    blankMeter,
 		SetUidStatusMeter:              make(map[SetUidStatus]metrics.Meter),
 		SyncerSetsMeter:                make(map[string]map[SyncerSetUidStatus]metrics.Meter),
+		UIDsCookieJarMeter:             make(map[CookieJar]metrics.Meter),
 		StoredResponsesMeter:           blankMeter,
 		GvlListRequestsMeter:           blankMeter,
 		LiveGVLFetchSuccess:            blankMeter,
 		LiveGVLFetchFailure:            blankMeter,
+		FloorsFetchMeter:               make(map[FloorsFetchStatus]metrics.Meter),
+		FloorsEnforcementMeter:         make(map[FloorsEnforcementStatus]metrics.Meter),
+		FloorsRejectedBidsMeter:        blankMeter,
 
 		ImpsTypeBanner: blankMeter,
 		ImpsTypeVideo:  blankMeter,
@@ -311,6 +329,7 @@ func NewMetrics(registry metrics.Registry, exchanges []openrtb_ext.BidderName, d
 	newMetrics.NoCookieMeter = metrics.GetOrRegisterMeter("no_cookie_requests", registry)
 	newMetrics.AppRequestMeter = metrics.GetOrRegisterMeter("app_requests", registry)
 	newMetrics.DebugRequestMeter = metrics.GetOrRegisterMeter("debug_requests", registry)
+	newMetrics.AccountLabelOverflowMeter = metrics.GetOrRegisterMeter("account_label_overflow", registry)
 	newMetrics.RequestTimer = metrics.GetOrRegisterTimer("request_time", registry)
 	newMetrics.DNSLookupTimer = metrics.GetOrRegisterTimer("dns_lookup_time", registry)
 	newMetrics.TLSHandshakeTimer = metrics.GetOrRegisterTimer("tls_handshake_time", registry)
@@ -320,6 +339,13 @@ func NewMetrics(registry metrics.Registry, exchanges []openrtb_ext.BidderName, d
 	newMetrics.GvlListRequestsMeter = metrics.GetOrRegisterMeter("gvl_requests", registry)
 	newMetrics.LiveGVLFetchSuccess = metrics.GetOrRegisterMeter("live_gvl_fetch.ok", registry)
 	newMetrics.LiveGVLFetchFailure = metrics.GetOrRegisterMeter("live_gvl_fetch.failed", registry)
+	for _, s := range FloorsFetchStatuses() {
+		newMetrics.FloorsFetchMeter[s] = metrics.GetOrRegisterMeter(fmt.Sprintf("floors_fetch.%s", s), registry)
+	}
+	for _, s := range FloorsEnforcementStatuses() {
+		newMetrics.FloorsEnforcementMeter[s] = metrics.GetOrRegisterMeter(fmt.Sprintf("floors_enforcement.%s", s), registry)
+	}
+	newMetrics.FloorsRejectedBidsMeter = metrics.GetOrRegisterMeter("floors_rejected_bids", registry)
 	newMetrics.OverheadTimer = makeOverheadTimerMetrics(registry)
 	newMetrics.BidderServerResponseTimer = metrics.GetOrRegisterTimer("bidder_server_response_time_seconds", registry)
 
@@ -346,6 +372,10 @@ func NewMetrics(registry metrics.Registry, exchanges []openrtb_ext.BidderName, d
 		newMetrics.SetUidStatusMeter[s] = metrics.GetOrRegisterMeter(fmt.Sprintf("setuid_requests.%s", s), registry)
 	}
 
+	for _, j := range CookieJars() {
+		newMetrics.UIDsCookieJarMeter[j] = metrics.GetOrRegisterMeter(fmt.Sprintf("uids_cookie_reads.%s", j), registry)
+	}
+
 	for _, syncerKey := range syncerKeys {
 		newMetrics.SyncerRequestsMeter[syncerKey] = make(map[SyncerCookieSyncStatus]metrics.Meter)
 		for _, status := range SyncerRequestStatuses() {
@@ -427,6 +457,13 @@ func makeBlankAdapterMetrics(disabledMetrics config.DisabledMetrics) *AdapterMet
 		MarkupMetrics:     makeBlankBidMarkupMetrics(),
 		ThrottledMeter:    blankMeter,
 	}
+	newAdapter.NotificationMeters = make(map[NotificationType]map[NotificationStatus]metrics.Meter)
+	for _, notificationType := range NotificationTypes() {
+		newAdapter.NotificationMeters[notificationType] = make(map[NotificationStatus]metrics.Meter)
+		for _, status := range NotificationStatuses() {
+			newAdapter.NotificationMeters[notificationType][status] = blankMeter
+		}
+	}
 	if !disabledMetrics.AdapterConnectionMetrics {
 		newAdapter.ConnCreated = metrics.NilCounter{}
 		newAdapter.ConnReused = metrics.NilCounter{}
@@ -521,6 +558,11 @@ func registerAdapterMetrics(registry metrics.Registry, adapterOrAccount string,
 	am.BuyerUIDScrubbed = metrics.GetOrRegisterMeter(fmt.Sprintf("%[1]s.%[2]s.buyeruid_scrubbed", adapterOrAccount, exchange), registry)
 	am.GDPRRequestBlocked = metrics.GetOrRegisterMeter(fmt.Sprintf("%[1]s.%[2]s.gdpr_request_blocked", adapterOrAccount, exchange), registry)
 	am.ThrottledMeter = metrics.GetOrRegisterMeter(fmt.Sprintf("%[1]s.%[2]s.requests.throttled", adapterOrAccount, exchange), registry)
+	for notificationType, statusMeters := range am.NotificationMeters {
+		for status := range statusMeters {
+			statusMeters[status] = metrics.GetOrRegisterMeter(fmt.Sprintf("%s.%s.notifications.%s.%s", adapterOrAccount, exchange, notificationType, status), registry)
+		}
+	}
 
 	am.BidValidationCreativeSizeErrorMeter = metrics.GetOrRegisterMeter(fmt.Sprintf("%[1]s.%[2]s.response.validation.size.err", adapterOrAccount, exchange), registry)
 	am.BidValidationCreativeSizeWarnMeter = metrics.GetOrRegisterMeter(fmt.Sprintf("%[1]s.%[2]s.response.validation.size.warn", adapterOrAccount, exchange), registry)
@@ -562,10 +604,16 @@ func makeDeliveryMetrics(registry metrics.Registry, prefix string, bidType openr
 
 // getAccountMetrics gets or registers the account metrics for account "id".
 // There is no getBlankAccountMetrics() as all metrics are generated dynamically.
+// The accounts beyond the account label limit share the metrics of the "other" account.
 func (me *Metrics) getAccountMetrics(id string) *accountMetrics {
 	var am *accountMetrics
 	var ok bool
 
+	id, overflow := me.AccountLabelLimiter.Limit("account", id)
+	if overflow {
+		me.AccountLabelOverflowMeter.Mark(1)
+	}
+
 	me.accountMetricsRWMutex.RLock()
 	am, ok = me.accountMetrics[id]
 	me.accountMetricsRWMutex.RUnlock()
@@ -591,6 +639,15 @@ func (me *Metrics) getAccountMetrics(id string) *accountMetrics {
 	am.adapterMetrics = make(map[string]*AdapterMetrics, len(me.exchanges))
 	am.moduleMetrics = make(map[string]*ModuleMetrics)
 	am.storedResponsesMeter = metrics.GetOrRegisterMeter(fmt.Sprintf("account.%s.stored_responses", id), me.MetricsRegistry)
+	am.floorsFetchMeter = make(map[FloorsFetchStatus]metrics.Meter)
+	for _, s := range FloorsFetchStatuses() {
+		am.floorsFetchMeter[s] = metrics.GetOrRegisterMeter(fmt.Sprintf("account.%s.floors_fetch.%s", id, s), me.MetricsRegistry)
+	}
+	am.floorsEnforcementMeter = make(map[FloorsEnforcementStatus]metrics.Meter)
+	for _, s := range FloorsEnforcementStatuses() {
+		am.floorsEnforcementMeter[s] = metrics.GetOrRegisterMeter(fmt.Sprintf("account.%s.floors_enforcement.%s", id, s), me.MetricsRegistry)
+	}
+	am.floorsRejectedBidsMeter = metrics.GetOrRegisterMeter(fmt.Sprintf("account.%s.floors_rejected_bids", id), me.MetricsRegistry)
 	if !me.MetricsDisabled.AccountAdapterDetails {
 		for _, a := range me.exchanges {
 			am.adapterMetrics[a] = makeBlankAdapterMetrics(me.MetricsDisabled)
@@ -660,6 +717,31 @@ func (me *Metrics) RecordStoredResponse(pubId string) {
 	}
 }
 
+func (me *Metrics) RecordFloorsFetch(pubID string, status FloorsFetchStatus) {
+	if meter, exists := me.FloorsFetchMeter[status]; exists {
+		meter.Mark(1)
+	}
+	if pubID != PublisherUnknown && pubID != "" {
+		if meter, exists := me.getAccountMetrics(pubID).floorsFetchMeter[status]; exists {
+			meter.Mark(1)
+		}
+	}
+}
+
+func (me *Metrics) RecordFloorsEnforcement(pubID string, status FloorsEnforcementStatus, rejectedBids int) {
+	if meter, exists := me.FloorsEnforcementMeter[status]; exists {
+		meter.Mark(1)
+	}
+	me.FloorsRejectedBidsMeter.Mark(int64(rejectedBids))
+	if pubID != PublisherUnknown && pubID != "" {
+		am := me.getAccountMetrics(pubID)
+		if meter, exists := am.floorsEnforcementMeter[status]; exists {
+			meter.Mark(1)
+		}
+		am.floorsRejectedBidsMeter.Mark(int64(rejectedBids))
+	}
+}
+
 func (me *Metrics) RecordGvlListRequest() {
 	me.GvlListRequestsMeter.Mark(1)
 }
@@ -910,6 +992,13 @@ func (me *Metrics) RecordSetUid(status SetUidStatus) {
 	}
 }
 
+// RecordUIDsCookieJar implements a part of the MetricsEngine interface. Records the cookie jars the uids cookie was read from
+func (me *Metrics) RecordUIDsCookieJar(jar CookieJar) {
+	if meter, exists := me.UIDsCookieJarMeter[jar]; exists {
+		meter.Mark(1)
+	}
+}
+
 // RecordSyncerSet implements a part of the MetricsEngine interface. Records a set uid sync request and status
 func (me *Metrics) RecordSyncerSet(key string, status SyncerSetUidStatus) {
 	if keyMeter, exists := me.SyncerSetsMeter[key]; exists {
@@ -1231,3 +1320,16 @@ func (me *Metrics) RecordAdapterThrottled(adapterName openrtb_ext.BidderName) {
 
 	am.ThrottledMeter.Mark(1)
 }
+
+func (me *Metrics) RecordNotification(adapterName openrtb_ext.BidderName, notificationType NotificationType, status NotificationStatus) {
+	adapterStr := adapterName.String()
+	am, ok := me.AdapterMetrics[strings.ToLower(adapterStr)]
+	if !ok {
+		logger.Errorf("Trying to log adapter notification metric for %s: adapter not found", adapterStr)
+		return
+	}
+
+	if meter, exists := am.NotificationMeters[notificationType][status]; exists {
+		meter.Mark(1)
+	}
+}
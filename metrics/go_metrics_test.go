@@ -19,6 +19,7 @@ func TestNewMetrics(t *testing.T) {
 
 	ensureContains(t, registry, "app_requests", m.AppRequestMeter)
 	ensureContains(t, registry, "debug_requests", m.DebugRequestMeter)
+	ensureContains(t, registry, "account_label_overflow", m.AccountLabelOverflowMeter)
 	ensureContains(t, registry, "no_cookie_requests", m.NoCookieMeter)
 	ensureContains(t, registry, "request_time", m.RequestTimer)
 	ensureContains(t, registry, "amp_no_cookie_requests", m.AmpNoCookieMeter)
@@ -229,6 +230,23 @@ func TestRecordBidTypeDisabledConfig(t *testing.T) {
 	}
 }
 
+func TestAccountMetricsLabelLimit(t *testing.T) {
+	registry := metrics.NewRegistry()
+	m := NewMetrics(registry, nil, config.DisabledMetrics{}, nil, nil)
+	m.AccountLabelLimiter = NewAccountLabelLimiter(config.AccountLabelLimit{MaxValues: 1, Allowlist: []string{"allowed"}})
+
+	for _, pubID := range []string{"first", "second", "third", "allowed", "first"} {
+		m.RecordRequest(Labels{RType: ReqTypeORTB2Web, RequestStatus: RequestStatusOK, PubID: pubID})
+	}
+
+	assert.Len(t, m.accountMetrics, 3)
+	assert.Equal(t, int64(2), m.accountMetrics["first"].requestMeter.Count())
+	assert.Equal(t, int64(1), m.accountMetrics["allowed"].requestMeter.Count())
+	assert.Equal(t, int64(2), m.accountMetrics[AccountLabelOther].requestMeter.Count())
+	assert.Equal(t, int64(2), m.AccountLabelOverflowMeter.Count())
+	assert.Nil(t, registry.Get("account.second.requests"))
+}
+
 func TestRecordDebugRequest(t *testing.T) {
 	testCases := []struct {
 		description               string
@@ -954,6 +972,17 @@ func TestRecordSyncerSet(t *testing.T) {
 	assert.Equal(t, m.SyncerSetsMeter["foo"][SyncerSetUidCleared].Count(), int64(1))
 }
 
+func TestRecordUIDsCookieJar(t *testing.T) {
+	registry := metrics.NewRegistry()
+	m := NewMetrics(registry, []openrtb_ext.BidderName{openrtb_ext.BidderName("Foo")}, config.DisabledMetrics{}, nil, nil)
+
+	m.RecordUIDsCookieJar(CookieJarPartitioned)
+	m.RecordUIDsCookieJar(CookieJar("unknown jar"))
+
+	assert.Equal(t, m.UIDsCookieJarMeter[CookieJarPartitioned].Count(), int64(1))
+	assert.Equal(t, m.UIDsCookieJarMeter[CookieJarUnpartitioned].Count(), int64(0))
+}
+
 func TestStoredResponses(t *testing.T) {
 	testCases := []struct {
 		description                           string
@@ -1003,6 +1032,55 @@ func TestStoredResponses(t *testing.T) {
 	}
 }
 
+func TestRecordFloorsFetch(t *testing.T) {
+	registry := metrics.NewRegistry()
+	m := NewMetrics(registry, []openrtb_ext.BidderName{openrtb_ext.BidderName("AnyName")}, config.DisabledMetrics{}, nil, nil)
+
+	m.RecordFloorsFetch("acct-id", FloorsFetchSuccess)
+	m.RecordFloorsFetch("acct-id", FloorsFetchTimeout)
+	m.RecordFloorsFetch(PublisherUnknown, FloorsFetchTimeout)
+
+	am := m.getAccountMetrics("acct-id")
+	assert.Equal(t, int64(1), m.FloorsFetchMeter[FloorsFetchSuccess].Count())
+	assert.Equal(t, int64(2), m.FloorsFetchMeter[FloorsFetchTimeout].Count())
+	assert.Equal(t, int64(0), m.FloorsFetchMeter[FloorsFetchInvalid].Count())
+	assert.Equal(t, int64(1), am.floorsFetchMeter[FloorsFetchSuccess].Count())
+	assert.Equal(t, int64(1), am.floorsFetchMeter[FloorsFetchTimeout].Count())
+}
+
+func TestRecordFloorsEnforcement(t *testing.T) {
+	registry := metrics.NewRegistry()
+	m := NewMetrics(registry, []openrtb_ext.BidderName{openrtb_ext.BidderName("AnyName")}, config.DisabledMetrics{}, nil, nil)
+
+	m.RecordFloorsEnforcement("acct-id", FloorsEnforcementEnforced, 2)
+	m.RecordFloorsEnforcement("acct-id", FloorsEnforcementSkipped, 0)
+	m.RecordFloorsEnforcement(PublisherUnknown, FloorsEnforcementEnforced, 1)
+
+	am := m.getAccountMetrics("acct-id")
+	assert.Equal(t, int64(2), m.FloorsEnforcementMeter[FloorsEnforcementEnforced].Count())
+	assert.Equal(t, int64(1), m.FloorsEnforcementMeter[FloorsEnforcementSkipped].Count())
+	assert.Equal(t, int64(0), m.FloorsEnforcementMeter[FloorsEnforcementNoData].Count())
+	assert.Equal(t, int64(3), m.FloorsRejectedBidsMeter.Count())
+	assert.Equal(t, int64(1), am.floorsEnforcementMeter[FloorsEnforcementEnforced].Count())
+	assert.Equal(t, int64(1), am.floorsEnforcementMeter[FloorsEnforcementSkipped].Count())
+	assert.Equal(t, int64(2), am.floorsRejectedBidsMeter.Count())
+}
+
+func TestRecordNotification(t *testing.T) {
+	registry := metrics.NewRegistry()
+	m := NewMetrics(registry, []openrtb_ext.BidderName{openrtb_ext.BidderAppnexus}, config.DisabledMetrics{}, nil, nil)
+
+	m.RecordNotification(openrtb_ext.BidderAppnexus, NotificationWin, NotificationSent)
+	m.RecordNotification(openrtb_ext.BidderAppnexus, NotificationWin, NotificationSent)
+	m.RecordNotification(openrtb_ext.BidderAppnexus, NotificationBilling, NotificationFailed)
+	m.RecordNotification(openrtb_ext.BidderName("unknown"), NotificationWin, NotificationSent)
+
+	am := m.AdapterMetrics[string(openrtb_ext.BidderAppnexus)]
+	assert.Equal(t, int64(2), am.NotificationMeters[NotificationWin][NotificationSent].Count())
+	assert.Equal(t, int64(1), am.NotificationMeters[NotificationBilling][NotificationFailed].Count())
+	assert.Equal(t, int64(0), am.NotificationMeters[NotificationBilling][NotificationSent].Count())
+}
+
 func TestRecordAdsCertSignTime(t *testing.T) {
 	testCases := []struct {
 		description           string
@@ -85,6 +85,7 @@ const (
 	RequestDataType  StoredDataType = "request"
 	VideoDataType    StoredDataType = "video"
 	ResponseDataType StoredDataType = "response"
+	FloorsDataType   StoredDataType = "floors"
 )
 
 func StoredDataTypes() []StoredDataType {
@@ -95,6 +96,7 @@ func StoredDataTypes() []StoredDataType {
 		RequestDataType,
 		VideoDataType,
 		ResponseDataType,
+		FloorsDataType,
 	}
 }
 
@@ -257,6 +259,40 @@ const (
 	CookieFlagUnknown CookieFlag = "unknown"
 )
 
+// CookieJar : the cookie jars the uids cookie was read from
+type CookieJar string
+
+// Cookie jar
+const (
+	CookieJarNone          CookieJar = "none"
+	CookieJarUnpartitioned CookieJar = "unpartitioned"
+	CookieJarPartitioned   CookieJar = "partitioned"
+	CookieJarBoth          CookieJar = "both"
+)
+
+func CookieJars() []CookieJar {
+	return []CookieJar{
+		CookieJarNone,
+		CookieJarUnpartitioned,
+		CookieJarPartitioned,
+		CookieJarBoth,
+	}
+}
+
+// NewCookieJar returns the cookie jar for the presence of the unpartitioned and partitioned uids cookies.
+func NewCookieJar(unpartitioned, partitioned bool) CookieJar {
+	switch {
+	case unpartitioned && partitioned:
+		return CookieJarBoth
+	case unpartitioned:
+		return CookieJarUnpartitioned
+	case partitioned:
+		return CookieJarPartitioned
+	default:
+		return CookieJarNone
+	}
+}
+
 func CookieTypes() []CookieFlag {
 	return []CookieFlag{
 		CookieFlagYes,
@@ -420,6 +456,7 @@ const (
 	SetUidBadRequest             SetUidStatus = "bad_request"
 	SetUidOptOut                 SetUidStatus = "opt_out"
 	SetUidGDPRHostCookieBlocked  SetUidStatus = "gdpr_blocked_host_cookie"
+	SetUidGDPRBidderBlocked      SetUidStatus = "gdpr_blocked_bidder"
 	SetUidAccountBlocked         SetUidStatus = "acct_blocked"
 	SetUidAccountConfigMalformed SetUidStatus = "acct_config_malformed"
 	SetUidAccountInvalid         SetUidStatus = "acct_invalid"
@@ -433,6 +470,7 @@ func SetUidStatuses() []SetUidStatus {
 		SetUidBadRequest,
 		SetUidOptOut,
 		SetUidGDPRHostCookieBlocked,
+		SetUidGDPRBidderBlocked,
 		SetUidAccountBlocked,
 		SetUidAccountConfigMalformed,
 		SetUidAccountInvalid,
@@ -456,6 +494,88 @@ func SyncerSetUidStatuses() []SyncerSetUidStatus {
 	}
 }
 
+// FloorsFetchStatus is the outcome of a fetch of the floors data of an account.
+type FloorsFetchStatus string
+
+const (
+	FloorsFetchSuccess     FloorsFetchStatus = "success"
+	FloorsFetchNotModified FloorsFetchStatus = "not_modified"
+	FloorsFetchInvalid     FloorsFetchStatus = "invalid"
+	FloorsFetchTimeout     FloorsFetchStatus = "timeout"
+	FloorsFetchTooLarge    FloorsFetchStatus = "too_large"
+	FloorsFetchError       FloorsFetchStatus = "error"
+)
+
+// FloorsFetchStatuses returns possible floors fetch statuses.
+func FloorsFetchStatuses() []FloorsFetchStatus {
+	return []FloorsFetchStatus{
+		FloorsFetchSuccess,
+		FloorsFetchNotModified,
+		FloorsFetchInvalid,
+		FloorsFetchTimeout,
+		FloorsFetchTooLarge,
+		FloorsFetchError,
+	}
+}
+
+// FloorsEnforcementStatus is the outcome of the price floors of an auction.
+type FloorsEnforcementStatus string
+
+const (
+	FloorsEnforcementEnforced    FloorsEnforcementStatus = "enforced"
+	FloorsEnforcementNotEnforced FloorsEnforcementStatus = "not_enforced"
+	FloorsEnforcementSkipped     FloorsEnforcementStatus = "skipped"
+	FloorsEnforcementNoData      FloorsEnforcementStatus = "no_data"
+)
+
+// FloorsEnforcementStatuses returns possible floors enforcement statuses.
+func FloorsEnforcementStatuses() []FloorsEnforcementStatus {
+	return []FloorsEnforcementStatus{
+		FloorsEnforcementEnforced,
+		FloorsEnforcementNotEnforced,
+		FloorsEnforcementSkipped,
+		FloorsEnforcementNoData,
+	}
+}
+
+// NotificationType is the type of a notice URL of a bid fired by PBS on behalf of the client.
+type NotificationType string
+
+const (
+	NotificationWin     NotificationType = "win"
+	NotificationBilling NotificationType = "billing"
+)
+
+// NotificationTypes returns possible notification types.
+func NotificationTypes() []NotificationType {
+	return []NotificationType{
+		NotificationWin,
+		NotificationBilling,
+	}
+}
+
+// NotificationStatus is the outcome of firing a notice URL of a bid.
+type NotificationStatus string
+
+const (
+	NotificationSent      NotificationStatus = "sent"
+	NotificationRetried   NotificationStatus = "retried"
+	NotificationFailed    NotificationStatus = "failed"
+	NotificationDropped   NotificationStatus = "dropped"
+	NotificationDuplicate NotificationStatus = "duplicate"
+)
+
+// NotificationStatuses returns possible notification statuses.
+func NotificationStatuses() []NotificationStatus {
+	return []NotificationStatus{
+		NotificationSent,
+		NotificationRetried,
+		NotificationFailed,
+		NotificationDropped,
+		NotificationDuplicate,
+	}
+}
+
 // MetricsEngine is a generic interface to record PBS metrics into the desired backend
 // The first three metrics function fire off once per incoming request, so total metrics
 // will equal the total number of incoming requests. The remaining 5 fire off per outgoing
@@ -483,6 +603,7 @@ type MetricsEngine interface {
 	RecordSyncerRequest(key string, status SyncerCookieSyncStatus)
 	RecordSetUid(status SetUidStatus)
 	RecordSyncerSet(key string, status SyncerSetUidStatus)
+	RecordUIDsCookieJar(jar CookieJar)
 	RecordStoredReqCacheResult(cacheResult CacheResult, inc int)
 	RecordStoredImpCacheResult(cacheResult CacheResult, inc int)
 	RecordAccountCacheResult(cacheResult CacheResult, inc int)
@@ -498,6 +619,8 @@ type MetricsEngine interface {
 	RecordStoredResponse(pubId string)
 	RecordGvlListRequest()
 	RecordLiveGVLFetch(success bool)
+	RecordFloorsFetch(pubID string, status FloorsFetchStatus)
+	RecordFloorsEnforcement(pubID string, status FloorsEnforcementStatus, rejectedBids int)
 	RecordAdsCertReq(success bool)
 	RecordAdsCertSignTime(adsCertSignTime time.Duration)
 	RecordBidValidationCreativeSizeError(adapter openrtb_ext.BidderName, account string)
@@ -512,6 +635,7 @@ type MetricsEngine interface {
 	RecordModuleExecutionError(labels ModuleLabels)
 	RecordModuleTimeout(labels ModuleLabels)
 	RecordAdapterThrottled(adapterName openrtb_ext.BidderName)
+	RecordNotification(adapterName openrtb_ext.BidderName, notificationType NotificationType, status NotificationStatus)
 	RecordAdapterConnectionDialError(adapterName openrtb_ext.BidderName)
 	RecordAdapterConnectionDialTime(adapterName openrtb_ext.BidderName, dialStartTime time.Duration)
 }
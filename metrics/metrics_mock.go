@@ -116,6 +116,11 @@ func (me *MetricsEngineMock) RecordSetUid(status SetUidStatus) {
 	me.Called(status)
 }
 
+// RecordUIDsCookieJar mock
+func (me *MetricsEngineMock) RecordUIDsCookieJar(jar CookieJar) {
+	me.Called(jar)
+}
+
 // RecordSyncerSet mock
 func (me *MetricsEngineMock) RecordSyncerSet(key string, status SyncerSetUidStatus) {
 	me.Called(key, status)
@@ -183,6 +188,14 @@ func (me *MetricsEngineMock) RecordLiveGVLFetch(success bool) {
 	me.Called(success)
 }
 
+func (me *MetricsEngineMock) RecordFloorsFetch(pubID string, status FloorsFetchStatus) {
+	me.Called(pubID, status)
+}
+
+func (me *MetricsEngineMock) RecordFloorsEnforcement(pubID string, status FloorsEnforcementStatus, rejectedBids int) {
+	me.Called(pubID, status, rejectedBids)
+}
+
 func (me *MetricsEngineMock) RecordAdsCertReq(success bool) {
 	me.Called(success)
 }
@@ -239,6 +252,10 @@ func (me *MetricsEngineMock) RecordAdapterThrottled(adapterName openrtb_ext.Bidd
 	me.Called(adapterName)
 }
 
+func (me *MetricsEngineMock) RecordNotification(adapterName openrtb_ext.BidderName, notificationType NotificationType, status NotificationStatus) {
+	me.Called(adapterName, notificationType, status)
+}
+
 func (me *MetricsEngineMock) RecordAdapterConnectionDialError(adapterName openrtb_ext.BidderName) {
 	me.Called()
 }
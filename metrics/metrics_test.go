@@ -47,3 +47,10 @@ func TestGetEndpointFromRequestType(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCookieJar(t *testing.T) {
+	assert.Equal(t, CookieJarNone, NewCookieJar(false, false))
+	assert.Equal(t, CookieJarUnpartitioned, NewCookieJar(true, false))
+	assert.Equal(t, CookieJarPartitioned, NewCookieJar(false, true))
+	assert.Equal(t, CookieJarBoth, NewCookieJar(true, true))
+}
@@ -16,6 +16,9 @@ func preloadLabelValues(m *Metrics, syncerKeys []string, moduleStageNames map[st
 		connectionErrorValues     = []string{connectionAcceptError, connectionCloseError}
 		cookieSyncStatusValues    = enumAsString(metrics.CookieSyncStatuses())
 		cookieValues              = enumAsString(metrics.CookieTypes())
+		cookieJarValues           = enumAsString(metrics.CookieJars())
+		floorsFetchStatusValues   = enumAsString(metrics.FloorsFetchStatuses())
+		floorsEnforcementValues   = enumAsString(metrics.FloorsEnforcementStatuses())
 		overheadTypes             = enumAsString(metrics.OverheadTypes())
 		requestStatusValues       = enumAsString(metrics.RequestStatuses())
 		requestTypeValues         = enumAsString(metrics.RequestTypes())
@@ -36,10 +39,22 @@ func preloadLabelValues(m *Metrics, syncerKeys []string, moduleStageNames map[st
 		statusLabel: cookieSyncStatusValues,
 	})
 
+	preloadLabelValuesForCounter(m.floorsFetch, map[string][]string{
+		statusLabel: floorsFetchStatusValues,
+	})
+
+	preloadLabelValuesForCounter(m.floorsEnforcement, map[string][]string{
+		statusLabel: floorsEnforcementValues,
+	})
+
 	preloadLabelValuesForCounter(m.setUid, map[string][]string{
 		statusLabel: setUidStatusValues,
 	})
 
+	preloadLabelValuesForCounter(m.uidsCookieJars, map[string][]string{
+		jarLabel: cookieJarValues,
+	})
+
 	preloadLabelValuesForCounter(m.impressions, map[string][]string{
 		isBannerLabel: boolValues,
 		isVideoLabel:  boolValues,
@@ -84,6 +99,10 @@ func preloadLabelValues(m *Metrics, syncerKeys []string, moduleStageNames map[st
 		storedDataFetchTypeLabel: storedDataFetchTypeValues,
 	})
 
+	preloadLabelValuesForHistogram(m.storedFloorsFetchTimer, map[string][]string{
+		storedDataFetchTypeLabel: storedDataFetchTypeValues,
+	})
+
 	preloadLabelValuesForCounter(m.storedAccountErrors, map[string][]string{
 		storedDataErrorLabel: storedDataErrorValues,
 	})
@@ -108,6 +127,10 @@ func preloadLabelValues(m *Metrics, syncerKeys []string, moduleStageNames map[st
 		storedDataErrorLabel: storedDataErrorValues,
 	})
 
+	preloadLabelValuesForCounter(m.storedFloorsErrors, map[string][]string{
+		storedDataErrorLabel: storedDataErrorValues,
+	})
+
 	preloadLabelValuesForCounter(m.requestsWithoutCookie, map[string][]string{
 		requestTypeLabel: requestTypeValues,
 	})
@@ -25,6 +25,7 @@ type Metrics struct {
 	connectionsOpened            prometheus.Counter
 	cookieSync                   *prometheus.CounterVec
 	setUid                       *prometheus.CounterVec
+	uidsCookieJars               *prometheus.CounterVec
 	impressions                  *prometheus.CounterVec
 	prebidCacheWriteTimer        *prometheus.HistogramVec
 	requests                     *prometheus.CounterVec
@@ -56,8 +57,13 @@ type Metrics struct {
 	storedResponses              prometheus.Counter
 	gvlListRequests              prometheus.Counter
 	liveGVLFetch                 *prometheus.CounterVec
+	floorsFetch                  *prometheus.CounterVec
+	floorsEnforcement            *prometheus.CounterVec
+	floorsRejectedBids           prometheus.Counter
 	storedResponsesFetchTimer    *prometheus.HistogramVec
 	storedResponsesErrors        *prometheus.CounterVec
+	storedFloorsFetchTimer       *prometheus.HistogramVec
+	storedFloorsErrors           *prometheus.CounterVec
 	adsCertRequests              *prometheus.CounterVec
 	adsCertSignTimer             prometheus.Histogram
 	bidderServerResponseTimer    prometheus.Histogram
@@ -80,6 +86,7 @@ type Metrics struct {
 	adapterBidResponseSecureMarkupError   *prometheus.CounterVec
 	adapterBidResponseSecureMarkupWarn    *prometheus.CounterVec
 	adapterThrottled                      *prometheus.CounterVec
+	adapterNotifications                  *prometheus.CounterVec
 	adapterConnectionDialErrors           *prometheus.CounterVec
 	adapterConnectionDialTime             *prometheus.HistogramVec
 
@@ -91,10 +98,14 @@ type Metrics struct {
 	accountRequests                       *prometheus.CounterVec
 	accountDebugRequests                  *prometheus.CounterVec
 	accountStoredResponses                *prometheus.CounterVec
+	accountFloorsFetch                    *prometheus.CounterVec
+	accountFloorsEnforcement              *prometheus.CounterVec
+	accountFloorsRejectedBids             *prometheus.CounterVec
 	accountBidResponseValidationSizeError *prometheus.CounterVec
 	accountBidResponseValidationSizeWarn  *prometheus.CounterVec
 	accountBidResponseSecureMarkupError   *prometheus.CounterVec
 	accountBidResponseSecureMarkupWarn    *prometheus.CounterVec
+	accountLabelOverflow                  *prometheus.CounterVec
 
 	// Module Metrics as a map where the key is the module name
 	moduleDuration        map[string]*prometheus.HistogramVec
@@ -106,7 +117,8 @@ type Metrics struct {
 	moduleExecutionErrors map[string]*prometheus.CounterVec
 	moduleTimeouts        map[string]*prometheus.CounterVec
 
-	metricsDisabled config.DisabledMetrics
+	metricsDisabled     config.DisabledMetrics
+	accountLabelLimiter *metrics.AccountLabelLimiter
 }
 
 const (
@@ -119,11 +131,14 @@ const (
 	connectionErrorLabel = "connection_error"
 	cookieLabel          = "cookie"
 	hasBidsLabel         = "has_bids"
+	jarLabel             = "jar"
 	isAudioLabel         = "audio"
 	isBannerLabel        = "banner"
 	isNativeLabel        = "native"
 	isVideoLabel         = "video"
 	markupDeliveryLabel  = "delivery"
+	metricFamilyLabel    = "metric"
+	notificationLabel    = "notification"
 	optOutLabel          = "opt_out"
 	overheadTypeLabel    = "overhead_type"
 	privacyBlockedLabel  = "privacy_blocked"
@@ -168,7 +183,9 @@ const (
 )
 
 // NewMetrics initializes a new Prometheus metrics instance with preloaded label values.
-func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMetrics, syncerKeys []string, moduleStageNames map[string][]string) *Metrics {
+func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMetrics, accountLabelLimit config.AccountLabelLimit, syncerKeys []string, moduleStageNames map[string][]string) *Metrics {
+	accountLabelLimiter := metrics.NewAccountLabelLimiter(accountLabelLimit)
+
 	standardTimeBuckets := []float64{0.05, 0.1, 0.15, 0.20, 0.25, 0.3, 0.4, 0.5, 0.75, 1}
 	cacheWriteTimeBuckets := []float64{0.001, 0.002, 0.005, 0.01, 0.025, 0.05, 0.1, 0.2, 0.3, 0.4, 0.5, 1}
 	priceBuckets := []float64{250, 500, 750, 1000, 1500, 2000, 2500, 3000, 3500, 4000}
@@ -179,6 +196,7 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 	metrics := Metrics{}
 	reg := prometheus.NewRegistry()
 	metrics.metricsDisabled = disabledMetrics
+	metrics.accountLabelLimiter = accountLabelLimiter
 
 	metrics.connectionsClosed = newCounterWithoutLabels(cfg, reg,
 		"connections_closed",
@@ -207,6 +225,11 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"Count of set uid requests to Prebid Server.",
 		[]string{statusLabel})
 
+	metrics.uidsCookieJars = newCounter(cfg, reg,
+		"uids_cookie_reads",
+		"Count of requests by the partitioned and unpartitioned cookie jars the uids cookie was read from.",
+		[]string{jarLabel})
+
 	metrics.impressions = newCounter(cfg, reg,
 		"impressions_requests",
 		"Count of requested impressions to Prebid Server labeled by type.",
@@ -372,6 +395,17 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"Count of stored video errors by error type",
 		[]string{storedDataErrorLabel})
 
+	metrics.storedFloorsFetchTimer = newHistogramVec(cfg, reg,
+		"stored_floors_fetch_time_seconds",
+		"Seconds to fetch stored floors labeled by fetch type",
+		[]string{storedDataFetchTypeLabel},
+		standardTimeBuckets)
+
+	metrics.storedFloorsErrors = newCounter(cfg, reg,
+		"stored_floors_errors",
+		"Count of stored floors errors by error type",
+		[]string{storedDataErrorLabel})
+
 	metrics.storedResponses = newCounterWithoutLabels(cfg, reg,
 		"stored_responses",
 		"Count of total requests to Prebid Server that have stored responses")
@@ -385,6 +419,20 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"Count of live GVL vendor ID fetches labeled by success or failure.",
 		[]string{successLabel})
 
+	metrics.floorsFetch = newCounter(cfg, reg,
+		"floors_fetch",
+		"Count of price floors data fetches labeled by status.",
+		[]string{statusLabel})
+
+	metrics.floorsEnforcement = newCounter(cfg, reg,
+		"floors_enforcement",
+		"Count of auctions with price floors labeled by enforcement status.",
+		[]string{statusLabel})
+
+	metrics.floorsRejectedBids = newCounterWithoutLabels(cfg, reg,
+		"floors_rejected_bids",
+		"Count of bids rejected for being below the price floor.")
+
 	metrics.adapterBids = newCounter(cfg, reg,
 		"adapter_bids",
 		"Count of bids labeled by adapter and markup delivery type (adm or nurl).",
@@ -467,6 +515,11 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"Count of requests throttled labeled by adapter.",
 		[]string{adapterLabel})
 
+	metrics.adapterNotifications = newCounter(cfg, reg,
+		"adapter_notifications",
+		"Count of win and billing notice URLs fired on behalf of the clients labeled by adapter, type and status.",
+		[]string{adapterLabel, notificationLabel, statusLabel})
+
 	metrics.overheadTimer = newHistogramVec(cfg, reg,
 		"overhead_time_seconds",
 		"Seconds to prepare adapter request or resolve adapter response",
@@ -524,6 +577,11 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"Count that tracks number of bids removed from bid response that had a invalid bidAdm labeled by account (warn)",
 		[]string{accountLabel, successLabel})
 
+	metrics.accountLabelOverflow = newCounter(cfg, reg,
+		"account_label_overflow",
+		"Count of account metric observations folded into the 'other' account label due to the cardinality limit labeled by metric family.",
+		[]string{metricFamilyLabel})
+
 	metrics.requestsQueueTimer = newHistogramVec(cfg, reg,
 		"request_queue_time",
 		"Seconds request was waiting in queue",
@@ -535,6 +593,21 @@ func NewMetrics(cfg config.PrometheusMetrics, disabledMetrics config.DisabledMet
 		"Count of total requests to Prebid Server that have stored responses labled by account",
 		[]string{accountLabel})
 
+	metrics.accountFloorsFetch = newCounter(cfg, reg,
+		"account_floors_fetch",
+		"Count of price floors data fetches labeled by account and status.",
+		[]string{accountLabel, statusLabel})
+
+	metrics.accountFloorsEnforcement = newCounter(cfg, reg,
+		"account_floors_enforcement",
+		"Count of auctions with price floors labeled by account and enforcement status.",
+		[]string{accountLabel, statusLabel})
+
+	metrics.accountFloorsRejectedBids = newCounter(cfg, reg,
+		"account_floors_rejected_bids",
+		"Count of bids rejected for being below the price floor labeled by account.",
+		[]string{accountLabel})
+
 	metrics.adsCertSignTimer = newHistogram(cfg, reg,
 		"ads_cert_sign_time",
 		"Seconds to generate an AdsCert header",
@@ -695,6 +768,18 @@ func (m *Metrics) RecordConnectionClose(success bool) {
 	}
 }
 
+// accountLabelValue returns the account label value to record for the metric family, folding
+// accounts beyond the configured cardinality limit into the "other" bucket.
+func (m *Metrics) accountLabelValue(family, account string) string {
+	value, overflow := m.accountLabelLimiter.Limit(family, account)
+	if overflow {
+		m.accountLabelOverflow.With(prometheus.Labels{
+			metricFamilyLabel: family,
+		}).Inc()
+	}
+	return value
+}
+
 func (m *Metrics) RecordRequest(labels metrics.Labels) {
 	m.requests.With(prometheus.Labels{
 		requestTypeLabel:   string(labels.RType),
@@ -716,7 +801,7 @@ func (m *Metrics) RecordRequest(labels metrics.Labels) {
 
 	if labels.PubID != metrics.PublisherUnknown {
 		m.accountRequests.With(prometheus.Labels{
-			accountLabel: labels.PubID,
+			accountLabel: m.accountLabelValue("account_requests", labels.PubID),
 		}).Inc()
 	}
 }
@@ -726,7 +811,7 @@ func (m *Metrics) RecordDebugRequest(debugEnabled bool, pubID string) {
 		m.debugRequests.Inc()
 		if !m.metricsDisabled.AccountDebug && pubID != metrics.PublisherUnknown {
 			m.accountDebugRequests.With(prometheus.Labels{
-				accountLabel: pubID,
+				accountLabel: m.accountLabelValue("account_debug_requests", pubID),
 			}).Inc()
 		}
 	}
@@ -736,11 +821,41 @@ func (m *Metrics) RecordStoredResponse(pubId string) {
 	m.storedResponses.Inc()
 	if !m.metricsDisabled.AccountStoredResponses && pubId != metrics.PublisherUnknown {
 		m.accountStoredResponses.With(prometheus.Labels{
-			accountLabel: pubId,
+			accountLabel: m.accountLabelValue("account_stored_responses", pubId),
+		}).Inc()
+	}
+}
+
+func (m *Metrics) RecordFloorsFetch(pubID string, status metrics.FloorsFetchStatus) {
+	m.floorsFetch.With(prometheus.Labels{
+		statusLabel: string(status),
+	}).Inc()
+
+	if pubID != metrics.PublisherUnknown && pubID != "" {
+		m.accountFloorsFetch.With(prometheus.Labels{
+			accountLabel: m.accountLabelValue("account_floors_fetch", pubID),
+			statusLabel:  string(status),
 		}).Inc()
 	}
 }
 
+func (m *Metrics) RecordFloorsEnforcement(pubID string, status metrics.FloorsEnforcementStatus, rejectedBids int) {
+	m.floorsEnforcement.With(prometheus.Labels{
+		statusLabel: string(status),
+	}).Inc()
+	m.floorsRejectedBids.Add(float64(rejectedBids))
+
+	if pubID != metrics.PublisherUnknown && pubID != "" {
+		m.accountFloorsEnforcement.With(prometheus.Labels{
+			accountLabel: m.accountLabelValue("account_floors_enforcement", pubID),
+			statusLabel:  string(status),
+		}).Inc()
+		m.accountFloorsRejectedBids.With(prometheus.Labels{
+			accountLabel: m.accountLabelValue("account_floors_rejected_bids", pubID),
+		}).Add(float64(rejectedBids))
+	}
+}
+
 func (m *Metrics) RecordGvlListRequest() {
 	m.gvlListRequests.Inc()
 }
@@ -800,6 +915,10 @@ func (m *Metrics) RecordStoredDataFetchTime(labels metrics.StoredDataLabels, len
 		m.storedResponsesFetchTimer.With(prometheus.Labels{
 			storedDataFetchTypeLabel: string(labels.DataFetchType),
 		}).Observe(length.Seconds())
+	case metrics.FloorsDataType:
+		m.storedFloorsFetchTimer.With(prometheus.Labels{
+			storedDataFetchTypeLabel: string(labels.DataFetchType),
+		}).Observe(length.Seconds())
 	}
 }
 
@@ -829,6 +948,10 @@ func (m *Metrics) RecordStoredDataError(labels metrics.StoredDataLabels) {
 		m.storedResponsesErrors.With(prometheus.Labels{
 			storedDataErrorLabel: string(labels.Error),
 		}).Inc()
+	case metrics.FloorsDataType:
+		m.storedFloorsErrors.With(prometheus.Labels{
+			storedDataErrorLabel: string(labels.Error),
+		}).Inc()
 	}
 }
 
@@ -940,6 +1063,12 @@ func (m *Metrics) RecordSetUid(status metrics.SetUidStatus) {
 	}).Inc()
 }
 
+func (m *Metrics) RecordUIDsCookieJar(jar metrics.CookieJar) {
+	m.uidsCookieJars.With(prometheus.Labels{
+		jarLabel: string(jar),
+	}).Inc()
+}
+
 func (m *Metrics) RecordSyncerSet(key string, status metrics.SyncerSetUidStatus) {
 	m.syncerSets.With(prometheus.Labels{
 		syncerLabel: key,
@@ -1065,7 +1194,7 @@ func (m *Metrics) RecordBidValidationCreativeSizeError(adapter openrtb_ext.Bidde
 
 	if !m.metricsDisabled.AccountAdapterDetails && account != metrics.PublisherUnknown {
 		m.accountBidResponseValidationSizeError.With(prometheus.Labels{
-			accountLabel: account, successLabel: successLabel,
+			accountLabel: m.accountLabelValue("account_response_validation_size_err", account), successLabel: successLabel,
 		}).Inc()
 	}
 }
@@ -1078,7 +1207,7 @@ func (m *Metrics) RecordBidValidationCreativeSizeWarn(adapter openrtb_ext.Bidder
 
 	if !m.metricsDisabled.AccountAdapterDetails && account != metrics.PublisherUnknown {
 		m.accountBidResponseValidationSizeWarn.With(prometheus.Labels{
-			accountLabel: account, successLabel: successLabel,
+			accountLabel: m.accountLabelValue("account_response_validation_size_warn", account), successLabel: successLabel,
 		}).Inc()
 	}
 }
@@ -1090,7 +1219,7 @@ func (m *Metrics) RecordBidValidationSecureMarkupError(adapter openrtb_ext.Bidde
 
 	if !m.metricsDisabled.AccountAdapterDetails && account != metrics.PublisherUnknown {
 		m.accountBidResponseSecureMarkupError.With(prometheus.Labels{
-			accountLabel: account, successLabel: successLabel,
+			accountLabel: m.accountLabelValue("account_response_validation_secure_err", account), successLabel: successLabel,
 		}).Inc()
 	}
 }
@@ -1102,7 +1231,7 @@ func (m *Metrics) RecordBidValidationSecureMarkupWarn(adapter openrtb_ext.Bidder
 
 	if !m.metricsDisabled.AccountAdapterDetails && account != metrics.PublisherUnknown {
 		m.accountBidResponseSecureMarkupWarn.With(prometheus.Labels{
-			accountLabel: account, successLabel: successLabel,
+			accountLabel: m.accountLabelValue("account_response_validation_secure_warn", account), successLabel: successLabel,
 		}).Inc()
 	}
 }
@@ -1159,6 +1288,14 @@ func (m *Metrics) RecordAdapterThrottled(adapterName openrtb_ext.BidderName) {
 	}).Inc()
 }
 
+func (m *Metrics) RecordNotification(adapterName openrtb_ext.BidderName, notificationType metrics.NotificationType, status metrics.NotificationStatus) {
+	m.adapterNotifications.With(prometheus.Labels{
+		adapterLabel:      strings.ToLower(string(adapterName)),
+		notificationLabel: string(notificationType),
+		statusLabel:       string(status),
+	}).Inc()
+}
+
 func (m *Metrics) RecordAdapterConnectionDialError(adapterName openrtb_ext.BidderName) {
 	m.adapterConnectionDialErrors.With(prometheus.Labels{
 		adapterLabel: strings.ToLower(string(adapterName)),
@@ -22,7 +22,7 @@ func createMetricsForTesting() *Metrics {
 		Port:      8080,
 		Namespace: "prebid",
 		Subsystem: "server",
-	}, config.DisabledMetrics{}, syncerKeys, modulesStages)
+	}, config.DisabledMetrics{}, config.AccountLabelLimit{}, syncerKeys, modulesStages)
 }
 
 func TestMetricCountGatekeeping(t *testing.T) {
@@ -416,6 +416,26 @@ func TestAccountMetric(t *testing.T) {
 	}
 }
 
+func TestAccountMetricLabelLimit(t *testing.T) {
+	m := NewMetrics(config.PrometheusMetrics{}, config.DisabledMetrics{}, config.AccountLabelLimit{
+		MaxValues: 1,
+		Allowlist: []string{"allowed"},
+	}, nil, nil)
+
+	for _, pubID := range []string{"first", "second", "third", "allowed", "first"} {
+		m.RecordRequest(metrics.Labels{
+			RType:         metrics.ReqTypeORTB2Web,
+			RequestStatus: metrics.RequestStatusOK,
+			PubID:         pubID,
+		})
+	}
+
+	assertCounterVecValue(t, "first", "accountRequests", m.accountRequests, 2, prometheus.Labels{accountLabel: "first"})
+	assertCounterVecValue(t, "allowed", "accountRequests", m.accountRequests, 1, prometheus.Labels{accountLabel: "allowed"})
+	assertCounterVecValue(t, "other", "accountRequests", m.accountRequests, 2, prometheus.Labels{accountLabel: metrics.AccountLabelOther})
+	assertCounterVecValue(t, "overflow", "accountLabelOverflow", m.accountLabelOverflow, 2, prometheus.Labels{metricFamilyLabel: "account_requests"})
+}
+
 func TestImpressionsMetric(t *testing.T) {
 	performTest := func(m *Metrics, isBanner, isVideo, isAudio, isNative bool) {
 		m.RecordImps(metrics.ImpLabels{
@@ -671,6 +691,11 @@ func TestRecordStoredDataFetchTime(t *testing.T) {
 			dataType:    metrics.ResponseDataType,
 			fetchType:   metrics.FetchDelta,
 		},
+		{
+			description: "Update stored floors histogram with delta label",
+			dataType:    metrics.FloorsDataType,
+			fetchType:   metrics.FetchDelta,
+		},
 	}
 
 	for _, tt := range tests {
@@ -696,6 +721,8 @@ func TestRecordStoredDataFetchTime(t *testing.T) {
 			metricsTimer = m.storedVideoFetchTimer
 		case metrics.ResponseDataType:
 			metricsTimer = m.storedResponsesFetchTimer
+		case metrics.FloorsDataType:
+			metricsTimer = m.storedFloorsFetchTimer
 		}
 
 		result, found := getHistogramFromHistogramVec(
@@ -780,6 +807,12 @@ func TestRecordStoredDataError(t *testing.T) {
 			errorType:   metrics.StoredDataErrorNetwork,
 			metricName:  "stored_response_errors",
 		},
+		{
+			description: "Update stored_floors_errors counter with network label",
+			dataType:    metrics.FloorsDataType,
+			errorType:   metrics.StoredDataErrorNetwork,
+			metricName:  "stored_floors_errors",
+		},
 	}
 
 	for _, tt := range tests {
@@ -803,6 +836,8 @@ func TestRecordStoredDataError(t *testing.T) {
 			metricsCounter = m.storedVideoErrors
 		case metrics.ResponseDataType:
 			metricsCounter = m.storedResponsesErrors
+		case metrics.FloorsDataType:
+			metricsCounter = m.storedFloorsErrors
 		}
 
 		assertCounterVecValue(t, tt.description, tt.metricName, metricsCounter,
@@ -1339,6 +1374,18 @@ func TestSetUidMetric(t *testing.T) {
 	}
 }
 
+func TestRecordUIDsCookieJarMetric(t *testing.T) {
+	m := createMetricsForTesting()
+
+	m.RecordUIDsCookieJar(metrics.CookieJarBoth)
+
+	assertCounterVecValue(t, "", "uids_cookie_reads:both", m.uidsCookieJars,
+		float64(1),
+		prometheus.Labels{
+			jarLabel: string(metrics.CookieJarBoth),
+		})
+}
+
 func TestRecordSyncerSetMetric(t *testing.T) {
 	key := "anyKey"
 
@@ -1686,7 +1733,7 @@ func TestDisabledMetrics(t *testing.T) {
 		AdapterBuyerUIDScrubbed:   true,
 		AdapterConnectionMetrics:  true,
 		AdapterGDPRRequestBlocked: true,
-	},
+	}, config.AccountLabelLimit{},
 		nil, nil)
 
 	// Assert counter vector was not initialized
@@ -1973,6 +2020,86 @@ func TestRecordLiveGVLFetch(t *testing.T) {
 		})
 }
 
+func TestRecordFloorsFetch(t *testing.T) {
+	m := createMetricsForTesting()
+
+	m.RecordFloorsFetch("acct-id", metrics.FloorsFetchSuccess)
+	m.RecordFloorsFetch("acct-id", metrics.FloorsFetchNotModified)
+	m.RecordFloorsFetch(metrics.PublisherUnknown, metrics.FloorsFetchNotModified)
+
+	assertCounterVecValue(t, "", "floors_fetch:success", m.floorsFetch,
+		float64(1),
+		prometheus.Labels{
+			statusLabel: string(metrics.FloorsFetchSuccess),
+		})
+
+	assertCounterVecValue(t, "", "floors_fetch:not_modified", m.floorsFetch,
+		float64(2),
+		prometheus.Labels{
+			statusLabel: string(metrics.FloorsFetchNotModified),
+		})
+
+	assertCounterVecValue(t, "", "account_floors_fetch:not_modified", m.accountFloorsFetch,
+		float64(1),
+		prometheus.Labels{
+			accountLabel: "acct-id",
+			statusLabel:  string(metrics.FloorsFetchNotModified),
+		})
+}
+
+func TestRecordFloorsEnforcement(t *testing.T) {
+	m := createMetricsForTesting()
+
+	m.RecordFloorsEnforcement("acct-id", metrics.FloorsEnforcementEnforced, 2)
+	m.RecordFloorsEnforcement("acct-id", metrics.FloorsEnforcementSkipped, 0)
+	m.RecordFloorsEnforcement(metrics.PublisherUnknown, metrics.FloorsEnforcementEnforced, 1)
+
+	assertCounterVecValue(t, "", "floors_enforcement:enforced", m.floorsEnforcement,
+		float64(2),
+		prometheus.Labels{
+			statusLabel: string(metrics.FloorsEnforcementEnforced),
+		})
+
+	assertCounterValue(t, "", "floors_rejected_bids", m.floorsRejectedBids, float64(3))
+
+	assertCounterVecValue(t, "", "account_floors_enforcement:skipped", m.accountFloorsEnforcement,
+		float64(1),
+		prometheus.Labels{
+			accountLabel: "acct-id",
+			statusLabel:  string(metrics.FloorsEnforcementSkipped),
+		})
+
+	assertCounterVecValue(t, "", "account_floors_rejected_bids", m.accountFloorsRejectedBids,
+		float64(2),
+		prometheus.Labels{
+			accountLabel: "acct-id",
+		})
+}
+
+func TestRecordNotification(t *testing.T) {
+	m := createMetricsForTesting()
+
+	m.RecordNotification(openrtb_ext.BidderName("AnyName"), metrics.NotificationWin, metrics.NotificationSent)
+	m.RecordNotification(openrtb_ext.BidderName("AnyName"), metrics.NotificationWin, metrics.NotificationSent)
+	m.RecordNotification(openrtb_ext.BidderName("AnyName"), metrics.NotificationBilling, metrics.NotificationRetried)
+
+	assertCounterVecValue(t, "", "adapter_notifications:win:sent", m.adapterNotifications,
+		float64(2),
+		prometheus.Labels{
+			adapterLabel:      "anyname",
+			notificationLabel: string(metrics.NotificationWin),
+			statusLabel:       string(metrics.NotificationSent),
+		})
+
+	assertCounterVecValue(t, "", "adapter_notifications:billing:retried", m.adapterNotifications,
+		float64(1),
+		prometheus.Labels{
+			adapterLabel:      "anyname",
+			notificationLabel: string(metrics.NotificationBilling),
+			statusLabel:       string(metrics.NotificationRetried),
+		})
+}
+
 func TestRecordAdsCertReqMetric(t *testing.T) {
 	testCases := []struct {
 		description                  string
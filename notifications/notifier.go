@@ -0,0 +1,180 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alitto/pond"
+	"github.com/coocood/freecache"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/logger"
+	"github.com/prebid/prebid-server/v4/metrics"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+)
+
+// Notifier fires the win (nurl) and billing (burl) notice URLs of the bids on behalf of the clients, which don't
+// fire them in some CTV and app integrations. As the bidders reuse their bid IDs across bidders and auctions, the bids
+// are identified by their account, bidder and bid ID, and the win notices by their auction too.
+type Notifier interface {
+	// Win fires the win notice URL of a bid selected as the winner of its imp
+	Win(accountID, auctionID string, bidder openrtb_ext.BidderName, bidID, nurl string)
+	// RecordBilling keeps the billing notice URL of a bid until the billing event of the bid is received. The bid ID
+	// must be unique across the auctions of the account, as the billing events don't carry the auction.
+	RecordBilling(accountID string, bidder openrtb_ext.BidderName, bidID, burl string)
+	// Billing fires the billing notice URL recorded for a bid
+	Billing(accountID string, bidder openrtb_ext.BidderName, bidID string)
+	Stop()
+}
+
+type WorkerPool interface {
+	TrySubmit(task func()) bool
+	Stop()
+}
+
+// notification is a notice URL to fire, along with the number of attempts already made
+type notification struct {
+	bidder           openrtb_ext.BidderName
+	notificationType metrics.NotificationType
+	url              string
+	attempt          int
+}
+
+type NotificationFirer struct {
+	pool          WorkerPool            // Goroutines worker pool
+	cache         *freecache.Cache      // Billing notice URLs waiting for their event and notices already fired
+	httpClient    *http.Client          // http client to fire the notice URLs
+	metricsEngine metrics.MetricsEngine // Records the outcome of the notifications per bidder
+	maxRetries    int                   // Max number of retries of failing notifications
+	retryBackoff  time.Duration         // Delay before the first retry, doubled on every retry
+	timeout       time.Duration         // Timeout of a single attempt
+	ttl           int                   // Seconds the billing notice URLs and the fired notices are kept
+}
+
+func workerPanicHandler(p interface{}) {
+	logger.Errorf("notification firer worker panicked: %v", p)
+}
+
+// NewNotifier returns the firer of the bids notice URLs, or nil if the notifications are disabled for the host
+func NewNotifier(cfg config.Notifications, httpClient *http.Client, metricsEngine metrics.MetricsEngine) Notifier {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &NotificationFirer{
+		pool:          pond.New(cfg.Worker, cfg.Capacity, pond.PanicHandler(workerPanicHandler)),
+		cache:         freecache.NewCache(cfg.CacheSize * 1024 * 1024),
+		httpClient:    httpClient,
+		metricsEngine: metricsEngine,
+		maxRetries:    cfg.MaxRetries,
+		retryBackoff:  time.Duration(cfg.RetryBackoffMS) * time.Millisecond,
+		timeout:       time.Duration(cfg.TimeoutMS) * time.Millisecond,
+		ttl:           cfg.BillingTTLSeconds,
+	}
+}
+
+func (f *NotificationFirer) Win(accountID, auctionID string, bidder openrtb_ext.BidderName, bidID, nurl string) {
+	if nurl == "" {
+		return
+	}
+	f.fire(notification{bidder: bidder, notificationType: metrics.NotificationWin, url: nurl}, bidKey(accountID, bidder, bidID, auctionID))
+}
+
+func (f *NotificationFirer) RecordBilling(accountID string, bidder openrtb_ext.BidderName, bidID, burl string) {
+	if burl == "" {
+		return
+	}
+	if err := f.cache.Set([]byte("burl:"+bidKey(accountID, bidder, bidID)), []byte(burl), f.ttl); err != nil {
+		logger.Errorf("could not keep the billing notice URL of bid %s: %v", bidID, err)
+	}
+}
+
+func (f *NotificationFirer) Billing(accountID string, bidder openrtb_ext.BidderName, bidID string) {
+	key := bidKey(accountID, bidder, bidID)
+	burl, err := f.cache.Get([]byte("burl:" + key))
+	if err != nil {
+		return
+	}
+	f.fire(notification{bidder: bidder, notificationType: metrics.NotificationBilling, url: string(burl)}, key)
+}
+
+func (f *NotificationFirer) Stop() {
+	f.pool.Stop()
+}
+
+// bidKey identifies a bid by its account, bidder and bid ID, followed by any further scope such as its auction. The
+// bidder is lower cased as the event endpoint normalises the bidder of the billing events.
+func bidKey(accountID string, bidder openrtb_ext.BidderName, bidID string, scope ...string) string {
+	parts := append([]string{accountID, strings.ToLower(bidder.String()), bidID}, scope...)
+	for i, part := range parts {
+		parts[i] = url.QueryEscape(part)
+	}
+	return strings.Join(parts, ":")
+}
+
+// fire submits the notification unless the same type of notification was already fired for the bid
+func (f *NotificationFirer) fire(n notification, key string) {
+	firedKey := []byte(fmt.Sprintf("fired:%s:%s", n.notificationType, key))
+	if previous, err := f.cache.GetOrSet(firedKey, []byte{1}, f.ttl); err == nil && previous != nil {
+		f.metricsEngine.RecordNotification(n.bidder, n.notificationType, metrics.NotificationDuplicate)
+		return
+	}
+	f.submit(n)
+}
+
+func (f *NotificationFirer) submit(n notification) {
+	if !f.pool.TrySubmit(func() { f.send(n) }) {
+		f.metricsEngine.RecordNotification(n.bidder, n.notificationType, metrics.NotificationDropped)
+	}
+}
+
+// send fires the notice URL, scheduling a retry with an exponential backoff when it fails with a retryable error
+func (f *NotificationFirer) send(n notification) {
+	retryable, err := f.get(n.url)
+	if err == nil {
+		f.metricsEngine.RecordNotification(n.bidder, n.notificationType, metrics.NotificationSent)
+		return
+	}
+
+	if !retryable || n.attempt >= f.maxRetries {
+		logger.Warnf("the %s notification of bidder %s failed: %v", n.notificationType, n.bidder, err)
+		f.metricsEngine.RecordNotification(n.bidder, n.notificationType, metrics.NotificationFailed)
+		return
+	}
+
+	f.metricsEngine.RecordNotification(n.bidder, n.notificationType, metrics.NotificationRetried)
+	backoff := f.retryBackoff << n.attempt
+	n.attempt++
+	time.AfterFunc(backoff, func() { f.submit(n) })
+}
+
+// get requests the notice URL, returning whether the request is worth retrying when it fails
+func (f *NotificationFirer) get(url string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), f.timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	response, err := f.httpClient.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		// read the entire response body to ensure full connection reuse
+		io.Copy(io.Discard, response.Body)
+		response.Body.Close()
+	}()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		retryable := response.StatusCode >= http.StatusInternalServerError || response.StatusCode == http.StatusTooManyRequests
+		return retryable, fmt.Errorf("the notice URL responded with status code %d", response.StatusCode)
+	}
+	return false, nil
+}
@@ -0,0 +1,231 @@
+package notifications
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/metrics"
+	metricsConf "github.com/prebid/prebid-server/v4/metrics/config"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+)
+
+var testConfig = config.Notifications{
+	Enabled:           true,
+	Worker:            2,
+	Capacity:          10,
+	MaxRetries:        2,
+	RetryBackoffMS:    1,
+	TimeoutMS:         1000,
+	BillingTTLSeconds: 60,
+	CacheSize:         1,
+}
+
+// countingServer responds with the status codes in order, then with 200
+func countingServer(hits *int32, statusCodes ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit := int(atomic.AddInt32(hits, 1))
+		if hit <= len(statusCodes) {
+			w.WriteHeader(statusCodes[hit-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+// notificationsRecorder counts the recorded notifications, being safe to read while the workers record them
+type notificationsRecorder struct {
+	metricsConf.NilMetricsEngine
+	mutex  sync.Mutex
+	counts map[notificationKey]int
+}
+
+type notificationKey struct {
+	bidder           openrtb_ext.BidderName
+	notificationType metrics.NotificationType
+	status           metrics.NotificationStatus
+}
+
+func (r *notificationsRecorder) RecordNotification(bidder openrtb_ext.BidderName, notificationType metrics.NotificationType, status metrics.NotificationStatus) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.counts == nil {
+		r.counts = make(map[notificationKey]int)
+	}
+	r.counts[notificationKey{bidder, notificationType, status}]++
+}
+
+func (r *notificationsRecorder) count(bidder openrtb_ext.BidderName, notificationType metrics.NotificationType, status metrics.NotificationStatus) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.counts[notificationKey{bidder, notificationType, status}]
+}
+
+func assertNotifications(t *testing.T, recorder *notificationsRecorder, bidder openrtb_ext.BidderName, notificationType metrics.NotificationType, status metrics.NotificationStatus, expected int) {
+	assert.Eventually(t, func() bool {
+		return recorder.count(bidder, notificationType, status) == expected
+	}, time.Second, 5*time.Millisecond, "%s notifications %s", notificationType, status)
+}
+
+func TestNewNotifierDisabled(t *testing.T) {
+	assert.Nil(t, NewNotifier(config.Notifications{Enabled: false}, http.DefaultClient, &notificationsRecorder{}))
+}
+
+func TestWin(t *testing.T) {
+	var hits int32
+	server := countingServer(&hits)
+	defer server.Close()
+
+	metricsEngine := &notificationsRecorder{}
+	notifier := NewNotifier(testConfig, server.Client(), metricsEngine)
+	defer notifier.Stop()
+
+	notifier.Win("account-1", "auction-1", openrtb_ext.BidderAppnexus, "bid-1", server.URL+"/win")
+	notifier.Win("account-1", "auction-1", openrtb_ext.BidderAppnexus, "bid-1", server.URL+"/win")
+	notifier.Win("account-1", "auction-1", openrtb_ext.BidderAppnexus, "bid-2", "")
+
+	assertNotifications(t, metricsEngine, openrtb_ext.BidderAppnexus, metrics.NotificationWin, metrics.NotificationSent, 1)
+	assertNotifications(t, metricsEngine, openrtb_ext.BidderAppnexus, metrics.NotificationWin, metrics.NotificationDuplicate, 1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestWinCollidingBidIDs(t *testing.T) {
+	var hits int32
+	server := countingServer(&hits)
+	defer server.Close()
+
+	metricsEngine := &notificationsRecorder{}
+	notifier := NewNotifier(testConfig, server.Client(), metricsEngine)
+	defer notifier.Stop()
+
+	notifier.Win("account-1", "auction-1", openrtb_ext.BidderAppnexus, "1", server.URL+"/win")
+	notifier.Win("account-1", "auction-1", openrtb_ext.BidderPubmatic, "1", server.URL+"/win")
+	notifier.Win("account-1", "auction-2", openrtb_ext.BidderAppnexus, "1", server.URL+"/win")
+	notifier.Win("account-2", "auction-1", openrtb_ext.BidderAppnexus, "1", server.URL+"/win")
+
+	assertNotifications(t, metricsEngine, openrtb_ext.BidderAppnexus, metrics.NotificationWin, metrics.NotificationSent, 3)
+	assertNotifications(t, metricsEngine, openrtb_ext.BidderPubmatic, metrics.NotificationWin, metrics.NotificationSent, 1)
+	assert.Equal(t, 0, metricsEngine.count(openrtb_ext.BidderAppnexus, metrics.NotificationWin, metrics.NotificationDuplicate))
+	assert.Equal(t, int32(4), atomic.LoadInt32(&hits))
+}
+
+func TestBilling(t *testing.T) {
+	var hits int32
+	server := countingServer(&hits)
+	defer server.Close()
+
+	metricsEngine := &notificationsRecorder{}
+	notifier := NewNotifier(testConfig, server.Client(), metricsEngine)
+	defer notifier.Stop()
+
+	notifier.RecordBilling("account-1", openrtb_ext.BidderPubmatic, "bid-1", server.URL+"/billing")
+	notifier.Billing("account-1", openrtb_ext.BidderPubmatic, "bid-1")
+	notifier.Billing("account-1", openrtb_ext.BidderPubmatic, "bid-1")
+	notifier.Billing("account-1", openrtb_ext.BidderPubmatic, "unknown-bid")
+
+	assertNotifications(t, metricsEngine, openrtb_ext.BidderPubmatic, metrics.NotificationBilling, metrics.NotificationSent, 1)
+	assertNotifications(t, metricsEngine, openrtb_ext.BidderPubmatic, metrics.NotificationBilling, metrics.NotificationDuplicate, 1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestBillingCollidingBidIDs(t *testing.T) {
+	var mutex sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		paths = append(paths, r.URL.Path)
+	}))
+	defer server.Close()
+
+	metricsEngine := &notificationsRecorder{}
+	notifier := NewNotifier(testConfig, server.Client(), metricsEngine)
+	defer notifier.Stop()
+
+	notifier.RecordBilling("account-1", openrtb_ext.BidderAppnexus, "1", server.URL+"/appnexus")
+	notifier.RecordBilling("account-1", openrtb_ext.BidderPubmatic, "1", server.URL+"/pubmatic")
+
+	// another account can't fire the billing notice URL of the bid
+	notifier.Billing("account-2", openrtb_ext.BidderAppnexus, "1")
+	// the billing events normalise the bidder
+	notifier.Billing("account-1", openrtb_ext.BidderName("APPNEXUS"), "1")
+	notifier.Billing("account-1", openrtb_ext.BidderPubmatic, "1")
+
+	assertNotifications(t, metricsEngine, openrtb_ext.BidderAppnexus, metrics.NotificationBilling, metrics.NotificationSent, 0)
+	assertNotifications(t, metricsEngine, openrtb_ext.BidderName("APPNEXUS"), metrics.NotificationBilling, metrics.NotificationSent, 1)
+	assertNotifications(t, metricsEngine, openrtb_ext.BidderPubmatic, metrics.NotificationBilling, metrics.NotificationSent, 1)
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.ElementsMatch(t, []string{"/appnexus", "/pubmatic"}, paths)
+}
+
+func TestRetries(t *testing.T) {
+	testCases := []struct {
+		name            string
+		statusCodes     []int
+		expectedHits    int32
+		expectedRetried int
+		expectedSent    int
+		expectedFailed  int
+	}{
+		{
+			name:            "succeeds_after_retries",
+			statusCodes:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			expectedHits:    3,
+			expectedRetried: 2,
+			expectedSent:    1,
+		},
+		{
+			name:            "fails_after_max_retries",
+			statusCodes:     []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			expectedHits:    3,
+			expectedRetried: 2,
+			expectedFailed:  1,
+		},
+		{
+			name:           "not_retryable",
+			statusCodes:    []int{http.StatusNotFound},
+			expectedHits:   1,
+			expectedFailed: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var hits int32
+			server := countingServer(&hits, tc.statusCodes...)
+			defer server.Close()
+
+			metricsEngine := &notificationsRecorder{}
+			notifier := NewNotifier(testConfig, server.Client(), metricsEngine)
+			defer notifier.Stop()
+
+			notifier.Win("account-1", "auction-1", openrtb_ext.BidderAppnexus, "bid-1", server.URL+"/win")
+
+			assertNotifications(t, metricsEngine, openrtb_ext.BidderAppnexus, metrics.NotificationWin, metrics.NotificationSent, tc.expectedSent)
+			assertNotifications(t, metricsEngine, openrtb_ext.BidderAppnexus, metrics.NotificationWin, metrics.NotificationFailed, tc.expectedFailed)
+			assertNotifications(t, metricsEngine, openrtb_ext.BidderAppnexus, metrics.NotificationWin, metrics.NotificationRetried, tc.expectedRetried)
+			assert.Equal(t, tc.expectedHits, atomic.LoadInt32(&hits))
+		})
+	}
+}
+
+type fullWorkerPool struct{}
+
+func (fullWorkerPool) TrySubmit(task func()) bool { return false }
+func (fullWorkerPool) Stop()                      {}
+
+func TestDropped(t *testing.T) {
+	metricsEngine := &notificationsRecorder{}
+	notifier := NewNotifier(testConfig, http.DefaultClient, metricsEngine).(*NotificationFirer)
+	notifier.pool = fullWorkerPool{}
+
+	notifier.Win("account-1", "auction-1", openrtb_ext.BidderAppnexus, "bid-1", "http://localhost/win")
+
+	assert.Equal(t, 1, metricsEngine.count(openrtb_ext.BidderAppnexus, metrics.NotificationWin, metrics.NotificationDropped))
+}
@@ -27,6 +27,7 @@ type ExtBidPrebid struct {
 	BidId             string              `json:"bidid,omitempty"`
 	Passthrough       json.RawMessage     `json:"passthrough,omitempty"`
 	Floors            *ExtBidPrebidFloors `json:"floors,omitempty"`
+	Prices            map[string]float64  `json:"prices,omitempty"`
 }
 
 // ExtBidPrebidFloors defines the contract for bidresponse.seatbid.bid[i].ext.prebid.floors
@@ -188,6 +189,11 @@ func min(x, y int) int {
 	return y
 }
 
+// CurrencyKey returns the key for the value of the targeting key in another currency, such as hb_pb_EUR
+func (key TargetingKey) CurrencyKey(currency string) TargetingKey {
+	return key + TargetingKey("_"+currency)
+}
+
 func (key TargetingKey) TruncateKey(prefix string, maxLength int) string {
 	result := prefix + string(key)
 	if maxLength > 0 {
@@ -200,5 +206,6 @@ const (
 	StoredRequestAttributes = "storedrequestattributes"
 	OriginalBidCpmKey       = "origbidcpm"
 	OriginalBidCurKey       = "origbidcur"
+	RateSnapshotIDKey       = "ratesnapshotid"
 	Passthrough             = "passthrough"
 )
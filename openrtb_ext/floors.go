@@ -18,9 +18,10 @@ const (
 
 // Defines strings for PriceFloorLocation
 const (
-	NoDataLocation  = "noData"
-	RequestLocation = "request"
-	FetchLocation   = "fetch"
+	NoDataLocation    = "noData"
+	RequestLocation   = "request"
+	FetchLocation     = "fetch"
+	OptimizerLocation = "optimizer"
 )
 
 // PriceFloorRules defines the contract for bidrequest.ext.prebid.floors
@@ -29,6 +30,7 @@ type PriceFloorRules struct {
 	FloorMinCur        string                 `json:"floormincur,omitempty"`
 	SkipRate           int                    `json:"skiprate,omitempty"`
 	Location           *PriceFloorEndpoint    `json:"floorendpoint,omitempty"`
+	FloorsID           string                 `json:"floorsid,omitempty"`
 	Data               *PriceFloorData        `json:"data,omitempty"`
 	Enforcement        *PriceFloorEnforcement `json:"enforcement,omitempty"`
 	Enabled            *bool                  `json:"enabled,omitempty"`
@@ -38,6 +40,29 @@ type PriceFloorRules struct {
 	PriceFloorLocation string                 `json:"location,omitempty"`
 }
 
+// PriceFloorsOutcome summarises the price floors resolved and enforced in an auction for the analytics modules
+type PriceFloorsOutcome struct {
+	Location      string                  `json:"location,omitempty"`
+	FetchStatus   string                  `json:"fetchstatus,omitempty"`
+	FloorProvider string                  `json:"floorprovider,omitempty"`
+	ModelVersion  string                  `json:"modelversion,omitempty"`
+	Skipped       bool                    `json:"skipped"`
+	Enforced      bool                    `json:"enforced"`
+	Imps          []PriceFloorsImpOutcome `json:"imps,omitempty"`
+	RejectedBids  int                     `json:"rejectedbids"`
+	// RejectedValue is the sum of the prices of the rejected bids in USD
+	RejectedValue float64 `json:"rejectedvalue"`
+}
+
+// PriceFloorsImpOutcome is the floors rule matched for an imp and the resulting floor
+type PriceFloorsImpOutcome struct {
+	ImpID          string  `json:"impid"`
+	FloorRule      string  `json:"floorrule,omitempty"`
+	FloorRuleValue float64 `json:"floorrulevalue,omitempty"`
+	FloorValue     float64 `json:"floorvalue,omitempty"`
+	FloorCurrency  string  `json:"floorcurrency,omitempty"`
+}
+
 // GetEnforcePBS will check if floors enforcement is enabled in request
 func (Floors *PriceFloorRules) GetEnforcePBS() bool {
 	if Floors != nil && Floors.Enforcement != nil && Floors.Enforcement.EnforcePBS != nil {
@@ -205,6 +205,9 @@ type ExtRequestTargeting struct {
 	AppendBidderNames         bool                       `json:"appendbiddernames,omitempty"`
 	AlwaysIncludeDeals        bool                       `json:"alwaysincludedeals,omitempty"`
 	Prefix                    string                     `json:"prefix,omitempty"`
+	// AdditionalCurrencies lists the currencies the winning bids prices are also returned in, along with
+	// their price targeting keys such as hb_pb_EUR
+	AdditionalCurrencies []string `json:"additionalcurrencies,omitempty"`
 }
 
 type ExtIncludeBrandCategory struct {
@@ -31,6 +31,32 @@ type ExtResponseDebug struct {
 	HttpCalls map[BidderName][]*ExtHttpCall `json:"httpcalls,omitempty"`
 	// Request after resolution of stored requests and debug overrides
 	ResolvedRequest json.RawMessage `json:"resolvedrequest,omitempty"`
+	// PrivacyAudit defines the contract for bidresponse.ext.debug.privacyaudit
+	PrivacyAudit ExtPrivacyAudit `json:"privacyaudit,omitempty"`
+}
+
+// ExtPrivacyAudit holds the privacy decisions recorded for a request, keyed by component type and component name
+type ExtPrivacyAudit map[string]map[string]*ExtPrivacyAuditComponent
+
+// ExtPrivacyAuditComponent holds the activity decisions and scrubbing recorded for a component
+type ExtPrivacyAuditComponent struct {
+	Activities []ExtPrivacyAuditActivity `json:"activities,omitempty"`
+	Scrubbed   []ExtPrivacyAuditScrub    `json:"scrubbed,omitempty"`
+}
+
+// ExtPrivacyAuditActivity is an activity decision and the rule or policy which made it. Dry run decisions
+// come from the dry run rule set of the account and were not enforced.
+type ExtPrivacyAuditActivity struct {
+	Activity string `json:"activity"`
+	Allowed  bool   `json:"allowed"`
+	Rule     string `json:"rule"`
+	DryRun   bool   `json:"dryrun,omitempty"`
+}
+
+// ExtPrivacyAuditScrub lists the fields removed or truncated and the policy which required it
+type ExtPrivacyAuditScrub struct {
+	Fields []string `json:"fields"`
+	Reason string   `json:"reason"`
 }
 
 // ExtResponseSyncData defines the contract for bidresponse.ext.usersync.{bidder}
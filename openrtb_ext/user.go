@@ -36,11 +36,12 @@ type ConsentedProvidersSettingsOut struct {
 }
 
 // ParseConsentedProvidersString takes a string formatted as Google's Additional Consent format and returns a list with its
-// elements. For instance, the following string "1~1.35.41.101" would result in []int{1, 35, 41, 101}
+// elements. For instance, the following string "1~1.35.41.101" would result in []int{1, 35, 41, 101}. The disclosed
+// providers section of the version 2 format, such as "2~1.35.41.101~dv.9.21", is ignored.
 func ParseConsentedProvidersString(cps string) []int {
 	// Additional Consent format version is separated from elements by the '~' character
 	parts := strings.Split(cps, "~")
-	if len(parts) != 2 {
+	if len(parts) != 2 && (len(parts) != 3 || parts[0] != "2") {
 		return nil
 	}
 
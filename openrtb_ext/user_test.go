@@ -0,0 +1,52 @@
+package openrtb_ext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConsentedProvidersString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []int
+	}{
+		{
+			name:     "v1",
+			input:    "1~1.35.41.101",
+			expected: []int{1, 35, 41, 101},
+		},
+		{
+			name:     "v2",
+			input:    "2~1.35.41.101~dv.9.21",
+			expected: []int{1, 35, 41, 101},
+		},
+		{
+			name:     "v2-no-disclosed",
+			input:    "2~1.35~dv.",
+			expected: []int{1, 35},
+		},
+		{
+			name:     "v1-with-extra-section",
+			input:    "1~1.35~dv.9",
+			expected: nil,
+		},
+		{
+			name:     "invalid-elements-skipped",
+			input:    "1~1.X.35",
+			expected: []int{1, 35},
+		},
+		{
+			name:     "malformed",
+			input:    "malformed",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseConsentedProvidersString(tt.input))
+		})
+	}
+}
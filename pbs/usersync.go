@@ -25,6 +25,7 @@ type UserSyncDeps struct {
 	HostCookieConfig *config.HostCookie
 	PriorityGroups   [][]string
 	CertPool         *x509.CertPool
+	IDStore          usersync.IDStore
 }
 
 // Struct for parsing json in google's response
@@ -69,8 +70,8 @@ func (deps *UserSyncDeps) VerifyRecaptcha(response string) error {
 func (deps *UserSyncDeps) OptOut(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	optout := r.FormValue("optout")
 	rr := r.FormValue("g-recaptcha-response")
-	encoder := usersync.Base64Encoder{}
-	decoder := usersync.Base64Decoder{}
+	encoder := usersync.NewEncoder(deps.HostCookieConfig)
+	decoder := usersync.CompactDecoder{}
 
 	if rr == "" {
 		http.Redirect(w, r, fmt.Sprintf("%s/static/optout.html", deps.ExternalUrl), http.StatusMovedPermanently)
@@ -89,6 +90,15 @@ func (deps *UserSyncDeps) OptOut(w http.ResponseWriter, r *http.Request, _ httpr
 	usersync.SyncHostCookie(r, pc, deps.HostCookieConfig)
 	pc.SetOptOut(optout != "")
 
+	// Remove all server side IDs of users who opt out
+	if optout != "" && deps.IDStore != nil {
+		if hostID := usersync.HostID(r, deps.HostCookieConfig); hostID != "" {
+			if err := deps.IDStore.DeleteAll(r.Context(), hostID); err != nil {
+				logger.Errorf("Opt Out failed to delete stored user ids: %v", err)
+			}
+		}
+	}
+
 	// Write Cookie
 	encodedCookie, err := encoder.Encode(pc)
 	if err != nil {
@@ -97,6 +107,11 @@ func (deps *UserSyncDeps) OptOut(w http.ResponseWriter, r *http.Request, _ httpr
 	}
 	usersync.WriteCookie(w, encodedCookie, deps.HostCookieConfig, false)
 
+	// Keep the partitioned copy in step, since an opt out in either copy applies to both
+	if _, partitioned := usersync.ReadCookieJars(r); partitioned || deps.HostCookieConfig.Partitioned.Enabled {
+		usersync.WritePartitionedCookie(w, encodedCookie, deps.HostCookieConfig)
+	}
+
 	if optout == "" {
 		http.Redirect(w, r, deps.HostCookieConfig.OptInURL, http.StatusMovedPermanently)
 	} else {
@@ -26,6 +26,13 @@ func NewRequestFromBidRequest(r openrtb_ext.RequestWrapper) ActivityRequest {
 type ActivityRequest struct {
 	policies   *Policies
 	bidRequest *openrtb_ext.RequestWrapper
+	eidSource  string
+}
+
+// WithEIDSource returns a copy of the request for the transmission of an eid from the source
+func (r ActivityRequest) WithEIDSource(source string) ActivityRequest {
+	r.eidSource = source
+	return r
 }
 
 func (r ActivityRequest) IsPolicies() bool {
@@ -37,27 +44,45 @@ func (r ActivityRequest) IsBidRequest() bool {
 }
 
 type ActivityControl struct {
-	plans      map[Activity]ActivityPlan
-	IPv6Config config.IPv6
-	IPv4Config config.IPv4
+	plans             map[Activity]ActivityPlan
+	dryRunPlans       map[Activity]ActivityPlan
+	audit             *AuditLog
+	precisionProfiles map[string]config.PrecisionProfile
+	precisionPolicies config.PrecisionPolicies
+	IPv6Config        config.IPv6
+	IPv4Config        config.IPv4
 }
 
 func NewActivityControl(cfg *config.AccountPrivacy) ActivityControl {
 	ac := ActivityControl{}
 
-	if cfg == nil || cfg.AllowActivities == nil {
+	if cfg == nil {
+		return ac
+	}
+
+	if cfg.Audit.Enabled || cfg.Audit.DryRunActivities != nil {
+		ac.audit = &AuditLog{}
+	}
+	if cfg.Audit.DryRunActivities != nil {
+		ac.dryRunPlans = buildPlans(cfg.Audit.DryRunActivities)
+	}
+
+	ac.precisionProfiles = cfg.PrecisionProfiles
+	ac.precisionPolicies = cfg.PrecisionPolicies
+
+	if cfg.AllowActivities == nil && !cfg.USNat.Enabled {
 		return ac
 	}
 
-	plans := make(map[Activity]ActivityPlan, 8)
-	plans[ActivitySyncUser] = buildPlan(cfg.AllowActivities.SyncUser)
-	plans[ActivityFetchBids] = buildPlan(cfg.AllowActivities.FetchBids)
-	plans[ActivityEnrichUserFPD] = buildPlan(cfg.AllowActivities.EnrichUserFPD)
-	plans[ActivityReportAnalytics] = buildPlan(cfg.AllowActivities.ReportAnalytics)
-	plans[ActivityTransmitUserFPD] = buildPlan(cfg.AllowActivities.TransmitUserFPD)
-	plans[ActivityTransmitPreciseGeo] = buildPlan(cfg.AllowActivities.TransmitPreciseGeo)
-	plans[ActivityTransmitUniqueRequestIDs] = buildPlan(cfg.AllowActivities.TransmitUniqueRequestIds)
-	plans[ActivityTransmitTIDs] = buildPlan(cfg.AllowActivities.TransmitTids)
+	allowActivities := cfg.AllowActivities
+	if allowActivities == nil {
+		allowActivities = &config.AllowActivities{}
+	}
+
+	plans := buildPlans(allowActivities)
+	if cfg.USNat.Enabled {
+		addUSNatRules(plans, cfg.USNat, cfg.PrecisionPolicies.USNat)
+	}
 	ac.plans = plans
 
 	ac.IPv4Config = cfg.IPv4Config
@@ -66,6 +91,20 @@ func NewActivityControl(cfg *config.AccountPrivacy) ActivityControl {
 	return ac
 }
 
+func buildPlans(allowActivities *config.AllowActivities) map[Activity]ActivityPlan {
+	plans := make(map[Activity]ActivityPlan, 9)
+	plans[ActivitySyncUser] = buildPlan(allowActivities.SyncUser)
+	plans[ActivityFetchBids] = buildPlan(allowActivities.FetchBids)
+	plans[ActivityEnrichUserFPD] = buildPlan(allowActivities.EnrichUserFPD)
+	plans[ActivityReportAnalytics] = buildPlan(allowActivities.ReportAnalytics)
+	plans[ActivityTransmitUserFPD] = buildPlan(allowActivities.TransmitUserFPD)
+	plans[ActivityTransmitPreciseGeo] = buildPlan(allowActivities.TransmitPreciseGeo)
+	plans[ActivityTransmitUniqueRequestIDs] = buildPlan(allowActivities.TransmitUniqueRequestIds)
+	plans[ActivityTransmitTIDs] = buildPlan(allowActivities.TransmitTids)
+	plans[ActivityTransmitEIDs] = buildPlan(allowActivities.TransmitEids)
+	return plans
+}
+
 func buildPlan(activity config.Activity) ActivityPlan {
 	return ActivityPlan{
 		rules:         cfgToRules(activity.Rules),
@@ -83,9 +122,11 @@ func cfgToRules(rules []config.ActivityRule) []Rule {
 		}
 
 		er := ConditionRule{
-			result:        result,
-			componentName: r.Condition.ComponentName,
-			componentType: r.Condition.ComponentType,
+			result:           result,
+			componentName:    r.Condition.ComponentName,
+			componentType:    r.Condition.ComponentType,
+			eidSource:        r.Condition.EIDSource,
+			precisionProfile: r.PrecisionProfile,
 		}
 		enfRules = append(enfRules, er)
 	}
@@ -100,13 +141,82 @@ func cfgToDefaultResult(activityDefault *bool) bool {
 }
 
 func (e ActivityControl) Allow(activity Activity, target Component, request ActivityRequest) bool {
-	plan, planDefined := e.plans[activity]
+	allowed, _ := e.allow(activity, target, request)
+	return allowed
+}
 
-	if !planDefined {
-		return defaultActivityResult
+// AllowPreciseGeo evaluates the transmitPreciseGeo activity like Allow. If the activity is denied, it also
+// returns the precision profile referenced by the rule which denied it, or nil for the default treatment.
+func (e ActivityControl) AllowPreciseGeo(target Component, request ActivityRequest) (bool, *config.PrecisionProfile) {
+	allowed, rule := e.allow(ActivityTransmitPreciseGeo, target, request)
+	if allowed {
+		return true, nil
+	}
+	return false, e.precisionProfile(rulePrecisionProfile(rule))
+}
+
+// PolicyPrecisionProfile returns the precision profile the account applies when the privacy policy, such
+// as GDPR, restricts precise geolocation, or nil for the default treatment.
+func (e ActivityControl) PolicyPrecisionProfile(policy string) *config.PrecisionProfile {
+	switch policy {
+	case AuditPolicyGDPR:
+		return e.precisionProfile(e.precisionPolicies.GDPR)
+	}
+	return nil
+}
+
+func (e ActivityControl) precisionProfile(name string) *config.PrecisionProfile {
+	if name == "" {
+		return nil
+	}
+	if profile, ok := e.precisionProfiles[name]; ok {
+		return &profile
+	}
+	return nil
+}
+
+// allow evaluates the activity, recording the decision in the privacy audit, and returns the rule which
+// decided it, or nil if the default result applied
+func (e ActivityControl) allow(activity Activity, target Component, request ActivityRequest) (bool, Rule) {
+	allowed, ruleName, rule := defaultActivityResult, auditRuleDefault, Rule(nil)
+	if plan, planDefined := e.plans[activity]; planDefined {
+		allowed, ruleName, rule = plan.evaluate(target, request)
+	}
+
+	if e.audit != nil {
+		e.audit.recordActivity(activity, target, allowed, ruleName, false)
+		if plan, planDefined := e.dryRunPlans[activity]; planDefined {
+			dryRunAllowed, dryRunRuleName, _ := plan.evaluate(target, request)
+			e.audit.recordActivity(activity, target, dryRunAllowed, dryRunRuleName, true)
+		}
+	}
+
+	return allowed, rule
+}
+
+// RecordPolicyDecision records a decision of a privacy policy other than the activity controls, such as
+// GDPR or CCPA, in the privacy audit. It does nothing unless the account enabled the audit.
+func (e ActivityControl) RecordPolicyDecision(activity Activity, target Component, allowed bool, policy string) {
+	if e.audit != nil {
+		e.audit.recordActivity(activity, target, allowed, policy, false)
+	}
+}
+
+// RecordScrub records the fields scrubbed from the request of a component and the policy which required
+// it in the privacy audit. It does nothing unless the account enabled the audit or if no field was scrubbed.
+func (e ActivityControl) RecordScrub(target Component, reason string, fields []string) {
+	if e.audit != nil {
+		e.audit.recordScrub(target, reason, fields)
 	}
+}
 
-	return plan.Evaluate(target, request)
+// AuditReport returns the decisions recorded by the privacy audit so far, or nil unless the account
+// enabled the audit.
+func (e ActivityControl) AuditReport() openrtb_ext.ExtPrivacyAudit {
+	if e.audit == nil {
+		return nil
+	}
+	return e.audit.report()
 }
 
 type ActivityPlan struct {
@@ -115,11 +225,18 @@ type ActivityPlan struct {
 }
 
 func (p ActivityPlan) Evaluate(target Component, request ActivityRequest) bool {
-	for _, rule := range p.rules {
+	allowed, _, _ := p.evaluate(target, request)
+	return allowed
+}
+
+// evaluate returns the result of the plan, the name of the rule which decided it for the privacy audit and
+// the rule itself, which is nil if the default result applied
+func (p ActivityPlan) evaluate(target Component, request ActivityRequest) (bool, string, Rule) {
+	for i, rule := range p.rules {
 		result := rule.Evaluate(target, request)
 		if result == ActivityDeny || result == ActivityAllow {
-			return result == ActivityAllow
+			return result == ActivityAllow, auditRuleName(i, rule), rule
 		}
 	}
-	return p.defaultResult
+	return p.defaultResult, auditRuleDefault, nil
 }
@@ -32,6 +32,7 @@ func TestNewActivityControl(t *testing.T) {
 					TransmitPreciseGeo:       getTestActivityConfig(false),
 					TransmitUniqueRequestIds: getTestActivityConfig(true),
 					TransmitTids:             getTestActivityConfig(true),
+					TransmitEids:             getTestActivityConfig(false),
 				},
 				IPv6Config: config.IPv6{AnonKeepBits: 32},
 				IPv4Config: config.IPv4{AnonKeepBits: 16},
@@ -46,6 +47,7 @@ func TestNewActivityControl(t *testing.T) {
 					ActivityTransmitPreciseGeo:       getTestActivityPlan(ActivityDeny),
 					ActivityTransmitUniqueRequestIDs: getTestActivityPlan(ActivityAllow),
 					ActivityTransmitTIDs:             getTestActivityPlan(ActivityAllow),
+					ActivityTransmitEIDs:             getTestActivityPlan(ActivityDeny),
 				},
 				IPv6Config: config.IPv6{AnonKeepBits: 32},
 				IPv4Config: config.IPv4{AnonKeepBits: 16},
@@ -142,6 +144,68 @@ func TestActivityControlAllow(t *testing.T) {
 	}
 }
 
+func TestActivityControlAllowPreciseGeo(t *testing.T) {
+	coarse := config.PrecisionProfile{LatLonDecimals: ptrutil.ToPtr(1)}
+	none := config.PrecisionProfile{DropGeo: true}
+	privacyConf := config.AccountPrivacy{
+		AllowActivities: &config.AllowActivities{
+			TransmitPreciseGeo: config.Activity{
+				Rules: []config.ActivityRule{
+					{Allow: false, Condition: config.ActivityCondition{ComponentName: []string{"bidderA"}}, PrecisionProfile: "coarse"},
+					{Allow: false, Condition: config.ActivityCondition{ComponentName: []string{"bidderB"}}},
+					{Allow: false, Condition: config.ActivityCondition{ComponentName: []string{"bidderC"}}, PrecisionProfile: "undefined"},
+				},
+			},
+		},
+		PrecisionProfiles: map[string]config.PrecisionProfile{"coarse": coarse, "none": none},
+		PrecisionPolicies: config.PrecisionPolicies{GDPR: "none"},
+	}
+	activityControl := NewActivityControl(&privacyConf)
+
+	testCases := []struct {
+		name            string
+		bidder          string
+		expectedAllowed bool
+		expectedProfile *config.PrecisionProfile
+	}{
+		{
+			name:            "denied_with_profile",
+			bidder:          "bidderA",
+			expectedAllowed: false,
+			expectedProfile: &coarse,
+		},
+		{
+			name:            "denied_without_profile",
+			bidder:          "bidderB",
+			expectedAllowed: false,
+			expectedProfile: nil,
+		},
+		{
+			name:            "denied_with_undefined_profile",
+			bidder:          "bidderC",
+			expectedAllowed: false,
+			expectedProfile: nil,
+		},
+		{
+			name:            "allowed_by_default",
+			bidder:          "bidderD",
+			expectedAllowed: true,
+			expectedProfile: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			allowed, profile := activityControl.AllowPreciseGeo(Component{Type: ComponentTypeBidder, Name: test.bidder}, ActivityRequest{})
+			assert.Equal(t, test.expectedAllowed, allowed)
+			assert.Equal(t, test.expectedProfile, profile)
+		})
+	}
+
+	assert.Equal(t, &none, activityControl.PolicyPrecisionProfile(AuditPolicyGDPR), "gdpr")
+	assert.Nil(t, activityControl.PolicyPrecisionProfile(AuditPolicyCCPA), "ccpa")
+}
+
 func TestActivityRequest(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		r := ActivityRequest{}
@@ -0,0 +1,108 @@
+package privacy
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+)
+
+// Policies other than the activity controls reported by the privacy audit
+const (
+	AuditPolicyGDPR  = "gdpr"
+	AuditPolicyCCPA  = "ccpa"
+	AuditPolicyLMT   = "lmt"
+	AuditPolicyCOPPA = "coppa"
+)
+
+// auditRuleDefault names the default result of an activity in the privacy audit
+const auditRuleDefault = "default"
+
+// AuditLog records the activity decisions and the scrubbing applied to each component of a request. It's
+// shared by all copies of the activity control of the request and is safe for concurrent use.
+type AuditLog struct {
+	lock       sync.Mutex
+	components openrtb_ext.ExtPrivacyAudit
+}
+
+func (l *AuditLog) component(target Component) *openrtb_ext.ExtPrivacyAuditComponent {
+	if l.components == nil {
+		l.components = make(openrtb_ext.ExtPrivacyAudit)
+	}
+	byName, ok := l.components[target.Type]
+	if !ok {
+		byName = make(map[string]*openrtb_ext.ExtPrivacyAuditComponent)
+		l.components[target.Type] = byName
+	}
+	component, ok := byName[target.Name]
+	if !ok {
+		component = &openrtb_ext.ExtPrivacyAuditComponent{}
+		byName[target.Name] = component
+	}
+	return component
+}
+
+func (l *AuditLog) recordActivity(activity Activity, target Component, allowed bool, rule string, dryRun bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	component := l.component(target)
+	component.Activities = append(component.Activities, openrtb_ext.ExtPrivacyAuditActivity{
+		Activity: activity.String(),
+		Allowed:  allowed,
+		Rule:     rule,
+		DryRun:   dryRun,
+	})
+}
+
+func (l *AuditLog) recordScrub(target Component, reason string, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	component := l.component(target)
+	component.Scrubbed = append(component.Scrubbed, openrtb_ext.ExtPrivacyAuditScrub{
+		Fields: fields,
+		Reason: reason,
+	})
+}
+
+// report returns a copy of the decisions recorded so far
+func (l *AuditLog) report() openrtb_ext.ExtPrivacyAudit {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	report := make(openrtb_ext.ExtPrivacyAudit, len(l.components))
+	for componentType, byName := range l.components {
+		reportByName := make(map[string]*openrtb_ext.ExtPrivacyAuditComponent, len(byName))
+		for name, component := range byName {
+			reportByName[name] = &openrtb_ext.ExtPrivacyAuditComponent{
+				Activities: append([]openrtb_ext.ExtPrivacyAuditActivity(nil), component.Activities...),
+				Scrubbed:   append([]openrtb_ext.ExtPrivacyAuditScrub(nil), component.Scrubbed...),
+			}
+		}
+		report[componentType] = reportByName
+	}
+	return report
+}
+
+// ScrubEIDFields names the eids of the sources removed by the transmitEids activity for the privacy audit
+func ScrubEIDFields(sources []string) []string {
+	fields := make([]string, 0, len(sources))
+	for _, source := range sources {
+		fields = append(fields, fmt.Sprintf("user.eids[%s]", source))
+	}
+	return fields
+}
+
+// auditRuleName names a rule of an activity plan in the privacy audit. Account rules are named after their
+// position in the account config, which the US National rule always follows.
+func auditRuleName(index int, rule Rule) string {
+	if _, ok := rule.(USNatRule); ok {
+		return "usnat"
+	}
+	return fmt.Sprintf("rules[%d]", index)
+}
@@ -0,0 +1,135 @@
+package privacy
+
+import (
+	"testing"
+
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditReport(t *testing.T) {
+	bidderA := Component{Type: ComponentTypeBidder, Name: "bidderA"}
+	bidderB := Component{Type: ComponentTypeBidder, Name: "bidderB"}
+
+	testCases := []struct {
+		name           string
+		privacyConf    config.AccountPrivacy
+		expectedReport openrtb_ext.ExtPrivacyAudit
+	}{
+		{
+			name:           "audit_disabled",
+			privacyConf:    config.AccountPrivacy{AllowActivities: &config.AllowActivities{SyncUser: getTestActivityConfig(false)}},
+			expectedReport: nil,
+		},
+		{
+			name: "audit_enabled",
+			privacyConf: config.AccountPrivacy{
+				AllowActivities: &config.AllowActivities{SyncUser: getTestActivityConfig(false)},
+				Audit:           config.PrivacyAudit{Enabled: true},
+			},
+			expectedReport: openrtb_ext.ExtPrivacyAudit{
+				ComponentTypeBidder: {
+					"bidderA": {
+						Activities: []openrtb_ext.ExtPrivacyAuditActivity{
+							{Activity: "syncUser", Allowed: false, Rule: "rules[0]"},
+						},
+						Scrubbed: []openrtb_ext.ExtPrivacyAuditScrub{
+							{Fields: []string{"source.tid"}, Reason: "transmitTid"},
+						},
+					},
+					"bidderB": {
+						Activities: []openrtb_ext.ExtPrivacyAuditActivity{
+							{Activity: "syncUser", Allowed: true, Rule: "default"},
+							{Activity: "fetchBids", Allowed: false, Rule: "gdpr"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "audit_enabled_without_activities",
+			privacyConf: config.AccountPrivacy{
+				Audit: config.PrivacyAudit{Enabled: true},
+			},
+			expectedReport: openrtb_ext.ExtPrivacyAudit{
+				ComponentTypeBidder: {
+					"bidderA": {
+						Activities: []openrtb_ext.ExtPrivacyAuditActivity{
+							{Activity: "syncUser", Allowed: true, Rule: "default"},
+						},
+						Scrubbed: []openrtb_ext.ExtPrivacyAuditScrub{
+							{Fields: []string{"source.tid"}, Reason: "transmitTid"},
+						},
+					},
+					"bidderB": {
+						Activities: []openrtb_ext.ExtPrivacyAuditActivity{
+							{Activity: "syncUser", Allowed: true, Rule: "default"},
+							{Activity: "fetchBids", Allowed: false, Rule: "gdpr"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "dry_run",
+			privacyConf: config.AccountPrivacy{
+				Audit: config.PrivacyAudit{
+					DryRunActivities: &config.AllowActivities{SyncUser: getTestActivityConfig(false)},
+				},
+			},
+			expectedReport: openrtb_ext.ExtPrivacyAudit{
+				ComponentTypeBidder: {
+					"bidderA": {
+						Activities: []openrtb_ext.ExtPrivacyAuditActivity{
+							{Activity: "syncUser", Allowed: true, Rule: "default"},
+							{Activity: "syncUser", Allowed: false, Rule: "rules[0]", DryRun: true},
+						},
+						Scrubbed: []openrtb_ext.ExtPrivacyAuditScrub{
+							{Fields: []string{"source.tid"}, Reason: "transmitTid"},
+						},
+					},
+					"bidderB": {
+						Activities: []openrtb_ext.ExtPrivacyAuditActivity{
+							{Activity: "syncUser", Allowed: true, Rule: "default"},
+							{Activity: "syncUser", Allowed: true, Rule: "default", DryRun: true},
+							{Activity: "fetchBids", Allowed: false, Rule: "gdpr"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			activityControl := NewActivityControl(&test.privacyConf)
+			request := NewRequestFromPolicies(Policies{})
+
+			activityControl.Allow(ActivitySyncUser, bidderA, request)
+			activityControl.RecordScrub(bidderA, ActivityTransmitTIDs.String(), []string{"source.tid"})
+			activityControl.Allow(ActivitySyncUser, bidderB, request)
+			activityControl.RecordPolicyDecision(ActivityFetchBids, bidderB, false, AuditPolicyGDPR)
+
+			assert.Equal(t, test.expectedReport, activityControl.AuditReport())
+		})
+	}
+}
+
+func TestAuditDryRunNotEnforced(t *testing.T) {
+	bidderA := Component{Type: ComponentTypeBidder, Name: "bidderA"}
+
+	activityControl := NewActivityControl(&config.AccountPrivacy{
+		AllowActivities: &config.AllowActivities{SyncUser: getTestActivityConfig(true)},
+		Audit: config.PrivacyAudit{
+			DryRunActivities: &config.AllowActivities{SyncUser: getTestActivityConfig(false)},
+		},
+	})
+
+	assert.True(t, activityControl.Allow(ActivitySyncUser, bidderA, NewRequestFromPolicies(Policies{})))
+}
+
+func TestAuditRuleName(t *testing.T) {
+	assert.Equal(t, "rules[2]", auditRuleName(2, ConditionRule{}))
+	assert.Equal(t, "usnat", auditRuleName(2, USNatRule{}))
+}
@@ -0,0 +1,40 @@
+package gpp
+
+import (
+	gpplib "github.com/prebid/go-gpp"
+	gppConstants "github.com/prebid/go-gpp/constants"
+)
+
+// legacySection is a GPP section holding a legacy privacy string, which is encoded as is
+type legacySection struct {
+	sectionID gppConstants.SectionID
+	value     string
+}
+
+func (s legacySection) GetID() gppConstants.SectionID {
+	return s.sectionID
+}
+
+func (s legacySection) GetValue() string {
+	return s.value
+}
+
+func (s legacySection) Encode(bool) []byte {
+	return []byte(s.value)
+}
+
+// EncodeLegacy returns a GPP string holding the TCF EU v2 consent string and the US Privacy string, omitting
+// those which are empty, or an empty string if both are.
+func EncodeLegacy(tcfConsent, usPrivacy string) (string, error) {
+	var sections []gpplib.Section
+	if tcfConsent != "" {
+		sections = append(sections, legacySection{sectionID: gppConstants.SectionTCFEU2, value: tcfConsent})
+	}
+	if usPrivacy != "" {
+		sections = append(sections, legacySection{sectionID: gppConstants.SectionUSPV1, value: usPrivacy})
+	}
+	if len(sections) == 0 {
+		return "", nil
+	}
+	return gpplib.Encode(sections)
+}
@@ -0,0 +1,59 @@
+package gpp
+
+import (
+	"testing"
+
+	gpplib "github.com/prebid/go-gpp"
+	gppConstants "github.com/prebid/go-gpp/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeLegacy(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		tcfConsent       string
+		usPrivacy        string
+		expectedSections []gppConstants.SectionID
+		expectedValues   []string
+	}{
+		{
+			desc: "none",
+		},
+		{
+			desc:             "tcf_only",
+			tcfConsent:       "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
+			expectedSections: []gppConstants.SectionID{gppConstants.SectionTCFEU2},
+			expectedValues:   []string{"CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"},
+		},
+		{
+			desc:             "usp_only",
+			usPrivacy:        "1YNN",
+			expectedSections: []gppConstants.SectionID{gppConstants.SectionUSPV1},
+			expectedValues:   []string{"1YNN"},
+		},
+		{
+			desc:             "tcf_and_usp",
+			tcfConsent:       "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
+			usPrivacy:        "1YNN",
+			expectedSections: []gppConstants.SectionID{gppConstants.SectionTCFEU2, gppConstants.SectionUSPV1},
+			expectedValues:   []string{"CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA", "1YNN"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			encoded, err := EncodeLegacy(tc.tcfConsent, tc.usPrivacy)
+			assert.NoError(t, err)
+			if tc.expectedSections == nil {
+				assert.Empty(t, encoded)
+				return
+			}
+
+			parsed, errs := gpplib.Parse(encoded)
+			assert.Empty(t, errs)
+			assert.Equal(t, tc.expectedSections, parsed.SectionTypes)
+			for i, section := range parsed.Sections {
+				assert.Equal(t, tc.expectedValues[i], section.GetValue())
+			}
+		})
+	}
+}
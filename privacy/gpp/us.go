@@ -0,0 +1,170 @@
+package gpp
+
+import (
+	gpplib "github.com/prebid/go-gpp"
+	gppConstants "github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/sections/uspca"
+	"github.com/prebid/go-gpp/sections/uspco"
+	"github.com/prebid/go-gpp/sections/uspct"
+	"github.com/prebid/go-gpp/sections/uspnat"
+	"github.com/prebid/go-gpp/sections/usput"
+	"github.com/prebid/go-gpp/sections/uspva"
+)
+
+// Values of the notice, opt out, consent and mode fields of the US sections. Zero always means not applicable.
+const (
+	USOptedOut      byte = 1
+	USNoConsent     byte = 1
+	USNoticeMissing byte = 2
+	USModeYes       byte = 1
+)
+
+// preciseGeoIndexBySID is the position of the precise geolocation category in the sensitive data
+// processing field of each US section. Colorado does not consider precise geolocation to be sensitive.
+var preciseGeoIndexBySID = map[gppConstants.SectionID]int{
+	gppConstants.SectionUSPNAT: 7,
+	gppConstants.SectionUSPCA:  2,
+	gppConstants.SectionUSPVA:  7,
+	gppConstants.SectionUSPUT:  7,
+	gppConstants.SectionUSPCT:  7,
+}
+
+// USSection holds the fields of a GPP US National or US state section relevant to enforcement,
+// normalized across the sections. Fields a section doesn't define are left as not applicable.
+type USSection struct {
+	SectionID                       gppConstants.SectionID
+	SharingNotice                   byte
+	SaleOptOutNotice                byte
+	SharingOptOutNotice             byte
+	TargetedAdvertisingOptOutNotice byte
+	SaleOptOut                      byte
+	SharingOptOut                   byte
+	TargetedAdvertisingOptOut       byte
+	SensitiveDataProcessing         []byte
+	KnownChildSensitiveDataConsents []byte
+	PersonalDataConsents            byte
+	MspaServiceProviderMode         byte
+	Gpc                             bool
+}
+
+// MissingNotice returns true if the user was not given notice of the sale, sharing or use of their
+// data for targeted advertising.
+func (s USSection) MissingNotice() bool {
+	for _, notice := range []byte{s.SharingNotice, s.SaleOptOutNotice, s.SharingOptOutNotice, s.TargetedAdvertisingOptOutNotice} {
+		if notice == USNoticeMissing {
+			return true
+		}
+	}
+	return false
+}
+
+// SensitiveDataOptOut returns true if the user opted out of the processing of any sensitive data category.
+func (s USSection) SensitiveDataOptOut() bool {
+	for _, v := range s.SensitiveDataProcessing {
+		if v == USOptedOut {
+			return true
+		}
+	}
+	return false
+}
+
+// PreciseGeoOptOut returns true if the user opted out of the processing of their precise geolocation.
+func (s USSection) PreciseGeoOptOut() bool {
+	i, ok := preciseGeoIndexBySID[s.SectionID]
+	return ok && i < len(s.SensitiveDataProcessing) && s.SensitiveDataProcessing[i] == USOptedOut
+}
+
+// KnownChildNoConsent returns true if the user is a known child whose parent did not consent to the
+// processing of their data.
+func (s USSection) KnownChildNoConsent() bool {
+	for _, v := range s.KnownChildSensitiveDataConsents {
+		if v == USNoConsent {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadUSSections returns the US National and US state sections of the GPP string which apply to the
+// request according to the GPP SID list.
+func ReadUSSections(gpp gpplib.GppContainer, gppSIDs []int8) []USSection {
+	var result []USSection
+	for _, section := range gpp.Sections {
+		if !IsSIDInList(gppSIDs, section.GetID()) {
+			continue
+		}
+		if s, ok := newUSSection(section); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func newUSSection(section gpplib.Section) (USSection, bool) {
+	switch s := section.(type) {
+	case uspnat.USPNAT:
+		return USSection{
+			SectionID:                       s.SectionID,
+			SharingNotice:                   s.CoreSegment.SharingNotice,
+			SaleOptOutNotice:                s.CoreSegment.SaleOptOutNotice,
+			SharingOptOutNotice:             s.CoreSegment.SharingOptOutNotice,
+			TargetedAdvertisingOptOutNotice: s.CoreSegment.TargetedAdvertisingOptOutNotice,
+			SaleOptOut:                      s.CoreSegment.SaleOptOut,
+			SharingOptOut:                   s.CoreSegment.SharingOptOut,
+			TargetedAdvertisingOptOut:       s.CoreSegment.TargetedAdvertisingOptOut,
+			SensitiveDataProcessing:         s.CoreSegment.SensitiveDataProcessing,
+			KnownChildSensitiveDataConsents: s.CoreSegment.KnownChildSensitiveDataConsents,
+			PersonalDataConsents:            s.CoreSegment.PersonalDataConsents,
+			MspaServiceProviderMode:         s.CoreSegment.MspaServiceProviderMode,
+			Gpc:                             s.GPCSegment.Gpc,
+		}, true
+	case uspca.USPCA:
+		return USSection{
+			SectionID:                       s.SectionID,
+			SaleOptOutNotice:                s.CoreSegment.SaleOptOutNotice,
+			SharingOptOutNotice:             s.CoreSegment.SharingOptOutNotice,
+			SaleOptOut:                      s.CoreSegment.SaleOptOut,
+			SharingOptOut:                   s.CoreSegment.SharingOptOut,
+			SensitiveDataProcessing:         s.CoreSegment.SensitiveDataProcessing,
+			KnownChildSensitiveDataConsents: s.CoreSegment.KnownChildSensitiveDataConsents,
+			PersonalDataConsents:            s.CoreSegment.PersonalDataConsents,
+			MspaServiceProviderMode:         s.CoreSegment.MspaServiceProviderMode,
+			Gpc:                             s.GPCSegment.Gpc,
+		}, true
+	case uspva.USPVA:
+		return newCommonUSSection(s.SectionID, s.CoreSegment, sections.CommonUSGPCSegment{}), true
+	case uspco.USPCO:
+		return newCommonUSSection(s.SectionID, s.CoreSegment, s.GPCSegment), true
+	case uspct.USPCT:
+		return newCommonUSSection(s.SectionID, s.CoreSegment, s.GPCSegment), true
+	case usput.USPUT:
+		return USSection{
+			SectionID:                       s.SectionID,
+			SharingNotice:                   s.CoreSegment.SharingNotice,
+			SaleOptOutNotice:                s.CoreSegment.SaleOptOutNotice,
+			TargetedAdvertisingOptOutNotice: s.CoreSegment.TargetedAdvertisingOptOutNotice,
+			SaleOptOut:                      s.CoreSegment.SaleOptOut,
+			TargetedAdvertisingOptOut:       s.CoreSegment.TargetedAdvertisingOptOut,
+			SensitiveDataProcessing:         s.CoreSegment.SensitiveDataProcessing,
+			KnownChildSensitiveDataConsents: []byte{s.CoreSegment.KnownChildSensitiveDataConsents},
+			MspaServiceProviderMode:         s.CoreSegment.MspaServiceProviderMode,
+		}, true
+	}
+	return USSection{}, false
+}
+
+func newCommonUSSection(sid gppConstants.SectionID, core sections.CommonUSCoreSegment, gpc sections.CommonUSGPCSegment) USSection {
+	return USSection{
+		SectionID:                       sid,
+		SharingNotice:                   core.SharingNotice,
+		SaleOptOutNotice:                core.SaleOptOutNotice,
+		TargetedAdvertisingOptOutNotice: core.TargetedAdvertisingOptOutNotice,
+		SaleOptOut:                      core.SaleOptOut,
+		TargetedAdvertisingOptOut:       core.TargetedAdvertisingOptOut,
+		SensitiveDataProcessing:         core.SensitiveDataProcessing,
+		KnownChildSensitiveDataConsents: core.KnownChildSensitiveDataConsents,
+		MspaServiceProviderMode:         core.MspaServiceProviderMode,
+		Gpc:                             gpc.Gpc,
+	}
+}
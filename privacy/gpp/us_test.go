@@ -0,0 +1,151 @@
+package gpp
+
+import (
+	"testing"
+
+	gpplib "github.com/prebid/go-gpp"
+	gppConstants "github.com/prebid/go-gpp/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadUSSections(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		gpp          string
+		gppSIDs      []int8
+		expectedSIDs []gppConstants.SectionID
+	}{
+		{
+			desc:         "usnat_applicable",
+			gpp:          "DBABLA~BVVqqqqqAWA.QA",
+			gppSIDs:      []int8{7},
+			expectedSIDs: []gppConstants.SectionID{gppConstants.SectionUSPNAT},
+		},
+		{
+			desc:         "usnat_not_applicable",
+			gpp:          "DBABLA~BVVqqqqqAWA.QA",
+			gppSIDs:      []int8{8},
+			expectedSIDs: nil,
+		},
+		{
+			desc:         "usnat_and_usca_applicable",
+			gpp:          "DBABrw~BVVqqqqqAWA.QA~BVmqqoBY.QA",
+			gppSIDs:      []int8{7, 8},
+			expectedSIDs: []gppConstants.SectionID{gppConstants.SectionUSPNAT, gppConstants.SectionUSPCA},
+		},
+		{
+			desc:         "tcf_ignored",
+			gpp:          "DBABMA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
+			gppSIDs:      []int8{2},
+			expectedSIDs: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			container, errs := gpplib.Parse(tc.gpp)
+			require.Empty(t, errs)
+
+			var actualSIDs []gppConstants.SectionID
+			for _, section := range ReadUSSections(container, tc.gppSIDs) {
+				actualSIDs = append(actualSIDs, section.SectionID)
+			}
+			assert.Equal(t, tc.expectedSIDs, actualSIDs)
+		})
+	}
+}
+
+func TestUSSectionSignals(t *testing.T) {
+	testCases := []struct {
+		desc                        string
+		gpp                         string
+		expectedSaleOptOut          byte
+		expectedSharingOptOut       byte
+		expectedMissingNotice       bool
+		expectedSensitiveDataOptOut bool
+		expectedPreciseGeoOptOut    bool
+		expectedKnownChildNoConsent bool
+		expectedGpc                 bool
+	}{
+		{
+			desc:                  "usnat_no_opt_outs",
+			gpp:                   "DBABLA~BVVqqqqqAWA.QA",
+			expectedSaleOptOut:    2,
+			expectedSharingOptOut: 2,
+		},
+		{
+			desc:                  "usnat_sale_opt_out",
+			gpp:                   "DBABLA~BVVaqqqqAWA.QA",
+			expectedSaleOptOut:    1,
+			expectedSharingOptOut: 2,
+		},
+		{
+			desc:                  "usnat_missing_notice",
+			gpp:                   "DBABLA~BZVqqqqqAWA.QA",
+			expectedSaleOptOut:    2,
+			expectedSharingOptOut: 2,
+			expectedMissingNotice: true,
+		},
+		{
+			desc:                        "usnat_sensitive_data_opt_out",
+			gpp:                         "DBABLA~BVVqaqqqAWA.QA",
+			expectedSaleOptOut:          2,
+			expectedSharingOptOut:       2,
+			expectedSensitiveDataOptOut: true,
+		},
+		{
+			desc:                        "usnat_precise_geo_opt_out",
+			gpp:                         "DBABLA~BVVqqqmqAWA.QA",
+			expectedSaleOptOut:          2,
+			expectedSharingOptOut:       2,
+			expectedSensitiveDataOptOut: true,
+			expectedPreciseGeoOptOut:    true,
+		},
+		{
+			desc:                        "usnat_known_child",
+			gpp:                         "DBABLA~BVVqqqqqQWA.QA",
+			expectedSaleOptOut:          2,
+			expectedSharingOptOut:       2,
+			expectedKnownChildNoConsent: true,
+		},
+		{
+			desc:                  "usnat_gpc",
+			gpp:                   "DBABLA~BVVqqqqqAWA.YA",
+			expectedSaleOptOut:    2,
+			expectedSharingOptOut: 2,
+			expectedGpc:           true,
+		},
+		{
+			desc:                  "usca_sharing_opt_out",
+			gpp:                   "DBABBg~BVmqqoBY.QA",
+			expectedSaleOptOut:    2,
+			expectedSharingOptOut: 1,
+		},
+		{
+			desc:                        "usva_precise_geo_opt_out",
+			gpp:                         "DBABRg~BVqqqRY",
+			expectedSaleOptOut:          2,
+			expectedSharingOptOut:       0,
+			expectedSensitiveDataOptOut: true,
+			expectedPreciseGeoOptOut:    true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			container, errs := gpplib.Parse(tc.gpp)
+			require.Empty(t, errs)
+
+			sections := ReadUSSections(container, []int8{int8(container.SectionTypes[0])})
+			require.Len(t, sections, 1)
+
+			section := sections[0]
+			assert.Equal(t, tc.expectedSaleOptOut, section.SaleOptOut, "sale-opt-out")
+			assert.Equal(t, tc.expectedSharingOptOut, section.SharingOptOut, "sharing-opt-out")
+			assert.Equal(t, tc.expectedMissingNotice, section.MissingNotice(), "missing-notice")
+			assert.Equal(t, tc.expectedSensitiveDataOptOut, section.SensitiveDataOptOut(), "sensitive-data-opt-out")
+			assert.Equal(t, tc.expectedPreciseGeoOptOut, section.PreciseGeoOptOut(), "precise-geo-opt-out")
+			assert.Equal(t, tc.expectedKnownChildNoConsent, section.KnownChildNoConsent(), "known-child-no-consent")
+			assert.Equal(t, tc.expectedGpc, section.Gpc, "gpc")
+		})
+	}
+}
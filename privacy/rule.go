@@ -3,3 +3,15 @@ package privacy
 type Rule interface {
 	Evaluate(target Component, request ActivityRequest) ActivityResult
 }
+
+// rulePrecisionProfile returns the name of the precision profile applied when the rule denies
+// transmitPreciseGeo, or an empty name for the default treatment
+func rulePrecisionProfile(rule Rule) string {
+	switch r := rule.(type) {
+	case ConditionRule:
+		return r.precisionProfile
+	case USNatRule:
+		return r.precisionProfile
+	}
+	return ""
+}
@@ -1,5 +1,7 @@
 package privacy
 
+import "strings"
+
 // noClausesDefinedResult represents the default return when there is no matching criteria specified.
 const noClausesDefinedResult = true
 
@@ -8,6 +10,9 @@ type ConditionRule struct {
 	componentName []string
 	componentType []string
 	gppSID        []int8
+	eidSource     []string
+	// precisionProfile names the precision profile applied when the rule denies transmitPreciseGeo
+	precisionProfile string
 }
 
 func (r ConditionRule) Evaluate(target Component, request ActivityRequest) ActivityResult {
@@ -23,6 +28,10 @@ func (r ConditionRule) Evaluate(target Component, request ActivityRequest) Activ
 		return ActivityAbstain
 	}
 
+	if matched := evaluateEIDSource(r.eidSource, request); !matched {
+		return ActivityAbstain
+	}
+
 	return r.result
 }
 
@@ -71,6 +80,21 @@ func evaluateGPPSID(sid []int8, request ActivityRequest) bool {
 	return false
 }
 
+// evaluateEIDSource matches the source of the eid being transmitted. A request for anything other than the
+// transmission of an eid has no source, so never matches the clauses.
+func evaluateEIDSource(sources []string, request ActivityRequest) bool {
+	if len(sources) == 0 {
+		return noClausesDefinedResult
+	}
+
+	for _, source := range sources {
+		if request.eidSource != "" && strings.EqualFold(source, request.eidSource) {
+			return true
+		}
+	}
+	return false
+}
+
 func getGPPSID(request ActivityRequest) []int8 {
 	if request.IsPolicies() {
 		return request.policies.GPPSID
@@ -80,6 +80,15 @@ func TestComponentEnforcementRuleEvaluate(t *testing.T) {
 			target:         Component{Type: "bidder", Name: "bidderA"},
 			activityResult: ActivityAllow,
 		},
+		{
+			name: "abstain_eid_source_without_eid",
+			componentRule: ConditionRule{
+				result:    ActivityDeny,
+				eidSource: []string{"uidapi.com"},
+			},
+			target:         Component{Type: "bidder", Name: "bidderA"},
+			activityResult: ActivityAbstain,
+		},
 		{
 			name: "no-conditions-deny",
 			componentRule: ConditionRule{
@@ -98,6 +107,59 @@ func TestComponentEnforcementRuleEvaluate(t *testing.T) {
 	}
 }
 
+func TestEvaluateEIDSource(t *testing.T) {
+	testCases := []struct {
+		name            string
+		sourceCondition []string
+		sourceRequest   string
+		expected        bool
+	}{
+		{
+			name:            "condition-nil-request-none",
+			sourceCondition: nil,
+			sourceRequest:   "",
+			expected:        true,
+		},
+		{
+			name:            "condition-nil-request-source",
+			sourceCondition: nil,
+			sourceRequest:   "uidapi.com",
+			expected:        true,
+		},
+		{
+			name:            "condition-source-request-none",
+			sourceCondition: []string{"uidapi.com"},
+			sourceRequest:   "",
+			expected:        false,
+		},
+		{
+			name:            "condition-source-request-match",
+			sourceCondition: []string{"id5-sync.com", "uidapi.com"},
+			sourceRequest:   "uidapi.com",
+			expected:        true,
+		},
+		{
+			name:            "condition-source-request-match-case-insensitive",
+			sourceCondition: []string{"UIDAPI.com"},
+			sourceRequest:   "uidapi.com",
+			expected:        true,
+		},
+		{
+			name:            "condition-source-request-no-match",
+			sourceCondition: []string{"id5-sync.com"},
+			sourceRequest:   "uidapi.com",
+			expected:        false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			request := ActivityRequest{}.WithEIDSource(test.sourceRequest)
+			assert.Equal(t, test.expected, evaluateEIDSource(test.sourceCondition, request))
+		})
+	}
+}
+
 func TestEvaluateGPPSID(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -1,7 +1,9 @@
 package privacy
 
 import (
+	"bytes"
 	"encoding/json"
+	"math"
 	"net"
 
 	"github.com/prebid/prebid-server/v4/util/jsonutil"
@@ -17,8 +19,21 @@ type IPConf struct {
 	IPV4 config.IPv4
 }
 
-func scrubDeviceIDs(reqWrapper *openrtb_ext.RequestWrapper) {
+// scrubbedFields names the fields changed by the scrubbers, as reported by the privacy audit
+type scrubbedFields []string
+
+func (f *scrubbedFields) add(field string, changed bool) {
+	if changed {
+		*f = append(*f, field)
+	}
+}
+
+// scrubDeviceIDs removes the hashed device and platform ids, the mac address hashes and the ifa, all reported as device.ids
+func scrubDeviceIDs(reqWrapper *openrtb_ext.RequestWrapper, fields *scrubbedFields) {
 	if reqWrapper.Device != nil {
+		device := reqWrapper.Device
+		fields.add("device.ids", device.DIDMD5 != "" || device.DIDSHA1 != "" || device.DPIDMD5 != "" || device.DPIDSHA1 != "" ||
+			device.IFA != "" || device.MACMD5 != "" || device.MACSHA1 != "")
 		reqWrapper.Device.DIDMD5 = ""
 		reqWrapper.Device.DIDSHA1 = ""
 		reqWrapper.Device.DPIDMD5 = ""
@@ -29,8 +44,16 @@ func scrubDeviceIDs(reqWrapper *openrtb_ext.RequestWrapper) {
 	}
 }
 
-func scrubUserIDs(reqWrapper *openrtb_ext.RequestWrapper) {
+func scrubUserIDs(reqWrapper *openrtb_ext.RequestWrapper, fields *scrubbedFields) {
 	if reqWrapper.User != nil {
+		user := reqWrapper.User
+		fields.add("user.id", user.ID != "")
+		fields.add("user.buyeruid", user.BuyerUID != "")
+		fields.add("user.yob", user.Yob != 0)
+		fields.add("user.gender", user.Gender != "")
+		fields.add("user.keywords", user.Keywords != "")
+		fields.add("user.kwarray", len(user.KwArray) > 0)
+		fields.add("user.data", len(user.Data) > 0)
 		reqWrapper.User.Data = nil
 		reqWrapper.User.ID = ""
 		reqWrapper.User.BuyerUID = ""
@@ -41,8 +64,13 @@ func scrubUserIDs(reqWrapper *openrtb_ext.RequestWrapper) {
 	}
 }
 
-func scrubUserDemographics(reqWrapper *openrtb_ext.RequestWrapper) {
+func scrubUserDemographics(reqWrapper *openrtb_ext.RequestWrapper, fields *scrubbedFields) {
 	if reqWrapper.User != nil {
+		user := reqWrapper.User
+		fields.add("user.id", user.ID != "")
+		fields.add("user.buyeruid", user.BuyerUID != "")
+		fields.add("user.yob", user.Yob != 0)
+		fields.add("user.gender", user.Gender != "")
 		reqWrapper.User.BuyerUID = ""
 		reqWrapper.User.ID = ""
 		reqWrapper.User.Yob = 0
@@ -50,7 +78,7 @@ func scrubUserDemographics(reqWrapper *openrtb_ext.RequestWrapper) {
 	}
 }
 
-func scrubUserExt(reqWrapper *openrtb_ext.RequestWrapper, fieldName string) error {
+func scrubUserExt(reqWrapper *openrtb_ext.RequestWrapper, fieldName string, fields *scrubbedFields) error {
 	if reqWrapper.User != nil {
 		userExt, err := reqWrapper.GetUserExt()
 		if err != nil {
@@ -61,6 +89,7 @@ func scrubUserExt(reqWrapper *openrtb_ext.RequestWrapper, fieldName string) erro
 		if hasField {
 			delete(ext, fieldName)
 			userExt.SetExt(ext)
+			fields.add("user.ext."+fieldName, true)
 		}
 	}
 	return nil
@@ -71,85 +100,230 @@ func ScrubEIDs(reqWrapper *openrtb_ext.RequestWrapper) error {
 	if reqWrapper.User != nil {
 		reqWrapper.User.EIDs = nil
 	}
-	return scrubUserExt(reqWrapper, "eids")
+	return scrubUserExt(reqWrapper, "eids", &scrubbedFields{})
+}
+
+// ScrubEIDsBySource removes the eids of the user whose source isn't allowed, returning the sources removed
+func ScrubEIDsBySource(reqWrapper *openrtb_ext.RequestWrapper, allowSource func(source string) bool) []string {
+	if reqWrapper.User == nil || len(reqWrapper.User.EIDs) == 0 {
+		return nil
+	}
+
+	var removed []string
+	eids := make([]openrtb2.EID, 0, len(reqWrapper.User.EIDs))
+	for _, eid := range reqWrapper.User.EIDs {
+		if allowSource(eid.Source) {
+			eids = append(eids, eid)
+		} else {
+			removed = append(removed, eid.Source)
+		}
+	}
+
+	if len(removed) > 0 {
+		if len(eids) == 0 {
+			eids = nil
+		}
+		reqWrapper.User.EIDs = eids
+	}
+	return removed
 }
 
-func ScrubTID(reqWrapper *openrtb_ext.RequestWrapper) {
+// ScrubTID removes the transaction ids of the request, returning the fields changed
+func ScrubTID(reqWrapper *openrtb_ext.RequestWrapper) []string {
+	var fields scrubbedFields
 	if reqWrapper.Source != nil {
+		fields.add("source.tid", reqWrapper.Source.TID != "")
 		reqWrapper.Source.TID = ""
 	}
 	impWrapper := reqWrapper.GetImp()
+	impExtScrubbed := false
 	for ind, imp := range impWrapper {
 		impExt := scrubExtIDs(imp.Ext, "tid")
+		impExtScrubbed = impExtScrubbed || !bytes.Equal(impExt, imp.Ext)
 		impWrapper[ind].Ext = impExt
 	}
+	fields.add("imp.ext.tid", impExtScrubbed)
 	reqWrapper.SetImp(impWrapper)
+	return fields
 }
 
-func scrubGEO(reqWrapper *openrtb_ext.RequestWrapper) {
+func scrubGEO(reqWrapper *openrtb_ext.RequestWrapper, fields *scrubbedFields) {
 	//round user's geographic location by rounding off IP address and lat/lng data.
 	//this applies to both device.geo and user.geo
-	if reqWrapper.User != nil && reqWrapper.User.Geo != nil {
-		reqWrapper.User.Geo = scrubGeoPrecision(reqWrapper.User.Geo)
-	}
-
 	if reqWrapper.Device != nil && reqWrapper.Device.Geo != nil {
-		reqWrapper.Device.Geo = scrubGeoPrecision(reqWrapper.Device.Geo)
+		geo := scrubGeoPrecision(reqWrapper.Device.Geo)
+		fields.add("device.geo", geoChanged(reqWrapper.Device.Geo, geo))
+		reqWrapper.Device.Geo = geo
 	}
-}
 
-func scrubGeoFull(reqWrapper *openrtb_ext.RequestWrapper) {
 	if reqWrapper.User != nil && reqWrapper.User.Geo != nil {
-		reqWrapper.User.Geo = &openrtb2.Geo{}
+		geo := scrubGeoPrecision(reqWrapper.User.Geo)
+		fields.add("user.geo", geoChanged(reqWrapper.User.Geo, geo))
+		reqWrapper.User.Geo = geo
 	}
+}
+
+func scrubGeoFull(reqWrapper *openrtb_ext.RequestWrapper, fields *scrubbedFields) {
 	if reqWrapper.Device != nil && reqWrapper.Device.Geo != nil {
 		reqWrapper.Device.Geo = &openrtb2.Geo{}
+		fields.add("device.geo", true)
+	}
+	if reqWrapper.User != nil && reqWrapper.User.Geo != nil {
+		reqWrapper.User.Geo = &openrtb2.Geo{}
+		fields.add("user.geo", true)
 	}
 
 }
 
-func scrubDeviceIP(reqWrapper *openrtb_ext.RequestWrapper, ipConf IPConf) {
+func scrubDeviceIP(reqWrapper *openrtb_ext.RequestWrapper, ipConf IPConf, fields *scrubbedFields) {
 	if reqWrapper.Device != nil {
-		reqWrapper.Device.IP = scrubIP(reqWrapper.Device.IP, ipConf.IPV4.AnonKeepBits, iputil.IPv4BitSize)
-		reqWrapper.Device.IPv6 = scrubIP(reqWrapper.Device.IPv6, ipConf.IPV6.AnonKeepBits, iputil.IPv6BitSize)
+		ip := scrubIP(reqWrapper.Device.IP, ipConf.IPV4.AnonKeepBits, iputil.IPv4BitSize)
+		fields.add("device.ip", ip != reqWrapper.Device.IP)
+		reqWrapper.Device.IP = ip
+
+		ipv6 := scrubIP(reqWrapper.Device.IPv6, ipConf.IPV6.AnonKeepBits, iputil.IPv6BitSize)
+		fields.add("device.ipv6", ipv6 != reqWrapper.Device.IPv6)
+		reqWrapper.Device.IPv6 = ipv6
 	}
 }
 
-func ScrubDeviceIDsIPsUserDemoExt(reqWrapper *openrtb_ext.RequestWrapper, ipConf IPConf, fieldName string, scrubFullGeo bool) {
-	scrubDeviceIDs(reqWrapper)
-	scrubDeviceIP(reqWrapper, ipConf)
-	scrubUserDemographics(reqWrapper)
-	scrubUserExt(reqWrapper, fieldName)
+// ScrubDeviceIDsIPsUserDemoExt removes the device ids, the user demographics and the field of the user ext, and
+// truncates the IP addresses and the geolocation, returning the fields changed
+func ScrubDeviceIDsIPsUserDemoExt(reqWrapper *openrtb_ext.RequestWrapper, ipConf IPConf, fieldName string, scrubFullGeo bool) []string {
+	var fields scrubbedFields
+	scrubDeviceIDs(reqWrapper, &fields)
+	scrubDeviceIP(reqWrapper, ipConf, &fields)
+	scrubUserDemographics(reqWrapper, &fields)
+	scrubUserExt(reqWrapper, fieldName, &fields)
 
 	if scrubFullGeo {
-		scrubGeoFull(reqWrapper)
+		scrubGeoFull(reqWrapper, &fields)
 	} else {
-		scrubGEO(reqWrapper)
+		scrubGEO(reqWrapper, &fields)
+	}
+	return fields
+}
+
+// ScrubUserFPD removes the device ids and the user first party data, returning the fields changed
+func ScrubUserFPD(reqWrapper *openrtb_ext.RequestWrapper) []string {
+	var fields scrubbedFields
+	scrubDeviceIDs(reqWrapper, &fields)
+	scrubUserIDs(reqWrapper, &fields)
+	scrubUserExt(reqWrapper, "data", &fields)
+	if reqWrapper.User != nil {
+		fields.add("user.eids", len(reqWrapper.User.EIDs) > 0)
+		reqWrapper.User.EIDs = nil
+	}
+	return fields
+}
+
+// ScrubGdprID removes the device ids, the user demographics and the eids of the user ext, returning the fields changed
+func ScrubGdprID(reqWrapper *openrtb_ext.RequestWrapper) []string {
+	var fields scrubbedFields
+	scrubDeviceIDs(reqWrapper, &fields)
+	scrubUserDemographics(reqWrapper, &fields)
+	scrubUserExt(reqWrapper, "eids", &fields)
+	return fields
+}
+
+// ScrubGeoAndDeviceIP truncates the geolocation and the IP addresses of the device, returning the fields changed
+func ScrubGeoAndDeviceIP(reqWrapper *openrtb_ext.RequestWrapper, ipConf IPConf) []string {
+	var fields scrubbedFields
+	scrubDeviceIP(reqWrapper, ipConf, &fields)
+	scrubGEO(reqWrapper, &fields)
+	return fields
+}
+
+// ScrubGeoAndDeviceIPWithProfile generalises the geolocation and the IP addresses of the device as the
+// precision profile defines, falling back to ScrubGeoAndDeviceIP if there is no profile. It returns the
+// fields changed.
+func ScrubGeoAndDeviceIPWithProfile(reqWrapper *openrtb_ext.RequestWrapper, ipConf IPConf, profile *config.PrecisionProfile) []string {
+	if profile == nil {
+		return ScrubGeoAndDeviceIP(reqWrapper, ipConf)
+	}
+
+	var fields scrubbedFields
+	if profile.IPv4KeepBits != nil {
+		ipConf.IPV4.AnonKeepBits = *profile.IPv4KeepBits
 	}
+	if profile.IPv6KeepBits != nil {
+		ipConf.IPV6.AnonKeepBits = *profile.IPv6KeepBits
+	}
+	scrubDeviceIP(reqWrapper, ipConf, &fields)
+
+	if reqWrapper.Device != nil && reqWrapper.Device.Geo != nil {
+		geo := scrubGeoWithProfile(reqWrapper.Device.Geo, profile)
+		fields.add("device.geo", geoChanged(reqWrapper.Device.Geo, geo))
+		reqWrapper.Device.Geo = geo
+	}
+	if reqWrapper.User != nil && reqWrapper.User.Geo != nil {
+		geo := scrubGeoWithProfile(reqWrapper.User.Geo, profile)
+		fields.add("user.geo", geoChanged(reqWrapper.User.Geo, geo))
+		reqWrapper.User.Geo = geo
+	}
+	return fields
 }
 
-func ScrubUserFPD(reqWrapper *openrtb_ext.RequestWrapper) {
-	scrubDeviceIDs(reqWrapper)
-	scrubUserIDs(reqWrapper)
-	scrubUserExt(reqWrapper, "data")
-	reqWrapper.User.EIDs = nil
+func scrubGeoWithProfile(geo *openrtb2.Geo, profile *config.PrecisionProfile) *openrtb2.Geo {
+	if profile.DropGeo {
+		return nil
+	}
+
+	decimals := defaultLatLonDecimals
+	if profile.LatLonDecimals != nil {
+		decimals = *profile.LatLonDecimals
+	}
+
+	geoCopy := *geo
+	geoCopy.Lat = roundCoordinate(geo.Lat, decimals)
+	geoCopy.Lon = roundCoordinate(geo.Lon, decimals)
+	if profile.DropCity {
+		geoCopy.City = ""
+	}
+	if profile.DropZip {
+		geoCopy.ZIP = ""
+	}
+	if profile.DropMetro {
+		geoCopy.Metro = ""
+	}
+	return &geoCopy
 }
 
-func ScrubGdprID(reqWrapper *openrtb_ext.RequestWrapper) {
-	scrubDeviceIDs(reqWrapper)
-	scrubUserDemographics(reqWrapper)
-	scrubUserExt(reqWrapper, "eids")
+// geoChanged tells whether the geolocation scrubbers dropped the geolocation or changed one of its fields
+func geoChanged(geo, scrubbed *openrtb2.Geo) bool {
+	if scrubbed == nil {
+		return true
+	}
+	return !coordinateEqual(geo.Lat, scrubbed.Lat) || !coordinateEqual(geo.Lon, scrubbed.Lon) ||
+		geo.City != scrubbed.City || geo.ZIP != scrubbed.ZIP || geo.Metro != scrubbed.Metro
+}
+
+func coordinateEqual(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
 }
 
-func ScrubGeoAndDeviceIP(reqWrapper *openrtb_ext.RequestWrapper, ipConf IPConf) {
-	scrubDeviceIP(reqWrapper, ipConf)
-	scrubGEO(reqWrapper)
+// defaultLatLonDecimals is the precision of the latitude and longitude kept by scrubGeoPrecision
+const defaultLatLonDecimals = 2
+
+func roundCoordinate(coordinate *float64, decimals int) *float64 {
+	if coordinate == nil {
+		return nil
+	}
+	scale := math.Pow10(decimals)
+	rounded := math.Round(*coordinate*scale) / scale
+	return &rounded
 }
 
+// scrubIP keeps the first bits of the IP address, clamping the bits kept to the size of the address as net.CIDRMask
+// returns a nil mask for an out of range size
 func scrubIP(ip string, ones, bits int) string {
 	if ip == "" {
 		return ""
 	}
+	ones = min(max(ones, 0), bits)
 	ipMask := net.CIDRMask(ones, bits)
 	ipMasked := net.ParseIP(ip).Mask(ipMask)
 	return ipMasked.String()
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v4/config"
 	"github.com/prebid/prebid-server/v4/openrtb_ext"
 	"github.com/prebid/prebid-server/v4/util/ptrutil"
 	"github.com/stretchr/testify/assert"
@@ -15,11 +16,18 @@ func TestScrubDeviceIDs(t *testing.T) {
 		name           string
 		deviceIn       *openrtb2.Device
 		expectedDevice *openrtb2.Device
+		expectedFields scrubbedFields
 	}{
 		{
 			name:           "all",
 			deviceIn:       &openrtb2.Device{DIDMD5: "MD5", DIDSHA1: "SHA1", DPIDMD5: "MD5", DPIDSHA1: "SHA1", IFA: "IFA", MACMD5: "MD5", MACSHA1: "SHA1"},
 			expectedDevice: &openrtb2.Device{DIDMD5: "", DIDSHA1: "", DPIDMD5: "", DPIDSHA1: "", IFA: "", MACMD5: "", MACSHA1: ""},
+			expectedFields: scrubbedFields{"device.ids"},
+		},
+		{
+			name:           "none",
+			deviceIn:       &openrtb2.Device{Make: "make"},
+			expectedDevice: &openrtb2.Device{Make: "make"},
 		},
 		{
 			name:           "nil",
@@ -30,9 +38,11 @@ func TestScrubDeviceIDs(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			brw := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{Device: test.deviceIn}}
-			scrubDeviceIDs(brw)
+			var fields scrubbedFields
+			scrubDeviceIDs(brw, &fields)
 			brw.RebuildRequest()
 			assert.Equal(t, test.expectedDevice, brw.Device)
+			assert.Equal(t, test.expectedFields, fields)
 		})
 	}
 }
@@ -57,7 +67,7 @@ func TestScrubUserIDs(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			brw := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{User: test.userIn}}
-			scrubUserIDs(brw)
+			scrubUserIDs(brw, &scrubbedFields{})
 			brw.RebuildRequest()
 			assert.Equal(t, test.expectedUser, brw.User)
 		})
@@ -84,7 +94,7 @@ func TestScrubUserDemographics(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			brw := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{User: test.userIn}}
-			scrubUserDemographics(brw)
+			scrubUserDemographics(brw, &scrubbedFields{})
 			brw.RebuildRequest()
 			assert.Equal(t, test.expectedUser, brw.User)
 		})
@@ -134,7 +144,7 @@ func TestScrubUserExt(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			brw := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{User: test.userIn}}
-			scrubUserExt(brw, test.fieldName)
+			scrubUserExt(brw, test.fieldName, &scrubbedFields{})
 			brw.RebuildRequest()
 			assert.Equal(t, test.expectedUser, brw.User)
 		})
@@ -173,6 +183,53 @@ func TestScrubEids(t *testing.T) {
 	}
 }
 
+func TestScrubEIDsBySource(t *testing.T) {
+	allowUIDAPI := func(source string) bool { return source == "uidapi.com" }
+
+	testCases := []struct {
+		name            string
+		userIn          *openrtb2.User
+		expectedUser    *openrtb2.User
+		expectedRemoved []string
+	}{
+		{
+			name:            "some_removed",
+			userIn:          &openrtb2.User{ID: "ID", EIDs: []openrtb2.EID{{Source: "uidapi.com"}, {Source: "id5-sync.com"}}},
+			expectedUser:    &openrtb2.User{ID: "ID", EIDs: []openrtb2.EID{{Source: "uidapi.com"}}},
+			expectedRemoved: []string{"id5-sync.com"},
+		},
+		{
+			name:            "all_removed",
+			userIn:          &openrtb2.User{ID: "ID", EIDs: []openrtb2.EID{{Source: "id5-sync.com"}}},
+			expectedUser:    &openrtb2.User{ID: "ID", EIDs: nil},
+			expectedRemoved: []string{"id5-sync.com"},
+		},
+		{
+			name:         "none_removed",
+			userIn:       &openrtb2.User{ID: "ID", EIDs: []openrtb2.EID{{Source: "uidapi.com"}}},
+			expectedUser: &openrtb2.User{ID: "ID", EIDs: []openrtb2.EID{{Source: "uidapi.com"}}},
+		},
+		{
+			name:         "nil_eids",
+			userIn:       &openrtb2.User{ID: "ID", EIDs: nil},
+			expectedUser: &openrtb2.User{ID: "ID", EIDs: nil},
+		},
+		{
+			name:         "nil",
+			userIn:       nil,
+			expectedUser: nil,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			brw := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{User: test.userIn}}
+			removed := ScrubEIDsBySource(brw, allowUIDAPI)
+			assert.Equal(t, test.expectedRemoved, removed)
+			assert.Equal(t, test.expectedUser, brw.User)
+		})
+	}
+}
+
 func TestScrubTID(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -180,6 +237,7 @@ func TestScrubTID(t *testing.T) {
 		impIn          []openrtb2.Imp
 		expectedSource *openrtb2.Source
 		expectedImp    []openrtb2.Imp
+		expectedFields []string
 	}{
 		{
 			name:           "nil",
@@ -192,6 +250,7 @@ func TestScrubTID(t *testing.T) {
 			impIn:          []openrtb2.Imp{{ID: "impID", Ext: nil}},
 			expectedSource: &openrtb2.Source{TID: ""},
 			expectedImp:    []openrtb2.Imp{{ID: "impID", Ext: nil}},
+			expectedFields: []string{"source.tid"},
 		},
 		{
 			name:           "empty_imp_ext",
@@ -199,6 +258,7 @@ func TestScrubTID(t *testing.T) {
 			impIn:          []openrtb2.Imp{{ID: "impID", Ext: json.RawMessage(`{}`)}},
 			expectedSource: &openrtb2.Source{TID: ""},
 			expectedImp:    []openrtb2.Imp{{ID: "impID", Ext: json.RawMessage(`{}`)}},
+			expectedFields: []string{"source.tid"},
 		},
 		{
 			name:           "ext_with_tid",
@@ -206,6 +266,7 @@ func TestScrubTID(t *testing.T) {
 			impIn:          []openrtb2.Imp{{ID: "impID", Ext: json.RawMessage(`{"tid":"123","test":1}`)}},
 			expectedSource: &openrtb2.Source{TID: ""},
 			expectedImp:    []openrtb2.Imp{{ID: "impID", Ext: json.RawMessage(`{"test":1}`)}},
+			expectedFields: []string{"source.tid", "imp.ext.tid"},
 		},
 		{
 			name:           "ext_without_tid",
@@ -213,15 +274,25 @@ func TestScrubTID(t *testing.T) {
 			impIn:          []openrtb2.Imp{{ID: "impID", Ext: json.RawMessage(`{"data":"123","test":1}`)}},
 			expectedSource: &openrtb2.Source{TID: ""},
 			expectedImp:    []openrtb2.Imp{{ID: "impID", Ext: json.RawMessage(`{"data":"123","test":1}`)}},
+			expectedFields: []string{"source.tid"},
+		},
+		{
+			name:           "empty_tid",
+			sourceIn:       &openrtb2.Source{},
+			impIn:          []openrtb2.Imp{{ID: "impID", Ext: json.RawMessage(`{"tid":"123"}`)}},
+			expectedSource: &openrtb2.Source{},
+			expectedImp:    []openrtb2.Imp{{ID: "impID", Ext: json.RawMessage(`{}`)}},
+			expectedFields: []string{"imp.ext.tid"},
 		},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			brw := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{Source: test.sourceIn, Imp: test.impIn}}
-			ScrubTID(brw)
+			fields := ScrubTID(brw)
 			brw.RebuildRequest()
 			assert.Equal(t, test.expectedSource, brw.Source)
 			assert.Equal(t, test.expectedImp, brw.Imp)
+			assert.Equal(t, test.expectedFields, fields)
 		})
 	}
 }
@@ -233,6 +304,7 @@ func TestScrubGEO(t *testing.T) {
 		expectedUser   *openrtb2.User
 		deviceIn       *openrtb2.Device
 		expectedDevice *openrtb2.Device
+		expectedFields scrubbedFields
 	}{
 		{
 			name:           "nil",
@@ -247,6 +319,7 @@ func TestScrubGEO(t *testing.T) {
 			expectedUser:   &openrtb2.User{ID: "ID", Geo: nil},
 			deviceIn:       &openrtb2.Device{Geo: &openrtb2.Geo{Lat: ptrutil.ToPtr(123.123)}},
 			expectedDevice: &openrtb2.Device{Geo: &openrtb2.Geo{Lat: ptrutil.ToPtr(123.12)}},
+			expectedFields: scrubbedFields{"device.geo"},
 		},
 		{
 			name:           "with_user_geo",
@@ -254,6 +327,14 @@ func TestScrubGEO(t *testing.T) {
 			expectedUser:   &openrtb2.User{ID: "ID", Geo: &openrtb2.Geo{Lat: ptrutil.ToPtr(123.12)}},
 			deviceIn:       &openrtb2.Device{},
 			expectedDevice: &openrtb2.Device{},
+			expectedFields: scrubbedFields{"user.geo"},
+		},
+		{
+			name:           "user_geo_already_truncated",
+			userIn:         &openrtb2.User{ID: "ID", Geo: &openrtb2.Geo{Lat: ptrutil.ToPtr(123.12)}},
+			expectedUser:   &openrtb2.User{ID: "ID", Geo: &openrtb2.Geo{Lat: ptrutil.ToPtr(123.12)}},
+			deviceIn:       &openrtb2.Device{},
+			expectedDevice: &openrtb2.Device{},
 		},
 		{
 			name:           "nil_device_geo",
@@ -268,6 +349,7 @@ func TestScrubGEO(t *testing.T) {
 			expectedUser:   &openrtb2.User{},
 			deviceIn:       &openrtb2.Device{Geo: &openrtb2.Geo{Lat: ptrutil.ToPtr(123.123)}},
 			expectedDevice: &openrtb2.Device{Geo: &openrtb2.Geo{Lat: ptrutil.ToPtr(123.12)}},
+			expectedFields: scrubbedFields{"device.geo"},
 		},
 		{
 			name:           "with_user_and_device_geo",
@@ -275,15 +357,83 @@ func TestScrubGEO(t *testing.T) {
 			expectedUser:   &openrtb2.User{ID: "ID", Geo: &openrtb2.Geo{Lat: ptrutil.ToPtr(123.12)}},
 			deviceIn:       &openrtb2.Device{Geo: &openrtb2.Geo{Lat: ptrutil.ToPtr(123.123)}},
 			expectedDevice: &openrtb2.Device{Geo: &openrtb2.Geo{Lat: ptrutil.ToPtr(123.12)}},
+			expectedFields: scrubbedFields{"device.geo", "user.geo"},
 		},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			brw := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{User: test.userIn, Device: test.deviceIn}}
-			scrubGEO(brw)
+			var fields scrubbedFields
+			scrubGEO(brw, &fields)
 			brw.RebuildRequest()
 			assert.Equal(t, test.expectedUser, brw.User)
 			assert.Equal(t, test.expectedDevice, brw.Device)
+			assert.Equal(t, test.expectedFields, fields)
+		})
+	}
+}
+
+func TestScrubGeoAndDeviceIPWithProfile(t *testing.T) {
+	ipConf := IPConf{IPV4: config.IPv4{AnonKeepBits: 24}, IPV6: config.IPv6{AnonKeepBits: 56}}
+	geo := openrtb2.Geo{Lat: ptrutil.ToPtr(12.34567), Lon: ptrutil.ToPtr(65.4321), City: "city", ZIP: "zip", Metro: "metro", Country: "USA"}
+
+	testCases := []struct {
+		name           string
+		profile        *config.PrecisionProfile
+		expectedGeo    *openrtb2.Geo
+		expectedIP     string
+		expectedIPv6   string
+		expectedUserID string
+	}{
+		{
+			name:         "nil_profile",
+			profile:      nil,
+			expectedGeo:  &openrtb2.Geo{Lat: ptrutil.ToPtr(12.35), Lon: ptrutil.ToPtr(65.43), City: "city", ZIP: "zip", Metro: "metro", Country: "USA"},
+			expectedIP:   "1.2.3.0",
+			expectedIPv6: "2001:1db8:abcd:1200::",
+		},
+		{
+			name:         "empty_profile",
+			profile:      &config.PrecisionProfile{},
+			expectedGeo:  &openrtb2.Geo{Lat: ptrutil.ToPtr(12.35), Lon: ptrutil.ToPtr(65.43), City: "city", ZIP: "zip", Metro: "metro", Country: "USA"},
+			expectedIP:   "1.2.3.0",
+			expectedIPv6: "2001:1db8:abcd:1200::",
+		},
+		{
+			name: "coarse_profile",
+			profile: &config.PrecisionProfile{
+				LatLonDecimals: ptrutil.ToPtr(1),
+				DropCity:       true,
+				DropZip:        true,
+				DropMetro:      true,
+				IPv4KeepBits:   ptrutil.ToPtr(16),
+				IPv6KeepBits:   ptrutil.ToPtr(32),
+			},
+			expectedGeo:  &openrtb2.Geo{Lat: ptrutil.ToPtr(12.3), Lon: ptrutil.ToPtr(65.4), Country: "USA"},
+			expectedIP:   "1.2.0.0",
+			expectedIPv6: "2001:1db8::",
+		},
+		{
+			name:         "drop_geo",
+			profile:      &config.PrecisionProfile{DropGeo: true},
+			expectedGeo:  nil,
+			expectedIP:   "1.2.3.0",
+			expectedIPv6: "2001:1db8:abcd:1200::",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			userGeo, deviceGeo := geo, geo
+			brw := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+				User:   &openrtb2.User{Geo: &userGeo},
+				Device: &openrtb2.Device{Geo: &deviceGeo, IP: "1.2.3.4", IPv6: "2001:1db8:abcd:1234::1"},
+			}}
+			fields := ScrubGeoAndDeviceIPWithProfile(brw, ipConf, test.profile)
+			assert.Equal(t, []string{"device.ip", "device.ipv6", "device.geo", "user.geo"}, fields)
+			assert.Equal(t, test.expectedGeo, brw.User.Geo)
+			assert.Equal(t, test.expectedGeo, brw.Device.Geo)
+			assert.Equal(t, test.expectedIP, brw.Device.IP)
+			assert.Equal(t, test.expectedIPv6, brw.Device.IPv6)
 		})
 	}
 }
@@ -342,7 +492,7 @@ func TestScrubGeoFull(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			brw := &openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{User: test.userIn, Device: test.deviceIn}}
-			scrubGeoFull(brw)
+			scrubGeoFull(brw, &scrubbedFields{})
 			brw.RebuildRequest()
 			assert.Equal(t, test.expectedUser, brw.User)
 			assert.Equal(t, test.expectedDevice, brw.Device)
@@ -405,6 +555,18 @@ func TestScrubIP(t *testing.T) {
 			bits:      128,
 			maskBits:  96,
 		},
+		{
+			IP:        "111.222.33.44",
+			cleanedIP: "111.222.33.44",
+			bits:      32,
+			maskBits:  33,
+		},
+		{
+			IP:        "2001:1db8::ff00:42:8329",
+			cleanedIP: "::",
+			bits:      128,
+			maskBits:  -1,
+		},
 	}
 	for _, test := range testCases {
 		t.Run(test.IP, func(t *testing.T) {
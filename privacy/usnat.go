@@ -0,0 +1,162 @@
+package privacy
+
+import (
+	"slices"
+	"sync"
+
+	gpplib "github.com/prebid/go-gpp"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/privacy/gpp"
+)
+
+// usNatBasicSignals deny every activity which shares or uses the data of the user for advertising.
+var usNatBasicSignals = []config.USNatSignal{
+	config.USNatSignalSaleOptOut,
+	config.USNatSignalSharingOptOut,
+	config.USNatSignalTargetedAdvertisingOptOut,
+	config.USNatSignalMissingNotice,
+	config.USNatSignalKnownChild,
+	config.USNatSignalPersonalData,
+	config.USNatSignalGPC,
+	config.USNatSignalServiceProviderMode,
+}
+
+// defaultUSNatSignals is the default mapping of the GPP US National and US state sections onto the
+// activities. Transmitting user first party data, which includes eids, and transmitting eids of any
+// source are additionally denied by an opt out of any sensitive data category, and transmitting precise
+// geolocation by an opt out of the precise geolocation category. Fetching bids, reporting analytics and
+// transmitting tids are not controlled by the US sections.
+func defaultUSNatSignals(activity Activity) []config.USNatSignal {
+	switch activity {
+	case ActivitySyncUser, ActivityEnrichUserFPD, ActivityTransmitUniqueRequestIDs:
+		return usNatBasicSignals
+	case ActivityTransmitUserFPD, ActivityTransmitEIDs:
+		return append(slices.Clone(usNatBasicSignals), config.USNatSignalSensitiveData)
+	case ActivityTransmitPreciseGeo:
+		return append(slices.Clone(usNatBasicSignals), config.USNatSignalPreciseGeo)
+	}
+	return nil
+}
+
+func usNatSignals(activity Activity, cfg config.USNatActivities) []config.USNatSignal {
+	var override []config.USNatSignal
+	switch activity {
+	case ActivitySyncUser:
+		override = cfg.SyncUser
+	case ActivityEnrichUserFPD:
+		override = cfg.EnrichUserFPD
+	case ActivityTransmitUserFPD:
+		override = cfg.TransmitUserFPD
+	case ActivityTransmitPreciseGeo:
+		override = cfg.TransmitPreciseGeo
+	case ActivityTransmitUniqueRequestIDs:
+		override = cfg.TransmitUniqueRequestIds
+	case ActivityTransmitEIDs:
+		override = cfg.TransmitEids
+	}
+
+	if override != nil {
+		return override
+	}
+	return defaultUSNatSignals(activity)
+}
+
+// addUSNatRules appends a rule enforcing the GPP US sections to the plan of each controlled activity.
+// The rules follow those of the account, so the account may explicitly allow an activity regardless
+// of the consent signals.
+func addUSNatRules(plans map[Activity]ActivityPlan, cfg config.USNat, precisionProfile string) {
+	reader := &usNatReader{}
+	for activity, plan := range plans {
+		signals := usNatSignals(activity, cfg.Activities)
+		if len(signals) == 0 {
+			continue
+		}
+		plan.rules = append(plan.rules, USNatRule{signals: signals, reader: reader, precisionProfile: precisionProfile})
+		plans[activity] = plan
+	}
+}
+
+// USNatRule denies an activity if any of the applicable GPP US National or US state sections of the
+// request carries one of its signals.
+type USNatRule struct {
+	signals          []config.USNatSignal
+	reader           *usNatReader
+	precisionProfile string
+}
+
+func (r USNatRule) Evaluate(target Component, request ActivityRequest) ActivityResult {
+	for _, section := range r.reader.read(request) {
+		for _, signal := range r.signals {
+			if usNatSignalPresent(signal, section) {
+				return ActivityDeny
+			}
+		}
+	}
+	return ActivityAbstain
+}
+
+func usNatSignalPresent(signal config.USNatSignal, section gpp.USSection) bool {
+	switch signal {
+	case config.USNatSignalSaleOptOut:
+		return section.SaleOptOut == gpp.USOptedOut
+	case config.USNatSignalSharingOptOut:
+		return section.SharingOptOut == gpp.USOptedOut
+	case config.USNatSignalTargetedAdvertisingOptOut:
+		return section.TargetedAdvertisingOptOut == gpp.USOptedOut
+	case config.USNatSignalMissingNotice:
+		return section.MissingNotice()
+	case config.USNatSignalSensitiveData:
+		return section.SensitiveDataOptOut()
+	case config.USNatSignalPreciseGeo:
+		return section.PreciseGeoOptOut()
+	case config.USNatSignalKnownChild:
+		return section.KnownChildNoConsent()
+	case config.USNatSignalPersonalData:
+		return section.PersonalDataConsents == gpp.USNoConsent
+	case config.USNatSignalGPC:
+		return section.Gpc
+	case config.USNatSignalServiceProviderMode:
+		return section.MspaServiceProviderMode == gpp.USModeYes
+	}
+	return false
+}
+
+// usNatReader parses the GPP string of a request once and shares the applicable US sections between
+// the rules of all activities.
+type usNatReader struct {
+	lock     sync.Mutex
+	gpp      string
+	gppSID   []int8
+	sections []gpp.USSection
+}
+
+func (r *usNatReader) read(request ActivityRequest) []gpp.USSection {
+	gppString, gppSID := getGPP(request)
+	if gppString == "" || len(gppSID) == 0 {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if gppString != r.gpp || !slices.Equal(gppSID, r.gppSID) {
+		// parse errors are reported by the endpoints, sections which could be parsed are still enforced
+		container, _ := gpplib.Parse(gppString)
+		r.gpp = gppString
+		r.gppSID = gppSID
+		r.sections = gpp.ReadUSSections(container, gppSID)
+	}
+	return r.sections
+}
+
+func getGPP(request ActivityRequest) (string, []int8) {
+	if request.IsPolicies() {
+		return request.policies.GPP, request.policies.GPPSID
+	}
+
+	if request.IsBidRequest() && request.bidRequest.Regs != nil {
+		return request.bidRequest.Regs.GPP, request.bidRequest.Regs.GPPSID
+	}
+
+	return "", nil
+}
@@ -0,0 +1,182 @@
+package privacy
+
+import (
+	"testing"
+
+	"github.com/prebid/openrtb/v20/openrtb2"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/openrtb_ext"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testUSNatNoOptOuts      = "DBABLA~BVVqqqqqAWA.QA"
+	testUSNatSaleOptOut     = "DBABLA~BVVaqqqqAWA.QA"
+	testUSNatSensitiveData  = "DBABLA~BVVqaqqqAWA.QA"
+	testUSNatPreciseGeo     = "DBABLA~BVVqqqmqAWA.QA"
+	testUSNatGPC            = "DBABLA~BVVqqqqqAWA.YA"
+	testUSNatAndUSCASharing = "DBABrw~BVVqqqqqAWA.QA~BVmqqoBY.QA"
+)
+
+func TestUSNatActivityControl(t *testing.T) {
+	bidderA := Component{Type: ComponentTypeBidder, Name: "bidderA"}
+
+	testCases := []struct {
+		name           string
+		privacyConf    config.AccountPrivacy
+		gpp            string
+		gppSID         []int8
+		activity       Activity
+		expectedResult bool
+	}{
+		{
+			name:           "disabled",
+			privacyConf:    config.AccountPrivacy{},
+			gpp:            testUSNatSaleOptOut,
+			gppSID:         []int8{7},
+			activity:       ActivitySyncUser,
+			expectedResult: true,
+		},
+		{
+			name:           "no_opt_outs",
+			privacyConf:    config.AccountPrivacy{USNat: config.USNat{Enabled: true}},
+			gpp:            testUSNatNoOptOuts,
+			gppSID:         []int8{7},
+			activity:       ActivitySyncUser,
+			expectedResult: true,
+		},
+		{
+			name:           "sale_opt_out_denies_sync_user",
+			privacyConf:    config.AccountPrivacy{USNat: config.USNat{Enabled: true}},
+			gpp:            testUSNatSaleOptOut,
+			gppSID:         []int8{7},
+			activity:       ActivitySyncUser,
+			expectedResult: false,
+		},
+		{
+			name:           "sale_opt_out_section_not_applicable",
+			privacyConf:    config.AccountPrivacy{USNat: config.USNat{Enabled: true}},
+			gpp:            testUSNatSaleOptOut,
+			gppSID:         []int8{8},
+			activity:       ActivitySyncUser,
+			expectedResult: true,
+		},
+		{
+			name:           "sale_opt_out_does_not_control_fetch_bids",
+			privacyConf:    config.AccountPrivacy{USNat: config.USNat{Enabled: true}},
+			gpp:            testUSNatSaleOptOut,
+			gppSID:         []int8{7},
+			activity:       ActivityFetchBids,
+			expectedResult: true,
+		},
+		{
+			name:           "gpc_denies_transmit_ufpd",
+			privacyConf:    config.AccountPrivacy{USNat: config.USNat{Enabled: true}},
+			gpp:            testUSNatGPC,
+			gppSID:         []int8{7},
+			activity:       ActivityTransmitUserFPD,
+			expectedResult: false,
+		},
+		{
+			name:           "sensitive_data_denies_transmit_ufpd",
+			privacyConf:    config.AccountPrivacy{USNat: config.USNat{Enabled: true}},
+			gpp:            testUSNatSensitiveData,
+			gppSID:         []int8{7},
+			activity:       ActivityTransmitUserFPD,
+			expectedResult: false,
+		},
+		{
+			name:           "sensitive_data_does_not_deny_sync_user",
+			privacyConf:    config.AccountPrivacy{USNat: config.USNat{Enabled: true}},
+			gpp:            testUSNatSensitiveData,
+			gppSID:         []int8{7},
+			activity:       ActivitySyncUser,
+			expectedResult: true,
+		},
+		{
+			name:           "sensitive_data_does_not_deny_transmit_precise_geo",
+			privacyConf:    config.AccountPrivacy{USNat: config.USNat{Enabled: true}},
+			gpp:            testUSNatSensitiveData,
+			gppSID:         []int8{7},
+			activity:       ActivityTransmitPreciseGeo,
+			expectedResult: true,
+		},
+		{
+			name:           "precise_geo_denies_transmit_precise_geo",
+			privacyConf:    config.AccountPrivacy{USNat: config.USNat{Enabled: true}},
+			gpp:            testUSNatPreciseGeo,
+			gppSID:         []int8{7},
+			activity:       ActivityTransmitPreciseGeo,
+			expectedResult: false,
+		},
+		{
+			name:           "state_section_opt_out_denies",
+			privacyConf:    config.AccountPrivacy{USNat: config.USNat{Enabled: true}},
+			gpp:            testUSNatAndUSCASharing,
+			gppSID:         []int8{7, 8},
+			activity:       ActivitySyncUser,
+			expectedResult: false,
+		},
+		{
+			name: "account_override_ignores_signal",
+			privacyConf: config.AccountPrivacy{USNat: config.USNat{
+				Enabled:    true,
+				Activities: config.USNatActivities{SyncUser: []config.USNatSignal{config.USNatSignalGPC}},
+			}},
+			gpp:            testUSNatSaleOptOut,
+			gppSID:         []int8{7},
+			activity:       ActivitySyncUser,
+			expectedResult: true,
+		},
+		{
+			name: "account_override_disables_activity",
+			privacyConf: config.AccountPrivacy{USNat: config.USNat{
+				Enabled:    true,
+				Activities: config.USNatActivities{TransmitUserFPD: []config.USNatSignal{}},
+			}},
+			gpp:            testUSNatGPC,
+			gppSID:         []int8{7},
+			activity:       ActivityTransmitUserFPD,
+			expectedResult: true,
+		},
+		{
+			name: "account_rule_takes_precedence",
+			privacyConf: config.AccountPrivacy{
+				AllowActivities: &config.AllowActivities{SyncUser: getTestActivityConfig(true)},
+				USNat:           config.USNat{Enabled: true},
+			},
+			gpp:            testUSNatSaleOptOut,
+			gppSID:         []int8{7},
+			activity:       ActivitySyncUser,
+			expectedResult: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			activityControl := NewActivityControl(&test.privacyConf)
+
+			policiesRequest := NewRequestFromPolicies(Policies{GPP: test.gpp, GPPSID: test.gppSID})
+			assert.Equal(t, test.expectedResult, activityControl.Allow(test.activity, bidderA, policiesRequest), "policies")
+
+			bidRequest := NewRequestFromBidRequest(openrtb_ext.RequestWrapper{BidRequest: &openrtb2.BidRequest{
+				Regs: &openrtb2.Regs{GPP: test.gpp, GPPSID: test.gppSID},
+			}})
+			assert.Equal(t, test.expectedResult, activityControl.Allow(test.activity, bidderA, bidRequest), "bid-request")
+		})
+	}
+}
+
+func TestUSNatReaderCachesSections(t *testing.T) {
+	reader := &usNatReader{}
+
+	saleOptOut := reader.read(NewRequestFromPolicies(Policies{GPP: testUSNatSaleOptOut, GPPSID: []int8{7}}))
+	assert.Len(t, saleOptOut, 1)
+	assert.Equal(t, byte(1), saleOptOut[0].SaleOptOut)
+
+	notApplicable := reader.read(NewRequestFromPolicies(Policies{GPP: testUSNatSaleOptOut, GPPSID: []int8{8}}))
+	assert.Empty(t, notApplicable)
+
+	noGPP := reader.read(NewRequestFromPolicies(Policies{}))
+	assert.Empty(t, noGPP)
+}
@@ -7,10 +7,11 @@ import (
 
 	"github.com/prebid/prebid-server/v4/currency"
 	"github.com/prebid/prebid-server/v4/endpoints"
+	"github.com/prebid/prebid-server/v4/gdpr"
 	"github.com/prebid/prebid-server/v4/version"
 )
 
-func Admin(rateConverter *currency.RateConverter, rateConverterFetchingInterval time.Duration) *http.ServeMux {
+func Admin(rateConverter *currency.RateConverter, rateConverterFetchingInterval time.Duration, vendorListUploader gdpr.VendorListUploader) *http.ServeMux {
 	// Add endpoints to the admin server
 	// Making sure to add pprof routes
 	mux := http.NewServeMux()
@@ -23,5 +24,8 @@ func Admin(rateConverter *currency.RateConverter, rateConverterFetchingInterval
 	// Register prebid-server defined admin handlers
 	mux.HandleFunc("/currency/rates", endpoints.NewCurrencyRatesEndpoint(rateConverter, rateConverterFetchingInterval))
 	mux.HandleFunc("/version", endpoints.NewVersionEndpoint(version.Ver, version.Rev))
+	if vendorListUploader != nil {
+		mux.HandleFunc("/gdpr/vendorlist", endpoints.NewVendorListUploadEndpoint(vendorListUploader))
+	}
 	return mux
 }
@@ -3,7 +3,6 @@ package file_fetcher
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -19,13 +18,20 @@ import (
 // This expects each file in the directory to be named "{config_id}.json".
 // For example, when asked to fetch the request with ID == "23", it will return the data from "directory/23.json".
 func NewFileFetcher(directory string) (stored_requests.AllFetcher, error) {
+	return NewResponsesFileFetcher(directory, "stored_responses")
+}
+
+// NewResponsesFileFetcher works like NewFileFetcher, except that FetchResponses reads the data from the
+// responsesDirectory sub directory. This lets other data fetched by ID, such as the stored floors, share the loader.
+func NewResponsesFileFetcher(directory string, responsesDirectory string) (stored_requests.AllFetcher, error) {
 	storedData, err := collectStoredData(directory, FileSystem{make(map[string]FileSystem), make(map[string]json.RawMessage)}, nil)
-	return &eagerFetcher{storedData, nil}, err
+	return &eagerFetcher{FileSystem: storedData, responsesDirectory: responsesDirectory}, err
 }
 
 type eagerFetcher struct {
-	FileSystem FileSystem
-	Categories map[string]map[string]stored_requests.Category
+	FileSystem         FileSystem
+	Categories         map[string]map[string]stored_requests.Category
+	responsesDirectory string
 }
 
 func (fetcher *eagerFetcher) FetchRequests(ctx context.Context, requestIDs []string, impIDs []string) (map[string]json.RawMessage, map[string]json.RawMessage, []error) {
@@ -37,10 +43,11 @@ func (fetcher *eagerFetcher) FetchRequests(ctx context.Context, requestIDs []str
 }
 
 // Fetch Responses - Implements the interface to read the stored response information from the fetcher's FileSystem, the directory name is "stored_responses"
+// unless the fetcher was created with NewResponsesFileFetcher
 func (fetcher *eagerFetcher) FetchResponses(ctx context.Context, ids []string) (data map[string]json.RawMessage, errs []error) {
-	storedRespFS, found := fetcher.FileSystem.Directories["stored_responses"]
+	storedRespFS, found := fetcher.FileSystem.Directories[fetcher.responsesDirectory]
 	if !found {
-		return nil, append(errs, errors.New(`no "stored_responses" directory found`))
+		return nil, append(errs, fmt.Errorf(`no "%s" directory found`, fetcher.responsesDirectory))
 	}
 
 	data = storedRespFS.Files
@@ -75,6 +75,21 @@ func TestStoredResponseFileFetcher(t *testing.T) {
 	})
 }
 
+func TestResponsesFileFetcher(t *testing.T) {
+	fetcher, err := NewResponsesFileFetcher("./test", "stored_floors")
+	assert.NoError(t, err, "Failed to create test fetcher")
+
+	storedFloors, errs := fetcher.FetchResponses(context.Background(), []string{"floors-1", "does_not_exist"})
+	assertErrorCount(t, 1, errs)
+	assert.JSONEq(t, `{"currency":"USD","modelgroups":[{"schema":{"fields":["mediaType"]},"values":{"banner":1.5}}]}`, string(storedFloors["floors-1"]))
+
+	fetcher, err = NewResponsesFileFetcher("./test", "stored_missing")
+	assert.NoError(t, err, "Failed to create test fetcher")
+
+	_, errs = fetcher.FetchResponses(context.Background(), []string{"floors-1"})
+	assert.Equal(t, []error{fmt.Errorf(`no "stored_missing" directory found`)}, errs)
+}
+
 func TestAccountFetcher(t *testing.T) {
 	fetcher, err := NewFileFetcher("./test")
 	assert.NoError(t, err, "Failed to create test fetcher")
@@ -0,0 +1,89 @@
+package http_fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/prebid/prebid-server/v4/stored_requests"
+	"github.com/prebid/prebid-server/v4/util/jsonutil"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// NewFloorsFetcher returns a Fetcher which uses the Client to pull the stored floors from the endpoint.
+//
+// Stored floors are read by FetchResponses, other data is fetched like NewFetcher does:
+// GET {endpoint}?floors-ids=["floors1","floors2"]
+//
+// If useRfcCompliantBuilder is true (symbols will be URLEncoded)
+// GET {endpoint}?floors-id=floors1&floors-id=floors2
+//
+// The above endpoint should return a payload like:
+//
+//	{
+//	  "floors": {
+//	    "floors1": { ... floors data for floors1 ... },
+//	    "floors2": { ... floors data for floors2 ... },
+//	  },
+//	}
+func NewFloorsFetcher(client *http.Client, endpoint string, useRfcCompliantBuilder bool) *FloorsFetcher {
+	return &FloorsFetcher{NewFetcher(client, endpoint, useRfcCompliantBuilder)}
+}
+
+type FloorsFetcher struct {
+	*HttpFetcher
+}
+
+func (fetcher *FloorsFetcher) FetchResponses(ctx context.Context, ids []string) (data map[string]json.RawMessage, errs []error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	u := *fetcher.EndpointURL
+	q := u.Query()
+	AddQueryParam(&q, "floors-id", ids, fetcher.UseRfcCompliantBuilder)
+	u.RawQuery = q.Encode()
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, []error{
+			fmt.Errorf(`Error fetching floors %v via http: build request failed with %v`, ids, err),
+		}
+	}
+	httpResp, err := ctxhttp.Do(ctx, fetcher.client, httpReq)
+	if err != nil {
+		return nil, []error{
+			fmt.Errorf(`Error fetching floors %v via http: %v`, ids, err),
+		}
+	}
+	defer httpResp.Body.Close()
+
+	respBytes, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, []error{
+			fmt.Errorf(`Error fetching floors %v via http: error reading response: %v`, ids, err),
+		}
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, []error{
+			fmt.Errorf(`Error fetching floors %v via http: unexpected response status %d`, ids, httpResp.StatusCode),
+		}
+	}
+	var responseData floorsResponseContract
+	if err = jsonutil.UnmarshalValid(respBytes, &responseData); err != nil {
+		return nil, []error{
+			fmt.Errorf(`Error fetching floors %v via http: failed to parse response: %v`, ids, err),
+		}
+	}
+	for _, id := range ids {
+		if val, ok := responseData.Floors[id]; !ok || val == nil {
+			delete(responseData.Floors, id)
+			errs = append(errs, stored_requests.NotFoundError{ID: id, DataType: "Floors"})
+		}
+	}
+	return responseData.Floors, errs
+}
+
+type floorsResponseContract struct {
+	Floors map[string]json.RawMessage `json:"floors"`
+}
@@ -0,0 +1,91 @@
+package http_fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prebid/prebid-server/v4/stored_requests"
+	"github.com/prebid/prebid-server/v4/util/jsonutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchFloorsRfcCompliant(t *testing.T) {
+	fetcher, close := newTestFloorsFetcher(t, []string{"floors-1", "floors-2"}, true)
+	defer close()
+
+	floorsData, errs := fetcher.FetchResponses(context.Background(), []string{"floors-1", "floors-2"})
+	assert.Empty(t, errs, "Unexpected error fetching known floors")
+	assertMapKeys(t, floorsData, "floors-1", "floors-2")
+}
+
+func TestFetchFloors(t *testing.T) {
+	fetcher, close := newTestFloorsFetcher(t, []string{"floors-1", "floors-2", "missing"}, false)
+	defer close()
+
+	floorsData, errs := fetcher.FetchResponses(context.Background(), []string{"floors-1", "floors-2", "missing"})
+	assert.Equal(t, []error{stored_requests.NotFoundError{ID: "missing", DataType: "Floors"}}, errs)
+	assertMapKeys(t, floorsData, "floors-1", "floors-2")
+}
+
+func TestFetchFloorsNoIDsProvided(t *testing.T) {
+	fetcher, close := newTestFloorsFetcher(t, nil, false)
+	defer close()
+
+	floorsData, errs := fetcher.FetchResponses(context.Background(), nil)
+	assert.Empty(t, errs, "Unexpected error fetching empty floors")
+	assert.Nil(t, floorsData, "Fetching empty floors should return nil")
+}
+
+func TestFetchFloorsNoData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	fetcher := NewFloorsFetcher(server.Client(), server.URL, false)
+
+	floorsData, errs := fetcher.FetchResponses(context.Background(), []string{"floors-1"})
+	assert.Len(t, errs, 1, "Fetching unknown floors should have returned an error")
+	assert.Nil(t, floorsData, "Fetching unknown floors should return nil floors map")
+}
+
+func TestFetchResponsesNotFloors(t *testing.T) {
+	fetcher, close := newTestFloorsFetcher(t, nil, false)
+	defer close()
+
+	respData, errs := fetcher.HttpFetcher.FetchResponses(context.Background(), []string{"resp-1"})
+	assert.Empty(t, errs, "Stored responses aren't fetched via http")
+	assert.Nil(t, respData, "Stored responses aren't fetched via http")
+}
+
+func newTestFloorsFetcher(t *testing.T, expectFloorsIDs []string, useRfcCompliantBuilder bool) (fetcher *FloorsFetcher, closer func()) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var gotFloorsIDs []string
+		if !useRfcCompliantBuilder {
+			gotFloorsIDs = richSplit(query.Get("floors-ids"))
+		} else {
+			gotFloorsIDs = query["floors-id"]
+		}
+
+		assertMatches(t, gotFloorsIDs, expectFloorsIDs)
+
+		floorsIDResponse := make(map[string]json.RawMessage, len(gotFloorsIDs))
+		for _, floorsID := range gotFloorsIDs {
+			if floorsID != "" && floorsID != "missing" {
+				floorsIDResponse[floorsID] = json.RawMessage(`{"currency":"USD"}`)
+			}
+		}
+
+		if respBytes, err := jsonutil.Marshal(floorsResponseContract{Floors: floorsIDResponse}); err != nil {
+			t.Errorf("failed to marshal floorsResponseContract in test:  %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		} else {
+			w.Write(respBytes)
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	return NewFloorsFetcher(server.Client(), server.URL, useRfcCompliantBuilder), server.Close
+}
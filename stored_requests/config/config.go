@@ -90,6 +90,8 @@ func CreateStoredRequests(cfg *config.StoredRequests, metricsEngine metrics.Metr
 // 4. A Fetcher which can be used to get Account data
 // 5. A Fetcher which can be used to get Category Mapping data
 // 6. A Fetcher which can be used to get Stored Requests for /openrtb2/video
+// 7. A Fetcher which can be used to get Stored Responses
+// 8. A Fetcher which can be used to get Stored Floors
 //
 // If any errors occur, the program will exit with an error message.
 // It probably means you have a bad config or networking issue.
@@ -102,7 +104,8 @@ func NewStoredRequests(cfg *config.Configuration, metricsEngine metrics.MetricsE
 	accountsFetcher stored_requests.AccountFetcher,
 	categoriesFetcher stored_requests.CategoryFetcher,
 	videoFetcher stored_requests.Fetcher,
-	storedRespFetcher stored_requests.Fetcher) {
+	storedRespFetcher stored_requests.Fetcher,
+	storedFloorsFetcher stored_requests.Fetcher) {
 
 	var provider db_provider.DbProvider
 
@@ -112,6 +115,7 @@ func NewStoredRequests(cfg *config.Configuration, metricsEngine metrics.MetricsE
 	fetcher4, shutdown4 := CreateStoredRequests(&cfg.StoredVideo, metricsEngine, client, router, provider)
 	fetcher5, shutdown5 := CreateStoredRequests(&cfg.Accounts, metricsEngine, client, router, provider)
 	fetcher6, shutdown6 := CreateStoredRequests(&cfg.StoredResponses, metricsEngine, client, router, provider)
+	fetcher7, shutdown7 := CreateStoredRequests(&cfg.StoredFloors, metricsEngine, client, router, provider)
 
 	fetcher = fetcher1.(stored_requests.Fetcher)
 	ampFetcher = fetcher2.(stored_requests.Fetcher)
@@ -119,6 +123,7 @@ func NewStoredRequests(cfg *config.Configuration, metricsEngine metrics.MetricsE
 	videoFetcher = fetcher4.(stored_requests.Fetcher)
 	accountsFetcher = fetcher5.(stored_requests.AccountFetcher)
 	storedRespFetcher = fetcher6.(stored_requests.Fetcher)
+	storedFloorsFetcher = fetcher7.(stored_requests.Fetcher)
 
 	shutdown = func() {
 		shutdown1()
@@ -127,6 +132,7 @@ func NewStoredRequests(cfg *config.Configuration, metricsEngine metrics.MetricsE
 		shutdown4()
 		shutdown5()
 		shutdown6()
+		shutdown7()
 	}
 
 	return
@@ -165,7 +171,11 @@ func newFetcher(cfg *config.StoredRequests, client *http.Client, provider db_pro
 	}
 	if cfg.HTTP.Endpoint != "" {
 		logger.Infof("Loading Stored %s data via HTTP. endpoint=%s", cfg.DataType(), cfg.HTTP.Endpoint)
-		idList = append(idList, http_fetcher.NewFetcher(client, cfg.HTTP.Endpoint, cfg.HTTP.UseRfcCompliantBuilder))
+		if cfg.DataType() == config.FloorsDataType {
+			idList = append(idList, http_fetcher.NewFloorsFetcher(client, cfg.HTTP.Endpoint, cfg.HTTP.UseRfcCompliantBuilder))
+		} else {
+			idList = append(idList, http_fetcher.NewFetcher(client, cfg.HTTP.Endpoint, cfg.HTTP.UseRfcCompliantBuilder))
+		}
 	}
 
 	fetcher = consolidate(cfg.DataType(), idList)
@@ -234,7 +244,11 @@ func newHttpEvents(client *http.Client, timeout time.Duration, refreshRate time.
 
 func newFilesystem(dataType config.DataType, configPath string) stored_requests.AllFetcher {
 	logger.Infof("Loading Stored %s data from filesystem at path %s", dataType, configPath)
-	fetcher, err := file_fetcher.NewFileFetcher(configPath)
+	responsesDirectory := "stored_responses"
+	if dataType == config.FloorsDataType {
+		responsesDirectory = "stored_floors"
+	}
+	fetcher, err := file_fetcher.NewResponsesFileFetcher(configPath, responsesDirectory)
 	if err != nil {
 		logger.Fatalf("Failed to create a %s FileFetcher: %v", dataType, err)
 	}
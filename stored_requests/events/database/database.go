@@ -27,6 +27,7 @@ var storedDataTypeMetricMap = map[config.DataType]metrics.StoredDataType{
 	config.AMPRequestDataType: metrics.AMPDataType,
 	config.AccountDataType:    metrics.AccountDataType,
 	config.ResponseDataType:   metrics.ResponseDataType,
+	config.FloorsDataType:     metrics.FloorsDataType,
 }
 
 type DatabaseEventProducerConfig struct {
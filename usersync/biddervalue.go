@@ -0,0 +1,155 @@
+package usersync
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prebid/prebid-server/v4/config"
+)
+
+// BidderValue describes how valuable a bidder has been for an account in recent live auctions.
+type BidderValue struct {
+	// Revenue is the average bid price returned per auction the bidder was called in.
+	Revenue float64
+	// BidRate is the fraction of auctions the bidder was called in which returned at least one bid.
+	BidRate float64
+}
+
+func (v BidderValue) less(other BidderValue) bool {
+	if v.Revenue != other.Revenue {
+		return v.Revenue < other.Revenue
+	}
+	return v.BidRate < other.BidRate
+}
+
+// BidderValueTracker records the outcomes of bidders in live auctions per account. Outcomes decay
+// exponentially with the configured half life so the ranking follows changes in demand.
+type BidderValueTracker struct {
+	halfLife    time.Duration
+	maxAccounts int
+
+	lock     sync.Mutex
+	accounts map[string]map[string]*bidderOutcomes
+}
+
+type bidderOutcomes struct {
+	requests float64
+	bids     float64
+	revenue  float64
+	updated  time.Time
+}
+
+// NewBidderValueTracker returns an empty tracker, or nil if value ranking is disabled.
+func NewBidderValueTracker(cfg config.UserSyncValueRanking) *BidderValueTracker {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	return &BidderValueTracker{
+		halfLife:    cfg.HalfLife(),
+		maxAccounts: cfg.MaxAccounts,
+		accounts:    make(map[string]map[string]*bidderOutcomes),
+	}
+}
+
+// RecordRequest records that the bidder was called in an auction for the account, and whether it bid.
+func (t *BidderValueTracker) RecordRequest(account, bidder string, hasBids bool) {
+	t.record(time.Now(), account, bidder, func(o *bidderOutcomes) {
+		o.requests++
+		if hasBids {
+			o.bids++
+		}
+	})
+}
+
+// RecordRevenue records the price of a bid returned by the bidder in an auction for the account.
+func (t *BidderValueTracker) RecordRevenue(account, bidder string, price float64) {
+	t.record(time.Now(), account, bidder, func(o *bidderOutcomes) {
+		o.revenue += price
+	})
+}
+
+// Value returns the observed value of the bidder for the account. Bidders which have not been
+// observed have a zero value.
+func (t *BidderValueTracker) Value(account, bidder string) BidderValue {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	outcomes, ok := t.accounts[account][bidder]
+	if !ok || outcomes.requests <= 0 {
+		return BidderValue{}
+	}
+
+	// decay is applied uniformly to all counters, so the ratios don't need to be decayed to now
+	return BidderValue{
+		Revenue: outcomes.revenue / outcomes.requests,
+		BidRate: outcomes.bids / outcomes.requests,
+	}
+}
+
+func (t *BidderValueTracker) record(now time.Time, account, bidder string, update func(o *bidderOutcomes)) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	bidders, ok := t.accounts[account]
+	if !ok {
+		if len(t.accounts) >= t.maxAccounts {
+			return
+		}
+		bidders = make(map[string]*bidderOutcomes)
+		t.accounts[account] = bidders
+	}
+
+	outcomes, ok := bidders[bidder]
+	if !ok {
+		outcomes = &bidderOutcomes{updated: now}
+		bidders[bidder] = outcomes
+	}
+
+	outcomes.decay(now, t.halfLife)
+	update(outcomes)
+}
+
+func (o *bidderOutcomes) decay(now time.Time, halfLife time.Duration) {
+	elapsed := now.Sub(o.updated)
+	if elapsed <= 0 {
+		return
+	}
+
+	factor := math.Exp2(-float64(elapsed) / float64(halfLife))
+	o.requests *= factor
+	o.bids *= factor
+	o.revenue *= factor
+	o.updated = now
+}
+
+// valueShuffler orders bidders from most to least valuable. Among bidders of equal value, the preferred
+// ones come first. Bidders of equal value and preference, such as those which have never been observed,
+// are kept in the random order of the fallback shuffler so new bidders still get a chance to be synced.
+type valueShuffler struct {
+	value     func(bidder string) BidderValue
+	preferred func(bidder string) bool
+	fallback  shuffler
+}
+
+func (s valueShuffler) shuffle(v []string) {
+	s.fallback.shuffle(v)
+
+	values := make(map[string]BidderValue, len(v))
+	preferred := make(map[string]bool, len(v))
+	for _, bidder := range v {
+		values[bidder] = s.value(bidder)
+		if s.preferred != nil {
+			preferred[bidder] = s.preferred(bidder)
+		}
+	}
+
+	sort.SliceStable(v, func(i, j int) bool {
+		if values[v[i]] == values[v[j]] {
+			return preferred[v[i]] && !preferred[v[j]]
+		}
+		return values[v[j]].less(values[v[i]])
+	})
+}
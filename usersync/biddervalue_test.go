@@ -0,0 +1,61 @@
+package usersync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBidderValueTracker(t *testing.T) {
+	assert.Nil(t, NewBidderValueTracker(config.UserSyncValueRanking{Enabled: false}), "disabled")
+	assert.NotNil(t, NewBidderValueTracker(config.UserSyncValueRanking{Enabled: true, HalfLifeMinutes: 60, MaxAccounts: 1}), "enabled")
+}
+
+func TestBidderValueTracker(t *testing.T) {
+	tracker := NewBidderValueTracker(config.UserSyncValueRanking{Enabled: true, HalfLifeMinutes: 60, MaxAccounts: 1})
+
+	tracker.RecordRequest("account1", "bidderA", true)
+	tracker.RecordRevenue("account1", "bidderA", 4)
+	tracker.RecordRequest("account1", "bidderA", false)
+	tracker.RecordRequest("account1", "bidderB", false)
+
+	assert.InDelta(t, 2, tracker.Value("account1", "bidderA").Revenue, 0.01, "bidderA-revenue")
+	assert.InDelta(t, 0.5, tracker.Value("account1", "bidderA").BidRate, 0.01, "bidderA-bid-rate")
+	assert.Equal(t, BidderValue{}, tracker.Value("account1", "bidderB"), "bidderB-no-bids")
+	assert.Equal(t, BidderValue{}, tracker.Value("account1", "bidderC"), "bidderC-unobserved")
+
+	tracker.RecordRequest("account2", "bidderA", true)
+	assert.Equal(t, BidderValue{}, tracker.Value("account2", "bidderA"), "max-accounts")
+}
+
+func TestBidderValueTrackerDecay(t *testing.T) {
+	tracker := NewBidderValueTracker(config.UserSyncValueRanking{Enabled: true, HalfLifeMinutes: 60, MaxAccounts: 1})
+	start := time.Now()
+
+	// a bidder which always bid an hour ago, and never bids now
+	tracker.record(start, "account", "bidder", func(o *bidderOutcomes) { o.requests++; o.bids++ })
+	tracker.record(start.Add(time.Hour), "account", "bidder", func(o *bidderOutcomes) { o.requests++ })
+
+	assert.InDelta(t, 1.0/3.0, tracker.Value("account", "bidder").BidRate, 0.01)
+}
+
+func TestValueShuffler(t *testing.T) {
+	values := map[string]BidderValue{
+		"a": {Revenue: 1, BidRate: 0.5},
+		"b": {Revenue: 2, BidRate: 0.1},
+		"c": {Revenue: 1, BidRate: 0.9},
+	}
+
+	shuffler := valueShuffler{
+		value:    func(bidder string) BidderValue { return values[bidder] },
+		fallback: reverseShuffler{},
+	}
+
+	given := []string{"x", "a", "y", "b", "c"}
+	shuffler.shuffle(given)
+
+	// unobserved bidders keep the order of the fallback shuffler
+	assert.Equal(t, []string{"b", "c", "a", "y", "x"}, given)
+}
@@ -14,8 +14,9 @@ type Chooser interface {
 	Choose(request Request, cookie *Cookie) Result
 }
 
-// NewChooser returns a new instance of the standard chooser implementation.
-func NewChooser(bidderSyncerLookup map[string]Syncer, biddersKnown map[string]struct{}, bidderInfo map[string]config.BidderInfo) Chooser {
+// NewChooser returns a new instance of the standard chooser implementation. Bidders are ranked by their
+// observed value when a bidder value tracker is provided, and shuffled randomly otherwise.
+func NewChooser(bidderSyncerLookup map[string]Syncer, biddersKnown map[string]struct{}, bidderInfo map[string]config.BidderInfo, valueTracker *BidderValueTracker) Chooser {
 	bidders := make([]string, 0, len(bidderSyncerLookup))
 
 	for k := range bidderSyncerLookup {
@@ -29,11 +30,13 @@ func NewChooser(bidderSyncerLookup map[string]Syncer, biddersKnown map[string]st
 		normalizeValidBidderName: openrtb_ext.NormalizeBidderName,
 		biddersKnown:             biddersKnown,
 		bidderInfo:               bidderInfo,
+		valueTracker:             valueTracker,
 	}
 }
 
 // Request specifies a user sync request.
 type Request struct {
+	Account        string
 	Bidders        []string
 	Cooperative    Cooperative
 	Limit          int
@@ -122,6 +125,7 @@ type standardChooser struct {
 	normalizeValidBidderName func(name string) (openrtb_ext.BidderName, bool)
 	biddersKnown             map[string]struct{}
 	bidderInfo               map[string]config.BidderInfo
+	valueTracker             *BidderValueTracker
 }
 
 // Choose randomly selects user syncers which are permitted by the user's privacy settings and
@@ -142,7 +146,7 @@ func (c standardChooser) Choose(request Request, cookie *Cookie) Result {
 	biddersEvaluated := make([]BidderEvaluation, 0)
 	syncersChosen := make([]SyncerChoice, 0)
 
-	bidders := c.bidderChooser.choose(request.Bidders, c.biddersAvailable, request.Cooperative)
+	bidders := c.chooseBidders(request, cookie)
 	for i := 0; i < len(bidders) && (limitDisabled || len(syncersChosen) < request.Limit); i++ {
 		if _, ok := biddersSeen[bidders[i]]; ok {
 			continue
@@ -159,6 +163,78 @@ func (c standardChooser) Choose(request Request, cookie *Cookie) Result {
 	return Result{Status: StatusOK, BiddersEvaluated: biddersEvaluated, SyncersChosen: syncersChosen}
 }
 
+// chooseBidders returns the ordered bidders to consider for syncing. With value ranking, bidders are
+// ordered by their value within each cooperative sync tier, bidders needing a refresh coming before the
+// unsynced bidders of equal value. Otherwise bidders needing a refresh are moved ahead of the randomly
+// ordered bidders.
+func (c standardChooser) chooseBidders(request Request, cookie *Cookie) []string {
+	if c.valueTracker == nil {
+		bidders := c.bidderChooser.choose(request.Bidders, c.biddersAvailable, request.Cooperative)
+		return c.prioritizeRefreshes(bidders, cookie)
+	}
+
+	bidderChooser := standardBidderChooser{
+		shuffler: valueShuffler{
+			value:     func(bidder string) BidderValue { return c.matchAdjustedValue(request.Account, bidder, cookie) },
+			preferred: func(bidder string) bool { return c.needsRefresh(bidder, cookie) },
+			fallback:  randomShuffler{},
+		},
+	}
+	return bidderChooser.choose(request.Bidders, c.biddersAvailable, request.Cooperative)
+}
+
+// matchAdjustedValue returns the observed value of the bidder for the account, or no value when the user
+// is already matched with the bidder by an id outside of its refresh window.
+func (c standardChooser) matchAdjustedValue(account, bidder string, cookie *Cookie) BidderValue {
+	bidderNormalized, exists := c.normalizeValidBidderName(bidder)
+	if !exists {
+		return BidderValue{}
+	}
+
+	value := c.valueTracker.Value(account, bidderNormalized.String())
+
+	syncer, exists := c.bidderSyncerLookup[bidderNormalized.String()]
+	if !exists {
+		return value
+	}
+
+	if cookie.HasFreshSync(syncer.Key(), syncer.UIDPolicy()) {
+		return BidderValue{}
+	}
+	return value
+}
+
+// prioritizeRefreshes moves bidders whose synced ids are within the refresh window of their uid policy
+// ahead of all other bidders, preserving the order from the bidder chooser, so ids are renewed before
+// they expire and the user stops matching.
+func (c standardChooser) prioritizeRefreshes(bidders []string, cookie *Cookie) []string {
+	refreshes := make([]string, 0)
+	others := make([]string, 0, len(bidders))
+
+	for _, bidder := range bidders {
+		if c.needsRefresh(bidder, cookie) {
+			refreshes = append(refreshes, bidder)
+		} else {
+			others = append(others, bidder)
+		}
+	}
+
+	if len(refreshes) == 0 {
+		return bidders
+	}
+	return append(refreshes, others...)
+}
+
+func (c standardChooser) needsRefresh(bidder string, cookie *Cookie) bool {
+	bidderNormalized, exists := c.normalizeValidBidderName(bidder)
+	if !exists {
+		return false
+	}
+
+	syncer, exists := c.bidderSyncerLookup[bidderNormalized.String()]
+	return exists && cookie.NeedsRefresh(syncer.Key(), syncer.UIDPolicy())
+}
+
 func (c standardChooser) evaluate(bidder string, syncersSeen map[string]struct{}, syncTypeFilter SyncTypeFilter, privacy Privacy, cookie *Cookie, GPPSID string) (Syncer, BidderEvaluation) {
 	bidderNormalized, exists := c.normalizeValidBidderName(bidder)
 	if !exists {
@@ -184,7 +260,7 @@ func (c standardChooser) evaluate(bidder string, syncersSeen map[string]struct{}
 		return nil, BidderEvaluation{Status: StatusRejectedByFilter, Bidder: bidder, SyncerKey: syncer.Key()}
 	}
 
-	if cookie.HasLiveSync(syncer.Key()) {
+	if cookie.HasFreshSync(syncer.Key(), syncer.UIDPolicy()) {
 		return nil, BidderEvaluation{Status: StatusAlreadySynced, Bidder: bidder, SyncerKey: syncer.Key()}
 	}
 
@@ -43,7 +43,7 @@ func TestNewChooser(t *testing.T) {
 	}
 
 	for _, test := range testCases {
-		chooser, _ := NewChooser(test.bidderSyncerLookup, make(map[string]struct{}), test.bidderInfo).(standardChooser)
+		chooser, _ := NewChooser(test.bidderSyncerLookup, make(map[string]struct{}), test.bidderInfo, nil).(standardChooser)
 		assert.ElementsMatch(t, test.expectedBiddersAvailable, chooser.biddersAvailable, test.description)
 	}
 }
@@ -451,6 +451,145 @@ func TestChooserChoose(t *testing.T) {
 	}
 }
 
+func TestChooserChooseRefreshes(t *testing.T) {
+	policy := UIDPolicy{TTL: 7 * 24 * time.Hour, RefreshWindow: 24 * time.Hour}
+	fakeSyncerA := fakeSyncer{key: "keyA", supportsIFrame: true, uidPolicy: policy}
+	fakeSyncerB := fakeSyncer{key: "keyB", supportsIFrame: true, uidPolicy: policy}
+	fakeSyncerC := fakeSyncer{key: "keyC", supportsIFrame: true}
+	bidderSyncerLookup := map[string]Syncer{"a": fakeSyncerA, "b": fakeSyncerB, "c": fakeSyncerC}
+
+	normalizedBidderNamesLookup := func(name string) (openrtb_ext.BidderName, bool) {
+		return openrtb_ext.BidderName(name), true
+	}
+
+	cookie := Cookie{uids: map[string]UIDEntry{
+		"keyA": {UID: "1", Expires: time.Now().Add(3 * 24 * time.Hour)},
+		"keyB": {UID: "2", Expires: time.Now().Add(12 * time.Hour)},
+	}}
+
+	request := Request{
+		Bidders: []string{"a", "b", "c"},
+		Privacy: &fakePrivacy{gdprAllowsHostCookie: true, gdprAllowsBidderSync: true, ccpaAllowsBidderSync: true, activityAllowUserSync: true},
+		SyncTypeFilter: SyncTypeFilter{
+			IFrame:   NewUniformBidderFilter(BidderFilterModeInclude),
+			Redirect: NewUniformBidderFilter(BidderFilterModeExclude),
+		},
+		Limit: 1,
+	}
+
+	mockBidderChooser := &mockBidderChooser{}
+	mockBidderChooser.
+		On("choose", request.Bidders, []string{}, request.Cooperative).
+		Return([]string{"a", "c", "b"})
+
+	chooser := standardChooser{
+		bidderSyncerLookup:       bidderSyncerLookup,
+		biddersAvailable:         []string{},
+		bidderChooser:            mockBidderChooser,
+		normalizeValidBidderName: normalizedBidderNamesLookup,
+		biddersKnown:             map[string]struct{}{},
+		bidderInfo:               map[string]config.BidderInfo{},
+	}
+
+	result := chooser.Choose(request, &cookie)
+
+	expected := Result{
+		Status:           StatusOK,
+		BiddersEvaluated: []BidderEvaluation{{Bidder: "b", SyncerKey: "keyB", Status: StatusOK}},
+		SyncersChosen:    []SyncerChoice{{Bidder: "b", Syncer: fakeSyncerB}},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestChooserChooseByValue(t *testing.T) {
+	policy := UIDPolicy{TTL: 7 * 24 * time.Hour, RefreshWindow: 24 * time.Hour}
+	bidderSyncerLookup := map[string]Syncer{
+		"a": fakeSyncer{key: "keyA", supportsIFrame: true},
+		"b": fakeSyncer{key: "keyB", supportsIFrame: true, uidPolicy: policy},
+		"c": fakeSyncer{key: "keyC", supportsIFrame: true},
+		"d": fakeSyncer{key: "keyD", supportsIFrame: true},
+		"e": fakeSyncer{key: "keyE", supportsIFrame: true, uidPolicy: policy},
+		"f": fakeSyncer{key: "keyF", supportsIFrame: true, uidPolicy: policy},
+	}
+
+	normalizedBidderNamesLookup := func(name string) (openrtb_ext.BidderName, bool) {
+		return openrtb_ext.BidderName(name), true
+	}
+
+	tracker := NewBidderValueTracker(config.UserSyncValueRanking{Enabled: true, HalfLifeMinutes: 60, MaxAccounts: 10})
+	// outcomes set directly so that b and c have exactly the same value
+	tracker.accounts["account"] = make(map[string]*bidderOutcomes)
+	for bidder, revenue := range map[string]float64{"a": 1, "b": 2, "c": 2, "d": 10, "e": 5, "f": 3} {
+		tracker.accounts["account"][bidder] = &bidderOutcomes{requests: 1, bids: 1, revenue: revenue, updated: time.Now()}
+	}
+
+	testCases := []struct {
+		name            string
+		givenUIDs       map[string]UIDEntry
+		expectedBidders []string
+	}{
+		{
+			name: "refresh-ranked-before-unsynced-of-equal-value",
+			givenUIDs: map[string]UIDEntry{
+				"keyB": {UID: "2", Expires: time.Now().Add(12 * time.Hour)},
+			},
+			expectedBidders: []string{"e", "f", "b", "c", "a"},
+		},
+		{
+			name: "refresh-ranked-by-value",
+			givenUIDs: map[string]UIDEntry{
+				"keyB": {UID: "2", Expires: time.Now().Add(12 * time.Hour)},
+				"keyE": {UID: "5", Expires: time.Now().Add(12 * time.Hour)},
+			},
+			expectedBidders: []string{"e", "f", "b", "c", "a"},
+		},
+		{
+			name: "fresh-sync-not-chosen",
+			givenUIDs: map[string]UIDEntry{
+				"keyB": {UID: "2", Expires: time.Now().Add(12 * time.Hour)},
+				"keyE": {UID: "5", Expires: time.Now().Add(5 * 24 * time.Hour)},
+			},
+			expectedBidders: []string{"f", "b", "c", "a", "d"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cookie := Cookie{uids: test.givenUIDs}
+
+			chooser := standardChooser{
+				bidderSyncerLookup:       bidderSyncerLookup,
+				biddersAvailable:         []string{"d"},
+				normalizeValidBidderName: normalizedBidderNamesLookup,
+				biddersKnown:             map[string]struct{}{},
+				bidderInfo:               map[string]config.BidderInfo{},
+				valueTracker:             tracker,
+			}
+
+			request := Request{
+				Account:     "account",
+				Bidders:     []string{"a", "b", "c", "e", "f"},
+				Cooperative: Cooperative{Enabled: true},
+				Privacy:     &fakePrivacy{gdprAllowsHostCookie: true, gdprAllowsBidderSync: true, ccpaAllowsBidderSync: true, activityAllowUserSync: true},
+				SyncTypeFilter: SyncTypeFilter{
+					IFrame:   NewUniformBidderFilter(BidderFilterModeInclude),
+					Redirect: NewUniformBidderFilter(BidderFilterModeExclude),
+				},
+				Limit: 5,
+			}
+
+			result := chooser.Choose(request, &cookie)
+
+			// the more valuable cooperative bidder d is ranked after all requested bidders
+			actualBidders := make([]string, 0, len(result.SyncersChosen))
+			for _, choice := range result.SyncersChosen {
+				actualBidders = append(actualBidders, choice.Bidder)
+			}
+			assert.Equal(t, test.expectedBidders, actualBidders)
+		})
+	}
+}
+
 func TestChooserEvaluate(t *testing.T) {
 	fakeSyncerA := fakeSyncer{key: "keyA", supportsIFrame: true}
 	fakeSyncerB := fakeSyncer{key: "keyB", supportsIFrame: false}
@@ -696,7 +835,7 @@ func TestChooserEvaluate(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
-			chooser, _ := NewChooser(bidderSyncerLookup, biddersKnown, test.givenBidderInfo).(standardChooser)
+			chooser, _ := NewChooser(bidderSyncerLookup, biddersKnown, test.givenBidderInfo, nil).(standardChooser)
 			chooser.normalizeValidBidderName = test.normalizedBidderNamesLookup
 			sync, evaluation := chooser.evaluate(test.givenBidder, test.givenSyncersSeen, test.givenSyncTypeFilter, &test.givenPrivacy, &test.givenCookie, test.givenGPPSID)
 
@@ -721,6 +860,7 @@ type fakeSyncer struct {
 	supportsIFrame   bool
 	supportsRedirect bool
 	formatOverride   string
+	uidPolicy        UIDPolicy
 }
 
 func (s fakeSyncer) Key() string {
@@ -747,6 +887,10 @@ func (fakeSyncer) GetSync([]SyncType, macros.UserSyncPrivacy) (Sync, error) {
 	return Sync{}, nil
 }
 
+func (s fakeSyncer) UIDPolicy() UIDPolicy {
+	return s.uidPolicy
+}
+
 type fakePrivacy struct {
 	gdprAllowsHostCookie  bool
 	gdprAllowsBidderSync  bool
@@ -0,0 +1,178 @@
+package usersync
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+// The compact uids cookie format is a versioned binary encoding of the cookie. Encoded values start
+// with compactPrefix, which is outside of the base64 url alphabet used by the legacy format, followed
+// by the base64 url encoding of:
+//
+//	header byte: format version in the low bits, compactFlagDeflate if the body is deflated
+//	body:        flags byte, uvarint entry count, then per entry:
+//	               uvarint key ref (dictionary index + 1, or 0 followed by an inline length prefixed key)
+//	               length prefixed uid
+//	               varint expiration in epoch seconds (0 for no expiration)
+const compactPrefix = "~"
+
+const (
+	compactVersion1    byte = 1
+	compactVersionMask byte = 0x7f
+	compactFlagDeflate byte = 0x80
+)
+
+const compactFlagOptOut byte = 1
+
+// maxCompactBodySize bounds the decompressed body to guard against decompression bombs.
+const maxCompactBodySize = 64 * 1024
+
+var compactKeyIndexV1 = buildCompactKeyIndex(compactKeysV1)
+
+var errCompactMalformed = errors.New("malformed compact cookie")
+
+func buildCompactKeyIndex(keys []string) map[string]uint64 {
+	index := make(map[string]uint64, len(keys))
+	for i, key := range keys {
+		index[key] = uint64(i)
+	}
+	return index
+}
+
+func marshalCompact(cookie *Cookie) []byte {
+	buf := make([]byte, 0, 64)
+
+	var flags byte
+	if cookie.optOut {
+		flags |= compactFlagOptOut
+	}
+	buf = append(buf, flags)
+	buf = binary.AppendUvarint(buf, uint64(len(cookie.uids)))
+
+	for key, entry := range cookie.uids {
+		if i, ok := compactKeyIndexV1[key]; ok {
+			buf = binary.AppendUvarint(buf, i+1)
+		} else {
+			buf = binary.AppendUvarint(buf, 0)
+			buf = appendCompactString(buf, key)
+		}
+		buf = appendCompactString(buf, entry.UID)
+
+		var expires int64
+		if !entry.Expires.IsZero() {
+			expires = entry.Expires.Unix()
+		}
+		buf = binary.AppendVarint(buf, expires)
+	}
+
+	return buf
+}
+
+func appendCompactString(buf []byte, value string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+func unmarshalCompact(data []byte) (*Cookie, error) {
+	r := bytes.NewReader(data)
+
+	flags, err := r.ReadByte()
+	if err != nil {
+		return nil, errCompactMalformed
+	}
+
+	count, err := binary.ReadUvarint(r)
+	if err != nil || count > uint64(r.Len()) {
+		return nil, errCompactMalformed
+	}
+
+	cookie := NewCookie()
+	cookie.optOut = flags&compactFlagOptOut != 0
+
+	for i := uint64(0); i < count; i++ {
+		keyRef, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errCompactMalformed
+		}
+
+		var key string
+		if keyRef == 0 {
+			if key, err = readCompactString(r); err != nil {
+				return nil, err
+			}
+		} else if keyRef <= uint64(len(compactKeysV1)) {
+			key = compactKeysV1[keyRef-1]
+		} else {
+			return nil, errCompactMalformed
+		}
+
+		uid, err := readCompactString(r)
+		if err != nil {
+			return nil, err
+		}
+
+		expires, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, errCompactMalformed
+		}
+
+		// Audience Network Handling
+		if checkAudienceNetwork(key, uid) {
+			continue
+		}
+
+		entry := UIDEntry{UID: uid}
+		if expires != 0 {
+			entry.Expires = time.Unix(expires, 0).UTC()
+		}
+		cookie.uids[key] = entry
+	}
+
+	if cookie.optOut {
+		cookie.uids = make(map[string]UIDEntry)
+	}
+
+	return cookie, nil
+}
+
+func readCompactString(r *bytes.Reader) (string, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil || length > uint64(r.Len()) {
+		return "", errCompactMalformed
+	}
+	value := make([]byte, length)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return "", errCompactMalformed
+	}
+	return string(value), nil
+}
+
+func deflateCompact(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func inflateCompact(data []byte) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+
+	body, err := io.ReadAll(io.LimitReader(r, maxCompactBodySize+1))
+	if err != nil || len(body) > maxCompactBodySize {
+		return nil, errCompactMalformed
+	}
+	return body, nil
+}
@@ -0,0 +1,233 @@
+package usersync
+
+// compactKeysV1 is the syncer key dictionary of version 1 of the compact uids cookie encoding. Keys
+// found in the dictionary are written as their index rather than in full. The order of this list
+// is part of the cookie format and must never change; new keys require a new format version and
+// are written inline until then.
+var compactKeysV1 = []string{
+	"33across",
+	"360playvid",
+	"Beintoo",
+	"aax",
+	"acuityads",
+	"adagio",
+	"adastra",
+	"adf",
+	"adform",
+	"adipolo",
+	"adkernel",
+	"adkernelAdn",
+	"adman",
+	"admatic",
+	"admixer",
+	"adnimation",
+	"adnxs",
+	"adot",
+	"adpone",
+	"adport",
+	"adprime",
+	"adquery",
+	"ads_interactive",
+	"adsinteractive",
+	"adtarget",
+	"adtelligent",
+	"adtonos",
+	"aduptech",
+	"advangelists",
+	"adverxo",
+	"adxcg",
+	"adyoulike",
+	"aidem",
+	"aja",
+	"alchemyx",
+	"alkimi",
+	"alliance_gravity",
+	"amx",
+	"apacdex",
+	"apester",
+	"appStockSSP",
+	"aso",
+	"audienceNetwork",
+	"avocet",
+	"axis",
+	"axonix",
+	"bcmint",
+	"beachfront",
+	"bematterfull",
+	"between",
+	"beyondmedia",
+	"bidfuse",
+	"bidgency",
+	"bidmatic",
+	"bidmyadz",
+	"bidsmind",
+	"bidtheatre",
+	"bliink",
+	"blis",
+	"blue",
+	"bmtm",
+	"boldwin",
+	"bwx",
+	"cadent_aperture_mx",
+	"ccx",
+	"colossus",
+	"compass",
+	"connatix",
+	"connectad",
+	"connektai",
+	"consumable",
+	"contxtful",
+	"conversant",
+	"copper6",
+	"copper6ssp",
+	"cpmstar",
+	"criteo",
+	"cwire",
+	"datablocks",
+	"deepintent",
+	"dianomi",
+	"dmx",
+	"dpai",
+	"driftpixel",
+	"e_volution",
+	"emtv",
+	"emx_digital",
+	"eplanning",
+	"epsilon",
+	"evtech",
+	"exco",
+	"feedad",
+	"freewheel-ssp",
+	"freewheelssp",
+	"frvradn",
+	"fwssp",
+	"gamma",
+	"gamoshi",
+	"globalsun",
+	"grid",
+	"gumgum",
+	"harrenmedia",
+	"imds",
+	"impactify",
+	"improvedigital",
+	"indicue",
+	"inmobi",
+	"insticator",
+	"invibes",
+	"iqx",
+	"iqzone",
+	"ix",
+	"janet",
+	"jdpmedia",
+	"jixie",
+	"kargo",
+	"kiviads",
+	"krushmedia",
+	"kuantyx",
+	"kueezrtb",
+	"lemmadigital",
+	"lm_kiviads",
+	"lockerdome",
+	"logan",
+	"logicad",
+	"lunamedia",
+	"markapp",
+	"marsmedia",
+	"mediago",
+	"medianet",
+	"mediasquare",
+	"metax",
+	"mgid",
+	"mgidX",
+	"minutemedia",
+	"missena",
+	"mobilefuse",
+	"mycodemedia",
+	"nativo",
+	"nextmillennium",
+	"nexx360",
+	"nobid",
+	"ogury",
+	"omnidex",
+	"onetag",
+	"openweb",
+	"openx",
+	"operaads",
+	"optidigital",
+	"oraki",
+	"orbidder",
+	"outbrain",
+	"ownadx",
+	"pgam",
+	"pgamssp",
+	"playdigo",
+	"programmaticX",
+	"progx",
+	"pubmatic",
+	"pubrise",
+	"pulsepoint",
+	"pwbid",
+	"qt",
+	"quantumdex",
+	"rediads",
+	"richaudience",
+	"rise",
+	"robustApps",
+	"rocketlab",
+	"rtbhouse",
+	"rubicon",
+	"sa_lunamedia",
+	"screencore",
+	"seedingAlliance",
+	"seedtag",
+	"sharethrough",
+	"smaato",
+	"smartadserver",
+	"smarthub",
+	"smartrtb",
+	"smartyads",
+	"smilewanted",
+	"smoot",
+	"sonobi",
+	"sovrn",
+	"sparteo",
+	"sspBC",
+	"streamkey",
+	"stroeerCore",
+	"suntContent",
+	"taboola",
+	"tagoras",
+	"tappx",
+	"telaria",
+	"theadx",
+	"thetradedesk",
+	"tpmn",
+	"tredio",
+	"triplelift",
+	"triplelift_native",
+	"trustedstack",
+	"trustx",
+	"ucfunnel",
+	"undertone",
+	"unruly",
+	"valueimpression",
+	"vidazoo",
+	"videobyte",
+	"vidoomy",
+	"viewdeos",
+	"visiblemeasures",
+	"visx",
+	"vox",
+	"vrtcal",
+	"xapads",
+	"xeworks",
+	"yahooAds",
+	"yahooAdvertising",
+	"yahoossp",
+	"yandex",
+	"yieldlab",
+	"yieldmo",
+	"yieldone",
+	"zeroclickfraud",
+	"zeta_global_ssp",
+}
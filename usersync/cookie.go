@@ -12,9 +12,13 @@ import (
 
 const uidCookieName = "uids"
 
+// uidPartitionedCookieName is the name of the CHIPS partitioned copy of the uids cookie, which is
+// available in browsers that restrict third party cookies.
+const uidPartitionedCookieName = "uids_p"
+
 // uidTTL is the default amount of time a uid stored within a cookie is considered valid. This is
 // separate from the cookie ttl.
-const uidTTL = 14 * 24 * time.Hour
+const uidTTL = config.DefaultUIDTTLHours * time.Hour
 
 // Cookie is the cookie used in Prebid Server.
 //
@@ -40,20 +44,55 @@ func NewCookie() *Cookie {
 	}
 }
 
-// ReadCookie reads the cookie from the request
+// ReadCookie reads the cookie from the request, merging the unpartitioned and partitioned copies of
+// the uids cookie if both are present.
 func ReadCookie(r *http.Request, decoder Decoder, host *config.HostCookie) *Cookie {
 	if hostOptOutCookie := checkHostCookieOptOut(r, host); hostOptOutCookie != nil {
 		return hostOptOutCookie
 	}
 
-	// Read cookie from request
-	cookieFromRequest, err := r.Cookie(uidCookieName)
-	if err != nil {
+	// Read cookies from request
+	unpartitioned, unpartitionedErr := r.Cookie(uidCookieName)
+	partitioned, partitionedErr := r.Cookie(uidPartitionedCookieName)
+
+	switch {
+	case unpartitionedErr == nil && partitionedErr == nil:
+		return mergeCookies(decoder.Decode(unpartitioned.Value), decoder.Decode(partitioned.Value))
+	case unpartitionedErr == nil:
+		return decoder.Decode(unpartitioned.Value)
+	case partitionedErr == nil:
+		return decoder.Decode(partitioned.Value)
+	default:
 		return NewCookie()
 	}
-	decodedCookie := decoder.Decode(cookieFromRequest.Value)
+}
+
+// ReadCookieJars reports whether the request carries the unpartitioned and the partitioned copies of
+// the uids cookie.
+func ReadCookieJars(r *http.Request) (unpartitioned bool, partitioned bool) {
+	_, unpartitionedErr := r.Cookie(uidCookieName)
+	_, partitionedErr := r.Cookie(uidPartitionedCookieName)
+	return unpartitionedErr == nil, partitionedErr == nil
+}
+
+// mergeCookies combines two copies of the uids cookie. An opt out in either copy wins, otherwise the
+// uid with the latest expiration is kept for each syncer key.
+func mergeCookies(a, b *Cookie) *Cookie {
+	if !a.AllowSyncs() || !b.AllowSyncs() {
+		optOut := NewCookie()
+		optOut.SetOptOut(true)
+		return optOut
+	}
 
-	return decodedCookie
+	merged := NewCookie()
+	for _, cookie := range []*Cookie{a, b} {
+		for key, entry := range cookie.uids {
+			if existing, ok := merged.uids[key]; !ok || existing.Expires.Before(entry.Expires) {
+				merged.uids[key] = entry
+			}
+		}
+	}
+	return merged
 }
 
 // PrepareCookieForWrite ejects UIDs as long as the cookie is too full
@@ -112,8 +151,34 @@ func WriteCookie(w http.ResponseWriter, encodedCookie string, cfg *config.HostCo
 	w.Header().Add("Set-Cookie", httpCookie.String())
 }
 
+// WritePartitionedCookie sets the prepared cookie onto the header as a CHIPS partitioned cookie, which
+// browsers keep in a separate cookie jar per top level site. Partitioned cookies must be secure.
+func WritePartitionedCookie(w http.ResponseWriter, encodedCookie string, cfg *config.HostCookie) {
+	httpCookie := &http.Cookie{
+		Name:        uidPartitionedCookieName,
+		Value:       encodedCookie,
+		Expires:     time.Now().Add(cfg.TTLDuration()),
+		Path:        "/",
+		Secure:      true,
+		SameSite:    http.SameSiteNoneMode,
+		Partitioned: true,
+	}
+
+	if cfg.Domain != "" {
+		httpCookie.Domain = cfg.Domain
+	}
+
+	w.Header().Add("Set-Cookie", httpCookie.String())
+}
+
 // Sync tries to set the UID for some syncer key. It returns an error if the set didn't happen.
 func (cookie *Cookie) Sync(key string, uid string) error {
+	return cookie.SyncWithPolicy(key, uid, UIDPolicy{})
+}
+
+// SyncWithPolicy tries to set the UID for some syncer key, expiring it according to the syncer's
+// uid policy. It returns an error if the set didn't happen.
+func (cookie *Cookie) SyncWithPolicy(key string, uid string, policy UIDPolicy) error {
 	if !cookie.AllowSyncs() {
 		return errors.New("the user has opted out of prebid server cookie syncs")
 	}
@@ -122,10 +187,15 @@ func (cookie *Cookie) Sync(key string, uid string) error {
 		return errors.New("audienceNetwork uses a UID of 0 as \"not yet recognized\"")
 	}
 
+	ttl := policy.TTL
+	if ttl <= 0 {
+		ttl = uidTTL
+	}
+
 	// Sync
 	cookie.uids[key] = UIDEntry{
 		UID:     uid,
-		Expires: time.Now().Add(uidTTL),
+		Expires: time.Now().Add(ttl),
 	}
 
 	return nil
@@ -134,12 +204,22 @@ func (cookie *Cookie) Sync(key string, uid string) error {
 // SyncHostCookie syncs the request cookie with the host cookie
 func SyncHostCookie(r *http.Request, requestCookie *Cookie, host *config.HostCookie) {
 	if uid, _, _ := requestCookie.GetUID(host.Family); uid == "" && host.CookieName != "" {
-		if hostCookie, err := r.Cookie(host.CookieName); err == nil {
+		if hostCookie, err := readHostCookie(r, host); err == nil {
 			requestCookie.Sync(host.Family, hostCookie.Value)
 		}
 	}
 }
 
+// readHostCookie reads the host cookie from the request, falling back to the partitioned copy of the
+// host cookie if one is configured.
+func readHostCookie(r *http.Request, host *config.HostCookie) (*http.Cookie, error) {
+	hostCookie, err := r.Cookie(host.CookieName)
+	if err != nil && host.Partitioned.HostCookieName != "" {
+		return r.Cookie(host.Partitioned.HostCookieName)
+	}
+	return hostCookie, err
+}
+
 func checkHostCookieOptOut(r *http.Request, host *config.HostCookie) *Cookie {
 	if host.OptOutCookie.Name != "" {
 		optOutCookie, err := r.Cookie(host.OptOutCookie.Name)
@@ -199,6 +279,22 @@ func (cookie *Cookie) HasLiveSync(key string) bool {
 	return isLive
 }
 
+// HasFreshSync returns true if we have an active UID for the given syncer key which is not yet
+// within the refresh window of the syncer's uid policy, and false otherwise.
+func (cookie *Cookie) HasFreshSync(key string, policy UIDPolicy) bool {
+	if cookie == nil {
+		return false
+	}
+	entry, ok := cookie.uids[key]
+	return ok && time.Now().Add(policy.RefreshWindow).Before(entry.Expires)
+}
+
+// NeedsRefresh returns true if we have an active UID for the given syncer key which is within the
+// refresh window of the syncer's uid policy, and false otherwise.
+func (cookie *Cookie) NeedsRefresh(key string, policy UIDPolicy) bool {
+	return cookie.HasLiveSync(key) && !cookie.HasFreshSync(key, policy)
+}
+
 // HasAnyLiveSyncs returns true if this cookie has at least one active sync.
 func (cookie *Cookie) HasAnyLiveSyncs() bool {
 	now := time.Now()
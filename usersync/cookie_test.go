@@ -192,6 +192,105 @@ func TestWriteCookie(t *testing.T) {
 	}
 }
 
+func TestReadCookiePartitioned(t *testing.T) {
+	encoder := Base64Encoder{}
+
+	testCases := []struct {
+		name                string
+		givenUnpartitioned  *Cookie
+		givenPartitioned    *Cookie
+		expectedUIDs        map[string]string
+		expectedAllowSyncs  bool
+		expectedJarsPresent [2]bool
+	}{
+		{
+			name:                "none",
+			expectedUIDs:        map[string]string{},
+			expectedAllowSyncs:  true,
+			expectedJarsPresent: [2]bool{false, false},
+		},
+		{
+			name: "partitioned-only",
+			givenPartitioned: &Cookie{
+				uids: map[string]UIDEntry{"adnxs": newTempId("partitioned", 10)},
+			},
+			expectedUIDs:        map[string]string{"adnxs": "partitioned"},
+			expectedAllowSyncs:  true,
+			expectedJarsPresent: [2]bool{false, true},
+		},
+		{
+			name: "both-latest-expiration-wins",
+			givenUnpartitioned: &Cookie{
+				uids: map[string]UIDEntry{
+					"adnxs":   newTempId("unpartitioned", 20),
+					"rubicon": newTempId("unpartitioned", 10),
+				},
+			},
+			givenPartitioned: &Cookie{
+				uids: map[string]UIDEntry{
+					"adnxs":    newTempId("partitioned", 10),
+					"rubicon":  newTempId("partitioned", 20),
+					"appnexus": newTempId("partitioned", 10),
+				},
+			},
+			expectedUIDs:        map[string]string{"adnxs": "unpartitioned", "rubicon": "partitioned", "appnexus": "partitioned"},
+			expectedAllowSyncs:  true,
+			expectedJarsPresent: [2]bool{true, true},
+		},
+		{
+			name: "both-opt-out-wins",
+			givenUnpartitioned: &Cookie{
+				uids: map[string]UIDEntry{"adnxs": newTempId("unpartitioned", 10)},
+			},
+			givenPartitioned: &Cookie{
+				uids:   map[string]UIDEntry{},
+				optOut: true,
+			},
+			expectedUIDs:        map[string]string{},
+			expectedAllowSyncs:  false,
+			expectedJarsPresent: [2]bool{true, true},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "http://www.prebid.com", nil)
+			for name, cookie := range map[string]*Cookie{uidCookieName: test.givenUnpartitioned, uidPartitionedCookieName: test.givenPartitioned} {
+				if cookie == nil {
+					continue
+				}
+				encoded, err := encoder.Encode(cookie)
+				assert.NoError(t, err)
+				r.AddCookie(&http.Cookie{Name: name, Value: encoded})
+			}
+
+			actualCookie := ReadCookie(r, Base64Decoder{}, &config.HostCookie{})
+
+			actualUIDs := make(map[string]string, len(actualCookie.uids))
+			for key, entry := range actualCookie.uids {
+				actualUIDs[key] = entry.UID
+			}
+			assert.Equal(t, test.expectedUIDs, actualUIDs, "uids")
+			assert.Equal(t, test.expectedAllowSyncs, actualCookie.AllowSyncs(), "allow-syncs")
+
+			unpartitioned, partitioned := ReadCookieJars(r)
+			assert.Equal(t, test.expectedJarsPresent, [2]bool{unpartitioned, partitioned}, "jars")
+		})
+	}
+}
+
+func TestWritePartitionedCookie(t *testing.T) {
+	w := httptest.NewRecorder()
+	WritePartitionedCookie(w, "encoded", &config.HostCookie{Domain: "prebid.com", TTL: 90})
+
+	writtenCookie := w.Header().Get("Set-Cookie")
+	assert.Contains(t, writtenCookie, "uids_p=encoded")
+	assert.Contains(t, writtenCookie, "Domain=prebid.com")
+	assert.Contains(t, writtenCookie, "Secure")
+	assert.Contains(t, writtenCookie, "SameSite=None")
+	assert.Contains(t, writtenCookie, "Partitioned")
+}
+
 func TestSync(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -253,6 +352,84 @@ func TestSync(t *testing.T) {
 	}
 }
 
+func TestSyncWithPolicy(t *testing.T) {
+	testCases := []struct {
+		name            string
+		givenPolicy     UIDPolicy
+		expectedExpires time.Time
+	}{
+		{
+			name:            "default-ttl",
+			givenPolicy:     UIDPolicy{},
+			expectedExpires: time.Now().Add(uidTTL),
+		},
+		{
+			name:            "policy-ttl",
+			givenPolicy:     UIDPolicy{TTL: 7 * 24 * time.Hour},
+			expectedExpires: time.Now().Add(7 * 24 * time.Hour),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cookie := NewCookie()
+			err := cookie.SyncWithPolicy("adnxs", "123", test.givenPolicy)
+			assert.NoError(t, err)
+			assert.WithinDuration(t, test.expectedExpires, cookie.uids["adnxs"].Expires, time.Minute)
+		})
+	}
+}
+
+func TestHasFreshSync(t *testing.T) {
+	now := time.Now()
+	policy := UIDPolicy{TTL: 7 * 24 * time.Hour, RefreshWindow: 24 * time.Hour}
+
+	testCases := []struct {
+		name                 string
+		givenCookie          *Cookie
+		expectedFresh        bool
+		expectedNeedsRefresh bool
+	}{
+		{
+			name:                 "nil-cookie",
+			givenCookie:          nil,
+			expectedFresh:        false,
+			expectedNeedsRefresh: false,
+		},
+		{
+			name:                 "missing",
+			givenCookie:          &Cookie{uids: map[string]UIDEntry{}},
+			expectedFresh:        false,
+			expectedNeedsRefresh: false,
+		},
+		{
+			name:                 "fresh",
+			givenCookie:          &Cookie{uids: map[string]UIDEntry{"adnxs": {UID: "123", Expires: now.Add(3 * 24 * time.Hour)}}},
+			expectedFresh:        true,
+			expectedNeedsRefresh: false,
+		},
+		{
+			name:                 "within-refresh-window",
+			givenCookie:          &Cookie{uids: map[string]UIDEntry{"adnxs": {UID: "123", Expires: now.Add(12 * time.Hour)}}},
+			expectedFresh:        false,
+			expectedNeedsRefresh: true,
+		},
+		{
+			name:                 "expired",
+			givenCookie:          &Cookie{uids: map[string]UIDEntry{"adnxs": {UID: "123", Expires: now.Add(-time.Hour)}}},
+			expectedFresh:        false,
+			expectedNeedsRefresh: false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedFresh, test.givenCookie.HasFreshSync("adnxs", policy), "fresh")
+			assert.Equal(t, test.expectedNeedsRefresh, test.givenCookie.NeedsRefresh("adnxs", policy), "needs-refresh")
+		})
+	}
+}
+
 func TestGetUIDs(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -622,6 +799,23 @@ func TestSyncHostCookie(t *testing.T) {
 	}
 }
 
+func TestSyncHostCookiePartitioned(t *testing.T) {
+	host := &config.HostCookie{
+		Family:      "syncer",
+		CookieName:  "adnxs",
+		Partitioned: config.HostCookiePartitioned{HostCookieName: "adnxs_p"},
+	}
+
+	r := httptest.NewRequest("POST", "http://www.prebid.com", nil)
+	r.AddCookie(&http.Cookie{Name: "adnxs_p", Value: "partitioned-user-id"})
+
+	cookie := NewCookie()
+	SyncHostCookie(r, cookie, host)
+
+	uid, _, _ := cookie.GetUID("syncer")
+	assert.Equal(t, "partitioned-user-id", uid)
+}
+
 func TestBidderNameGets(t *testing.T) {
 	cookie := newSampleCookie()
 	id, exists, _ := cookie.GetUID("adnxs")
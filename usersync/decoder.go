@@ -2,6 +2,7 @@ package usersync
 
 import (
 	"encoding/base64"
+	"strings"
 
 	"github.com/prebid/prebid-server/v4/util/jsonutil"
 )
@@ -25,3 +26,35 @@ func (d Base64Decoder) Decode(encodedValue string) *Cookie {
 
 	return &cookie
 }
+
+// CompactDecoder decodes cookies written in the compact binary format and transparently falls back
+// to the legacy base64 json format, so the write format may be changed without losing user syncs.
+type CompactDecoder struct{}
+
+func (d CompactDecoder) Decode(encodedValue string) *Cookie {
+	if !strings.HasPrefix(encodedValue, compactPrefix) {
+		return Base64Decoder{}.Decode(encodedValue)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encodedValue[len(compactPrefix):])
+	if err != nil || len(data) == 0 {
+		return NewCookie()
+	}
+
+	header, body := data[0], data[1:]
+	if header&compactVersionMask != compactVersion1 {
+		return NewCookie()
+	}
+
+	if header&compactFlagDeflate != 0 {
+		if body, err = inflateCompact(body); err != nil {
+			return NewCookie()
+		}
+	}
+
+	cookie, err := unmarshalCompact(body)
+	if err != nil {
+		return NewCookie()
+	}
+	return cookie
+}
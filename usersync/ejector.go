@@ -11,6 +11,13 @@ type Ejector interface {
 
 type OldestEjector struct{}
 
+// ValueEjector ejects the uid with the least remaining value, which is the time left until the uid
+// enters the refresh window of its syncer's uid policy. Uids about to be refreshed are worth less than
+// long lived uids which would otherwise need another sync to recover.
+type ValueEjector struct {
+	PoliciesByKey map[string]UIDPolicy
+}
+
 type PriorityBidderEjector struct {
 	PriorityGroups   [][]string
 	SyncersByBidder  map[string]Syncer
@@ -32,6 +39,30 @@ func (o *OldestEjector) Choose(uids map[string]UIDEntry) (string, error) {
 	return oldestElement, nil
 }
 
+// Choose method for value ejector will return the uid with the least remaining value
+func (v *ValueEjector) Choose(uids map[string]UIDEntry) (string, error) {
+	var lowestElement string
+	var lowestValue time.Time = time.Unix(1<<63-62135596801, 999999999) // Max value for time
+
+	for key, value := range uids {
+		refreshAt := value.Expires.Add(-v.PoliciesByKey[key].RefreshWindow)
+		if refreshAt.Before(lowestValue) || (refreshAt.Equal(lowestValue) && key < lowestElement) {
+			lowestElement = key
+			lowestValue = refreshAt
+		}
+	}
+	return lowestElement, nil
+}
+
+// UIDPoliciesByKey returns the uid policy of each syncer keyed by the syncer key.
+func UIDPoliciesByKey(syncersByBidder map[string]Syncer) map[string]UIDPolicy {
+	policies := make(map[string]UIDPolicy, len(syncersByBidder))
+	for _, syncer := range syncersByBidder {
+		policies[syncer.Key()] = syncer.UIDPolicy()
+	}
+	return policies
+}
+
 // Choose method for priority ejector will return the oldest lowest priority element
 func (p *PriorityBidderEjector) Choose(uids map[string]UIDEntry) (string, error) {
 	nonPriorityUids := getNonPriorityUids(uids, p.PriorityGroups, p.SyncersByBidder)
@@ -253,6 +253,69 @@ func TestOldestEjector(t *testing.T) {
 	}
 }
 
+func TestValueEjector(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name          string
+		givenUids     map[string]UIDEntry
+		givenPolicies map[string]UIDPolicy
+		expected      string
+	}{
+		{
+			name: "no-policies-ejects-oldest",
+			givenUids: map[string]UIDEntry{
+				"newestElement": {UID: "123", Expires: now.Add(90 * 24 * time.Hour)},
+				"oldestElement": {UID: "456", Expires: now.Add(24 * time.Hour)},
+			},
+			expected: "oldestElement",
+		},
+		{
+			name: "refresh-window-lowers-value",
+			givenUids: map[string]UIDEntry{
+				"weekly":    {UID: "123", Expires: now.Add(3 * 24 * time.Hour)},
+				"longLived": {UID: "456", Expires: now.Add(2 * 24 * time.Hour)},
+			},
+			givenPolicies: map[string]UIDPolicy{
+				"weekly": {TTL: 7 * 24 * time.Hour, RefreshWindow: 2 * 24 * time.Hour},
+			},
+			expected: "weekly",
+		},
+		{
+			name: "tie-ejects-lowest-key",
+			givenUids: map[string]UIDEntry{
+				"b": {UID: "123", Expires: now},
+				"a": {UID: "456", Expires: now},
+			},
+			expected: "a",
+		},
+		{
+			name:      "no-elements",
+			givenUids: map[string]UIDEntry{},
+			expected:  "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ejector := ValueEjector{PoliciesByKey: test.givenPolicies}
+			element, err := ejector.Choose(test.givenUids)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, element)
+		})
+	}
+}
+
+func TestUIDPoliciesByKey(t *testing.T) {
+	policy := UIDPolicy{TTL: time.Hour, RefreshWindow: time.Minute}
+	syncersByBidder := map[string]Syncer{
+		"bidderA": fakeSyncer{key: "keyA", uidPolicy: policy},
+		"bidderB": fakeSyncer{key: "keyB"},
+	}
+
+	assert.Equal(t, map[string]UIDPolicy{"keyA": policy, "keyB": {}}, UIDPoliciesByKey(syncersByBidder))
+}
+
 func TestGetNonPriorityUids(t *testing.T) {
 	syncersByBidder := map[string]Syncer{
 		"syncerKey1": fakeSyncer{
@@ -3,6 +3,7 @@ package usersync
 import (
 	"encoding/base64"
 
+	"github.com/prebid/prebid-server/v4/config"
 	"github.com/prebid/prebid-server/v4/util/jsonutil"
 )
 
@@ -11,6 +12,14 @@ type Encoder interface {
 	Encode(c *Cookie) (string, error)
 }
 
+// NewEncoder returns the encoder for the uids cookie format configured for the host.
+func NewEncoder(cfg *config.HostCookie) Encoder {
+	if cfg.UIDsEncoding == config.UIDsEncodingCompact {
+		return CompactEncoder{}
+	}
+	return Base64Encoder{}
+}
+
 type Base64Encoder struct{}
 
 func (e Base64Encoder) Encode(c *Cookie) (string, error) {
@@ -22,3 +31,28 @@ func (e Base64Encoder) Encode(c *Cookie) (string, error) {
 
 	return b64, nil
 }
+
+// CompactEncoder encodes the cookie in the versioned compact binary format, which represents known
+// syncer keys as dictionary indices and expirations as epoch seconds, and deflates the result when
+// that makes it smaller.
+type CompactEncoder struct{}
+
+func (e CompactEncoder) Encode(c *Cookie) (string, error) {
+	if c == nil {
+		c = NewCookie()
+	}
+
+	body := marshalCompact(c)
+	header := compactVersion1
+
+	if deflated, err := deflateCompact(body); err == nil && len(deflated) < len(body) {
+		body = deflated
+		header |= compactFlagDeflate
+	}
+
+	data := make([]byte, 0, len(body)+1)
+	data = append(data, header)
+	data = append(data, body...)
+
+	return compactPrefix + base64.RawURLEncoding.EncodeToString(data), nil
+}
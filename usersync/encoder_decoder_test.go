@@ -1,10 +1,15 @@
 package usersync
 
 import (
+	"encoding/base64"
+	"fmt"
+	"math/rand"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prebid/prebid-server/v4/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -147,3 +152,138 @@ func TestDecoder(t *testing.T) {
 		})
 	}
 }
+
+func TestNewEncoder(t *testing.T) {
+	assert.IsType(t, Base64Encoder{}, NewEncoder(&config.HostCookie{}), "default")
+	assert.IsType(t, Base64Encoder{}, NewEncoder(&config.HostCookie{UIDsEncoding: config.UIDsEncodingBase64}), "base64")
+	assert.IsType(t, CompactEncoder{}, NewEncoder(&config.HostCookie{UIDsEncoding: config.UIDsEncodingCompact}), "compact")
+}
+
+func TestCompactEncoderDecoder(t *testing.T) {
+	encoder := CompactEncoder{}
+	decoder := CompactDecoder{}
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		givenCookie    *Cookie
+		expectedCookie *Cookie
+	}{
+		{
+			name: "dictionary-and-inline-keys",
+			givenCookie: &Cookie{
+				uids: map[string]UIDEntry{
+					"adnxs":         {UID: "UID1", Expires: expires.Add(500 * time.Millisecond)},
+					"unknownSyncer": {UID: "UID2", Expires: expires},
+					"rubicon":       {UID: "UID3"},
+				},
+			},
+			expectedCookie: &Cookie{
+				uids: map[string]UIDEntry{
+					"adnxs":         {UID: "UID1", Expires: expires},
+					"unknownSyncer": {UID: "UID2", Expires: expires},
+					"rubicon":       {UID: "UID3"},
+				},
+			},
+		},
+		{
+			name:           "opt-out",
+			givenCookie:    &Cookie{uids: map[string]UIDEntry{}, optOut: true},
+			expectedCookie: &Cookie{uids: map[string]UIDEntry{}, optOut: true},
+		},
+		{
+			name:           "empty-cookie",
+			givenCookie:    &Cookie{},
+			expectedCookie: NewCookie(),
+		},
+		{
+			name:           "nil-cookie",
+			givenCookie:    nil,
+			expectedCookie: NewCookie(),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			encodedCookie, err := encoder.Encode(test.givenCookie)
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(encodedCookie, compactPrefix))
+
+			decodedCookie := decoder.Decode(encodedCookie)
+			assert.Equal(t, test.expectedCookie, decodedCookie)
+		})
+	}
+}
+
+func TestCompactDecoder(t *testing.T) {
+	decoder := CompactDecoder{}
+
+	testCases := []struct {
+		name               string
+		givenEncodedCookie string
+		expectedCookie     *Cookie
+	}{
+		{
+			name:               "legacy-format",
+			givenEncodedCookie: "eyJ0ZW1wVUlEcyI6eyJhZG54cyI6eyJ1aWQiOiJVSUQiLCJleHBpcmVzIjoiMDAwMS0wMS0wMVQwMDowMDowMFoifX19",
+			expectedCookie: &Cookie{
+				uids: map[string]UIDEntry{
+					"adnxs": {UID: "UID"},
+				},
+			},
+		},
+		{
+			name:               "empty",
+			givenEncodedCookie: "",
+			expectedCookie:     NewCookie(),
+		},
+		{
+			name:               "invalid-base64",
+			givenEncodedCookie: compactPrefix + "!!!",
+			expectedCookie:     NewCookie(),
+		},
+		{
+			name:               "unknown-version",
+			givenEncodedCookie: compactPrefix + base64.RawURLEncoding.EncodeToString([]byte{0x02, 0x00, 0x00}),
+			expectedCookie:     NewCookie(),
+		},
+		{
+			name:               "truncated-body",
+			givenEncodedCookie: compactPrefix + base64.RawURLEncoding.EncodeToString([]byte{compactVersion1, 0x00, 0x01}),
+			expectedCookie:     NewCookie(),
+		},
+		{
+			name:               "dictionary-index-out-of-range",
+			givenEncodedCookie: compactPrefix + base64.RawURLEncoding.EncodeToString([]byte{compactVersion1, 0x00, 0x01, 0xff, 0x7f, 0x00, 0x00}),
+			expectedCookie:     NewCookie(),
+		},
+		{
+			name:               "invalid-deflate",
+			givenEncodedCookie: compactPrefix + base64.RawURLEncoding.EncodeToString([]byte{compactVersion1 | compactFlagDeflate, 0xff, 0xff}),
+			expectedCookie:     NewCookie(),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			decodedCookie := decoder.Decode(test.givenEncodedCookie)
+			assert.Equal(t, test.expectedCookie, decodedCookie)
+		})
+	}
+}
+
+func TestCompactEncoderSize(t *testing.T) {
+	cookie := NewCookie()
+	expires := time.Now().Add(uidTTL)
+	random := rand.New(rand.NewSource(1))
+	for _, key := range compactKeysV1[:20] {
+		cookie.uids[key] = UIDEntry{UID: fmt.Sprintf("%016x", random.Uint64()), Expires: expires}
+	}
+
+	legacy, err := Base64Encoder{}.Encode(cookie)
+	assert.NoError(t, err)
+	compact, err := CompactEncoder{}.Encode(cookie)
+	assert.NoError(t, err)
+
+	assert.Less(t, len(compact)*2, len(legacy), "compact encoding should be less than half the size of the legacy encoding")
+}
@@ -0,0 +1,266 @@
+package usersync
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/stored_requests/backends/db_provider"
+)
+
+// IDStore persists bidder UIDs on the server as an alternative to the uids cookie. IDs are keyed
+// by the first party host ID of the user, which allows far more bidder IDs to be kept for a user
+// than fit within a cookie.
+type IDStore interface {
+	// Get returns the unexpired UIDs stored for the host ID keyed by syncer key.
+	Get(ctx context.Context, hostID string) (map[string]UIDEntry, error)
+
+	// Set stores the UID for the syncer key.
+	Set(ctx context.Context, hostID string, key string, entry UIDEntry) error
+
+	// Delete removes the UID for the syncer key.
+	Delete(ctx context.Context, hostID string, key string) error
+
+	// DeleteAll removes all UIDs stored for the host ID, such as when the user opts out.
+	DeleteAll(ctx context.Context, hostID string) error
+}
+
+// NewIDStore builds the ID store described by the config, or returns nil if the store is disabled.
+func NewIDStore(cfg config.UserSyncIDStore) IDStore {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	switch cfg.Type {
+	case config.UserSyncIDStoreTypeDatabase:
+		provider := db_provider.NewDbProvider(config.UserSyncIDDataType, cfg.Database.ConnectionInfo)
+		return NewDatabaseIDStore(provider, cfg.Database)
+	default:
+		return NewMemoryIDStore(cfg.Memory)
+	}
+}
+
+// HostID returns the first party host ID of the user from the host cookie, or an empty string
+// if the host cookie is not present.
+func HostID(r *http.Request, host *config.HostCookie) string {
+	if host.CookieName == "" {
+		return ""
+	}
+	if hostCookie, err := readHostCookie(r, host); err == nil {
+		return hostCookie.Value
+	}
+	return ""
+}
+
+// LoadStoredUIDs merges the UIDs held in the ID store for the host ID into the cookie. Stored UIDs
+// only fill syncer keys which are missing or expired in the cookie. Cookies of users who opted out
+// are left untouched.
+func LoadStoredUIDs(ctx context.Context, store IDStore, hostID string, cookie *Cookie) error {
+	if store == nil || hostID == "" || !cookie.AllowSyncs() {
+		return nil
+	}
+
+	entries, err := store.Get(ctx, hostID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for key, entry := range entries {
+		if !now.Before(entry.Expires) || checkAudienceNetwork(key, entry.UID) {
+			continue
+		}
+		if existing, ok := cookie.uids[key]; ok && now.Before(existing.Expires) {
+			continue
+		}
+		cookie.uids[key] = entry
+	}
+	return nil
+}
+
+// MemoryIDStore is an in-process IDStore. Its contents are lost on restart and are not shared
+// between Prebid Server instances. The expired IDs of all users are purged periodically, and the
+// least recently written users are evicted once the store holds the maximum number of users.
+type MemoryIDStore struct {
+	lock          sync.RWMutex
+	uids          map[string]*list.Element
+	order         *list.List // Users from the most to the least recently written
+	maxHostIDs    int
+	sweepInterval time.Duration
+	lastSweep     time.Time
+}
+
+// memoryHostIDs holds the UIDs of a user in the order of the MemoryIDStore
+type memoryHostIDs struct {
+	hostID  string
+	entries map[string]UIDEntry
+}
+
+// NewMemoryIDStore returns an empty in-memory ID store bounded by the config.
+func NewMemoryIDStore(cfg config.UserSyncIDStoreMemory) *MemoryIDStore {
+	return &MemoryIDStore{
+		uids:          make(map[string]*list.Element),
+		order:         list.New(),
+		maxHostIDs:    cfg.MaxHostIDs,
+		sweepInterval: time.Duration(cfg.SweepIntervalSeconds) * time.Second,
+		lastSweep:     time.Now(),
+	}
+}
+
+func (s *MemoryIDStore) Get(_ context.Context, hostID string) (map[string]UIDEntry, error) {
+	now := time.Now()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	element, ok := s.uids[hostID]
+	if !ok {
+		return map[string]UIDEntry{}, nil
+	}
+
+	stored := element.Value.(*memoryHostIDs).entries
+	entries := make(map[string]UIDEntry, len(stored))
+	for key, entry := range stored {
+		if now.Before(entry.Expires) {
+			entries[key] = entry
+		}
+	}
+	return entries, nil
+}
+
+func (s *MemoryIDStore) Set(_ context.Context, hostID string, key string, entry UIDEntry) error {
+	now := time.Now()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.sweepInterval > 0 && now.Sub(s.lastSweep) >= s.sweepInterval {
+		s.sweep(now)
+	}
+
+	element, ok := s.uids[hostID]
+	if ok {
+		s.order.MoveToFront(element)
+	} else {
+		element = s.order.PushFront(&memoryHostIDs{hostID: hostID, entries: make(map[string]UIDEntry)})
+		s.uids[hostID] = element
+		if s.maxHostIDs > 0 && s.order.Len() > s.maxHostIDs {
+			s.remove(s.order.Back())
+		}
+	}
+
+	element.Value.(*memoryHostIDs).entries[key] = entry
+	return nil
+}
+
+func (s *MemoryIDStore) Delete(_ context.Context, hostID string, key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if element, ok := s.uids[hostID]; ok {
+		entries := element.Value.(*memoryHostIDs).entries
+		delete(entries, key)
+		if len(entries) == 0 {
+			s.remove(element)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryIDStore) DeleteAll(_ context.Context, hostID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if element, ok := s.uids[hostID]; ok {
+		s.remove(element)
+	}
+	return nil
+}
+
+// sweep purges the expired IDs of all users, and the users left without IDs. The lock must be held.
+func (s *MemoryIDStore) sweep(now time.Time) {
+	for element := s.order.Front(); element != nil; {
+		next := element.Next()
+		entries := element.Value.(*memoryHostIDs).entries
+		for key, entry := range entries {
+			if !now.Before(entry.Expires) {
+				delete(entries, key)
+			}
+		}
+		if len(entries) == 0 {
+			s.remove(element)
+		}
+		element = next
+	}
+	s.lastSweep = now
+}
+
+// remove removes a user from the store. The lock must be held.
+func (s *MemoryIDStore) remove(element *list.Element) {
+	s.order.Remove(element)
+	delete(s.uids, element.Value.(*memoryHostIDs).hostID)
+}
+
+// DatabaseIDStore is an IDStore backed by a SQL database using the configured queries.
+type DatabaseIDStore struct {
+	provider db_provider.DbProvider
+	queries  config.UserSyncIDStoreDatabase
+}
+
+// NewDatabaseIDStore returns an ID store which runs the configured queries against the provider.
+func NewDatabaseIDStore(provider db_provider.DbProvider, queries config.UserSyncIDStoreDatabase) *DatabaseIDStore {
+	return &DatabaseIDStore{
+		provider: provider,
+		queries:  queries,
+	}
+}
+
+func (s *DatabaseIDStore) Get(ctx context.Context, hostID string) (map[string]UIDEntry, error) {
+	rows, err := s.provider.QueryContext(ctx, s.queries.GetQuery, db_provider.QueryParam{Name: "HOST_ID", Value: hostID})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	entries := make(map[string]UIDEntry)
+	for rows.Next() {
+		var key string
+		var entry UIDEntry
+		if err := rows.Scan(&key, &entry.UID, &entry.Expires); err != nil {
+			return nil, err
+		}
+		if now.Before(entry.Expires) {
+			entries[key] = entry
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (s *DatabaseIDStore) Set(ctx context.Context, hostID string, key string, entry UIDEntry) error {
+	_, err := s.provider.ExecContext(ctx, s.queries.SetQuery,
+		db_provider.QueryParam{Name: "HOST_ID", Value: hostID},
+		db_provider.QueryParam{Name: "SYNCER_KEY", Value: key},
+		db_provider.QueryParam{Name: "UID", Value: entry.UID},
+		db_provider.QueryParam{Name: "EXPIRES", Value: entry.Expires})
+	return err
+}
+
+func (s *DatabaseIDStore) Delete(ctx context.Context, hostID string, key string) error {
+	_, err := s.provider.ExecContext(ctx, s.queries.DeleteQuery,
+		db_provider.QueryParam{Name: "HOST_ID", Value: hostID},
+		db_provider.QueryParam{Name: "SYNCER_KEY", Value: key})
+	return err
+}
+
+func (s *DatabaseIDStore) DeleteAll(ctx context.Context, hostID string) error {
+	_, err := s.provider.ExecContext(ctx, s.queries.DeleteAllQuery,
+		db_provider.QueryParam{Name: "HOST_ID", Value: hostID})
+	return err
+}
@@ -0,0 +1,251 @@
+package usersync
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prebid/prebid-server/v4/config"
+	"github.com/prebid/prebid-server/v4/stored_requests/backends/db_provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIDStore(t *testing.T) {
+	assert.Nil(t, NewIDStore(config.UserSyncIDStore{Enabled: false}), "disabled")
+	assert.IsType(t, &MemoryIDStore{}, NewIDStore(config.UserSyncIDStore{Enabled: true, Type: config.UserSyncIDStoreTypeMemory}), "memory")
+}
+
+func TestHostID(t *testing.T) {
+	testCases := []struct {
+		name           string
+		givenCookie    string
+		givenHost      config.HostCookie
+		expectedHostID string
+	}{
+		{
+			name:           "found",
+			givenCookie:    "host=abc",
+			givenHost:      config.HostCookie{CookieName: "host"},
+			expectedHostID: "abc",
+		},
+		{
+			name:           "not-found",
+			givenCookie:    "other=abc",
+			givenHost:      config.HostCookie{CookieName: "host"},
+			expectedHostID: "",
+		},
+		{
+			name:           "not-configured",
+			givenCookie:    "host=abc",
+			givenHost:      config.HostCookie{},
+			expectedHostID: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://www.prebid.com", nil)
+			r.Header.Set("Cookie", test.givenCookie)
+			assert.Equal(t, test.expectedHostID, HostID(r, &test.givenHost))
+		})
+	}
+}
+
+func TestLoadStoredUIDs(t *testing.T) {
+	now := time.Now()
+	live := now.Add(time.Hour)
+	expired := now.Add(-time.Hour)
+
+	testCases := []struct {
+		name           string
+		givenCookie    *Cookie
+		givenStored    map[string]UIDEntry
+		givenHostID    string
+		expectedCookie map[string]UIDEntry
+	}{
+		{
+			name:           "adds-missing",
+			givenCookie:    &Cookie{uids: map[string]UIDEntry{"a": {UID: "cookieA", Expires: live}}},
+			givenStored:    map[string]UIDEntry{"b": {UID: "storeB", Expires: live}},
+			givenHostID:    "host",
+			expectedCookie: map[string]UIDEntry{"a": {UID: "cookieA", Expires: live}, "b": {UID: "storeB", Expires: live}},
+		},
+		{
+			name:           "cookie-wins-when-live",
+			givenCookie:    &Cookie{uids: map[string]UIDEntry{"a": {UID: "cookieA", Expires: live}}},
+			givenStored:    map[string]UIDEntry{"a": {UID: "storeA", Expires: live}},
+			givenHostID:    "host",
+			expectedCookie: map[string]UIDEntry{"a": {UID: "cookieA", Expires: live}},
+		},
+		{
+			name:           "store-replaces-expired",
+			givenCookie:    &Cookie{uids: map[string]UIDEntry{"a": {UID: "cookieA", Expires: expired}}},
+			givenStored:    map[string]UIDEntry{"a": {UID: "storeA", Expires: live}},
+			givenHostID:    "host",
+			expectedCookie: map[string]UIDEntry{"a": {UID: "storeA", Expires: live}},
+		},
+		{
+			name:           "no-host-id",
+			givenCookie:    &Cookie{uids: map[string]UIDEntry{}},
+			givenStored:    map[string]UIDEntry{"a": {UID: "storeA", Expires: live}},
+			givenHostID:    "",
+			expectedCookie: map[string]UIDEntry{},
+		},
+		{
+			name:           "opt-out",
+			givenCookie:    &Cookie{uids: map[string]UIDEntry{}, optOut: true},
+			givenStored:    map[string]UIDEntry{"a": {UID: "storeA", Expires: live}},
+			givenHostID:    "host",
+			expectedCookie: map[string]UIDEntry{},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			store := NewMemoryIDStore(config.UserSyncIDStoreMemory{})
+			for key, entry := range test.givenStored {
+				require.NoError(t, store.Set(context.Background(), "host", key, entry))
+			}
+
+			err := LoadStoredUIDs(context.Background(), store, test.givenHostID, test.givenCookie)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCookie, test.givenCookie.uids)
+		})
+	}
+}
+
+func TestLoadStoredUIDsError(t *testing.T) {
+	cookie := NewCookie()
+	err := LoadStoredUIDs(context.Background(), &failingIDStore{}, "host", cookie)
+	assert.EqualError(t, err, "store failure")
+	assert.Empty(t, cookie.uids)
+}
+
+func TestMemoryIDStore(t *testing.T) {
+	ctx := context.Background()
+	live := time.Now().Add(time.Hour)
+	expired := time.Now().Add(-time.Hour)
+
+	store := NewMemoryIDStore(config.UserSyncIDStoreMemory{})
+	require.NoError(t, store.Set(ctx, "host1", "a", UIDEntry{UID: "1", Expires: live}))
+	require.NoError(t, store.Set(ctx, "host1", "b", UIDEntry{UID: "2", Expires: expired}))
+	require.NoError(t, store.Set(ctx, "host2", "a", UIDEntry{UID: "3", Expires: live}))
+
+	entries, err := store.Get(ctx, "host1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]UIDEntry{"a": {UID: "1", Expires: live}}, entries, "get-excludes-expired")
+
+	require.NoError(t, store.Delete(ctx, "host1", "a"))
+	entries, err = store.Get(ctx, "host1")
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "delete")
+
+	require.NoError(t, store.DeleteAll(ctx, "host2"))
+	entries, err = store.Get(ctx, "host2")
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "delete-all")
+}
+
+func TestMemoryIDStoreSweep(t *testing.T) {
+	ctx := context.Background()
+	live := time.Now().Add(time.Hour)
+	expired := time.Now().Add(-time.Hour)
+
+	store := NewMemoryIDStore(config.UserSyncIDStoreMemory{SweepIntervalSeconds: 60})
+	require.NoError(t, store.Set(ctx, "host1", "a", UIDEntry{UID: "1", Expires: expired}))
+	require.NoError(t, store.Set(ctx, "host2", "a", UIDEntry{UID: "2", Expires: expired}))
+	require.NoError(t, store.Set(ctx, "host2", "b", UIDEntry{UID: "3", Expires: live}))
+	assert.Len(t, store.uids, 2, "not-swept-before-interval")
+
+	store.lastSweep = time.Now().Add(-time.Minute)
+	require.NoError(t, store.Set(ctx, "host3", "a", UIDEntry{UID: "4", Expires: live}))
+
+	assert.ElementsMatch(t, []string{"host2", "host3"}, slices.Collect(maps.Keys(store.uids)), "expired-users-purged")
+	assert.Equal(t, map[string]UIDEntry{"b": {UID: "3", Expires: live}}, store.uids["host2"].Value.(*memoryHostIDs).entries, "expired-ids-purged")
+}
+
+func TestMemoryIDStoreMaxHostIDs(t *testing.T) {
+	ctx := context.Background()
+	live := time.Now().Add(time.Hour)
+
+	store := NewMemoryIDStore(config.UserSyncIDStoreMemory{MaxHostIDs: 2})
+	require.NoError(t, store.Set(ctx, "host1", "a", UIDEntry{UID: "1", Expires: live}))
+	require.NoError(t, store.Set(ctx, "host2", "a", UIDEntry{UID: "2", Expires: live}))
+	require.NoError(t, store.Set(ctx, "host1", "b", UIDEntry{UID: "3", Expires: live}))
+	require.NoError(t, store.Set(ctx, "host3", "a", UIDEntry{UID: "4", Expires: live}))
+
+	assert.Len(t, store.uids, 2)
+	for hostID, expected := range map[string]int{"host1": 2, "host2": 0, "host3": 1} {
+		entries, err := store.Get(ctx, hostID)
+		assert.NoError(t, err)
+		assert.Len(t, entries, expected, "least-recently-written-evicted: %s", hostID)
+	}
+}
+
+func TestDatabaseIDStore(t *testing.T) {
+	ctx := context.Background()
+	live := time.Now().Add(time.Hour).UTC()
+	expired := time.Now().Add(-time.Hour).UTC()
+
+	queries := config.UserSyncIDStoreDatabase{
+		GetQuery:       "SELECT syncer_key, uid, expires FROM uids WHERE host_id = $HOST_ID",
+		SetQuery:       "REPLACE INTO uids (host_id, syncer_key, uid, expires) VALUES ($HOST_ID, $SYNCER_KEY, $UID, $EXPIRES)",
+		DeleteQuery:    "DELETE FROM uids WHERE host_id = $HOST_ID AND syncer_key = $SYNCER_KEY",
+		DeleteAllQuery: "DELETE FROM uids WHERE host_id = $HOST_ID",
+	}
+
+	provider, mock, err := db_provider.NewDbProviderMock()
+	require.NoError(t, err)
+	store := NewDatabaseIDStore(provider, queries)
+
+	mock.ExpectQuery(regexp.QuoteMeta(queries.GetQuery)).
+		WithArgs("host").
+		WillReturnRows(sqlmock.NewRows([]string{"syncer_key", "uid", "expires"}).
+			AddRow("a", "1", live).
+			AddRow("b", "2", expired))
+	entries, err := store.Get(ctx, "host")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]UIDEntry{"a": {UID: "1", Expires: live}}, entries)
+
+	mock.ExpectExec(regexp.QuoteMeta(queries.SetQuery)).
+		WithArgs("host", "a", "1", live).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, store.Set(ctx, "host", "a", UIDEntry{UID: "1", Expires: live}))
+
+	mock.ExpectExec(regexp.QuoteMeta(queries.DeleteQuery)).
+		WithArgs("host", "a").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, store.Delete(ctx, "host", "a"))
+
+	mock.ExpectExec(regexp.QuoteMeta(queries.DeleteAllQuery)).
+		WithArgs("host").
+		WillReturnError(errors.New("db failure"))
+	assert.EqualError(t, store.DeleteAll(ctx, "host"), "db failure")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type failingIDStore struct{}
+
+func (s *failingIDStore) Get(ctx context.Context, hostID string) (map[string]UIDEntry, error) {
+	return nil, errors.New("store failure")
+}
+
+func (s *failingIDStore) Set(ctx context.Context, hostID string, key string, entry UIDEntry) error {
+	return errors.New("store failure")
+}
+
+func (s *failingIDStore) Delete(ctx context.Context, hostID string, key string) error {
+	return errors.New("store failure")
+}
+
+func (s *failingIDStore) DeleteAll(ctx context.Context, hostID string) error {
+	return errors.New("store failure")
+}
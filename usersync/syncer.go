@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 	"text/template"
+	"time"
 
 	validator "github.com/asaskevich/govalidator"
 	"github.com/prebid/prebid-server/v4/config"
@@ -35,6 +36,16 @@ type Syncer interface {
 	// GetSync returns a user sync for the user's device to perform, or an error if the none of the
 	// sync types are supported or if macro substitution fails.
 	GetSync(syncTypes []SyncType, userSyncMacros macros.UserSyncPrivacy) (Sync, error)
+
+	// UIDPolicy returns the lifetime and refresh window of the user ids synced by this syncer.
+	UIDPolicy() UIDPolicy
+}
+
+// UIDPolicy specifies how long a synced user id lives and how long before expiration it should be
+// refreshed. A zero TTL uses the default uid lifetime and a zero RefreshWindow disables early refreshes.
+type UIDPolicy struct {
+	TTL           time.Duration
+	RefreshWindow time.Duration
 }
 
 // Sync represents a user sync to be performed by the user's device.
@@ -49,6 +60,7 @@ type standardSyncer struct {
 	iframe          *template.Template
 	redirect        *template.Template
 	formatOverride  string
+	uidPolicy       UIDPolicy
 }
 
 // NewSyncer creates a new Syncer from the provided configuration, or return an error if macro substition
@@ -66,6 +78,7 @@ func NewSyncer(hostConfig config.UserSync, syncerConfig config.Syncer, bidder st
 		key:             syncerConfig.Key,
 		defaultSyncType: resolveDefaultSyncType(syncerConfig),
 		formatOverride:  syncerConfig.FormatOverride,
+		uidPolicy:       resolveUIDPolicy(syncerConfig),
 	}
 
 	if syncerConfig.IFrame != nil {
@@ -100,6 +113,16 @@ func resolveDefaultSyncType(syncerConfig config.Syncer) SyncType {
 	return SyncTypeRedirect
 }
 
+func resolveUIDPolicy(syncerConfig config.Syncer) UIDPolicy {
+	if syncerConfig.UIDPolicy == nil {
+		return UIDPolicy{}
+	}
+	return UIDPolicy{
+		TTL:           time.Duration(syncerConfig.UIDPolicy.TTLHours) * time.Hour,
+		RefreshWindow: time.Duration(syncerConfig.UIDPolicy.RefreshWindowHours) * time.Hour,
+	}
+}
+
 // macro substitution regex
 var (
 	macroRegexExternalHost = regexp.MustCompile(`{{\s*\.ExternalURL\s*}}`)
@@ -187,6 +210,10 @@ func (s standardSyncer) Key() string {
 	return s.key
 }
 
+func (s standardSyncer) UIDPolicy() UIDPolicy {
+	return s.uidPolicy
+}
+
 func (s standardSyncer) DefaultResponseFormat() SyncType {
 	switch s.formatOverride {
 	case config.SyncResponseFormatIFrame:
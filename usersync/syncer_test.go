@@ -3,6 +3,7 @@ package usersync
 import (
 	"testing"
 	"text/template"
+	"time"
 
 	"github.com/prebid/prebid-server/v4/config"
 	"github.com/prebid/prebid-server/v4/macros"
@@ -476,6 +477,20 @@ func TestSyncerKey(t *testing.T) {
 	assert.Equal(t, "a", syncer.Key())
 }
 
+func TestSyncerUIDPolicy(t *testing.T) {
+	hostConfig := config.UserSync{ExternalURL: "http://host.com", RedirectURL: "{{.ExternalURL}}/host"}
+	redirectConfig := &config.SyncerEndpoint{URL: "https://bidder.com/redirect?redirect={{.RedirectURL}}"}
+
+	syncer, err := NewSyncer(hostConfig, config.Syncer{Key: "a", Redirect: redirectConfig}, "bidderA")
+	assert.NoError(t, err)
+	assert.Equal(t, UIDPolicy{}, syncer.UIDPolicy(), "default")
+
+	syncerConfig := config.Syncer{Key: "a", Redirect: redirectConfig, UIDPolicy: &config.SyncerUIDPolicy{TTLHours: 168, RefreshWindowHours: 24}}
+	syncer, err = NewSyncer(hostConfig, syncerConfig, "bidderA")
+	assert.NoError(t, err)
+	assert.Equal(t, UIDPolicy{TTL: 7 * 24 * time.Hour, RefreshWindow: 24 * time.Hour}, syncer.UIDPolicy(), "configured")
+}
+
 func TestSyncerDefaultSyncType(t *testing.T) {
 	syncer := standardSyncer{defaultSyncType: SyncTypeRedirect}
 	assert.Equal(t, SyncTypeRedirect, syncer.DefaultResponseFormat())